@@ -0,0 +1,17 @@
+package analysis
+
+import "github.com/tormoder/fit/internal/summary"
+
+// DefaultAscentHysteresis is the altitude change in metres needed to
+// register a change between climbing and descending when no hysteresis is
+// given to Summarize.
+const DefaultAscentHysteresis = summary.DefaultHysteresis
+
+// Ascent returns the total ascent and descent in metres of the altitude
+// profile alts. Small fluctuations are ignored: the direction (climbing or
+// descending) only changes after the altitude has moved at least hysteresis
+// metres away from the last turning point. Once a direction is established,
+// every further change in that direction counts. NaN altitudes are skipped.
+func Ascent(alts []float64, hysteresis float64) (ascent, descent float64) {
+	return summary.Ascent(alts, hysteresis)
+}
@@ -0,0 +1,314 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// Parameters of the climb detection and grade computation.
+const (
+	// profileSmoothing is the distance in metres over which altitudes are
+	// averaged before computing grades and detecting climbs.
+	profileSmoothing = 100.0
+
+	// gradeDistance is the distance in metres over which grades, and the
+	// maximum grade of climbs, are computed.
+	gradeDistance = 100.0
+
+	// climbTolerance is the descent in metres allowed within a climb.
+	// A larger descent ends the climb at its highest point.
+	climbTolerance = 10.0
+
+	// climbFlat is the altitude change in metres within which the flat
+	// approach to and continuation after a climb are trimmed from it.
+	climbFlat = 1.0
+)
+
+// Climb categorization thresholds, after the categories used by Strava.
+// The score of a climb is its length in metres times its average grade in
+// percent.
+const (
+	MinClimbGrade  = 3.0 // %
+	MinClimbLength = 500 // m
+
+	category4Score  = 8000
+	category3Score  = 16000
+	category2Score  = 32000
+	category1Score  = 64000
+	categoryHCScore = 80000
+)
+
+// A ClimbCategory is the category of a climb, from Category4, the easiest,
+// to CategoryHC, the hardest.
+type ClimbCategory int
+
+// Climb categories.
+const (
+	Uncategorized ClimbCategory = iota
+	Category4
+	Category3
+	Category2
+	Category1
+	CategoryHC // Hors catégorie.
+)
+
+func (c ClimbCategory) String() string {
+	switch c {
+	case Category4:
+		return "4"
+	case Category3:
+		return "3"
+	case Category2:
+		return "2"
+	case Category1:
+		return "1"
+	case CategoryHC:
+		return "HC"
+	}
+	return "uncategorized"
+}
+
+// Categorize returns the category of a climb of the given length in metres
+// and average grade in percent. Climbs shorter than MinClimbLength or less
+// steep than MinClimbGrade are uncategorized.
+func Categorize(length, avgGrade float64) ClimbCategory {
+	if length < MinClimbLength || avgGrade < MinClimbGrade {
+		return Uncategorized
+	}
+	switch score := length * avgGrade; {
+	case score >= categoryHCScore:
+		return CategoryHC
+	case score >= category1Score:
+		return Category1
+	case score >= category2Score:
+		return Category2
+	case score >= category3Score:
+		return Category3
+	case score >= category4Score:
+		return Category4
+	}
+	return Uncategorized
+}
+
+// A Climb is a categorized climb of an activity.
+type Climb struct {
+	StartTime     time.Time
+	EndTime       time.Time
+	StartDistance float64 // m
+	EndDistance   float64 // m
+	Length        float64 // m
+	ElevationGain float64 // m, from the bottom to the top of the climb
+	AvgGrade      float64 // %
+	MaxGrade      float64 // %, steepest 100 m of the climb
+	VAM           float64 // m/h, ElevationGain per hour of timer time
+	Category      ClimbCategory
+}
+
+// Climbs returns the categorized climbs of records, in order. Climbs are
+// detected on the altitude profile over distance, smoothed over 100 m. A
+// climb ends at its highest point when the altitude drops more than 10 m
+// below it, or below the start of the climb; flat sections at either end
+// are trimmed. Only climbs with a category are returned; see Categorize.
+// The time the timer was stopped does not count towards the VAM; see
+// WithTimerEvents.
+func Climbs(records []*fit.RecordMsg, opts ...Option) []Climb {
+	o := newOptions(opts)
+	t := newTimer(o.events)
+	p := newProfile(records)
+	if len(p.dist) < 2 {
+		return nil
+	}
+
+	var climbs []Climb
+	finish := func(low, peak int) {
+		if c, ok := p.climb(low, peak, t); ok {
+			climbs = append(climbs, c)
+		}
+	}
+	low, peak := 0, 0
+	for i, a := range p.alt {
+		switch {
+		case a < p.alt[low]:
+			finish(low, peak)
+			low, peak = i, i
+		case a > p.alt[peak]:
+			peak = i
+		case p.alt[peak]-a > climbTolerance:
+			finish(low, peak)
+			low, peak = i, i
+		}
+	}
+	finish(low, peak)
+	return climbs
+}
+
+// climb returns the climb from point low to point peak of p, with flat
+// ends trimmed, and whether it is categorized.
+func (p *profile) climb(low, peak int, t *timer.Timer) (Climb, bool) {
+	bottom, top := p.alt[low], p.alt[peak]
+	for low < peak && p.alt[low+1] <= bottom+climbFlat {
+		low++
+	}
+	for peak > low && p.alt[peak-1] >= top-climbFlat {
+		peak--
+	}
+	c := Climb{
+		StartTime:     p.records[low].Timestamp,
+		EndTime:       p.records[peak].Timestamp,
+		StartDistance: p.dist[low],
+		EndDistance:   p.dist[peak],
+		Length:        p.dist[peak] - p.dist[low],
+		ElevationGain: p.alt[peak] - p.alt[low],
+	}
+	if c.Length <= 0 {
+		return c, false
+	}
+	c.AvgGrade = 100 * c.ElevationGain / c.Length
+	c.Category = Categorize(c.Length, c.AvgGrade)
+	if c.Category == Uncategorized {
+		return c, false
+	}
+
+	c.MaxGrade = c.AvgGrade
+	if c.Length > gradeDistance {
+		c.MaxGrade = math.Inf(-1)
+		for i := low; i <= peak && p.dist[i]+gradeDistance <= c.EndDistance; i++ {
+			g := 100 * (p.at(p.dist[i]+gradeDistance) - p.alt[i]) / gradeDistance
+			c.MaxGrade = math.Max(c.MaxGrade, g)
+		}
+	}
+	if d := t.Overlap(c.StartTime, c.EndTime); d > 0 {
+		c.VAM = c.ElevationGain / d.Hours()
+	}
+	return c, true
+}
+
+// Grades returns the grade in percent at each record, computed from the
+// altitude profile over distance smoothed over 100 m, as the altitude
+// change over the 100 m centered on the record. The grade is NaN for
+// records without a valid distance and altitude. Grades recorded by the
+// device are not used.
+func Grades(records []*fit.RecordMsg) []float64 {
+	grades := make([]float64, len(records))
+	for i := range grades {
+		grades[i] = math.NaN()
+	}
+	p := newProfile(records)
+	if len(p.dist) < 2 {
+		return grades
+	}
+	first, last := p.dist[0], p.dist[len(p.dist)-1]
+	for k, d := range p.dist {
+		lo := math.Max(d-gradeDistance/2, first)
+		hi := math.Min(d+gradeDistance/2, last)
+		if hi <= lo {
+			continue
+		}
+		grades[p.index[k]] = 100 * (p.at(hi) - p.at(lo)) / (hi - lo)
+	}
+	return grades
+}
+
+// SetGrades sets the Grade field of every record that has no valid grade
+// to the grade computed by Grades, and returns the number of records set.
+func SetGrades(records []*fit.RecordMsg) int {
+	grades := Grades(records)
+	n := 0
+	for i, r := range records {
+		if r == nil || r.Grade != 0x7FFF {
+			continue
+		}
+		v := math.Round(grades[i] * 100)
+		if math.IsNaN(v) || v < math.MinInt16 || v >= 0x7FFF {
+			continue
+		}
+		r.Grade = int16(v)
+		n++
+	}
+	return n
+}
+
+// A profile is the smoothed altitude over distance of a sequence of
+// records.
+type profile struct {
+	records []*fit.RecordMsg // Records with a valid distance and altitude.
+	index   []int            // Index of each point in the input records.
+	dist    []float64        // Strictly increasing.
+	alt     []float64
+}
+
+func newProfile(records []*fit.RecordMsg) *profile {
+	p := new(profile)
+	for i, r := range records {
+		if r == nil {
+			continue
+		}
+		d, a := r.GetDistanceScaled(), recordAltitude(r)
+		if math.IsNaN(d) || math.IsNaN(a) {
+			continue
+		}
+		if n := len(p.dist); n > 0 && d <= p.dist[n-1] {
+			continue
+		}
+		p.records = append(p.records, r)
+		p.index = append(p.index, i)
+		p.dist = append(p.dist, d)
+		p.alt = append(p.alt, a)
+	}
+	if len(p.dist) > 0 {
+		p.smooth(profileSmoothing)
+	}
+	return p
+}
+
+// smooth replaces each altitude with the mean of the profile, interpolated
+// linearly between points, over the window metres centered on it. The
+// window is narrowed near the ends of the profile to stay centered, so that
+// constant grades are preserved.
+func (p *profile) smooth(window float64) {
+	// integral[i] is the integral of the altitude up to point i.
+	integral := make([]float64, len(p.dist))
+	for i := 1; i < len(p.dist); i++ {
+		integral[i] = integral[i-1] + (p.dist[i]-p.dist[i-1])*(p.alt[i]+p.alt[i-1])/2
+	}
+	integralAt := func(d float64) float64 {
+		i := sort.SearchFloat64s(p.dist, d)
+		if i == 0 {
+			return 0
+		}
+		if i == len(p.dist) {
+			return integral[i-1]
+		}
+		return integral[i-1] + (d-p.dist[i-1])*(p.at(d)+p.alt[i-1])/2
+	}
+
+	out := make([]float64, len(p.alt))
+	first, last := p.dist[0], p.dist[len(p.dist)-1]
+	for i, d := range p.dist {
+		half := math.Min(window/2, math.Min(d-first, last-d))
+		if half <= 0 {
+			out[i] = p.alt[i]
+			continue
+		}
+		out[i] = (integralAt(d+half) - integralAt(d-half)) / (2 * half)
+	}
+	p.alt = out
+}
+
+// at returns the altitude at distance d, interpolated linearly between the
+// points of p. d must be within the distance range of p.
+func (p *profile) at(d float64) float64 {
+	i := sort.SearchFloat64s(p.dist, d)
+	switch {
+	case i >= len(p.dist):
+		return p.alt[len(p.alt)-1]
+	case i == 0 || p.dist[i] == d:
+		return p.alt[i]
+	}
+	frac := (d - p.dist[i-1]) / (p.dist[i] - p.dist[i-1])
+	return p.alt[i-1] + frac*(p.alt[i]-p.alt[i-1])
+}
@@ -0,0 +1,114 @@
+package analysis_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+func TestCategorize(t *testing.T) {
+	for _, tt := range []struct {
+		length, grade float64
+		want          analysis.ClimbCategory
+	}{
+		{400, 10, analysis.Uncategorized},
+		{5000, 2.5, analysis.Uncategorized},
+		{2000, 3, analysis.Uncategorized},
+		{2000, 4, analysis.Category4},
+		{5000, 6, analysis.Category3},
+		{8000, 5, analysis.Category2},
+		{10000, 7, analysis.Category1},
+		{15000, 7, analysis.CategoryHC},
+	} {
+		if got := analysis.Categorize(tt.length, tt.grade); got != tt.want {
+			t.Errorf("%v m at %v%%: got %v, want %v", tt.length, tt.grade, got, tt.want)
+		}
+	}
+}
+
+// profileRecords returns records every 10 m, 2 s apart, with altitudes
+// from alt.
+func profileRecords(length float64, alt func(d float64) float64) []*fit.RecordMsg {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	var rs []*fit.RecordMsg
+	for d := 0.0; d <= length; d += 10 {
+		r := fit.NewRecordMsg()
+		r.Timestamp = t0.Add(time.Duration(d/5) * time.Second)
+		r.Distance = uint32(d * 100)
+		r.EnhancedAltitude = uint32((alt(d) + 500) * 5)
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func TestClimbs(t *testing.T) {
+	// 2 km flat, 5 km at 6% with a 5 m dip halfway, 1 km flat, 3 km
+	// down, 1 km at 2%.
+	rs := profileRecords(12000, func(d float64) float64 {
+		switch {
+		case d < 2000:
+			return 100
+		case d < 7000:
+			a := 100 + 0.06*(d-2000)
+			if d >= 4500 && d < 4700 {
+				a -= 5
+			}
+			return a
+		case d < 8000:
+			return 400
+		case d < 11000:
+			return 400 - 0.1*(d-8000)
+		}
+		return 100 + 0.02*(d-11000)
+	})
+	climbs := analysis.Climbs(rs)
+	if len(climbs) != 1 {
+		t.Fatalf("got %d climbs, want 1: %+v", len(climbs), climbs)
+	}
+	c := climbs[0]
+	if !within(c.StartDistance, 2000, 60) || !within(c.EndDistance, 7000, 60) {
+		t.Errorf("got climb from %v m to %v m, want 2000 m to 7000 m", c.StartDistance, c.EndDistance)
+	}
+	if !within(c.ElevationGain, 300, 5) || !within(c.AvgGrade, 6, 0.2) {
+		t.Errorf("got gain %v m at %v%%, want 300 m at 6%%", c.ElevationGain, c.AvgGrade)
+	}
+	// The steepest part is the recovery from the dip.
+	if c.MaxGrade < 8 || c.MaxGrade > 12 {
+		t.Errorf("got max grade %v%%, want 8%%-12%%", c.MaxGrade)
+	}
+	// 5 m/s at 6% is 0.3 m/s vertical.
+	if !within(c.VAM, 1080, 20) {
+		t.Errorf("got VAM %v m/h, want 1080 m/h", c.VAM)
+	}
+	if c.Category != analysis.Category3 {
+		t.Errorf("got category %v, want 3", c.Category)
+	}
+}
+
+func TestSetGrades(t *testing.T) {
+	rs := profileRecords(1000, func(d float64) float64 { return 0.05 * d })
+	rs[10].Grade = 1234
+	rs[20].Distance = 0xFFFFFFFF
+	if n := analysis.SetGrades(rs); n != len(rs)-2 {
+		t.Errorf("got %d records set, want %d", n, len(rs)-2)
+	}
+	for i, r := range rs {
+		switch i {
+		case 10:
+			if r.Grade != 1234 {
+				t.Errorf("record %d: device grade overwritten with %d", i, r.Grade)
+			}
+		case 20:
+			if r.Grade != 0x7FFF {
+				t.Errorf("record %d: got grade %d, want invalid", i, r.Grade)
+			}
+		default:
+			if g := r.GetGradeScaled(); math.Abs(g-5) > 0.15 {
+				t.Errorf("record %d: got grade %v%%, want 5%%", i, g)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// StandardDistances are common running race distances in metres: 400 m,
+// 1 km, 1 mile, 5 km, 10 km, half marathon and marathon.
+var StandardDistances = []float64{400, 1000, 1609.344, 5000, 10000, 21097.5, 42195}
+
+// A BestEffort is the fastest time over a distance.
+type BestEffort struct {
+	Distance float64 // m
+	Duration time.Duration
+	Start    time.Time
+	End      time.Time
+}
+
+// BestEfforts returns the fastest time over each of distances, computed
+// from the accumulated distance of records. The time the timer was stopped
+// is not counted; see WithTimerEvents. The start of an effort is
+// interpolated between records, so that efforts cover exactly the given
+// distance. Distances longer than the recording are left out of the result.
+func BestEfforts(records []*fit.RecordMsg, distances []float64, opts ...Option) []BestEffort {
+	o := newOptions(opts)
+	t := newTimer(o.events)
+
+	// Timer time and distance at every record with a valid distance. The
+	// distance is made non-decreasing to guard against glitches.
+	var (
+		times     []float64
+		dists     []float64
+		stamp     []time.Time
+		prev      *fit.RecordMsg
+		timerTime float64
+	)
+	for _, r := range records {
+		if r == nil || r.Distance == 0xFFFFFFFF {
+			continue
+		}
+		if prev != nil {
+			if !r.Timestamp.After(prev.Timestamp) {
+				continue
+			}
+			timerTime += t.Overlap(prev.Timestamp, r.Timestamp).Seconds()
+		}
+		prev = r
+		d := float64(r.Distance) / 100
+		if len(dists) > 0 && d < dists[len(dists)-1] {
+			d = dists[len(dists)-1]
+		}
+		times = append(times, timerTime)
+		dists = append(dists, d)
+		stamp = append(stamp, r.Timestamp)
+	}
+
+	var efforts []BestEffort
+	for _, dist := range distances {
+		if dist <= 0 || len(dists) < 2 || dists[len(dists)-1]-dists[0] < dist {
+			continue
+		}
+		var (
+			best      = -1.0
+			bestStart time.Time
+			bestEnd   time.Time
+			first     = 0
+		)
+		for last := 1; last < len(dists); last++ {
+			target := dists[last] - dist
+			if target < dists[0] {
+				continue
+			}
+			// Advance first to the last record at or before the
+			// target distance.
+			for first+1 < last && dists[first+1] <= target {
+				first++
+			}
+			// Interpolate the timer time at the target distance.
+			startTime := times[first]
+			startStamp := stamp[first]
+			if dd := dists[first+1] - dists[first]; dd > 0 {
+				f := (target - dists[first]) / dd
+				startTime += f * (times[first+1] - times[first])
+				startStamp = startStamp.Add(time.Duration(f * float64(stamp[first+1].Sub(stamp[first]))))
+			}
+			if d := times[last] - startTime; best < 0 || d < best {
+				best, bestStart, bestEnd = d, startStamp, stamp[last]
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		efforts = append(efforts, BestEffort{
+			Distance: dist,
+			Duration: time.Duration(best * float64(time.Second)),
+			Start:    bestStart,
+			End:      bestEnd,
+		})
+	}
+	return efforts
+}
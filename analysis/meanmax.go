@@ -0,0 +1,110 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// A MeanMaxPoint is the best average of a field over a duration.
+type MeanMaxPoint struct {
+	Duration time.Duration
+	Value    float64
+	Start    time.Time // Start of the best effort.
+}
+
+// MeanMax returns the highest average value of field over each of
+// durations, which are rounded down to whole seconds. If durations is nil,
+// MeanMaxDurations of the length of the recording are used.
+//
+// Records are resampled to 1 second intervals; see Resample, and Power for
+// how power is resampled. Only the periods where the timer was running are
+// used; see WithTimerEvents. Seconds where the timer was stopped or the
+// field has no valid value count as zero for power, which matches how power
+// curves are usually computed, while for other fields no effort may span
+// such a gap. Durations for which no effort was found are left out of the
+// result.
+func MeanMax(records []*fit.RecordMsg, field Field, durations []time.Duration, opts ...Option) []MeanMaxPoint {
+	o := newOptions(opts)
+	t := newTimer(o.events)
+	var (
+		ts     []time.Time
+		values []float64
+	)
+	if field == FieldPower {
+		ts, values = powerSeries(records, t)
+	} else {
+		s := resample(records, time.Second, t, []Field{field})
+		ts, values = s.Timestamp, s.Values(field)
+	}
+	if len(ts) == 0 {
+		return nil
+	}
+
+	// Compute prefix sums of the values and of the number of valid values.
+	start := ts[0]
+	n := len(values)
+	sums := make([]float64, n+1)
+	counts := make([]int, n+1)
+	for i, v := range values {
+		sums[i+1], counts[i+1] = sums[i], counts[i]
+		if !math.IsNaN(v) {
+			sums[i+1] += v
+			counts[i+1]++
+		}
+	}
+
+	if durations == nil {
+		durations = MeanMaxDurations(time.Duration(n) * time.Second)
+	}
+
+	var points []MeanMaxPoint
+	for _, d := range durations {
+		secs := int(d / time.Second)
+		if secs < 1 || secs > n {
+			continue
+		}
+		best, bestStart := math.Inf(-1), -1
+		for i := 0; i+secs <= n; i++ {
+			c := counts[i+secs] - counts[i]
+			if c == 0 || (field != FieldPower && c < secs) {
+				continue
+			}
+			if sum := sums[i+secs] - sums[i]; sum > best {
+				best, bestStart = sum, i
+			}
+		}
+		if bestStart < 0 {
+			continue
+		}
+		points = append(points, MeanMaxPoint{
+			Duration: time.Duration(secs) * time.Second,
+			Value:    best / float64(secs),
+			Start:    start.Add(time.Duration(bestStart) * time.Second),
+		})
+	}
+	return points
+}
+
+// MeanMaxDurations returns durations from one second through max, for
+// plotting a mean-maximal curve. Durations are one second apart up to 20
+// seconds and about 5% apart beyond that, so that the number of durations
+// grows with the logarithm of max. The last duration is max rounded down
+// to whole seconds.
+func MeanMaxDurations(max time.Duration) []time.Duration {
+	n := int(max / time.Second)
+	var ds []time.Duration
+	for secs := 1; secs < n; {
+		ds = append(ds, time.Duration(secs)*time.Second)
+		if step := secs / 20; step > 1 {
+			secs += step
+		} else {
+			secs++
+		}
+	}
+	if n > 0 {
+		ds = append(ds, time.Duration(n)*time.Second)
+	}
+	return ds
+}
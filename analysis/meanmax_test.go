@@ -0,0 +1,181 @@
+package analysis_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestMeanMaxPower(t *testing.T) {
+	records := append(constantPower(60, 100), constantPower(30, 300)...)
+	for i, r := range records[60:] {
+		r.Timestamp = records[59].Timestamp.Add(time.Duration(i+1) * time.Second)
+	}
+	durations := []time.Duration{time.Second, 30 * time.Second, time.Minute, time.Hour}
+	got := analysis.MeanMax(records, analysis.FieldPower, durations)
+	want := []analysis.MeanMaxPoint{
+		{time.Second, 300, records[60].Timestamp},
+		{30 * time.Second, 300, records[60].Timestamp},
+		{time.Minute, 200, records[30].Timestamp},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Duration != want[i].Duration || got[i].Value != want[i].Value || !got[i].Start.Equal(want[i].Start) {
+			t.Errorf("%d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMeanMaxSmartRecording(t *testing.T) {
+	// Records every 5 s, with a pause from 45 to 55 s and a missing heart
+	// rate in the second part, which is interpolated.
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	var records []*fit.RecordMsg
+	for i := 0; i <= 20; i++ {
+		r := fit.NewRecordMsg()
+		r.Timestamp = t0.Add(time.Duration(5*i) * time.Second)
+		r.HeartRate = 150
+		if i >= 10 {
+			r.HeartRate = 160
+		}
+		if i == 15 {
+			r.HeartRate = 0xFF
+		}
+		records = append(records, r)
+	}
+	timer := func(sec int, et fit.EventType) *fit.EventMsg {
+		e := fit.NewEventMsg()
+		e.Timestamp, e.Event, e.EventType = t0.Add(time.Duration(sec)*time.Second), fit.EventTimer, et
+		return e
+	}
+	events := []*fit.EventMsg{timer(0, fit.EventTypeStart), timer(45, fit.EventTypeStop), timer(55, fit.EventTypeStart)}
+	got := analysis.MeanMax(records, analysis.FieldHeartRate, nil, analysis.WithTimerEvents(events))
+	// 101 seconds, of which the pause splits the recording in two parts
+	// of 46 seconds.
+	if len(got) == 0 {
+		t.Fatal("got no durations")
+	}
+	if last := got[len(got)-1]; last.Value != 160 || last.Duration != 46*time.Second {
+		t.Errorf("longest effort: got %+v, want 46 s at 160", last)
+	}
+}
+
+func TestMeanMaxActivity(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit")
+	got := analysis.MeanMax(act.Records, analysis.FieldPower, nil, analysis.WithTimerEvents(act.Events))
+	if len(got) == 0 {
+		t.Fatal("got no mean maximal power")
+	}
+	if got[0].Value != float64(act.Sessions[0].MaxPower) {
+		t.Errorf("1 s power: got %v, want session max power %d", got[0].Value, act.Sessions[0].MaxPower)
+	}
+	length := act.Records[len(act.Records)-1].Timestamp.Sub(act.Records[0].Timestamp) + time.Second
+	if last := got[len(got)-1]; last.Duration != length {
+		t.Errorf("longest duration: got %v, want the length of the recording %v", last.Duration, length)
+	}
+	for _, p := range got[1:] {
+		if p.Value > got[0].Value {
+			t.Fatalf("%v: mean maximal power %v exceeds max power", p.Duration, p.Value)
+		}
+	}
+	if last := got[len(got)-1]; !within(last.Value, float64(act.Sessions[0].AvgPower), 2) {
+		t.Errorf("full length: got %v, want about the average power %d", last.Value, act.Sessions[0].AvgPower)
+	}
+}
+
+func TestMeanMaxDurations(t *testing.T) {
+	if got := analysis.MeanMaxDurations(0); len(got) != 0 {
+		t.Errorf("zero length: got %v", got)
+	}
+	got := analysis.MeanMaxDurations(24 * time.Hour)
+	if got[0] != time.Second || got[19] != 20*time.Second || got[len(got)-1] != 24*time.Hour {
+		t.Errorf("got %v, want 1 s through 24 h", got)
+	}
+	if len(got) > 250 {
+		t.Errorf("got %d durations for a day, want a logarithmic number", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] <= got[i-1] {
+			t.Fatalf("durations not increasing: %v after %v", got[i], got[i-1])
+		}
+	}
+}
+
+func TestBestEfforts(t *testing.T) {
+	// 10 s at 2 m/s, 100 s at 5 m/s, 10 s at 2 m/s, with records every
+	// 10 s.
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	speeds := []float64{2, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 2}
+	var (
+		records []*fit.RecordMsg
+		dist    float64
+	)
+	for i := 0; i <= len(speeds); i++ {
+		if i > 0 {
+			dist += 10 * speeds[i-1]
+		}
+		r := fit.NewRecordMsg()
+		r.Timestamp = t0.Add(time.Duration(10*i) * time.Second)
+		r.Distance = uint32(dist * 100)
+		records = append(records, r)
+	}
+
+	got := analysis.BestEfforts(records, []float64{400, 500, 1000, 10000})
+	want := []analysis.BestEffort{
+		{400, 80 * time.Second, t0.Add(10 * time.Second), t0.Add(90 * time.Second)},
+		{500, 100 * time.Second, t0.Add(10 * time.Second), t0.Add(110 * time.Second)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBestEffortsActivity(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "me", "activity-small-fenix2-run.fit")
+	efforts := analysis.BestEfforts(act.Records, analysis.StandardDistances, analysis.WithTimerEvents(act.Events))
+	// The run is about 9 km.
+	if len(efforts) != 4 {
+		t.Fatalf("got %d efforts, want 4", len(efforts))
+	}
+	avg := act.Sessions[0].GetTotalTimerTimeScaled() / act.Sessions[0].GetTotalDistanceScaled()
+	for _, e := range efforts {
+		pace := e.Duration.Seconds() / e.Distance
+		if pace > avg || math.IsNaN(pace) {
+			t.Errorf("%v m: pace %.3f s/m is slower than the average pace %.3f", e.Distance, pace, avg)
+		}
+		if e.End.Sub(e.Start) < e.Duration {
+			t.Errorf("%v m: start and end %v apart, less than duration %v", e.Distance, e.End.Sub(e.Start), e.Duration)
+		}
+	}
+}
+
+func BenchmarkMeanMax(b *testing.B) {
+	_, act := fittest.DecodeActivity(b, "dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analysis.MeanMax(act.Records, analysis.FieldPower, nil)
+	}
+}
+
+func BenchmarkMeanMaxDay(b *testing.B) {
+	// A day long recording at 1 s intervals.
+	records := constantPower(24*60*60, 200)
+	for i, r := range records {
+		r.Power = uint16(200 + i%100)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analysis.MeanMax(records, analysis.FieldPower, nil)
+	}
+}
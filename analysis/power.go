@@ -0,0 +1,248 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// npWindow is the length of the rolling average used for normalized power.
+const npWindow = 30
+
+// PowerMetrics holds power based training metrics. Values are NaN if they
+// could not be computed.
+type PowerMetrics struct {
+	AvgPower            float64 // W
+	NormalizedPower     float64 // W
+	IntensityFactor     float64 // NormalizedPower / FTP
+	TrainingStressScore float64
+	VariabilityIndex    float64 // NormalizedPower / AvgPower
+	Work                float64 // kJ
+	Duration            float64 // s, time with power data
+	FTP                 float64 // W, the functional threshold power used
+}
+
+// Power computes power metrics from the power of records, resampled to 1
+// second intervals; see Resample. Only the periods where the timer was
+// running are used; see WithTimerEvents. Where the accumulated power of
+// records is present, the power of each second is derived from it, which
+// gives the average power between records more than a second apart.
+// IntensityFactor and TrainingStressScore are NaN unless ftp is positive.
+func Power(records []*fit.RecordMsg, ftp float64, opts ...Option) *PowerMetrics {
+	o := newOptions(opts)
+	_, power := powerSeries(records, newTimer(o.events))
+
+	m := &PowerMetrics{
+		AvgPower:            math.NaN(),
+		NormalizedPower:     NormalizedPower(power),
+		IntensityFactor:     math.NaN(),
+		TrainingStressScore: math.NaN(),
+		VariabilityIndex:    math.NaN(),
+		Work:                math.NaN(),
+		FTP:                 math.NaN(),
+	}
+	var sum float64
+	for _, p := range power {
+		if !math.IsNaN(p) {
+			sum += p
+			m.Duration++
+		}
+	}
+	if m.Duration > 0 {
+		m.AvgPower = sum / m.Duration
+		m.Work = sum / 1000
+		if m.AvgPower > 0 {
+			m.VariabilityIndex = m.NormalizedPower / m.AvgPower
+		}
+	}
+	if ftp > 0 {
+		m.FTP = ftp
+		m.IntensityFactor = IntensityFactor(m.NormalizedPower, ftp)
+		m.TrainingStressScore = TrainingStressScore(m.NormalizedPower, ftp, m.Duration)
+	}
+	return m
+}
+
+// SessionPower computes power metrics for the records of act logged during
+// session, honouring the timer events of act. If ftp is zero, the threshold
+// power of session is used if present.
+func SessionPower(act *fit.ActivityFile, session *fit.SessionMsg, ftp float64) *PowerMetrics {
+	if ftp <= 0 && session.ThresholdPower != 0xFFFF {
+		ftp = float64(session.ThresholdPower)
+	}
+	return Power(sessionRecords(act.Records, session), ftp, WithTimerEvents(act.Events))
+}
+
+// sessionRecords returns the records logged between the start time and the
+// timestamp of session.
+func sessionRecords(records []*fit.RecordMsg, session *fit.SessionMsg) []*fit.RecordMsg {
+	return fit.RecordsBetween(records, session.StartTime, session.Timestamp)
+}
+
+// NormalizedPower returns the normalized power of power, which must be
+// sampled at 1 second intervals: the fourth root of the mean of the fourth
+// powers of the 30 second rolling average. NaN samples, such as pauses,
+// are skipped and restart the rolling average, so that it never spans a
+// pause. NaN is returned if power holds no 30 consecutive valid samples.
+func NormalizedPower(power []float64) float64 {
+	var (
+		sum, sum4 float64
+		run, n    int
+	)
+	for i, p := range power {
+		if math.IsNaN(p) {
+			sum, run = 0, 0
+			continue
+		}
+		sum += p
+		run++
+		if run > npWindow {
+			sum -= power[i-npWindow]
+		}
+		if run >= npWindow {
+			avg := sum / npWindow
+			sum4 += avg * avg * avg * avg
+			n++
+		}
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return math.Pow(sum4/float64(n), 0.25)
+}
+
+// IntensityFactor returns the intensity factor for a normalized power np
+// and a functional threshold power ftp.
+func IntensityFactor(np, ftp float64) float64 {
+	return np / ftp
+}
+
+// TrainingStressScore returns the training stress score of an effort of
+// the given duration in seconds with normalized power np, for an athlete
+// with functional threshold power ftp.
+func TrainingStressScore(np, ftp, duration float64) float64 {
+	return duration * np * IntensityFactor(np, ftp) / (ftp * 3600) * 100
+}
+
+// TimeInPowerZones returns the time spent in each of zones, ordered by
+// their high value, while the timer was running. The returned slice has one
+// element more than zones, holding the time spent above the highest zone.
+// A second with power p is counted in the first zone with p <= HighValue.
+func TimeInPowerZones(records []*fit.RecordMsg, zones []*fit.PowerZoneMsg, opts ...Option) []time.Duration {
+	o := newOptions(opts)
+	highs := make([]float64, 0, len(zones))
+	for _, z := range zones {
+		if z == nil || z.HighValue == 0xFFFF {
+			continue
+		}
+		highs = append(highs, float64(z.HighValue))
+	}
+	sort.Float64s(highs)
+	_, power := powerSeries(records, newTimer(o.events))
+	return timeInZones(power, highs)
+}
+
+// timeInZones returns the time in each zone defined by the sorted upper
+// bounds highs, plus the time above the last bound, for values sampled at 1
+// second intervals. NaN values are skipped.
+func timeInZones(values []float64, highs []float64) []time.Duration {
+	d := make([]time.Duration, len(highs)+1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			d[sort.SearchFloat64s(highs, v)] += time.Second
+		}
+	}
+	return d
+}
+
+// WPrimeSample is the W' balance at a point in time.
+type WPrimeSample struct {
+	Timestamp time.Time
+	Balance   float64 // J
+}
+
+// WPrimeBalance returns the W' balance at 1 second intervals using the
+// integral model of Skiba et al. (2012), for an athlete with critical power
+// cp in W and anaerobic work capacity wPrime in J. W' is expended above
+// critical power and recovers exponentially below it, with the time constant
+// 546 * e^(-0.01 * Dcp) + 316 seconds, where Dcp is the difference between
+// critical power and the average power of the samples below it. W' recovers
+// while the timer is stopped, but no balance is returned for such seconds.
+func WPrimeBalance(records []*fit.RecordMsg, cp, wPrime float64, opts ...Option) []WPrimeSample {
+	o := newOptions(opts)
+	ts, power := powerSeries(records, newTimer(o.events))
+
+	var (
+		below float64
+		n     int
+	)
+	for _, p := range power {
+		if p < cp {
+			below += p
+			n++
+		}
+	}
+	dcp := 0.0
+	if n > 0 {
+		dcp = cp - below/float64(n)
+	}
+	tau := 546*math.Exp(-0.01*dcp) + 316
+	decay := math.Exp(-1 / tau)
+
+	// The sum of expended W', each decayed by its age, can be updated
+	// incrementally instead of summing over all previous samples.
+	var (
+		expended float64
+		bal      []WPrimeSample
+	)
+	for i, p := range power {
+		expended *= decay
+		if math.IsNaN(p) {
+			continue
+		}
+		if p > cp {
+			expended += p - cp
+		}
+		bal = append(bal, WPrimeSample{Timestamp: ts[i], Balance: wPrime - expended})
+	}
+	return bal
+}
+
+// powerSeries returns the power of records resampled to 1 second
+// intervals, and the timestamps of the samples. Where a record's power is
+// invalid, or records are more than a second apart, the power of the
+// seconds since the record before it is the average given by their
+// accumulated power, if present. See Power.
+func powerSeries(records []*fit.RecordMsg, t *timer.Timer) ([]time.Time, []float64) {
+	s := resample(records, time.Second, t, []Field{FieldPower})
+	power := s.Values(FieldPower)
+	if len(power) == 0 {
+		return nil, nil
+	}
+	start := s.Timestamp[0]
+	var prev *fit.RecordMsg
+	for _, cur := range records {
+		if cur == nil {
+			continue
+		}
+		if prev != nil && cur.AccumulatedPower != 0xFFFFFFFF && prev.AccumulatedPower != 0xFFFFFFFF {
+			dt := cur.Timestamp.Sub(prev.Timestamp)
+			if dt > 0 && (cur.Power == 0xFFFF || dt > time.Second) && cur.AccumulatedPower >= prev.AccumulatedPower {
+				avg := float64(cur.AccumulatedPower-prev.AccumulatedPower) / dt.Seconds()
+				for ts := prev.Timestamp.Add(time.Second); !ts.After(cur.Timestamp); ts = ts.Add(time.Second) {
+					i := int(ts.Sub(start) / time.Second)
+					if i >= 0 && i < len(power) && t.Running(ts) {
+						power[i] = avg
+					}
+				}
+			}
+		}
+		if prev == nil || cur.Timestamp.After(prev.Timestamp) {
+			prev = cur
+		}
+	}
+	return s.Timestamp, power
+}
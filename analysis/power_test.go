@@ -0,0 +1,174 @@
+package analysis_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func constantPower(n int, p uint16) []*fit.RecordMsg {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	records := make([]*fit.RecordMsg, n)
+	for i := range records {
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = t0.Add(time.Duration(i) * time.Second)
+		records[i].Power = p
+	}
+	return records
+}
+
+func TestNormalizedPower(t *testing.T) {
+	if np := analysis.NormalizedPower(make([]float64, 29)); !math.IsNaN(np) {
+		t.Errorf("short series: got %v, want NaN", np)
+	}
+	power := make([]float64, 3600)
+	for i := range power {
+		power[i] = 250
+	}
+	if np := analysis.NormalizedPower(power); math.Abs(np-250) > 1e-9 {
+		t.Errorf("constant power: got %v, want 250", np)
+	}
+	// Alternating 60 s blocks of 0 and 400 W have a higher normalized power
+	// than their average of 200 W.
+	for i := range power {
+		power[i] = float64(i / 60 % 2 * 400)
+	}
+	if np := analysis.NormalizedPower(power); np <= 200 || np >= 400 {
+		t.Errorf("variable power: got %v, want between 200 and 400", np)
+	}
+	// The rolling average does not span pauses: 400 W blocks separated by
+	// pauses have a normalized power of 400 W.
+	for i := range power {
+		power[i] = 400
+		if i/60%2 == 1 {
+			power[i] = math.NaN()
+		}
+	}
+	if np := analysis.NormalizedPower(power); math.Abs(np-400) > 1e-9 {
+		t.Errorf("paused power: got %v, want 400", np)
+	}
+}
+
+func TestPowerPause(t *testing.T) {
+	records := constantPower(120, 300)
+	for _, r := range records[60:] {
+		r.Timestamp = r.Timestamp.Add(10 * time.Minute)
+	}
+	stop, start := fit.NewEventMsg(), fit.NewEventMsg()
+	stop.Timestamp, stop.Event, stop.EventType = records[59].Timestamp, fit.EventTimer, fit.EventTypeStopAll
+	start.Timestamp, start.Event, start.EventType = records[60].Timestamp, fit.EventTimer, fit.EventTypeStart
+	m := analysis.Power(records, 0, analysis.WithTimerEvents([]*fit.EventMsg{stop, start}))
+	if m.Duration != 120 || m.AvgPower != 300 || math.Abs(m.NormalizedPower-300) > 1e-9 {
+		t.Errorf("got duration %v, avg power %v, NP %v, want 120, 300, 300", m.Duration, m.AvgPower, m.NormalizedPower)
+	}
+}
+
+func TestTrainingStressScore(t *testing.T) {
+	if tss := analysis.TrainingStressScore(300, 300, 3600); tss != 100 {
+		t.Errorf("one hour at FTP: got %v, want 100", tss)
+	}
+}
+
+var sessionPowerTests = []struct {
+	path []string
+}{
+	{[]string{"dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit"}},
+	{[]string{"python-fitparse", "sample-activity-indoor-trainer.fit"}},
+}
+
+func TestSessionPower(t *testing.T) {
+	for _, test := range sessionPowerTests {
+		_, act := fittest.DecodeActivity(t, test.path...)
+		ses := act.Sessions[0]
+		m := analysis.SessionPower(act, ses, 0)
+		if m.FTP != float64(ses.ThresholdPower) {
+			t.Errorf("%s: FTP: got %v, want session threshold power %d", test.path[1], m.FTP, ses.ThresholdPower)
+		}
+		if !within(m.NormalizedPower, float64(ses.NormalizedPower), 1) {
+			t.Errorf("%s: NormalizedPower: got %v, want %d", test.path[1], m.NormalizedPower, ses.NormalizedPower)
+		}
+		if !within(m.IntensityFactor, ses.GetIntensityFactorScaled(), 0.005) {
+			t.Errorf("%s: IntensityFactor: got %v, want %v", test.path[1], m.IntensityFactor, ses.GetIntensityFactorScaled())
+		}
+		// Power is interpolated over dropouts, so the duration is the
+		// timer time, but devices differ in which duration they use for
+		// TSS.
+		if timer := ses.GetTotalTimerTimeScaled(); !within(m.Duration, timer, 5) {
+			t.Errorf("%s: Duration: got %v, want the timer time %v", test.path[1], m.Duration, timer)
+		}
+		if tss := ses.GetTrainingStressScoreScaled(); !within(m.TrainingStressScore, tss, tss*0.03) {
+			t.Errorf("%s: TrainingStressScore: got %v, want %v", test.path[1], m.TrainingStressScore, tss)
+		}
+		if m.VariabilityIndex < 1 {
+			t.Errorf("%s: VariabilityIndex: got %v, want >= 1", test.path[1], m.VariabilityIndex)
+		}
+	}
+}
+
+func TestPowerAccumulatedGap(t *testing.T) {
+	records := constantPower(10, 200)
+	for i, r := range records {
+		r.AccumulatedPower = uint32(200 * i)
+	}
+	// Power drops out for a few records, and one record is missing.
+	records[4].Power, records[5].Power = 0xFFFF, 0xFFFF
+	records = append(records[:7], records[8:]...)
+	m := analysis.Power(records, 0)
+	if m.Duration != 10 || m.AvgPower != 200 {
+		t.Errorf("got duration %v, avg power %v, want 10, 200", m.Duration, m.AvgPower)
+	}
+	if m.Work != 2 {
+		t.Errorf("Work: got %v kJ, want 2", m.Work)
+	}
+}
+
+func TestTimeInPowerZones(t *testing.T) {
+	records := append(constantPower(60, 100), constantPower(60, 200)...)
+	for i, r := range records[60:] {
+		r.Timestamp = records[59].Timestamp.Add(time.Duration(i+1) * time.Second)
+	}
+	zone := func(high uint16) *fit.PowerZoneMsg {
+		return &fit.PowerZoneMsg{MessageIndex: 0xFFFF, HighValue: high}
+	}
+	zones := []*fit.PowerZoneMsg{zone(250), zone(150)}
+	got := analysis.TimeInPowerZones(records, zones)
+	want := []time.Duration{time.Minute, time.Minute, 0}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestWPrimeBalance(t *testing.T) {
+	const cp, wPrime = 250, 20000
+	records := append(constantPower(100, 350), constantPower(600, 100)...)
+	for i, r := range records[100:] {
+		r.Timestamp = records[99].Timestamp.Add(time.Duration(i+1) * time.Second)
+	}
+	bal := analysis.WPrimeBalance(records, cp, wPrime)
+	if len(bal) != len(records) {
+		t.Fatalf("got %d samples, want %d", len(bal), len(records))
+	}
+	low := bal[99].Balance
+	if low > wPrime-8000 || low < wPrime-100*100 {
+		t.Errorf("balance after 100 s at 100 W above CP: got %v", low)
+	}
+	last := bal[len(bal)-1].Balance
+	if last <= low || last > wPrime {
+		t.Errorf("balance did not recover: got %v after %v", last, low)
+	}
+	for i := 1; i < 100; i++ {
+		if bal[i].Balance >= bal[i-1].Balance {
+			t.Fatalf("balance not decreasing above CP at %d", i)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/summary"
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// A LapStrategy decides where Relap starts new laps.
+type LapStrategy struct {
+	trigger fit.LapTrigger
+	// splits returns the increasing indices of the records at which new
+	// laps start. Index 0 is implied and must not be returned.
+	splits func(records []*fit.RecordMsg, t *timer.Timer) []int
+}
+
+// EveryDistance returns a strategy starting a new lap at the first record
+// every distance metres, like auto lap by distance.
+func EveryDistance(distance float64) LapStrategy {
+	return LapStrategy{
+		trigger: fit.LapTriggerDistance,
+		splits: func(records []*fit.RecordMsg, t *timer.Timer) []int {
+			var (
+				splits []int
+				start  float64
+				next   = distance
+				first  = true
+			)
+			for i, r := range records {
+				d := r.GetDistanceScaled()
+				if math.IsNaN(d) {
+					continue
+				}
+				if first {
+					start, first = d, false
+					continue
+				}
+				if distance <= 0 || d-start < next {
+					continue
+				}
+				splits = append(splits, i)
+				for d-start >= next {
+					next += distance
+				}
+			}
+			return splits
+		},
+	}
+}
+
+// EveryDuration returns a strategy starting a new lap at the first record
+// every d of timer time, like auto lap by time.
+func EveryDuration(d time.Duration) LapStrategy {
+	return LapStrategy{
+		trigger: fit.LapTriggerTime,
+		splits: func(records []*fit.RecordMsg, t *timer.Timer) []int {
+			var splits []int
+			if d <= 0 || len(records) == 0 {
+				return nil
+			}
+			next := d
+			for i, r := range records {
+				elapsed := t.Overlap(records[0].Timestamp, r.Timestamp)
+				if i == 0 || elapsed < next {
+					continue
+				}
+				splits = append(splits, i)
+				for elapsed >= next {
+					next += d
+				}
+			}
+			return splits
+		},
+	}
+}
+
+// AtPositions returns a strategy starting a new lap at the first record
+// within radius metres of any of positions, like auto lap by position. A
+// position must be left by more than twice radius before it can start
+// another lap, and positions the activity starts at only start laps once
+// they have been left.
+func AtPositions(positions []fit.Position, radius float64) LapStrategy {
+	return LapStrategy{
+		trigger: fit.LapTriggerPositionMarked,
+		splits: func(records []*fit.RecordMsg, t *timer.Timer) []int {
+			var (
+				splits []int
+				armed  = make([]bool, len(positions))
+				seen   bool
+			)
+			for i, r := range records {
+				p := fit.NewPosition(r.PositionLat, r.PositionLong)
+				if p.Invalid() {
+					continue
+				}
+				split := false
+				for j, q := range positions {
+					d := p.Haversine(q)
+					switch {
+					case d <= radius:
+						if armed[j] && seen {
+							split = true
+						}
+						armed[j] = false
+					case d > 2*radius:
+						armed[j] = true
+					}
+				}
+				seen = true
+				if split && i > 0 {
+					splits = append(splits, i)
+				}
+			}
+			return splits
+		},
+	}
+}
+
+// Relap replaces the laps of act with new laps split according to
+// strategy, and returns them. Laps are split within each session, and the
+// NumLaps and FirstLapIndex of the sessions are updated. If act has no
+// sessions, all records are split into laps. All aggregate fields
+// supported by Summary are recomputed from the records of each lap, as is
+// NormalizedPower, honouring the timer events of act; opts are passed to
+// Summarize. The last lap of each session is triggered by the session end.
+//
+// Consecutive laps share the record they are split at: it ends one lap
+// and starts the next.
+func Relap(act *fit.ActivityFile, strategy LapStrategy, opts ...Option) []*fit.LapMsg {
+	opts = append([]Option{WithTimerEvents(act.Events)}, opts...)
+	t := newTimer(newOptions(opts).events)
+
+	var laps []*fit.LapMsg
+	relap := func(records []*fit.RecordMsg, session *fit.SessionMsg) {
+		first := len(laps)
+		if len(records) > 0 {
+			bounds := []int{0}
+			for _, i := range strategy.splits(records, t) {
+				if i > bounds[len(bounds)-1] && i < len(records)-1 {
+					bounds = append(bounds, i)
+				}
+			}
+			bounds = append(bounds, len(records)-1)
+			for k := 0; k+1 < len(bounds); k++ {
+				trigger := strategy.trigger
+				if k+2 == len(bounds) {
+					trigger = fit.LapTriggerSessionEnd
+				}
+				lap := newLap(records[bounds[k]:bounds[k+1]+1], trigger, session, opts)
+				lap.MessageIndex = fit.MessageIndex(len(laps))
+				laps = append(laps, lap)
+			}
+		}
+		if session == nil {
+			return
+		}
+		session.NumLaps = uint16(len(laps) - first)
+		session.FirstLapIndex = 0xFFFF
+		if len(laps) > first {
+			session.FirstLapIndex = uint16(first)
+		}
+	}
+
+	if len(act.Sessions) == 0 {
+		relap(fit.RecordsBetween(act.Records, time.Time{}, time.Time{}), nil)
+	}
+	for _, s := range act.Sessions {
+		relap(sessionRecords(act.Records, s), s)
+	}
+	act.Laps = laps
+	return laps
+}
+
+// newLap returns a lap with its aggregate fields computed from records.
+func newLap(records []*fit.RecordMsg, trigger fit.LapTrigger, session *fit.SessionMsg, opts []Option) *fit.LapMsg {
+	lap := fit.NewLapMsg()
+	Summarize(records, opts...).SetLap(lap)
+	if np, ok := summary.Raw(Power(records, 0, opts...).NormalizedPower, 1, 0, 0xFFFF); ok {
+		lap.NormalizedPower = uint16(np)
+	}
+	lap.Event = fit.EventLap
+	lap.EventType = fit.EventTypeStop
+	lap.LapTrigger = trigger
+	if session != nil {
+		lap.Sport, lap.SubSport = session.Sport, session.SubSport
+	}
+	return lap
+}
@@ -0,0 +1,98 @@
+package analysis_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestRelapDistance(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit")
+	ses := act.Sessions[0]
+	laps := analysis.Relap(act, analysis.EveryDistance(1000))
+
+	want := int(math.Ceil(ses.GetTotalDistanceScaled() / 1000))
+	if len(laps) != want {
+		t.Fatalf("got %d laps, want %d", len(laps), want)
+	}
+	if len(act.Laps) != len(laps) || ses.NumLaps != uint16(len(laps)) || ses.FirstLapIndex != 0 {
+		t.Errorf("got %d activity laps, session NumLaps %d, FirstLapIndex %d, want %d, %d, 0",
+			len(act.Laps), ses.NumLaps, ses.FirstLapIndex, len(laps), len(laps))
+	}
+	var distance, timer float64
+	for i, lap := range laps {
+		if int(lap.MessageIndex) != i {
+			t.Errorf("lap %d: got MessageIndex %d", i, lap.MessageIndex)
+		}
+		if i > 0 && !lap.StartTime.Equal(laps[i-1].Timestamp) {
+			t.Errorf("lap %d: starts at %v, previous lap ends at %v", i, lap.StartTime, laps[i-1].Timestamp)
+		}
+		trigger := fit.LapTriggerDistance
+		if i == len(laps)-1 {
+			trigger = fit.LapTriggerSessionEnd
+		} else if d := lap.GetTotalDistanceScaled(); !within(d, 1000, 30) {
+			t.Errorf("lap %d: got distance %v, want about 1000", i, d)
+		}
+		if lap.LapTrigger != trigger || lap.Event != fit.EventLap || lap.Sport != ses.Sport {
+			t.Errorf("lap %d: got trigger %v, event %v, sport %v", i, lap.LapTrigger, lap.Event, lap.Sport)
+		}
+		distance += lap.GetTotalDistanceScaled()
+		timer += lap.GetTotalTimerTimeScaled()
+	}
+	if !within(distance, ses.GetTotalDistanceScaled(), 5) {
+		t.Errorf("got total lap distance %v, want %v", distance, ses.GetTotalDistanceScaled())
+	}
+	if !within(timer, ses.GetTotalTimerTimeScaled(), 2) {
+		t.Errorf("got total lap timer time %v, want %v", timer, ses.GetTotalTimerTimeScaled())
+	}
+	if laps[0].NormalizedPower == 0xFFFF || laps[0].AvgPower == 0xFFFF {
+		t.Error("power not set on first lap")
+	}
+}
+
+func TestRelapDuration(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "me", "activity-small-fenix2-run.fit")
+	laps := analysis.Relap(act, analysis.EveryDuration(5*time.Minute))
+	if len(laps) < 2 {
+		t.Fatalf("got %d laps, want at least 2", len(laps))
+	}
+	for i, lap := range laps[:len(laps)-1] {
+		if got := lap.GetTotalTimerTimeScaled(); !within(got, 300, 5) {
+			t.Errorf("lap %d: got timer time %v, want about 300", i, got)
+		}
+	}
+}
+
+func TestRelapPositions(t *testing.T) {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	start := fit.NewPositionDegrees(60, 10)
+	// Three laps of a 1 km out-and-back, passing the start twice.
+	var records []*fit.RecordMsg
+	for i := 0; i <= 600; i++ {
+		out := float64(i % 200)
+		if out > 100 {
+			out = 200 - out
+		}
+		p := start.Destination(90, out*10)
+		r := newRecord(t0.Add(time.Duration(i)*time.Second), float64(10*i), 10, 150, 200)
+		r.PositionLat, r.PositionLong = p.Lat, p.Long
+		records = append(records, r)
+	}
+	act := &fit.ActivityFile{Records: records}
+	laps := analysis.Relap(act, analysis.AtPositions([]fit.Position{start}, 5))
+	if len(laps) != 3 {
+		t.Fatalf("got %d laps, want 3", len(laps))
+	}
+	for i, lap := range laps {
+		if got := lap.GetTotalDistanceScaled(); !within(got, 2000, 0.01) {
+			t.Errorf("lap %d: got distance %v, want 2000", i, got)
+		}
+	}
+	if laps[0].LapTrigger != fit.LapTriggerPositionMarked {
+		t.Errorf("got trigger %v, want %v", laps[0].LapTrigger, fit.LapTriggerPositionMarked)
+	}
+}
@@ -0,0 +1,261 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// A Field selects a continuous record field. Values are scaled, in the units
+// given in the constant comments.
+type Field int
+
+// Record fields that can be analyzed and resampled.
+const (
+	FieldPower         Field = iota // W
+	FieldHeartRate                  // bpm
+	FieldSpeed                      // m/s, enhanced speed if present
+	FieldDistance                   // m
+	FieldAltitude                   // m, enhanced altitude if present
+	FieldCadence                    // rpm
+	FieldTemperature                // C
+	FieldGrade                      // %
+	FieldVerticalSpeed              // m/s
+
+	numFields
+)
+
+// value returns the value of f in r, or NaN if it is invalid.
+func (f Field) value(r *fit.RecordMsg) float64 {
+	switch f {
+	case FieldPower:
+		if r.Power == 0xFFFF {
+			return math.NaN()
+		}
+		return float64(r.Power)
+	case FieldHeartRate:
+		if r.HeartRate == 0xFF {
+			return math.NaN()
+		}
+		return float64(r.HeartRate)
+	case FieldSpeed:
+		return recordSpeed(r)
+	case FieldDistance:
+		return r.GetDistanceScaled()
+	case FieldAltitude:
+		return recordAltitude(r)
+	case FieldCadence:
+		if r.Cadence == 0xFF {
+			return math.NaN()
+		}
+		return float64(r.Cadence)
+	case FieldTemperature:
+		if r.Temperature == 0x7F {
+			return math.NaN()
+		}
+		return float64(r.Temperature)
+	case FieldGrade:
+		return r.GetGradeScaled()
+	case FieldVerticalSpeed:
+		return r.GetVerticalSpeedScaled()
+	}
+	panic("analysis: unknown field")
+}
+
+// A Series holds records resampled to a fixed interval. All slices have the
+// same length, one element per interval.
+type Series struct {
+	Interval     time.Duration
+	Timestamp    []time.Time
+	PositionLat  []fit.Latitude
+	PositionLong []fit.Longitude
+	ActivityType []fit.ActivityType
+	StrokeType   []fit.StrokeType
+
+	values [numFields][]float64
+}
+
+// Len returns the number of samples in s.
+func (s *Series) Len() int {
+	return len(s.Timestamp)
+}
+
+// Values returns the samples of f. Samples are NaN where no value could be
+// interpolated.
+func (s *Series) Values(f Field) []float64 {
+	return s.values[f]
+}
+
+// allFields lists every Field.
+var allFields = func() []Field {
+	fs := make([]Field, numFields)
+	for i := range fs {
+		fs[i] = Field(i)
+	}
+	return fs
+}()
+
+// maxSamples is the largest number of samples of a Series, about twelve
+// days at one second.
+const maxSamples = 1 << 20
+
+// Resample returns records resampled to a fixed interval, starting at the
+// timestamp of the first record. Records must be ordered by timestamp;
+// records with a timestamp not after the record before them are skipped, as
+// are records with a system time, written before the device clock was set.
+// An interval of zero or less means one second. The series is empty if it
+// would have more than about a million samples.
+//
+// Continuous fields are interpolated linearly between the nearest records
+// with a valid value, and positions along the great circle between them.
+// ActivityType and StrokeType hold the last valid value. A sample is NaN, or
+// invalid, if there is no valid value on both sides of it, and for every
+// sample where the timer was stopped according to the timer events given
+// with WithTimerEvents. Values are never interpolated across a pause.
+func Resample(records []*fit.RecordMsg, interval time.Duration, opts ...Option) *Series {
+	o := newOptions(opts)
+	return resample(records, interval, newTimer(o.events), allFields)
+}
+
+// resample is Resample for the given fields only. Positions, activity and
+// stroke types are only resampled if fields holds every field.
+func resample(records []*fit.RecordMsg, interval time.Duration, t *timer.Timer, fields []Field) *Series {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s := &Series{Interval: interval}
+
+	var rs []*fit.RecordMsg
+	for _, r := range records {
+		if r == nil || fit.IsSystemTime(r.Timestamp) || (len(rs) > 0 && !r.Timestamp.After(rs[len(rs)-1].Timestamp)) {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	if len(rs) == 0 {
+		return s
+	}
+
+	start := rs[0].Timestamp
+	span := rs[len(rs)-1].Timestamp.Sub(start) / interval
+	if span >= maxSamples {
+		return s
+	}
+	n := int(span) + 1
+	s.Timestamp = make([]time.Time, n)
+	running := make([]bool, n)
+	for i := range s.Timestamp {
+		s.Timestamp[i] = start.Add(time.Duration(i) * interval)
+		running[i] = t.Running(s.Timestamp[i])
+	}
+
+	for _, f := range fields {
+		var points []sample
+		for _, r := range rs {
+			if v := f.value(r); !math.IsNaN(v) {
+				points = append(points, sample{r.Timestamp, v})
+			}
+		}
+		vs := make([]float64, n)
+		interpolate(points, s.Timestamp, t, func(i int, a, b sample, frac float64) {
+			vs[i] = a.v + frac*(b.v-a.v)
+		}, func(i int) {
+			vs[i] = math.NaN()
+		})
+		s.values[f] = vs
+	}
+	for i := range running {
+		if running[i] {
+			continue
+		}
+		for _, f := range fields {
+			s.values[f][i] = math.NaN()
+		}
+	}
+	if len(fields) < int(numFields) {
+		return s
+	}
+
+	var positions []*fit.RecordMsg
+	for _, r := range rs {
+		if !r.PositionLat.Invalid() && !r.PositionLong.Invalid() {
+			positions = append(positions, r)
+		}
+	}
+	points := make([]sample, len(positions))
+	for i, r := range positions {
+		points[i] = sample{r.Timestamp, float64(i)}
+	}
+	s.PositionLat = make([]fit.Latitude, n)
+	s.PositionLong = make([]fit.Longitude, n)
+	interpolate(points, s.Timestamp, t, func(i int, a, b sample, frac float64) {
+		pa, pb := positions[int(a.v)], positions[int(b.v)]
+		p := fit.NewPosition(pa.PositionLat, pa.PositionLong).
+			Intermediate(fit.NewPosition(pb.PositionLat, pb.PositionLong), frac)
+		s.PositionLat[i], s.PositionLong[i] = p.Lat, p.Long
+	}, func(i int) {
+		s.PositionLat[i], s.PositionLong[i] = fit.NewLatitudeInvalid(), fit.NewLongitudeInvalid()
+	})
+
+	s.ActivityType = make([]fit.ActivityType, n)
+	s.StrokeType = make([]fit.StrokeType, n)
+	activity, stroke := fit.ActivityTypeInvalid, fit.StrokeTypeInvalid
+	j := 0
+	for i, ts := range s.Timestamp {
+		for ; j < len(rs) && !rs[j].Timestamp.After(ts); j++ {
+			if rs[j].ActivityType != fit.ActivityTypeInvalid {
+				activity = rs[j].ActivityType
+			}
+			if rs[j].StrokeType != fit.StrokeTypeInvalid {
+				stroke = rs[j].StrokeType
+			}
+		}
+		s.ActivityType[i], s.StrokeType[i] = activity, stroke
+	}
+
+	for i := range running {
+		if running[i] {
+			continue
+		}
+		s.PositionLat[i], s.PositionLong[i] = fit.NewLatitudeInvalid(), fit.NewLongitudeInvalid()
+		s.ActivityType[i], s.StrokeType[i] = fit.ActivityTypeInvalid, fit.StrokeTypeInvalid
+	}
+	return s
+}
+
+// sample is a value at a point in time.
+type sample struct {
+	t time.Time
+	v float64
+}
+
+// interpolate calls set for every timestamp in grid that is bracketed by
+// two points not separated by a pause, with the fraction of the way from
+// the first to the second point. A timestamp equal to a point's has a
+// fraction of zero. For other timestamps gap is called.
+func interpolate(points []sample, grid []time.Time, t *timer.Timer, set func(i int, a, b sample, frac float64), gap func(i int)) {
+	j := 0
+	for i, ts := range grid {
+		for j+1 < len(points) && !points[j+1].t.After(ts) {
+			j++
+		}
+		switch {
+		case len(points) == 0 || ts.Before(points[j].t):
+			gap(i)
+		case ts.Equal(points[j].t):
+			set(i, points[j], points[j], 0)
+		case j+1 < len(points):
+			a, b := points[j], points[j+1]
+			span := b.t.Sub(a.t)
+			if t.Overlap(a.t, b.t) != span {
+				gap(i)
+				continue
+			}
+			set(i, a, b, float64(ts.Sub(a.t))/float64(span))
+		default:
+			gap(i)
+		}
+	}
+}
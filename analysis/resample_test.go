@@ -0,0 +1,171 @@
+package analysis_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) != math.IsNaN(b[i]) || (!math.IsNaN(a[i]) && math.Abs(a[i]-b[i]) > 1e-9) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResampleInterpolation(t *testing.T) {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	var records []*fit.RecordMsg
+	for _, sec := range []int{0, 4, 5, 6} {
+		r := fit.NewRecordMsg()
+		r.Timestamp = t0.Add(time.Duration(sec) * time.Second)
+		r.Power = uint16(100 + 25*sec)
+		records = append(records, r)
+	}
+	records[1].HeartRate = 120
+	records[3].HeartRate = 140
+	records[0].ActivityType = fit.ActivityTypeCycling
+	records[2].ActivityType = fit.ActivityTypeRunning
+
+	s := analysis.Resample(records, 0)
+	if s.Len() != 7 || s.Interval != time.Second {
+		t.Fatalf("got %d samples at %v, want 7 at 1s", s.Len(), s.Interval)
+	}
+	if !s.Timestamp[6].Equal(records[3].Timestamp) {
+		t.Errorf("last timestamp: got %v, want %v", s.Timestamp[6], records[3].Timestamp)
+	}
+	nan := math.NaN()
+	if got, want := s.Values(analysis.FieldPower), []float64{100, 125, 150, 175, 200, 225, 250}; !equalFloats(got, want) {
+		t.Errorf("power: got %v, want %v", got, want)
+	}
+	if got, want := s.Values(analysis.FieldHeartRate), []float64{nan, nan, nan, nan, 120, 130, 140}; !equalFloats(got, want) {
+		t.Errorf("heart rate: got %v, want %v", got, want)
+	}
+	if got := s.Values(analysis.FieldAltitude); !math.IsNaN(got[0]) {
+		t.Errorf("altitude: got %v, want NaN", got)
+	}
+	wantActivity := []fit.ActivityType{
+		fit.ActivityTypeCycling, fit.ActivityTypeCycling, fit.ActivityTypeCycling,
+		fit.ActivityTypeCycling, fit.ActivityTypeCycling, fit.ActivityTypeRunning,
+		fit.ActivityTypeRunning,
+	}
+	for i, want := range wantActivity {
+		if s.ActivityType[i] != want {
+			t.Errorf("activity type %d: got %v, want %v", i, s.ActivityType[i], want)
+		}
+	}
+
+	s = analysis.Resample(records, 2*time.Second)
+	if got, want := s.Values(analysis.FieldPower), []float64{100, 150, 200, 250}; !equalFloats(got, want) {
+		t.Errorf("power at 2s: got %v, want %v", got, want)
+	}
+}
+
+func TestResampleTimes(t *testing.T) {
+	base := time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		times []time.Time
+		want  int
+	}{
+		{"base time", []time.Time{base.Add(5 * time.Second), date, date.Add(2 * time.Second)}, 3},
+		{"system time", []time.Time{base.Add(1000 * time.Hour), date}, 1},
+		{"only system time", []time.Time{base, base.Add(time.Second)}, 0},
+		{"too long", []time.Time{date, date.Add(30 * 24 * time.Hour)}, 0},
+	}
+	for _, test := range tests {
+		var records []*fit.RecordMsg
+		for _, ts := range test.times {
+			r := fit.NewRecordMsg()
+			r.Timestamp, r.Power = ts, 200
+			records = append(records, r)
+		}
+		if got := analysis.Resample(records, time.Second).Len(); got != test.want {
+			t.Errorf("%s: got %d samples, want %d", test.name, got, test.want)
+		}
+		if got := analysis.MeanMax(records, analysis.FieldPower, []time.Duration{time.Second}); test.want == 0 && len(got) != 0 {
+			t.Errorf("%s: got mean maximal power %v", test.name, got)
+		}
+	}
+}
+
+func TestResamplePause(t *testing.T) {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+	var records []*fit.RecordMsg
+	for _, sec := range []int{0, 2, 6, 8} {
+		r := fit.NewRecordMsg()
+		r.Timestamp = at(sec)
+		r.Power = 200
+		records = append(records, r)
+	}
+	timerEvent := func(ts time.Time, et fit.EventType) *fit.EventMsg {
+		e := fit.NewEventMsg()
+		e.Timestamp, e.Event, e.EventType = ts, fit.EventTimer, et
+		return e
+	}
+	events := []*fit.EventMsg{
+		timerEvent(at(0), fit.EventTypeStart),
+		timerEvent(at(2), fit.EventTypeStop),
+		timerEvent(at(6), fit.EventTypeStart),
+	}
+
+	s := analysis.Resample(records, time.Second, analysis.WithTimerEvents(events))
+	nan := math.NaN()
+	if got, want := s.Values(analysis.FieldPower), []float64{200, 200, 200, nan, nan, nan, 200, 200, 200}; !equalFloats(got, want) {
+		t.Errorf("power: got %v, want %v", got, want)
+	}
+}
+
+func TestResamplePosition(t *testing.T) {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	positions := [][2]float64{{0, 0}, {0, 1}, {60, 0}, {60, 1}}
+	var records []*fit.RecordMsg
+	for i, p := range positions {
+		r := fit.NewRecordMsg()
+		r.Timestamp = t0.Add(time.Duration(2*i) * time.Second)
+		r.PositionLat, r.PositionLong = fit.NewLatitudeDegrees(p[0]), fit.NewLongitudeDegrees(p[1])
+		records = append(records, r)
+	}
+	s := analysis.Resample(records, time.Second)
+	if s.Len() != 7 {
+		t.Fatalf("got %d samples, want 7", s.Len())
+	}
+	if lat, lng := s.PositionLat[1].Degrees(), s.PositionLong[1].Degrees(); math.Abs(lat) > 1e-6 || math.Abs(lng-0.5) > 1e-6 {
+		t.Errorf("midpoint on equator: got %v, %v, want 0, 0.5", lat, lng)
+	}
+	// The great circle between two points on the same parallel bulges
+	// towards the pole.
+	if lat := s.PositionLat[5].Degrees(); lat <= 60 {
+		t.Errorf("midpoint at 60N: got latitude %v, want > 60", lat)
+	}
+	if s.PositionLat[0] != records[0].PositionLat {
+		t.Errorf("sample at record: got %v, want %v", s.PositionLat[0], records[0].PositionLat)
+	}
+}
+
+func TestResampleActivity(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "me", "activity-small-fenix2-run.fit")
+	s := analysis.Resample(act.Records, time.Second, analysis.WithTimerEvents(act.Events))
+	first, last := act.Records[0].Timestamp, act.Records[len(act.Records)-1].Timestamp
+	if want := int(last.Sub(first)/time.Second) + 1; s.Len() != want {
+		t.Fatalf("got %d samples, want %d", s.Len(), want)
+	}
+	dist := s.Values(analysis.FieldDistance)
+	for _, r := range act.Records {
+		i := int(r.Timestamp.Sub(first) / time.Second)
+		if want := r.GetDistanceScaled(); !math.IsNaN(want) && !math.IsNaN(dist[i]) && dist[i] != want {
+			t.Fatalf("distance at %v: got %v, want %v", r.Timestamp, dist[i], want)
+		}
+	}
+}
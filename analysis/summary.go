@@ -0,0 +1,215 @@
+// Package analysis computes statistics from the records of FIT activity
+// files.
+package analysis
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/summary"
+)
+
+// DefaultMovingSpeed is the speed in m/s at or above which the athlete is
+// considered moving when no threshold is given to Summarize.
+const DefaultMovingSpeed = summary.DefaultMovingSpeed
+
+// A Summary holds aggregate values computed from a sequence of records. The
+// fields are named after the corresponding SessionMsg and LapMsg fields, but
+// hold scaled values in the units given in the field comments. A field is NaN
+// if it could not be computed, e.g. because no record had a valid value for
+// it.
+type Summary struct {
+	StartTime         time.Time // Timestamp of the first record.
+	Timestamp         time.Time // Timestamp of the last record.
+	StartPositionLat  fit.Latitude
+	StartPositionLong fit.Longitude
+	EndPositionLat    fit.Latitude
+	EndPositionLong   fit.Longitude
+
+	TotalElapsedTime float64 // s, includes pauses
+	TotalTimerTime   float64 // s, excludes pauses
+	TotalMovingTime  float64 // s, timer time spent at or above the moving speed
+	TotalDistance    float64 // m
+
+	AvgSpeed float64 // m/s, TotalDistance / TotalTimerTime
+	MaxSpeed float64 // m/s
+
+	AvgHeartRate float64 // bpm
+	MaxHeartRate float64 // bpm
+	MinHeartRate float64 // bpm
+
+	AvgCadence float64 // rpm
+	MaxCadence float64 // rpm
+
+	AvgPower  float64 // W
+	MaxPower  float64 // W
+	TotalWork float64 // J
+
+	TotalAscent   float64 // m
+	TotalDescent  float64 // m
+	TotalCalories float64 // kcal
+
+	AvgAltitude float64 // m
+	MinAltitude float64 // m
+	MaxAltitude float64 // m
+
+	AvgTemperature float64 // C
+	MaxTemperature float64 // C
+}
+
+type options struct {
+	events      []*fit.EventMsg
+	movingSpeed float64
+	hysteresis  float64
+	nonZero     bool
+}
+
+// Option configures an analysis.
+type Option func(*options)
+
+func newOptions(opts []Option) options {
+	o := options{
+		movingSpeed: DefaultMovingSpeed,
+		hysteresis:  DefaultAscentHysteresis,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTimerEvents configures an analysis to exclude the periods where the
+// timer was stopped according to the timer start and stop events in events.
+// Other events are ignored. Without timer events the timer is considered
+// running for the whole sequence of records.
+func WithTimerEvents(events []*fit.EventMsg) Option {
+	return func(o *options) {
+		o.events = events
+	}
+}
+
+// WithMovingSpeed sets the speed in m/s at or above which the athlete is
+// considered moving. The default is DefaultMovingSpeed.
+func WithMovingSpeed(speed float64) Option {
+	return func(o *options) {
+		o.movingSpeed = speed
+	}
+}
+
+// WithAscentHysteresis sets the hysteresis in metres used when computing
+// total ascent and descent. The default is DefaultAscentHysteresis. See
+// Ascent for details.
+func WithAscentHysteresis(hysteresis float64) Option {
+	return func(o *options) {
+		o.hysteresis = hysteresis
+	}
+}
+
+// WithNonZeroAverages excludes zero cadence and power values from the
+// averages, like the non_zero_avg_cadence and non_zero_avg_power settings of
+// a device do.
+func WithNonZeroAverages() Option {
+	return func(o *options) {
+		o.nonZero = true
+	}
+}
+
+// Summarize computes a summary of records, which must be ordered by
+// timestamp. Nil records are skipped.
+//
+// Only records logged while the timer was running contribute to the
+// summary. Averages are weighted by the timer time between a record and the
+// one before it, and fall back to the plain mean of the samples if no timer
+// time elapsed. Enhanced speed and altitude are preferred over their 16-bit
+// counterparts. The distance is computed from the accumulated record
+// distance if present, and from the record positions otherwise. Calories
+// are the difference between the accumulated calories of the last and first
+// record.
+func Summarize(records []*fit.RecordMsg, opts ...Option) *Summary {
+	o := newOptions(opts)
+	var rs []*fit.RecordMsg
+	for _, r := range records {
+		if r != nil {
+			rs = append(rs, r)
+		}
+	}
+	sum := summary.Compute(summary.Points(rs), summary.Options{
+		Timer:       newTimer(o.events),
+		MovingSpeed: o.movingSpeed,
+		Hysteresis:  o.hysteresis,
+		NonZero:     o.nonZero,
+		Dist: func(i, j int) float64 {
+			return recordPosition(rs[i]).Haversine(recordPosition(rs[j]))
+		},
+	})
+
+	s := &Summary{
+		StartTime:         sum.StartTime,
+		Timestamp:         sum.Timestamp,
+		StartPositionLat:  fit.NewLatitudeInvalid(),
+		StartPositionLong: fit.NewLongitudeInvalid(),
+		EndPositionLat:    fit.NewLatitudeInvalid(),
+		EndPositionLong:   fit.NewLongitudeInvalid(),
+	}
+	if i := sum.StartPosition; i >= 0 {
+		s.StartPositionLat, s.StartPositionLong = rs[i].PositionLat, rs[i].PositionLong
+	}
+	if i := sum.EndPosition; i >= 0 {
+		s.EndPositionLat, s.EndPositionLong = rs[i].PositionLat, rs[i].PositionLong
+	}
+	// Copy the values, which are named alike.
+	sv, v := reflect.ValueOf(sum).Elem(), reflect.ValueOf(s).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Kind() == reflect.Float64 {
+			f.SetFloat(sv.FieldByName(v.Type().Field(i).Name).Float())
+		}
+	}
+	return s
+}
+
+func recordPosition(r *fit.RecordMsg) fit.Position {
+	return fit.NewPosition(r.PositionLat, r.PositionLong)
+}
+
+// recordSpeed returns the speed of r in m/s, or NaN if r has no speed.
+func recordSpeed(r *fit.RecordMsg) float64 {
+	if v := r.GetEnhancedSpeedScaled(); !math.IsNaN(v) {
+		return v
+	}
+	return r.GetSpeedScaled()
+}
+
+// recordAltitude returns the altitude of r in m, or NaN if r has no
+// altitude.
+func recordAltitude(r *fit.RecordMsg) float64 {
+	if a := r.GetEnhancedAltitudeScaled(); !math.IsNaN(a) {
+		return a
+	}
+	return r.GetAltitudeScaled()
+}
+
+// SetSession sets the aggregate fields of msg to the values of s. Fields
+// that are NaN in s are left unchanged.
+func (s *Summary) SetSession(msg *fit.SessionMsg) {
+	s.setMsg(reflect.ValueOf(msg).Elem())
+}
+
+// SetLap sets the aggregate fields of msg to the values of s. Fields that
+// are NaN in s are left unchanged.
+func (s *Summary) SetLap(msg *fit.LapMsg) {
+	s.setMsg(reflect.ValueOf(msg).Elem())
+	if !s.EndPositionLat.Invalid() && !s.EndPositionLong.Invalid() {
+		msg.EndPositionLat, msg.EndPositionLong = s.EndPositionLat, s.EndPositionLong
+	}
+}
+
+// setMsg sets the fields that SessionMsg and LapMsg have in common from s.
+func (s *Summary) setMsg(msg reflect.Value) {
+	summary.SetMsg(msg, reflect.ValueOf(s).Elem())
+	if !s.StartPositionLat.Invalid() && !s.StartPositionLong.Invalid() {
+		msg.FieldByName("StartPositionLat").Set(reflect.ValueOf(s.StartPositionLat))
+		msg.FieldByName("StartPositionLong").Set(reflect.ValueOf(s.StartPositionLong))
+	}
+}
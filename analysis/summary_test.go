@@ -0,0 +1,189 @@
+package analysis_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+var ascentTests = []struct {
+	alts         []float64
+	hysteresis   float64
+	ascent, desc float64
+}{
+	{nil, 3, 0, 0},
+	{[]float64{100}, 3, 0, 0},
+	{[]float64{100, 101, 100, 101, 100}, 3, 0, 0},
+	{[]float64{100, 101, 102, 103, 104, 103, 105}, 3, 5, 0},
+	{[]float64{100, 110, 108, 112, 100}, 3, 12, 12},
+	{[]float64{100, 110, math.NaN(), 100}, 3, 10, 10},
+	{[]float64{100, 101, 100, 101, 100}, 0, 2, 2},
+}
+
+func TestAscent(t *testing.T) {
+	for i, test := range ascentTests {
+		asc, desc := analysis.Ascent(test.alts, test.hysteresis)
+		if asc != test.ascent || desc != test.desc {
+			t.Errorf("%d: got %v/%v, want %v/%v", i, asc, desc, test.ascent, test.desc)
+		}
+	}
+}
+
+func newRecord(ts time.Time, dist, speed float64, hr, power uint16) *fit.RecordMsg {
+	r := fit.NewRecordMsg()
+	r.Timestamp = ts
+	r.Distance = uint32(dist * 100)
+	r.EnhancedSpeed = uint32(speed * 1000)
+	r.HeartRate = uint8(hr)
+	r.Power = power
+	return r
+}
+
+func TestSummarizeTimerEvents(t *testing.T) {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+
+	var records []*fit.RecordMsg
+	// Ten seconds of riding at 5 m/s, a 60 second pause, and another ten
+	// seconds at 5 m/s.
+	for i := 0; i <= 10; i++ {
+		records = append(records, newRecord(at(i), float64(5*i), 5, 150, 200))
+	}
+	for i := 70; i <= 80; i++ {
+		records = append(records, newRecord(at(i), float64(5*(i-60)), 5, 150, 200))
+	}
+	// A record while paused that must be ignored.
+	paused := newRecord(at(40), 50, 0, 100, 0)
+	records = append(records[:11], append([]*fit.RecordMsg{paused}, records[11:]...)...)
+
+	timerEvent := func(ts time.Time, et fit.EventType) *fit.EventMsg {
+		e := fit.NewEventMsg()
+		e.Timestamp = ts
+		e.Event = fit.EventTimer
+		e.EventType = et
+		return e
+	}
+	events := []*fit.EventMsg{
+		timerEvent(at(0), fit.EventTypeStart),
+		timerEvent(at(10), fit.EventTypeStop),
+		timerEvent(at(70), fit.EventTypeStart),
+		timerEvent(at(80), fit.EventTypeStopAll),
+	}
+
+	s := analysis.Summarize(records, analysis.WithTimerEvents(events))
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"TotalElapsedTime", s.TotalElapsedTime, 80},
+		{"TotalTimerTime", s.TotalTimerTime, 20},
+		{"TotalMovingTime", s.TotalMovingTime, 20},
+		{"TotalDistance", s.TotalDistance, 100},
+		{"AvgSpeed", s.AvgSpeed, 5},
+		{"MaxSpeed", s.MaxSpeed, 5},
+		{"AvgHeartRate", s.AvgHeartRate, 150},
+		{"MinHeartRate", s.MinHeartRate, 150},
+		{"AvgPower", s.AvgPower, 200},
+		{"TotalWork", s.TotalWork, 4000},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if !math.IsNaN(s.AvgCadence) {
+		t.Errorf("AvgCadence: got %v, want NaN", s.AvgCadence)
+	}
+
+	// Without events the pause counts as timer time.
+	s = analysis.Summarize(records)
+	if s.TotalTimerTime != 80 {
+		t.Errorf("TotalTimerTime without events: got %v, want 80", s.TotalTimerTime)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	s := analysis.Summarize(nil)
+	if !math.IsNaN(s.TotalTimerTime) || !math.IsNaN(s.AvgHeartRate) {
+		t.Errorf("got %+v, want NaN values", s)
+	}
+	msg := fit.NewSessionMsg()
+	s.SetSession(msg)
+	if msg.TotalTimerTime != 0xFFFFFFFF || msg.AvgHeartRate != 0xFF {
+		t.Errorf("SetSession: NaN values changed the message")
+	}
+}
+
+func within(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+func TestSummarizeSession(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit")
+	s := analysis.Summarize(act.Records, analysis.WithTimerEvents(act.Events))
+	ses := act.Sessions[0]
+
+	checks := []struct {
+		name           string
+		got, want, tol float64
+	}{
+		{"TotalTimerTime", s.TotalTimerTime, ses.GetTotalTimerTimeScaled(), 2},
+		{"TotalDistance", s.TotalDistance, ses.GetTotalDistanceScaled(), 5},
+		{"AvgSpeed", s.AvgSpeed, ses.GetAvgSpeedScaled(), 0.01},
+		{"MaxSpeed", s.MaxSpeed, ses.GetMaxSpeedScaled(), 0},
+		{"AvgHeartRate", s.AvgHeartRate, float64(ses.AvgHeartRate), 1},
+		{"MaxHeartRate", s.MaxHeartRate, float64(ses.MaxHeartRate), 0},
+		{"AvgCadence", s.AvgCadence, float64(ses.AvgCadence), 1},
+		{"MaxCadence", s.MaxCadence, float64(ses.MaxCadence), 0},
+		{"AvgPower", s.AvgPower, float64(ses.AvgPower), 1},
+		{"MaxPower", s.MaxPower, float64(ses.MaxPower), 0},
+	}
+	for _, c := range checks {
+		if !within(c.got, c.want, c.tol) {
+			t.Errorf("%s: got %v, want %v ± %v", c.name, c.got, c.want, c.tol)
+		}
+	}
+
+	msg := fit.NewSessionMsg()
+	s.SetSession(msg)
+	if msg.MaxPower != ses.MaxPower {
+		t.Errorf("SetSession: MaxPower: got %d, want %d", msg.MaxPower, ses.MaxPower)
+	}
+	if !msg.StartTime.Equal(act.Records[0].Timestamp) {
+		t.Errorf("SetSession: StartTime: got %v, want %v", msg.StartTime, act.Records[0].Timestamp)
+	}
+	if got := msg.GetTotalDistanceScaled(); !within(got, s.TotalDistance, 0.01) {
+		t.Errorf("SetSession: TotalDistance: got %v, want %v", got, s.TotalDistance)
+	}
+	if got := msg.GetEnhancedMaxAltitudeScaled(); !within(got, s.MaxAltitude, 0.1) {
+		t.Errorf("SetSession: EnhancedMaxAltitude: got %v, want %v", got, s.MaxAltitude)
+	}
+	if got := float64(msg.MaxTemperature); math.IsNaN(s.MaxTemperature) || got != s.MaxTemperature {
+		t.Errorf("SetSession: MaxTemperature: got %v, want %v", got, s.MaxTemperature)
+	}
+}
+
+func TestSummarizeLap(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, "me", "activity-small-fenix2-run.fit")
+	for i, lap := range act.Laps {
+		var records []*fit.RecordMsg
+		for _, r := range act.Records {
+			if !r.Timestamp.Before(lap.StartTime) && !r.Timestamp.After(lap.Timestamp) {
+				records = append(records, r)
+			}
+		}
+		s := analysis.Summarize(records, analysis.WithTimerEvents(act.Events))
+		msg := fit.NewLapMsg()
+		s.SetLap(msg)
+		if !within(msg.GetTotalTimerTimeScaled(), lap.GetTotalTimerTimeScaled(), 2) {
+			t.Errorf("lap %d: TotalTimerTime: got %v, want %v", i, msg.GetTotalTimerTimeScaled(), lap.GetTotalTimerTimeScaled())
+		}
+		if msg.EndPositionLat.Invalid() {
+			t.Errorf("lap %d: EndPositionLat not set", i)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package analysis
+
+import (
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/summary"
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// newTimer returns a timer built from the timer start and stop events in
+// events, the way package fit builds it; see timer.New.
+func newTimer(events []*fit.EventMsg) *timer.Timer {
+	return summary.Timer(events)
+}
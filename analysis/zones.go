@@ -0,0 +1,196 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// hrZoneCalcPercentLthr is the percent of lactate threshold heart rate zone
+// calculation, hr_zone_calc value percent_lthr (3). It was added to the
+// profile in later FIT SDK versions than the one fit is generated from.
+const hrZoneCalcPercentLthr fit.HrZoneCalc = 3
+
+// Zones holds the upper bounds of training zones, in increasing order. A
+// nil slice means no zones for that field.
+type Zones struct {
+	HeartRate []float64 // bpm
+	Power     []float64 // W
+	Speed     []float64 // m/s
+	Cadence   []float64 // rpm
+}
+
+// SportZones returns the zones of sport. Heart rate zones are converted
+// to beats per minute according to the HrCalcType of the sport's zones
+// target: for percent of maximum heart rate, percent of heart rate reserve
+// and percent of lactate threshold heart rate, HighBpm holds a percentage
+// of MaxHeartRate, of the reserve between restingHR and MaxHeartRate, or of
+// ThresholdHeartRate. Likewise, power zones hold a percentage of
+// FunctionalThresholdPower if PwrCalcType is percent of FTP. Zones with an
+// invalid high value are ignored. An error is returned if a value needed
+// for the conversion is missing.
+func SportZones(sport *fit.SportFile, restingHR uint8) (*Zones, error) {
+	target := sport.ZonesTarget
+	if target == nil {
+		target = fit.NewZonesTargetMsg()
+	}
+	z := new(Zones)
+
+	var hrs []float64
+	for _, h := range sport.HrZones {
+		if h != nil && h.HighBpm != 0xFF {
+			hrs = append(hrs, float64(h.HighBpm))
+		}
+	}
+	switch target.HrCalcType {
+	case fit.HrZoneCalcPercentMaxHr:
+		if target.MaxHeartRate == 0xFF {
+			return nil, errors.New("analysis: zones target has no max heart rate")
+		}
+		z.HeartRate = percentOf(hrs, 0, float64(target.MaxHeartRate))
+	case fit.HrZoneCalcPercentHrr:
+		if target.MaxHeartRate == 0xFF || restingHR == 0xFF || restingHR == 0 {
+			return nil, errors.New("analysis: heart rate reserve needs max and resting heart rate")
+		}
+		z.HeartRate = percentOf(hrs, float64(restingHR), float64(target.MaxHeartRate))
+	case hrZoneCalcPercentLthr:
+		if target.ThresholdHeartRate == 0xFF {
+			return nil, errors.New("analysis: zones target has no threshold heart rate")
+		}
+		z.HeartRate = percentOf(hrs, 0, float64(target.ThresholdHeartRate))
+	case fit.HrZoneCalcCustom, fit.HrZoneCalcInvalid:
+		z.HeartRate = hrs
+	default:
+		return nil, fmt.Errorf("analysis: unknown heart rate zone calculation %v", target.HrCalcType)
+	}
+
+	var pwrs []float64
+	for _, p := range sport.PowerZones {
+		if p != nil && p.HighValue != 0xFFFF {
+			pwrs = append(pwrs, float64(p.HighValue))
+		}
+	}
+	switch target.PwrCalcType {
+	case fit.PwrZoneCalcPercentFtp:
+		if target.FunctionalThresholdPower == 0xFFFF {
+			return nil, errors.New("analysis: zones target has no functional threshold power")
+		}
+		z.Power = percentOf(pwrs, 0, float64(target.FunctionalThresholdPower))
+	case fit.PwrZoneCalcCustom, fit.PwrZoneCalcInvalid:
+		z.Power = pwrs
+	default:
+		return nil, fmt.Errorf("analysis: unknown power zone calculation %v", target.PwrCalcType)
+	}
+
+	for _, s := range sport.SpeedZones {
+		if s != nil && s.HighValue != 0xFFFF {
+			z.Speed = append(z.Speed, s.GetHighValueScaled())
+		}
+	}
+	for _, c := range sport.CadenceZones {
+		if c != nil && c.HighValue != 0xFF {
+			z.Cadence = append(z.Cadence, float64(c.HighValue))
+		}
+	}
+
+	for _, vs := range [][]float64{z.HeartRate, z.Power, z.Speed, z.Cadence} {
+		sort.Float64s(vs)
+	}
+	return z, nil
+}
+
+// percentOf converts the percentages pcts of the range from base to top to
+// absolute values.
+func percentOf(pcts []float64, base, top float64) []float64 {
+	if pcts == nil {
+		return nil
+	}
+	vs := make([]float64, len(pcts))
+	for i, p := range pcts {
+		vs[i] = base + p/100*(top-base)
+	}
+	return vs
+}
+
+// ZoneTimes holds the time spent in training zones. Each slice has one
+// element more than the corresponding zones, holding the time spent above
+// the highest zone, which is the layout of the TimeInHrZone,
+// TimeInPowerZone, TimeInSpeedZone and TimeInCadenceZone fields of session
+// and lap messages. A second with value v is counted in the first zone with
+// v <= its upper bound.
+type ZoneTimes struct {
+	HeartRate []time.Duration
+	Power     []time.Duration
+	Speed     []time.Duration
+	Cadence   []time.Duration
+}
+
+// TimeInZones returns the time spent in zones while the timer was
+// running; see WithTimerEvents. Records are resampled to 1 second
+// intervals; see Resample, and Power for how power is resampled. Fields
+// without zones are nil.
+func TimeInZones(records []*fit.RecordMsg, zones *Zones, opts ...Option) *ZoneTimes {
+	o := newOptions(opts)
+	t := newTimer(o.events)
+	s := resample(records, time.Second, t, []Field{FieldHeartRate, FieldSpeed, FieldCadence})
+	zt := new(ZoneTimes)
+	fieldTimes := func(f Field, highs []float64) []time.Duration {
+		if highs == nil {
+			return nil
+		}
+		return timeInZones(s.Values(f), highs)
+	}
+	zt.HeartRate = fieldTimes(FieldHeartRate, zones.HeartRate)
+	zt.Speed = fieldTimes(FieldSpeed, zones.Speed)
+	zt.Cadence = fieldTimes(FieldCadence, zones.Cadence)
+	if zones.Power != nil {
+		_, power := powerSeries(records, t)
+		zt.Power = timeInZones(power, zones.Power)
+	}
+	return zt
+}
+
+// SetSession sets the time in zone fields of msg to the times of z. Fields
+// without zones are left unchanged.
+func (z *ZoneTimes) SetSession(msg *fit.SessionMsg) {
+	setZoneTimes(&msg.TimeInHrZone, z.HeartRate)
+	setZoneTimes(&msg.TimeInPowerZone, z.Power)
+	setZoneTimes(&msg.TimeInSpeedZone, z.Speed)
+	setZoneTimes(&msg.TimeInCadenceZone, z.Cadence)
+}
+
+// SetLap sets the time in zone fields of msg to the times of z. Fields
+// without zones are left unchanged.
+func (z *ZoneTimes) SetLap(msg *fit.LapMsg) {
+	setZoneTimes(&msg.TimeInHrZone, z.HeartRate)
+	setZoneTimes(&msg.TimeInPowerZone, z.Power)
+	setZoneTimes(&msg.TimeInSpeedZone, z.Speed)
+	setZoneTimes(&msg.TimeInCadenceZone, z.Cadence)
+}
+
+func setZoneTimes(dst *[]uint32, ds []time.Duration) {
+	if ds == nil {
+		return
+	}
+	ms := make([]uint32, len(ds))
+	for i, d := range ds {
+		ms[i] = uint32(d / time.Millisecond)
+	}
+	*dst = ms
+}
+
+// SetTimeInZones sets the time in zone fields of every session and lap of
+// act to the time spent in zones by its records, honouring the timer
+// events of act.
+func SetTimeInZones(act *fit.ActivityFile, zones *Zones) {
+	events := WithTimerEvents(act.Events)
+	for _, s := range act.Sessions {
+		TimeInZones(sessionRecords(act.Records, s), zones, events).SetSession(s)
+	}
+	for _, l := range act.Laps {
+		TimeInZones(fit.RecordsBetween(act.Records, l.StartTime, l.Timestamp), zones, events).SetLap(l)
+	}
+}
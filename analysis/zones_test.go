@@ -0,0 +1,94 @@
+package analysis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+func sportFile(calc fit.HrZoneCalc, hrs ...uint8) *fit.SportFile {
+	target := fit.NewZonesTargetMsg()
+	target.MaxHeartRate, target.ThresholdHeartRate = 200, 170
+	target.FunctionalThresholdPower = 250
+	target.HrCalcType, target.PwrCalcType = calc, fit.PwrZoneCalcPercentFtp
+	sport := &fit.SportFile{ZonesTarget: target}
+	for i, hr := range hrs {
+		z := fit.NewHrZoneMsg()
+		z.MessageIndex, z.HighBpm = fit.MessageIndex(i), hr
+		sport.HrZones = append(sport.HrZones, z)
+	}
+	for _, pct := range []uint16{120, 55, 75, 90, 105} {
+		z := fit.NewPowerZoneMsg()
+		z.HighValue = pct
+		sport.PowerZones = append(sport.PowerZones, z)
+	}
+	return sport
+}
+
+func TestSportZones(t *testing.T) {
+	for _, tt := range []struct {
+		calc fit.HrZoneCalc
+		hrs  []uint8
+		want []float64
+	}{
+		{fit.HrZoneCalcCustom, []uint8{120, 140, 160}, []float64{120, 140, 160}},
+		{fit.HrZoneCalcPercentMaxHr, []uint8{60, 70, 80}, []float64{120, 140, 160}},
+		// Reserve of 150 bpm above 50 bpm resting.
+		{fit.HrZoneCalcPercentHrr, []uint8{60, 80}, []float64{140, 170}},
+		{3, []uint8{80, 100}, []float64{136, 170}}, // Percent of LTHR.
+	} {
+		z, err := analysis.SportZones(sportFile(tt.calc, tt.hrs...), 50)
+		if err != nil {
+			t.Errorf("calc %v: %v", tt.calc, err)
+			continue
+		}
+		if !equalFloats(z.HeartRate, tt.want) {
+			t.Errorf("calc %v: got heart rate zones %v, want %v", tt.calc, z.HeartRate, tt.want)
+		}
+		if want := []float64{137.5, 187.5, 225, 262.5, 300}; !equalFloats(z.Power, want) {
+			t.Errorf("calc %v: got power zones %v, want %v", tt.calc, z.Power, want)
+		}
+	}
+
+	sport := sportFile(fit.HrZoneCalcPercentHrr, 60)
+	if _, err := analysis.SportZones(sport, 0xFF); err == nil {
+		t.Error("heart rate reserve without resting heart rate: got no error")
+	}
+}
+
+func TestTimeInZones(t *testing.T) {
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	var records []*fit.RecordMsg
+	for i := 0; i < 120; i++ {
+		hr := uint16(130)
+		if i >= 60 {
+			hr = 170
+		}
+		records = append(records, newRecord(t0.Add(time.Duration(i)*time.Second), 0, 5, hr, 0xFFFF))
+	}
+	zones := &analysis.Zones{HeartRate: []float64{120, 140, 160}}
+	zt := analysis.TimeInZones(records, zones)
+	want := []time.Duration{0, 60 * time.Second, 0, 60 * time.Second}
+	if len(zt.HeartRate) != len(want) {
+		t.Fatalf("got %v, want %v", zt.HeartRate, want)
+	}
+	for i := range want {
+		if zt.HeartRate[i] != want[i] {
+			t.Errorf("zone %d: got %v, want %v", i, zt.HeartRate[i], want[i])
+		}
+	}
+	if zt.Power != nil {
+		t.Errorf("got power times %v without power zones", zt.Power)
+	}
+
+	msg := fit.NewSessionMsg()
+	zt.SetSession(msg)
+	if got := msg.TimeInHrZone; len(got) != 4 || got[1] != 60000 || got[3] != 60000 {
+		t.Errorf("SetSession: got TimeInHrZone %v, want [0 60000 0 60000]", got)
+	}
+	if msg.TimeInPowerZone != nil {
+		t.Errorf("SetSession: got TimeInPowerZone %v, want unchanged", msg.TimeInPowerZone)
+	}
+}
@@ -0,0 +1,280 @@
+// Command fitconv converts between FIT files and other formats. The formats
+// are given by the file extensions:
+//
+//	.fit      FIT
+//	.json     JSON, as produced by the fit package
+//	.gpx      GPX 1.1 track, with heart rate and cadence extensions
+//	.tcx      Training Center XML activity or course
+//	.csv      one row per record (output only)
+//	.geojson  GeoJSON feature collection (output only)
+//	.kml      KML document (output only)
+//
+// Activity and course FIT files can be converted to all formats. GPX input
+// is converted to a course, and TCX input to an activity or a course, so
+// they can be written as FIT files for devices.
+//
+// Usage:
+//
+//	fitconv [flags] in.fit out.gpx
+//	fitconv [flags] -to gpx indir outdir
+//
+// Given directories, fitconv converts every .fit file in indir and its
+// subdirectories to the format given by -to, writing the results to the
+// same relative paths in outdir. Files are converted in parallel.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/convert"
+	"github.com/tormoder/fit/internal/parallel"
+	"github.com/tormoder/fit/privacy"
+)
+
+var (
+	to           = flag.String("to", "", "output format when converting directories, such as gpx")
+	sportName    = flag.String("sport", "", "set the sport of courses, sessions and laps, such as running")
+	manufacturer = flag.String("manufacturer", "", "set the manufacturer in the file id, by name or number")
+	product      = flag.Uint("product", 0, "set the product in the file id")
+	anonymize    = flag.Bool("anonymize", false, "remove serial numbers and user profile data")
+	trim         = flag.Float64("trim", 0, "hide positions within `metres` of the start and end of tracks (implies -anonymize)")
+	workers      = flag.Int("j", runtime.NumCPU(), "number of files to convert in parallel")
+	zones        zoneFlag
+)
+
+func init() {
+	flag.Var(&zones, "zone", "hide positions within a zone given as `lat,lng,radius` in degrees and metres; may be repeated (implies -anonymize)")
+}
+
+func main() {
+	l := log.New(os.Stderr, "fitconv: ", 0)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: fitconv [flags] in out\n       fitconv [flags] -to format indir outdir\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c, err := newConverter()
+	if err != nil {
+		l.Fatal(err)
+	}
+	in, out := flag.Arg(0), flag.Arg(1)
+	fi, err := os.Stat(in)
+	if err != nil {
+		l.Fatal(err)
+	}
+	if !fi.IsDir() {
+		if err := c.convert(in, out); err != nil {
+			l.Fatalf("%s: %v", in, err)
+		}
+		return
+	}
+
+	if *to == "" {
+		l.Fatal("-to is required when converting a directory")
+	}
+	ext := "." + strings.TrimPrefix(*to, ".")
+	if _, ok := convert.Writers[ext]; !ok {
+		l.Fatalf("unknown output format %q", *to)
+	}
+	if !convertDir(c, in, out, ext, *workers, l) {
+		os.Exit(1)
+	}
+}
+
+// convertDir converts the .fit files in the directory in to files with the
+// extension ext in the directory out. It reports whether all files were
+// converted.
+func convertDir(c *converter, in, out, ext string, n int, l *log.Logger) bool {
+	var paths []string
+	err := filepath.Walk(in, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() && strings.EqualFold(filepath.Ext(path), ".fit") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		l.Print(err)
+		return false
+	}
+
+	var (
+		mu sync.Mutex
+		ok = true
+	)
+	parallel.Do(len(paths), n, func(i int) {
+		path := paths[i]
+		rel, _ := filepath.Rel(in, path)
+		dst := filepath.Join(out, strings.TrimSuffix(rel, filepath.Ext(rel))+ext)
+		err := os.MkdirAll(filepath.Dir(dst), 0777)
+		if err == nil {
+			err = c.convert(path, dst)
+		}
+		if err != nil {
+			mu.Lock()
+			l.Printf("%s: %v", path, err)
+			ok = false
+			mu.Unlock()
+		}
+	})
+	return ok
+}
+
+// converter converts single files. It is safe for concurrent use.
+type converter struct {
+	sport        fit.Sport
+	setSport     bool
+	manufacturer fit.Manufacturer
+	setMfr       bool
+	product      uint16
+	privacy      []privacy.Option
+	anonymize    bool
+}
+
+func newConverter() (*converter, error) {
+	c := &converter{product: uint16(*product)}
+	if *sportName != "" {
+		s, err := convert.ParseSport(*sportName)
+		if err != nil {
+			return nil, err
+		}
+		c.sport, c.setSport = s, true
+	}
+	if *manufacturer != "" {
+		m, err := parseManufacturer(*manufacturer)
+		if err != nil {
+			return nil, err
+		}
+		c.manufacturer, c.setMfr = m, true
+	}
+	if *trim > 0 {
+		c.privacy = append(c.privacy, privacy.WithTrim(*trim))
+	}
+	for _, z := range zones {
+		c.privacy = append(c.privacy, privacy.WithZone(z.Center, z.Radius))
+	}
+	c.anonymize = *anonymize || len(c.privacy) > 0
+	return c, nil
+}
+
+func (c *converter) convert(in, out string) error {
+	read, ok := convert.Readers[strings.ToLower(filepath.Ext(in))]
+	if !ok {
+		return fmt.Errorf("unknown input format %q", filepath.Ext(in))
+	}
+	write, ok := convert.Writers[strings.ToLower(filepath.Ext(out))]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", filepath.Ext(out))
+	}
+	r, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	f, err := read(r)
+	r.Close()
+	if err != nil {
+		return err
+	}
+	c.apply(f)
+
+	w, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := write(w, f); err != nil {
+		w.Close()
+		os.Remove(out)
+		return err
+	}
+	return w.Close()
+}
+
+// apply applies the flags to f.
+func (c *converter) apply(f *fit.File) {
+	if c.setMfr {
+		f.FileId.Manufacturer = c.manufacturer
+	}
+	if c.product != 0 {
+		f.FileId.Product = c.product
+	}
+	if c.setSport {
+		switch f.Type() {
+		case fit.FileTypeActivity:
+			act, _ := f.Activity()
+			for _, s := range act.Sessions {
+				s.Sport = c.sport
+			}
+			for _, l := range act.Laps {
+				l.Sport = c.sport
+			}
+		case fit.FileTypeCourse:
+			course, _ := f.Course()
+			if course.Course != nil {
+				course.Course.Sport = c.sport
+			}
+			for _, l := range course.Laps {
+				l.Sport = c.sport
+			}
+		}
+	}
+	if c.anonymize {
+		privacy.Anonymize(f, c.privacy...)
+	}
+}
+
+func parseManufacturer(s string) (fit.Manufacturer, error) {
+	if n, err := strconv.ParseUint(s, 10, 16); err == nil {
+		return fit.Manufacturer(n), nil
+	}
+	for i := 0; i < 0xFFFF; i++ {
+		if m := fit.Manufacturer(i); strings.EqualFold(m.String(), s) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown manufacturer %q", s)
+}
+
+// zoneFlag is a repeatable flag of privacy zones.
+type zoneFlag []privacy.Zone
+
+func (z *zoneFlag) String() string {
+	var s []string
+	for _, zone := range *z {
+		s = append(s, fmt.Sprintf("%.6f,%.6f,%g", zone.Center.Lat.Degrees(), zone.Center.Long.Degrees(), zone.Radius))
+	}
+	return strings.Join(s, " ")
+}
+
+func (z *zoneFlag) Set(s string) error {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return errors.New("zone must be lat,lng,radius")
+	}
+	var v [3]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return err
+		}
+		v[i] = f
+	}
+	*z = append(*z, privacy.Zone{Center: fit.NewPositionDegrees(v[0], v[1]), Radius: v[2]})
+	return nil
+}
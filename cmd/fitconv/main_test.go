@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tormoder/fit/internal/convert"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestConvertDir(t *testing.T) {
+	in, out := tempDir(t), tempDir(t)
+	defer os.RemoveAll(in)
+	defer os.RemoveAll(out)
+	files := map[string][]string{
+		"run.fit":            {"me", "activity-small-fenix2-run.fit"},
+		"a/b/ride.FIT":       {"dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit"},
+		"a/activity.fit":     {"fitsdk", "Activity.fit"},
+		"settings.fit":       {"fitsdk", "Settings.fit"},
+		"a/notes.txt":        {"dcrainmaker", "Ride notes.txt"},
+		"a/b/c/multi.fit":    {"me", "activity-large-fenxi2-multisport.fit"},
+		"a/b/c/run-copy.fit": {"me", "activity-small-fenix2-run.fit"},
+	}
+	for name, path := range files {
+		dst := filepath.Join(in, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(dst, fittest.Read(t, path...), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var logBuf bytes.Buffer
+	if convertDir(new(converter), in, out, ".gpx", 3, log.New(&logBuf, "", 0)) {
+		t.Error("got ok converting a settings file to gpx")
+	}
+	if got := strings.Count(logBuf.String(), "\n"); got != 1 || !strings.Contains(logBuf.String(), "settings.fit") {
+		t.Errorf("got log %q, want one error for settings.fit", logBuf.String())
+	}
+
+	for name := range files {
+		ext := filepath.Ext(name)
+		dst := filepath.Join(out, filepath.FromSlash(strings.TrimSuffix(name, ext))+".gpx")
+		_, err := os.Stat(dst)
+		if !strings.EqualFold(ext, ".fit") || name == "settings.fit" {
+			if !os.IsNotExist(err) {
+				t.Errorf("%s: got output %s", name, dst)
+			}
+			continue
+		}
+		r, err := os.Open(dst)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		_, err = convert.Readers[".gpx"](r)
+		r.Close()
+		if err != nil {
+			t.Errorf("%s: %v", dst, err)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	c := &converter{product: 42}
+	in := fittest.Path("me", "activity-small-fenix2-run.fit")
+	tcx, fitOut := filepath.Join(dir, "run.tcx"), filepath.Join(dir, "run.fit")
+	if err := c.convert(in, tcx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.convert(tcx, fitOut); err != nil {
+		t.Fatal(err)
+	}
+	f, err := convert.Readers[".fit"](bytes.NewReader(mustRead(t, fitOut)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.FileId.Product != 42 {
+		t.Errorf("got product %d, want 42", f.FileId.Product)
+	}
+	if err := c.convert(in, filepath.Join(dir, "run.xyz")); err == nil {
+		t.Error("got no error for unknown output format")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "run.xyz")); !os.IsNotExist(err) {
+		t.Error("got output file for unknown output format")
+	}
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "fitconv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -0,0 +1,166 @@
+// Command fitdiff compares two FIT files semantically and reports the
+// messages that were removed, added or changed, with the fields that differ.
+// Encoding differences such as local message numbers, compressed timestamps,
+// field order and byte order are ignored.
+//
+// Usage:
+//
+//	fitdiff [flags] a.fit b.fit
+//
+// Differences are printed one per line, prefixed with "-" for removed, "+"
+// for added and "~" for changed messages, or as one JSON object per line
+// with -json. The exit status is 0 if the files are equal, 1 if they differ
+// and 2 on errors.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/diff"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs fitdiff with the arguments args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	l := log.New(stderr, "fitdiff: ", 0)
+
+	flags := flag.NewFlagSet("fitdiff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		jsonOut = flags.Bool("json", false, "output one JSON object per line")
+		rel     = flags.Float64("rel", 0, "relative tolerance of numeric fields")
+		timeTol = flags.Duration("time", 0, "tolerance for aligning messages by timestamp and comparing times")
+		ignore  = flags.String("ignore", "", "comma-separated messages or fields to ignore, such as DeviceInfo,Record.Speed")
+		abs     toleranceFlag
+	)
+	flags.Var(&abs, "abs", "absolute tolerance of a field given as `field=value`, such as Record.Altitude=0.5; may be repeated")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: fitdiff [flags] a.fit b.fit\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	opts := []diff.Option{diff.WithRelativeTolerance(*rel), diff.WithTimeTolerance(*timeTol)}
+	for _, t := range abs {
+		opts = append(opts, diff.WithTolerance(t.field, t.abs))
+	}
+	if *ignore != "" {
+		opts = append(opts, diff.WithIgnore(strings.Split(*ignore, ",")...))
+	}
+
+	a, err := decode(flags.Arg(0))
+	if err != nil {
+		l.Print(err)
+		return 2
+	}
+	b, err := decode(flags.Arg(1))
+	if err != nil {
+		l.Print(err)
+		return 2
+	}
+	diffs, err := diff.Files(a, b, opts...)
+	if err != nil {
+		l.Print(err)
+		return 2
+	}
+
+	w := bufio.NewWriter(stdout)
+	enc := json.NewEncoder(w)
+	for _, d := range diffs {
+		if !*jsonOut {
+			fmt.Fprintln(w, d)
+			continue
+		}
+		jd := jsonDiff{Kind: d.Kind.String(), Mesg: d.MesgNum.String(), Index: d.Index}
+		if !d.Time.IsZero() {
+			jd.Time = d.Time.UTC().Format("2006-01-02T15:04:05Z07:00")
+		}
+		for _, f := range d.Fields {
+			jd.Fields = append(jd.Fields, jsonField{Name: f.Name, A: f.A, B: f.B})
+		}
+		enc.Encode(jd)
+	}
+	w.Flush()
+	if len(diffs) > 0 {
+		return 1
+	}
+	return 0
+}
+
+type jsonDiff struct {
+	Kind   string      `json:"kind"`
+	Mesg   string      `json:"mesg"`
+	Index  int         `json:"index"`
+	Time   string      `json:"time,omitempty"`
+	Fields []jsonField `json:"fields,omitempty"`
+}
+
+type jsonField struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+func decode(path string) (*fit.File, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	f, err := fit.Decode(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return f, nil
+}
+
+type tolerance struct {
+	field string
+	abs   float64
+}
+
+// toleranceFlag is a repeatable flag of absolute field tolerances.
+type toleranceFlag []tolerance
+
+func (t *toleranceFlag) String() string {
+	var s []string
+	for _, tol := range *t {
+		s = append(s, fmt.Sprintf("%s=%g", tol.field, tol.abs))
+	}
+	return strings.Join(s, " ")
+}
+
+func (t *toleranceFlag) Set(s string) error {
+	i := strings.IndexByte(s, '=')
+	if i < 1 {
+		return errors.New("tolerance must be field=value")
+	}
+	v, err := strconv.ParseFloat(s[i+1:], 64)
+	if err != nil {
+		return err
+	}
+	*t = append(*t, tolerance{field: s[:i], abs: v})
+	return nil
+}
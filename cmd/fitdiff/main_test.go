@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fitdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a := fittest.Path("fitsdk", "Activity.fit")
+	f, act := fittest.DecodeActivity(t, "fitsdk", "Activity.fit")
+	act.Records[10].Distance += 200 // 2 m.
+	b := filepath.Join(dir, "b.fit")
+	var buf bytes.Buffer
+	if err := fit.Encode(&buf, f); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(b, buf.Bytes(), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args   []string
+		status int
+		lines  int
+	}{
+		{[]string{a, a}, 0, 0},
+		{[]string{a, b}, 1, 1},
+		{[]string{"-json", a, b}, 1, 1},
+		{[]string{"-ignore", "Record.Distance", a, b}, 0, 0},
+		{[]string{"-ignore", "DeviceInfo,Distance", a, b}, 0, 0},
+		{[]string{"-ignore", "Record.Speed", a, b}, 1, 1},
+		{[]string{"-abs", "Record.Distance=2", a, b}, 0, 0},
+		{[]string{"-abs", "Record.Distance=1.5", a, b}, 1, 1},
+		{[]string{"-abs", "Record.Speed=1", "-abs", "Distance=3", a, b}, 0, 0},
+		{[]string{"-abs", "Distance", a, b}, 2, 0},
+		{[]string{a, filepath.Join(dir, "missing.fit")}, 2, 0},
+		{[]string{a, fittest.Path("corrupt", "activity-filecrc.fit")}, 2, 0},
+		{[]string{a}, 2, 0},
+	}
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		if status := run(test.args, &stdout, &stderr); status != test.status {
+			t.Errorf("%q: got exit status %d, want %d; stderr:\n%s", test.args, status, test.status, stderr.String())
+		}
+		if got := strings.Count(stdout.String(), "\n"); got != test.lines {
+			t.Errorf("%q: got %d differences, want %d:\n%s", test.args, got, test.lines, stdout.String())
+		}
+	}
+}
+
+func TestToleranceFlag(t *testing.T) {
+	var tol toleranceFlag
+	for _, s := range []string{"Record.Altitude=0.5", "Speed=1e-3"} {
+		if err := tol.Set(s); err != nil {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "=1", "Altitude", "Altitude=", "Altitude=high", "a=b=2"} {
+		if err := tol.Set(s); err == nil {
+			t.Errorf("%q: no error", s)
+		}
+	}
+	want := toleranceFlag{{"Record.Altitude", 0.5}, {"Speed", 1e-3}}
+	if len(tol) != len(want) || tol[0] != want[0] || tol[1] != want[1] {
+		t.Errorf("got %v, want %v", tol, want)
+	}
+	if got := tol.String(); got != "Record.Altitude=0.5 Speed=0.001" {
+		t.Errorf("got string %q", got)
+	}
+}
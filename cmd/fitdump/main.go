@@ -0,0 +1,394 @@
+// Command fitdump prints the contents of FIT files in file order: the
+// header, and every definition and data message with the byte offset, base
+// type, raw value and interpreted value of each field.
+//
+// Usage:
+//
+//	fitdump [flags] file.fit...
+//
+// Messages can be filtered by type with -m, given as a comma separated list
+// of message names or numbers, such as -m Record,Lap. Output is text by
+// default, and one JSON object per line with -json. Messages not in the
+// profile are always printed raw.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tormoder/fit"
+)
+
+func main() {
+	l := log.New(os.Stderr, "fitdump: ", 0)
+
+	var (
+		jsonOut = flag.Bool("json", false, "output one JSON object per line")
+		msgs    = flag.String("m", "", "comma separated list of message names or numbers to show (default all)")
+		noDefs  = flag.Bool("nodefs", false, "do not show definition messages")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: fitdump [flags] file.fit...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	filter, err := parseFilter(*msgs)
+	if err != nil {
+		l.Fatal(err)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	var p printer = &textPrinter{w: &stickyWriter{w: w}}
+	if *jsonOut {
+		p = &jsonPrinter{enc: json.NewEncoder(w)}
+	}
+
+	failed := false
+	for _, path := range flag.Args() {
+		if err := dump(path, p, filter, !*noDefs); err != nil {
+			w.Flush()
+			l.Printf("%s: %v", path, err)
+			failed = true
+		}
+	}
+	if err := w.Flush(); err != nil {
+		l.Print(err)
+		failed = true
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// parseFilter parses the -m flag. A nil filter shows all messages.
+func parseFilter(s string) (map[fit.MesgNum]bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	names := make(map[string]fit.MesgNum)
+	for n := 0; n < 0xFFFF; n++ {
+		mn := fit.MesgNum(n)
+		if name := mn.String(); !strings.HasPrefix(name, "MesgNum(") {
+			names[strings.ToLower(name)] = mn
+		}
+	}
+	filter := make(map[fit.MesgNum]bool)
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if n, err := strconv.ParseUint(f, 10, 16); err == nil {
+			filter[fit.MesgNum(n)] = true
+			continue
+		}
+		mn, ok := names[strings.ToLower(f)]
+		if !ok {
+			return nil, fmt.Errorf("unknown message %q", f)
+		}
+		filter[mn] = true
+	}
+	return filter, nil
+}
+
+func dump(path string, p printer, filter map[fit.MesgNum]bool, defs bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := fit.NewScanner(bufio.NewReader(f))
+	if err != nil {
+		return err
+	}
+	if err := p.header(path, s.Header()); err != nil {
+		return err
+	}
+	for {
+		msg, err := s.Next()
+		if err == io.EOF {
+			return p.crc(s.CRC())
+		}
+		if err != nil {
+			if msg != nil {
+				return fmt.Errorf("offset %d: %v", msg.Offset, err)
+			}
+			return err
+		}
+		if (filter == nil || filter[msg.MesgNum]) && (defs || msg.Definition == nil) {
+			if err := p.message(msg); err != nil {
+				return fmt.Errorf("offset %d: %v", msg.Offset, err)
+			}
+		}
+	}
+}
+
+// field is a data field prepared for printing.
+type field struct {
+	Offset int64       `json:"offset"`
+	Num    byte        `json:"num"`
+	Name   string      `json:"name,omitempty"`
+	Type   string      `json:"type"`
+	Size   byte        `json:"size"`
+	Raw    interface{} `json:"raw"`
+	Inv    bool        `json:"invalid,omitempty"`
+	Value  interface{} `json:"value,omitempty"`
+	Units  string      `json:"units,omitempty"`
+	Enum   string      `json:"enum,omitempty"`
+}
+
+// fields interprets the fields of the data message msg. The value and
+// units of profile fields are taken from the JSON encoding of the decoded
+// message, which applies scale and offset.
+func fields(msg *fit.RawMessage) []field {
+	var (
+		decoded map[string]json.RawMessage
+		mv      reflect.Value
+	)
+	if msg.Msg != nil {
+		mv = reflect.ValueOf(msg.Msg)
+		if b, err := json.Marshal(msg.Msg); err == nil {
+			_ = json.Unmarshal(b, &decoded)
+		}
+	}
+	fs := make([]field, len(msg.Fields))
+	for i, rf := range msg.Fields {
+		f := field{
+			Offset: rf.Offset,
+			Num:    rf.Num,
+			Name:   rf.Name,
+			Type:   rf.BaseType.String(),
+			Size:   rf.Size,
+			Raw:    rf.Value(),
+			Inv:    rf.Invalid(),
+		}
+		if b, ok := decoded[rf.Name]; ok && rf.Name != "" {
+			f.Value, f.Units = jsonValue(b)
+		}
+		if mv.IsValid() && rf.Name != "" && !f.Inv {
+			f.Enum = enumName(mv.FieldByName(rf.Name))
+		}
+		fs[i] = f
+	}
+	return fs
+}
+
+// jsonValue returns the value and units of a message field encoded as JSON.
+func jsonValue(b json.RawMessage) (interface{}, string) {
+	var withUnits struct {
+		Value interface{}
+		Units string
+	}
+	if len(b) > 0 && b[0] == '{' {
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if d.Decode(&withUnits) == nil && withUnits.Units != "" {
+			return withUnits.Value, withUnits.Units
+		}
+	}
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return string(b), ""
+	}
+	return v, ""
+}
+
+// enumName returns the name of the value of v if it has a named integer
+// type of the fit package, such as fit.Sport.
+func enumName(v reflect.Value) string {
+	if !v.IsValid() || v.Type().PkgPath() != "github.com/tormoder/fit" {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Int8:
+	default:
+		return ""
+	}
+	s, ok := v.Interface().(fmt.Stringer)
+	if !ok {
+		return ""
+	}
+	name := s.String()
+	if strings.Contains(name, "(") {
+		return ""
+	}
+	return name
+}
+
+func kind(msg *fit.RawMessage) string {
+	if msg.Definition != nil {
+		return "definition"
+	}
+	return "data"
+}
+
+type printer interface {
+	header(path string, h fit.Header) error
+	message(msg *fit.RawMessage) error
+	crc(crc uint16) error
+}
+
+type textPrinter struct {
+	w *stickyWriter
+}
+
+// stickyWriter is a writer that remembers its first error.
+type stickyWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *stickyWriter) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.w.Write(b)
+	w.err = err
+	return n, err
+}
+
+func (p *textPrinter) header(path string, h fit.Header) error {
+	fmt.Fprintf(p.w, "%s\n", path)
+	fmt.Fprintf(p.w, "header: size %d, protocol %v, profile %d, data size %d, type %q",
+		h.Size, fit.ProtocolVersion(h.ProtocolVersion), h.ProfileVersion, h.DataSize, h.DataType[:])
+	if h.Size >= 14 {
+		fmt.Fprintf(p.w, ", crc %#04x", h.CRC)
+	}
+	fmt.Fprintln(p.w)
+	return p.w.err
+}
+
+func (p *textPrinter) message(msg *fit.RawMessage) error {
+	name := msg.MesgNum.String()
+	if msg.Definition != nil {
+		d := msg.Definition
+		order := "little-endian"
+		if d.BigEndian {
+			order = "big-endian"
+		}
+		fmt.Fprintf(p.w, "%8d  definition  local %2d  %s (%d), %s, %d fields\n",
+			msg.Offset, msg.Local, name, uint16(msg.MesgNum), order, len(d.Fields))
+		for _, f := range d.Fields {
+			fmt.Fprintf(p.w, "          %3d %-24s %-8v size %d\n", f.Num, fieldName(f.Name), f.BaseType, f.Size)
+		}
+		return p.w.err
+	}
+	header := "data"
+	if msg.Compressed {
+		header = "data (compressed timestamp)"
+	}
+	if msg.Msg == nil {
+		name += " (unknown)"
+	}
+	fmt.Fprintf(p.w, "%8d  %s  local %2d  %s (%d)\n", msg.Offset, header, msg.Local, name, uint16(msg.MesgNum))
+	for _, f := range fields(msg) {
+		fmt.Fprintf(p.w, "%8d  %3d %-24s %-8s raw %v", f.Offset, f.Num, fieldName(f.Name), f.Type, f.Raw)
+		switch {
+		case f.Inv:
+			fmt.Fprint(p.w, " (invalid)")
+		case f.Value != nil && fmt.Sprint(f.Value) != f.Enum:
+			fmt.Fprintf(p.w, "  value %v", f.Value)
+			if f.Units != "" {
+				fmt.Fprintf(p.w, " %s", f.Units)
+			}
+		}
+		if f.Enum != "" {
+			fmt.Fprintf(p.w, "  enum %s", f.Enum)
+		}
+		fmt.Fprintln(p.w)
+	}
+	return p.w.err
+}
+
+func (p *textPrinter) crc(crc uint16) error {
+	fmt.Fprintf(p.w, "crc: %#04x\n", crc)
+	return p.w.err
+}
+
+func fieldName(name string) string {
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+type jsonPrinter struct {
+	enc *json.Encoder
+}
+
+func (p *jsonPrinter) header(path string, h fit.Header) error {
+	return p.enc.Encode(map[string]interface{}{
+		"file":   path,
+		"header": h,
+	})
+}
+
+func (p *jsonPrinter) message(msg *fit.RawMessage) error {
+	out := map[string]interface{}{
+		"offset":  msg.Offset,
+		"kind":    kind(msg),
+		"local":   msg.Local,
+		"mesgNum": uint16(msg.MesgNum),
+		"mesg":    msg.MesgNum.String(),
+	}
+	if msg.Definition != nil {
+		type fieldDef struct {
+			Num  byte   `json:"num"`
+			Name string `json:"name,omitempty"`
+			Type string `json:"type"`
+			Size byte   `json:"size"`
+		}
+		var defs []fieldDef
+		for _, f := range msg.Definition.Fields {
+			defs = append(defs, fieldDef{f.Num, f.Name, f.BaseType.String(), f.Size})
+		}
+		out["bigEndian"] = msg.Definition.BigEndian
+		out["fields"] = defs
+	} else {
+		out["compressed"] = msg.Compressed
+		out["known"] = msg.Msg != nil
+		fs := fields(msg)
+		for i := range fs {
+			fs[i].Raw = jsonRaw(fs[i].Raw)
+		}
+		out["fields"] = fs
+	}
+	return p.enc.Encode(out)
+}
+
+func (p *jsonPrinter) crc(crc uint16) error {
+	return p.enc.Encode(map[string]interface{}{"crc": crc})
+}
+
+// jsonRaw returns the raw field value v for JSON, which has no NaN or
+// infinities: non-finite floats are given as strings, such as "NaN".
+func jsonRaw(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	case []float64:
+		vs := make([]interface{}, len(v))
+		for i, x := range v {
+			vs[i] = jsonRaw(x)
+		}
+		return vs
+	}
+	return v
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestDumpText(t *testing.T) {
+	path := fittest.Path("sram", "Settings.fit")
+	var buf bytes.Buffer
+	filter := map[fit.MesgNum]bool{fit.MesgNumFileId: true}
+	if err := dump(path, &textPrinter{w: &stickyWriter{w: &buf}}, filter, false); err != nil {
+		t.Fatal(err)
+	}
+	want := path + `
+header: size 14, protocol 1.0, profile 1300, data size 1271, type ".FIT", crc 0x0000
+      38  data  local  0  FileId (0)
+      39    3 SerialNumber             uint32z  raw 3889965805  value 3889965805
+      43    4 TimeCreated              uint32   raw 4294967295 (invalid)
+      47    1 Manufacturer             uint16   raw 1  enum Garmin
+      49    2 Product                  uint16   raw 1561  value 1561
+      51    5 Number                   uint16   raw 65535 (invalid)
+      53    0 Type                     enum     raw 2  enum Settings
+crc: 0x2b9b
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDumpJSON(t *testing.T) {
+	var buf bytes.Buffer
+	filter := map[fit.MesgNum]bool{fit.MesgNumUserProfile: true}
+	if err := dump(fittest.Path("sram", "Settings.fit"), &jsonPrinter{enc: json.NewEncoder(&buf)}, filter, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want header, definition, data and crc:\n%s", len(lines), buf.String())
+	}
+	var data struct {
+		Kind   string
+		Mesg   string
+		Known  bool
+		Fields []field
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Kind != "data" || data.Mesg != "UserProfile" || !data.Known {
+		t.Errorf("got %s %s message, known %t; want known UserProfile data", data.Mesg, data.Kind, data.Known)
+	}
+	byNum := make(map[byte]field)
+	for _, f := range data.Fields {
+		byNum[f.Num] = f
+	}
+	if f := byNum[4]; f.Name != "Weight" || f.Raw != 780.0 || f.Value != 78.0 || f.Units != "kg" {
+		t.Errorf("got weight %+v", f)
+	}
+	if f := byNum[1]; f.Enum != "Male" {
+		t.Errorf("got gender %+v", f)
+	}
+	if f := byNum[24]; f.Name != "" || f.Raw != 255.0 || !f.Inv || f.Value != nil {
+		t.Errorf("got unknown field %+v, want raw 255 and invalid", f)
+	}
+}
+
+func TestDumpDeveloperData(t *testing.T) {
+	var buf bytes.Buffer
+	err := dump(fittest.Path("fitsdk", "DeveloperData.fit"), &textPrinter{w: &stickyWriter{w: &buf}}, nil, false)
+	if err == nil || !strings.HasPrefix(err.Error(), "offset 124: ") || !strings.Contains(err.Error(), "developer data fields") {
+		t.Errorf("got error %v, want developer data fields not supported at offset 124", err)
+	}
+	// The messages describing the developer field are printed.
+	const want = "      97    3 FieldName                string   raw doughnuts_earned  value [doughnuts_earned]\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output lacks the field description:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "crc:") {
+		t.Error("crc printed for a file not read to the end")
+	}
+}
+
+func TestJSONRaw(t *testing.T) {
+	for _, tt := range []struct {
+		raw  interface{}
+		want string
+	}{
+		{math.NaN(), `"NaN"`},
+		{math.Inf(-1), `"-Inf"`},
+		{1.5, `1.5`},
+		{[]float64{1, math.NaN(), math.Inf(1)}, `[1,"NaN","+Inf"]`},
+		{uint64(7), `7`},
+	} {
+		b, err := json.Marshal(field{Raw: jsonRaw(tt.raw)})
+		if err != nil {
+			t.Errorf("%v: %v", tt.raw, err)
+			continue
+		}
+		var got struct{ Raw json.RawMessage }
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if string(got.Raw) != tt.want {
+			t.Errorf("%v: got raw %s, want %s", tt.raw, got.Raw, tt.want)
+		}
+	}
+}
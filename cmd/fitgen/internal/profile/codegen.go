@@ -9,6 +9,7 @@ import (
 	"go/token"
 	"log"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/tormoder/fit/internal/types"
@@ -142,9 +143,53 @@ func (g *codeGenerator) genTypes(types map[string]*Type) {
 		}
 		g.p(t.Name, "Invalid ", t.Name, " = ", t.BaseType.GoInvalidValue())
 		g.p(")")
+		g.genTypeJSON(t)
 	}
 }
 
+func (g *codeGenerator) genTypeJSON(t *Type) {
+	values := lowerFirst(t.Name) + "Values"
+
+	g.p()
+	g.p("var ", values, " = map[string]", t.Name, "{")
+	for _, v := range t.Values {
+		g.p("\"", v.Name, "\": ", t.Name, v.Name, ",")
+	}
+	g.p("\"Invalid\": ", t.Name, "Invalid,")
+	g.p("}")
+
+	g.p()
+	g.p("// MarshalJSON implements the json.Marshaler interface.")
+	g.p("// Named values are encoded as a string, others as a number.")
+	g.p("func (x ", t.Name, ") MarshalJSON() ([]byte, error) {")
+	g.p("e := newJSONEncodeState()")
+	g.p("e.writeEnum(x.String(), uint64(x))")
+	g.p("return e.Bytes(), nil")
+	g.p("}")
+
+	g.p()
+	g.p("// UnmarshalJSON implements the json.Unmarshaler interface.")
+	g.p("// Both names and numbers are accepted. JSON null decodes to ", t.Name, "Invalid.")
+	g.p("func (x *", t.Name, ") UnmarshalJSON(b []byte) error {")
+	g.p("name, v, ok, err := unmarshalJSONEnum(b)")
+	g.p("switch {")
+	g.p("case err != nil:")
+	g.p("return err")
+	g.p("case !ok:")
+	g.p("*x = ", t.Name, "Invalid")
+	g.p("case name == \"\":")
+	g.p("*x = ", t.Name, "(v)")
+	g.p("default:")
+	g.p("val, found := ", values, "[name]")
+	g.p("if !found {")
+	g.p("return unknownEnumNameError{\"", t.Name, "\", name}")
+	g.p("}")
+	g.p("*x = val")
+	g.p("}")
+	g.p("return nil")
+	g.p("}")
+}
+
 func (g *codeGenerator) genMsgs(msgs []*Msg) {
 	g.p("import (")
 	g.p("\"math\"")
@@ -164,9 +209,22 @@ func (g *codeGenerator) genMsgs(msgs []*Msg) {
 		if len(compfs) > 0 {
 			g.genComponentsRelated(msg, compfs, dyncompfs)
 		}
+		g.genNewMsg(msg)
+		g.genMarshalJSON(msg)
+		g.genUnmarshalJSON(msg)
 	}
 }
 
+func (g *codeGenerator) genNewMsg(msg *Msg) {
+	g.p()
+	g.p("// New", msg.CCName, "Msg returns a ", msg.Name, " FIT message")
+	g.p("// initialized to all-invalid values.")
+	g.p("func New", msg.CCName, "Msg() *", msg.CCName, "Msg {")
+	g.p("msg := msgsAllInvalid[MesgNum", msg.CCName, "].Interface().(", msg.CCName, "Msg)")
+	g.p("return &msg")
+	g.p("}")
+}
+
 func (g *codeGenerator) genFields(msg *Msg) (scaledfi, dynfi, compfi []int, dyncompfi map[int][]int) {
 	dyncompfi = make(map[int][]int)
 	for i, f := range msg.Fields {
@@ -752,3 +810,205 @@ func (g *codeGenerator) genGetZeroValueMsgsArrayLookup() {
 	g.p("return val")
 	g.p("}")
 }
+
+func (g *codeGenerator) genMarshalJSON(msg *Msg) {
+	g.p()
+	g.p("// MarshalJSON implements the json.Marshaler interface.")
+	g.p("// Fields with invalid values are omitted. Scaled fields are")
+	g.p("// encoded with scale and any offset applied. Scaled fields and")
+	g.p("// fields with units are encoded as {\"Value\":x,\"Units\":u} objects.")
+	g.p("func (x ", msg.CCName, "Msg) MarshalJSON() ([]byte, error) {")
+	g.p("e := newJSONEncodeState()")
+	g.p("e.open()")
+	for _, f := range msg.Fields {
+		g.genMarshalJSONField(f)
+	}
+	g.p("e.close()")
+	g.p("return e.Bytes(), nil")
+	g.p("}")
+}
+
+func (g *codeGenerator) genMarshalJSONField(f *Field) {
+	x := "x." + f.CCName
+	field := "e.field(\"" + f.CCName + "\")"
+
+	switch f.FType.Kind() {
+	case types.TimeUTC, types.TimeLocal:
+		if f.FType.Array() {
+			panic("genMarshalJSONField: unhandled time array")
+		}
+		g.p("if isValidTime(", x, ") {")
+		g.p(field)
+		g.p("e.writeTime(", x, ")")
+		g.p("}")
+		return
+	case types.Lat, types.Lng:
+		if f.FType.Array() {
+			panic("genMarshalJSONField: unhandled coordinate array")
+		}
+		g.p("if !", x, ".Invalid() {")
+		g.p(field)
+		g.p("e.writeFloat(", x, ".Degrees())")
+		g.p("}")
+		return
+	}
+
+	base := f.FType.BaseType()
+	named := f.TypeName != f.FType.GoType()
+	units := jsonUnits(f)
+
+	if !f.FType.Array() {
+		g.p("if ", x, " != ", f.FType.GoInvalidValue(), " {")
+		g.p(field)
+		switch {
+		case base == types.BaseString:
+			g.p("e.writeString(", x, ")")
+		case f.TypeName == "Bool":
+			g.p("e.writeBoolEnum(", x, ")")
+		case named:
+			g.p("e.writeEnum(", x, ".String(), uint64(", x, "))")
+		case f.Scale != "":
+			g.p("e.writeScaled(", scaledExpr(x, f), ", ", strconv.Quote(units), ")")
+		case base.Signed():
+			g.p("e.writeIntUnits(int64(", x, "), ", strconv.Quote(units), ")")
+		default:
+			g.p("e.writeUintUnits(uint64(", x, "), ", strconv.Quote(units), ")")
+		}
+		g.p("}")
+		return
+	}
+
+	g.p("if len(", x, ") != 0 {")
+	g.p(field)
+	switch {
+	case base == types.BaseString:
+		g.p("e.writeStrings(", x, ")")
+	case named:
+		g.p("e.openArray()")
+		g.p("for i, v := range ", x, " {")
+		g.p("if i > 0 {")
+		g.p("e.c()")
+		g.p("}")
+		g.p("e.writeEnum(v.String(), uint64(v))")
+		g.p("}")
+		g.p("e.closeArray()")
+	default:
+		if f.Scale != "" {
+			g.p("e.openValue()")
+		} else {
+			g.p("e.openUnits(", strconv.Quote(units), ")")
+		}
+		g.p("e.openArray()")
+		g.p("for i, v := range ", x, " {")
+		g.p("if i > 0 {")
+		g.p("e.c()")
+		g.p("}")
+		g.p("switch {")
+		g.p("case v == ", base.GoInvalidValue(), ":")
+		g.p("e.writeNull()")
+		g.p("default:")
+		switch {
+		case f.Scale != "":
+			g.p("e.writeFloat(", scaledExpr("v", f), ")")
+		case base.Signed():
+			g.p("e.writeInt(int64(v))")
+		default:
+			g.p("e.writeUint(uint64(v))")
+		}
+		g.p("}")
+		g.p("}")
+		g.p("e.closeArray()")
+		if f.Scale != "" {
+			g.p("e.closeValue(", strconv.Quote(units), ")")
+		} else {
+			g.p("e.closeUnits(", strconv.Quote(units), ")")
+		}
+	}
+	g.p("}")
+}
+
+func (g *codeGenerator) genUnmarshalJSON(msg *Msg) {
+	g.p()
+	g.p("// UnmarshalJSON implements the json.Unmarshaler interface.")
+	g.p("// Fields not present in b are set to their invalid value.")
+	g.p("func (x *", msg.CCName, "Msg) UnmarshalJSON(b []byte) error {")
+	if len(msg.Fields) == 0 {
+		g.p("_, err := unmarshalJSONObject(b)")
+		g.p("return err")
+		g.p("}")
+		return
+	}
+	g.p("fields, err := unmarshalJSONObject(b)")
+	g.p("if err != nil {")
+	g.p("return err")
+	g.p("}")
+	g.p("*x = msgsAllInvalid[MesgNum", msg.CCName, "].Interface().(", msg.CCName, "Msg)")
+	g.p("for name, v := range fields {")
+	g.p("switch name {")
+	for _, f := range msg.Fields {
+		g.p("case \"", f.CCName, "\":")
+		g.genUnmarshalJSONField(f)
+	}
+	g.p("}")
+	g.p("if err != nil {")
+	g.p("return jsonFieldError{\"", msg.CCName, "Msg\", name, err}")
+	g.p("}")
+	g.p("}")
+	g.p("return nil")
+	g.p("}")
+}
+
+func (g *codeGenerator) genUnmarshalJSONField(f *Field) {
+	x := "x." + f.CCName
+	base := f.FType.BaseType()
+	named := f.TypeName != f.FType.GoType()
+
+	if f.FType.Kind() != types.NativeFit || base == types.BaseString || named {
+		g.p("err = unmarshalJSONValue(v, &", x, ")")
+		return
+	}
+
+	scale, offset := "1", "0"
+	if f.Scale != "" {
+		scale = f.Scale
+	}
+	if f.HasOffset() {
+		offset = f.Offset
+	}
+	args := scale + ", " + offset + ", " + base.GoInvalidValue()
+
+	if !f.FType.Array() {
+		g.p("var f float64")
+		g.p("f, err = unmarshalJSONScaled(v, ", args, ")")
+		g.p(x, " = ", f.TypeName, "(f)")
+		return
+	}
+
+	g.p("var fs []float64")
+	g.p("fs, err = unmarshalJSONScaledArray(v, ", args, ")")
+	g.p("if fs != nil {")
+	g.p(x, " = make(", f.TypeName, ", len(fs))")
+	g.p("for i, f := range fs {")
+	g.p(x, "[i] = ", base.GoType(), "(f)")
+	g.p("}")
+	g.p("}")
+}
+
+// jsonUnits returns the units a field is annotated with in JSON output.
+// The units of a field with a single component are those of the field and
+// its component. A field with several components lists the units of each
+// component, which do not apply to the packed field value.
+func jsonUnits(f *Field) string {
+	if len(f.Components) > 1 {
+		return ""
+	}
+	return f.Units
+}
+
+func scaledExpr(x string, f *Field) string {
+	expr := "float64(" + x + ") / " + f.Scale
+	if f.HasOffset() {
+		expr += " - " + f.Offset
+	}
+	return expr
+}
@@ -78,10 +78,10 @@ type sdk struct {
 }
 
 var sdks = []sdk{
-	{16, 20, 9140511954122483521},
-	{20, 14, 1110026377375606382},
-	{20, 27, 17729391755185608044},
-	{20, 43, 2113486054852011886},
+	{16, 20, 6062832931773108480},
+	{20, 14, 14939564625947786143},
+	{20, 27, 15393000225045969012},
+	{20, 43, 16677479512869711991},
 }
 
 func TestMain(m *testing.M) {
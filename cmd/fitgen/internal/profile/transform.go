@@ -20,6 +20,13 @@ func toCamelCase(s string) string {
 	return strings.Join(chunks, "")
 }
 
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
 var typeQuirks = map[string]string{
 	"activity": "activity_mode",
 	"file":     "file_type",
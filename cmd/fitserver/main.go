@@ -0,0 +1,65 @@
+// Command fitserver is an HTTP service that decodes, converts, validates and
+// summarizes FIT files, for programs not written in Go. It uses no network
+// resources besides its listener, and by default listens on localhost only,
+// so it can run as a sidecar.
+//
+// Usage:
+//
+//	fitserver [flags]
+//
+// Endpoints:
+//
+//	POST /decode              the file as JSON, or its records as NDJSON
+//	                          with ?format=ndjson
+//	POST /convert?to=gpx      the file converted to gpx, tcx, csv, geojson,
+//	                          kml, json or fit; the input is read as FIT,
+//	                          or as the format given by ?from=
+//	POST /validate            the conformance issues of the file as JSON
+//	POST /summary             summaries of the sessions of an activity, or
+//	                          of the track of a course, as JSON
+//	GET  /metrics             request counters in the Prometheus text format
+//
+// The file is sent as the request body, or as a file in a multipart form.
+// Requests are limited in size by -maxsize and in duration by -timeout,
+// and at most -j requests are served at a time; further requests wait
+// until their timeout. Errors are reported with a plain text message and
+// status 400 for invalid input, 413 for too large input and 503 when a
+// request times out.
+//
+// The timeout stops reading the file, but not the work after it. A request
+// holds the whole decoded file in memory: about four times its size for a
+// typical activity, and up to a hundred times for a file of minimal
+// records, as every record message takes about 200 bytes. Conversions and
+// summaries add to that. With the default -maxsize of 8 MiB, enough for two
+// days recorded every second, allow for up to 1 GB per concurrent request,
+// and set -maxsize and -j for the memory available.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+)
+
+func main() {
+	var (
+		addr    = flag.String("addr", "localhost:8780", "listen address")
+		maxSize = flag.Int64("maxsize", 8<<20, "maximum request body size in `bytes`")
+		timeout = flag.Duration("timeout", 30*time.Second, "maximum duration of a request")
+		workers = flag.Int("j", runtime.NumCPU(), "maximum number of requests served at a time")
+	)
+	flag.Parse()
+
+	l := log.New(os.Stderr, "fitserver: ", log.LstdFlags)
+	s := newServer(*maxSize, *timeout, *workers, l)
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	l.Printf("listening on %s", *addr)
+	l.Fatal(srv.ListenAndServe())
+}
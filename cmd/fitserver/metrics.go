@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// metrics are counters exposed in the Prometheus text format. They are safe
+// for concurrent use.
+type metrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	bytes     map[string]uint64
+	durations map[string]float64
+	inFlight  int
+}
+
+type requestKey struct {
+	endpoint string
+	code     int
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests:  make(map[requestKey]uint64),
+		bytes:     make(map[string]uint64),
+		durations: make(map[string]float64),
+	}
+}
+
+func (m *metrics) start() {
+	m.mu.Lock()
+	m.inFlight++
+	m.mu.Unlock()
+}
+
+// done records a served request.
+func (m *metrics) done(endpoint string, code int, n int64, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight--
+	m.requests[requestKey{endpoint, code}]++
+	m.bytes[endpoint] += uint64(n)
+	m.durations[endpoint] += d.Seconds()
+}
+
+func (m *metrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].code < keys[j].code
+	})
+	endpoints := make([]string, 0, len(m.bytes))
+	for e := range m.bytes {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+
+	fmt.Fprintln(w, "# HELP fitserver_requests_total Requests served, by endpoint and status code.")
+	fmt.Fprintln(w, "# TYPE fitserver_requests_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(w, "fitserver_requests_total{endpoint=%q,code=\"%d\"} %d\n", k.endpoint, k.code, m.requests[k])
+	}
+	fmt.Fprintln(w, "# HELP fitserver_request_bytes_total Request body bytes read, by endpoint.")
+	fmt.Fprintln(w, "# TYPE fitserver_request_bytes_total counter")
+	for _, e := range endpoints {
+		fmt.Fprintf(w, "fitserver_request_bytes_total{endpoint=%q} %d\n", e, m.bytes[e])
+	}
+	fmt.Fprintln(w, "# HELP fitserver_request_duration_seconds_total Time spent serving requests, by endpoint.")
+	fmt.Fprintln(w, "# TYPE fitserver_request_duration_seconds_total counter")
+	for _, e := range endpoints {
+		fmt.Fprintf(w, "fitserver_request_duration_seconds_total{endpoint=%q} %g\n", e, m.durations[e])
+	}
+	fmt.Fprintln(w, "# HELP fitserver_requests_in_flight Requests being served.")
+	fmt.Fprintln(w, "# TYPE fitserver_requests_in_flight gauge")
+	fmt.Fprintf(w, "fitserver_requests_in_flight %d\n", m.inFlight)
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/convert"
+)
+
+// server serves the endpoints. It is safe for concurrent use.
+type server struct {
+	mux     *http.ServeMux
+	maxSize int64
+	timeout time.Duration
+	sem     chan struct{}
+	metrics *metrics
+	log     *log.Logger
+}
+
+func newServer(maxSize int64, timeout time.Duration, workers int, l *log.Logger) *server {
+	if workers < 1 {
+		workers = 1
+	}
+	s := &server{
+		mux:     http.NewServeMux(),
+		maxSize: maxSize,
+		timeout: timeout,
+		sem:     make(chan struct{}, workers),
+		metrics: newMetrics(),
+		log:     l,
+	}
+	s.mux.Handle("/decode", s.handle("decode", s.decode))
+	s.mux.Handle("/convert", s.handle("convert", s.convert))
+	s.mux.Handle("/validate", s.handle("validate", s.validate))
+	s.mux.Handle("/summary", s.handle("summary", s.summary))
+	s.mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		s.metrics.write(w)
+	})
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// An httpError is an error reported with a status code.
+type httpError struct {
+	code int
+	msg  string
+}
+
+func (e *httpError) Error() string { return e.msg }
+
+func badRequest(format string, args ...interface{}) error {
+	return &httpError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+// input is the file of a request.
+type input struct {
+	io.Reader
+	ext  string // Extension of the file name, if any.
+	body *bodyReader
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, in *input) error
+
+// handle returns a handler that applies the limits of s to a POST
+// endpoint, reports its errors and records metrics.
+func (s *server) handle(endpoint string, fn handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		s.metrics.start()
+		rw := &responseWriter{ResponseWriter: w}
+		body := &bodyReader{r: http.MaxBytesReader(w, r.Body, s.maxSize)}
+		defer func() {
+			s.metrics.done(endpoint, rw.status(), body.n, time.Since(start))
+		}()
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+		defer cancel()
+		select {
+		case s.sem <- struct{}{}:
+			defer func() { <-s.sem }()
+		case <-ctx.Done():
+			http.Error(rw, "server busy", http.StatusServiceUnavailable)
+			return
+		}
+		body.ctx = ctx
+
+		err := func() error {
+			in, err := readInput(r, body)
+			if err != nil {
+				return err
+			}
+			return fn(rw, r, in)
+		}()
+		switch {
+		case err == nil:
+			return
+		case body.err != nil && body.n >= s.maxSize:
+			// http.MaxBytesReader stops at the limit.
+			err = &httpError{http.StatusRequestEntityTooLarge, fmt.Sprintf("request body larger than %d bytes", s.maxSize)}
+		case ctx.Err() != nil:
+			err = &httpError{http.StatusServiceUnavailable, "request timed out"}
+		}
+		if rw.code != 0 {
+			s.log.Printf("%s: %v", endpoint, err)
+			return
+		}
+		code := http.StatusBadRequest
+		if he, ok := err.(*httpError); ok {
+			code = he.code
+		}
+		http.Error(rw, err.Error(), code)
+	})
+}
+
+// readInput returns the file of a request, which is either the body or the
+// first file of a multipart form.
+func readInput(r *http.Request, body *bodyReader) (*input, error) {
+	r.Body = ioutil.NopCloser(body)
+	mr, err := r.MultipartReader()
+	if err == http.ErrNotMultipart {
+		return &input{Reader: body, body: body}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, badRequest("multipart form has no file")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.FileName() != "" {
+			return &input{Reader: p, ext: strings.ToLower(filepath.Ext(p.FileName())), body: body}, nil
+		}
+	}
+}
+
+// bodyReader counts the bytes read from a request body, records the last
+// read error and stops reading when its context is done, so that decoding
+// ends with the request.
+type bodyReader struct {
+	r   io.Reader
+	ctx context.Context
+	n   int64
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	if b.ctx != nil {
+		if err := b.ctx.Err(); err != nil {
+			b.err = err
+			return 0, err
+		}
+	}
+	n, err := b.r.Read(p)
+	b.n += int64(n)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+// responseWriter records the status code of a response.
+type responseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) status() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
+
+func decodeFIT(in *input) (*fit.File, error) {
+	f, err := fit.Decode(bufio.NewReader(in))
+	if err != nil {
+		return nil, badRequest("decoding fit file: %v", err)
+	}
+	return f, nil
+}
+
+func (s *server) decode(w http.ResponseWriter, r *http.Request, in *input) error {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "ndjson" {
+		return badRequest("unknown format %q", format)
+	}
+	f, err := decodeFIT(in)
+	if err != nil {
+		return err
+	}
+	if format != "ndjson" {
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(f)
+	}
+
+	recs, err := convert.Records(f)
+	if err != nil {
+		return badRequest("%v", err)
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	for i, rec := range recs {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+		if flusher != nil && i%256 == 255 {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
+
+func (s *server) convert(w http.ResponseWriter, r *http.Request, in *input) error {
+	q := r.URL.Query()
+	to := "." + strings.TrimPrefix(strings.ToLower(q.Get("to")), ".")
+	write, ok := convert.Writers[to]
+	if !ok {
+		return badRequest("unknown output format %q", q.Get("to"))
+	}
+	from := in.ext
+	if q.Get("from") != "" {
+		from = "." + strings.TrimPrefix(strings.ToLower(q.Get("from")), ".")
+	}
+	if from == "" {
+		from = ".fit"
+	}
+	read, ok := convert.Readers[from]
+	if !ok {
+		return badRequest("unknown input format %q", from)
+	}
+
+	f, err := read(in)
+	if err != nil {
+		return badRequest("reading %s: %v", strings.TrimPrefix(from, "."), err)
+	}
+	var buf bytes.Buffer
+	if err := write(&buf, f); err != nil {
+		return badRequest("writing %s: %v", strings.TrimPrefix(to, "."), err)
+	}
+	w.Header().Set("Content-Type", convert.ContentTypes[to])
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+type jsonIssue struct {
+	Offset   int64  `json:"offset"`
+	Severity string `json:"severity"`
+	Mesg     string `json:"mesg"`
+	Message  string `json:"message"`
+}
+
+func (s *server) validate(w http.ResponseWriter, r *http.Request, in *input) error {
+	issues := []jsonIssue{}
+	for _, issue := range fit.Validate(in) {
+		issues = append(issues, jsonIssue{
+			Offset:   issue.Offset,
+			Severity: issue.Severity.String(),
+			Mesg:     issue.MesgNum.String(),
+			Message:  issue.Message,
+		})
+	}
+	if in.body.err != nil {
+		// Issues caused by a limit are not issues of the file.
+		return in.body.err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(issues)
+}
+
+type jsonSummary struct {
+	Sport   string                 `json:"sport,omitempty"`
+	Summary map[string]interface{} `json:"summary"`
+}
+
+func (s *server) summary(w http.ResponseWriter, r *http.Request, in *input) error {
+	f, err := decodeFIT(in)
+	if err != nil {
+		return err
+	}
+	summaries := []jsonSummary{}
+	switch f.Type() {
+	case fit.FileTypeActivity:
+		act, _ := f.Activity()
+		opt := analysis.WithTimerEvents(act.Events)
+		if len(act.Sessions) == 0 {
+			summaries = append(summaries, jsonSummary{Summary: summaryMap(analysis.Summarize(act.Records, opt))})
+		}
+		for _, ss := range act.Sessions {
+			recs := act.Records
+			if len(act.Sessions) > 1 {
+				recs = fit.RecordsBetween(recs, ss.StartTime, ss.Timestamp)
+			}
+			summaries = append(summaries, jsonSummary{
+				Sport:   ss.Sport.String(),
+				Summary: summaryMap(analysis.Summarize(recs, opt)),
+			})
+		}
+	case fit.FileTypeCourse:
+		course, _ := f.Course()
+		js := jsonSummary{Summary: summaryMap(analysis.Summarize(course.Records))}
+		if course.Course != nil && course.Course.Sport != fit.SportInvalid {
+			js.Sport = course.Course.Sport.String()
+		}
+		summaries = append(summaries, js)
+	default:
+		return badRequest("%v files have no records", f.Type())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(summaries)
+}
+
+// summaryMap returns the fields of sum that could be computed, keyed by
+// name. JSON has no NaN.
+func summaryMap(sum *analysis.Summary) map[string]interface{} {
+	m := make(map[string]interface{})
+	v := reflect.ValueOf(sum).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch x := f.Interface().(type) {
+		case float64:
+			if math.IsNaN(x) {
+				continue
+			}
+		case time.Time:
+			if x.IsZero() || fit.IsBaseTime(x) {
+				continue
+			}
+		case fit.Latitude:
+			if x.Invalid() {
+				continue
+			}
+		case fit.Longitude:
+			if x.Invalid() {
+				continue
+			}
+		}
+		m[v.Type().Field(i).Name] = f.Interface()
+	}
+	return m
+}
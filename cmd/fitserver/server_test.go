@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit/internal/convert"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+var runPath = []string{"me", "activity-small-fenix2-run.fit"}
+
+func testServer() *server {
+	return newServer(8<<20, 10*time.Second, 2, log.New(ioutil.Discard, "", 0))
+}
+
+// post posts body to the url of s and returns the response.
+func post(s *server, url string, body io.Reader, contentType string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, url, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w
+}
+
+// multipartBody returns a multipart form with a text field and the file
+// data named name.
+func multipartBody(t *testing.T, name string, data []byte) (io.Reader, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("note", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	if name != "" {
+		fw, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(data)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf, mw.FormDataContentType()
+}
+
+func TestDecode(t *testing.T) {
+	s := testServer()
+	data := fittest.Read(t, runPath...)
+	_, act := fittest.DecodeActivity(t, runPath...)
+
+	w := post(s, "/decode", bytes.NewReader(data), "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("json: got status %d, content type %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body)
+	}
+	f, err := convert.Readers[".json"](w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := f.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Records) != len(act.Records) {
+		t.Errorf("json: got %d records, want %d", len(got.Records), len(act.Records))
+	}
+
+	w = post(s, "/decode?format=ndjson", bytes.NewReader(data), "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("ndjson: got status %d, content type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var lines int
+	sc := bufio.NewScanner(w.Body)
+	for sc.Scan() {
+		var rec map[string]interface{}
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("ndjson line %d: %v", lines, err)
+		}
+		if _, ok := rec["Timestamp"]; !ok {
+			t.Fatalf("ndjson line %d: no timestamp: %s", lines, sc.Bytes())
+		}
+		lines++
+	}
+	if lines != len(act.Records) {
+		t.Errorf("ndjson: got %d lines, want %d", lines, len(act.Records))
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := testServer()
+	data := fittest.Read(t, runPath...)
+	tests := []struct {
+		url  string
+		body []byte
+		code int
+	}{
+		{"/decode?format=xml", data, http.StatusBadRequest},
+		{"/decode", []byte("not fit"), http.StatusBadRequest},
+		{"/convert?to=xyz", data, http.StatusBadRequest},
+		{"/convert?to=gpx&from=xyz", data, http.StatusBadRequest},
+		{"/convert?to=gpx", fittest.Read(t, "fitsdk", "Settings.fit"), http.StatusBadRequest},
+		{"/summary", fittest.Read(t, "fitsdk", "Settings.fit"), http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		w := post(s, test.url, bytes.NewReader(test.body), "")
+		if w.Code != test.code {
+			t.Errorf("%s: got status %d, want %d: %s", test.url, w.Code, test.code, w.Body)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/decode", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET: got status %d, Allow %q", w.Code, w.Header().Get("Allow"))
+	}
+}
+
+func TestConvert(t *testing.T) {
+	s := testServer()
+	data := fittest.Read(t, runPath...)
+	_, act := fittest.DecodeActivity(t, runPath...)
+
+	for _, to := range []string{"gpx", "tcx", "csv", "geojson", "kml", "json", "fit"} {
+		w := post(s, "/convert?to="+to, bytes.NewReader(data), "")
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: got status %d: %s", to, w.Code, w.Body)
+			continue
+		}
+		if got, want := w.Header().Get("Content-Type"), convert.ContentTypes["."+to]; got != want {
+			t.Errorf("%s: got content type %q, want %q", to, got, want)
+		}
+		if w.Body.Len() == 0 {
+			t.Errorf("%s: got empty body", to)
+		}
+	}
+
+	// Convert to TCX and back, naming the input format by the query and
+	// by the name of a multipart file.
+	w := post(s, "/convert?to=tcx", bytes.NewReader(data), "")
+	tcx := w.Body.Bytes()
+	w = post(s, "/convert?to=fit&from=tcx", bytes.NewReader(tcx), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("from tcx: got status %d: %s", w.Code, w.Body)
+	}
+	body, ct := multipartBody(t, "run.TCX", tcx)
+	mw := post(s, "/convert?to=fit", body, ct)
+	if mw.Code != http.StatusOK {
+		t.Fatalf("multipart tcx: got status %d: %s", mw.Code, mw.Body)
+	}
+	if !bytes.Equal(mw.Body.Bytes(), w.Body.Bytes()) {
+		t.Error("multipart tcx: got other output than with ?from=tcx")
+	}
+	f, err := convert.Readers[".fit"](w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := f.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Laps) != len(act.Laps) {
+		t.Errorf("from tcx: got %d laps, want %d", len(got.Laps), len(act.Laps))
+	}
+}
+
+func TestMultipart(t *testing.T) {
+	s := testServer()
+	data := fittest.Read(t, runPath...)
+
+	body, ct := multipartBody(t, "run.fit", data)
+	w := post(s, "/validate", body, ct)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", w.Code, w.Body)
+	}
+	var issues []jsonIssue
+	if err := json.NewDecoder(w.Body).Decode(&issues); err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			t.Errorf("got %+v", issue)
+		}
+	}
+
+	body, ct = multipartBody(t, "", nil)
+	if w := post(s, "/decode", body, ct); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "no file") {
+		t.Errorf("no file: got status %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := testServer()
+	tests := []struct {
+		path   []string
+		errors int
+	}{
+		{runPath, 0},
+		{[]string{"corrupt", "activity-filecrc.fit"}, 1},
+	}
+	for _, test := range tests {
+		w := post(s, "/validate", bytes.NewReader(fittest.Read(t, test.path...)), "")
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("%v: got status %d: %s", test.path, w.Code, w.Body)
+			continue
+		}
+		var issues []jsonIssue
+		if err := json.NewDecoder(w.Body).Decode(&issues); err != nil {
+			t.Fatal(err)
+		}
+		var errs int
+		for _, issue := range issues {
+			if issue.Severity == "error" {
+				errs++
+			}
+		}
+		if errs != test.errors {
+			t.Errorf("%v: got %d errors, want %d: %+v", test.path, errs, test.errors, issues)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	s := testServer()
+	_, act := fittest.DecodeActivity(t, "me", "activity-large-fenxi2-multisport.fit")
+	w := post(s, "/summary", bytes.NewReader(fittest.Read(t, "me", "activity-large-fenxi2-multisport.fit")), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", w.Code, w.Body)
+	}
+	var summaries []jsonSummary
+	if err := json.NewDecoder(w.Body).Decode(&summaries); err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != len(act.Sessions) {
+		t.Fatalf("got %d summaries, want %d", len(summaries), len(act.Sessions))
+	}
+	for i, sum := range summaries {
+		if sum.Sport != act.Sessions[i].Sport.String() {
+			t.Errorf("summary %d: got sport %q, want %v", i, sum.Sport, act.Sessions[i].Sport)
+		}
+		if _, ok := sum.Summary["TotalElapsedTime"]; !ok {
+			t.Errorf("summary %d: got no elapsed time: %v", i, sum.Summary)
+		}
+	}
+
+	// A course from GPX.
+	gpx := post(s, "/convert?to=gpx", bytes.NewReader(fittest.Read(t, runPath...)), "").Body
+	course := post(s, "/convert?to=fit&from=gpx", gpx, "").Body
+	w = post(s, "/summary", course, "")
+	summaries = nil
+	if err := json.NewDecoder(w.Body).Decode(&summaries); err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].Summary["TotalDistance"] == nil {
+		t.Errorf("course: got %+v", summaries)
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	data := fittest.Read(t, runPath...)
+	s := newServer(int64(len(data)-1), 10*time.Second, 1, log.New(ioutil.Discard, "", 0))
+	for _, url := range []string{"/decode", "/convert?to=gpx", "/validate", "/summary"} {
+		w := post(s, url, bytes.NewReader(data), "")
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("%s: got status %d, want %d: %s", url, w.Code, http.StatusRequestEntityTooLarge, w.Body)
+		}
+	}
+	body, ct := multipartBody(t, "run.fit", data)
+	if w := post(s, "/decode", body, ct); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("multipart: got status %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	if w := post(s, "/decode", bytes.NewReader(data[:len(data)-1]), ""); w.Code != http.StatusBadRequest {
+		t.Errorf("truncated: got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// slowReader returns a byte per read, after a delay.
+type slowReader struct {
+	data  []byte
+	delay time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(r.delay)
+	n := copy(p[:1], r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestTimeout(t *testing.T) {
+	data := fittest.Read(t, runPath...)
+	s := newServer(8<<20, 50*time.Millisecond, 1, log.New(ioutil.Discard, "", 0))
+	start := time.Now()
+	w := post(s, "/validate", &slowReader{data, time.Millisecond}, "")
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "timed out") {
+		t.Errorf("slow body: got status %d: %s", w.Code, w.Body)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("slow body: took %v", d)
+	}
+
+	// All workers busy.
+	s.sem <- struct{}{}
+	w = post(s, "/decode", bytes.NewReader(data), "")
+	<-s.sem
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "busy") {
+		t.Errorf("busy: got status %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := testServer()
+	data := fittest.Read(t, runPath...)
+	post(s, "/decode", bytes.NewReader(data), "")
+	post(s, "/decode", bytes.NewReader(data), "")
+	post(s, "/decode", strings.NewReader("not fit"), "")
+	post(s, "/summary", bytes.NewReader(data), "")
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("got status %d, content type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`fitserver_requests_total{endpoint="decode",code="200"} 2`,
+		`fitserver_requests_total{endpoint="decode",code="400"} 1`,
+		`fitserver_requests_total{endpoint="summary",code="200"} 1`,
+		fmt.Sprintf(`fitserver_request_bytes_total{endpoint="decode"} %d`, 2*len(data)+len("not fit")),
+		fmt.Sprintf(`fitserver_request_bytes_total{endpoint="summary"} %d`, len(data)),
+		`fitserver_request_duration_seconds_total{endpoint="decode"} `,
+		"fitserver_requests_in_flight 0",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("got no %q in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `endpoint="validate"`) {
+		t.Errorf("got metrics for unused endpoint:\n%s", body)
+	}
+}
@@ -0,0 +1,177 @@
+// Command fitstat computes training statistics over a collection of activity
+// FIT files, such as a device's activity directory.
+//
+// Usage:
+//
+//	fitstat [flags] path...
+//
+// Each path is a FIT file or a directory that is searched for .fit files.
+// Files are first classified by their file id, so that files that are not
+// activities are skipped without being decoded. Copies of the same activity,
+// having the same serial number and creation time, are counted once. The
+// remaining activities are decoded in parallel.
+//
+// For every session, fitstat adds up the distance, timer time, ascent and
+// training stress score (TSS) per sport, per ISO week and per month, and
+// finds the fastest times over standard distances (PRs) per sport. TSS is
+// taken from the session if recorded, and computed from power otherwise,
+// using -ftp or the threshold power of the session. The statistics are
+// printed as tables, or as JSON with -json.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/parallel"
+)
+
+var (
+	jsonOut = flag.Bool("json", false, "output JSON")
+	ftp     = flag.Float64("ftp", 0, "functional threshold power in `watts` for computing TSS")
+	tz      = flag.String("tz", "Local", "time zone for weeks and months, such as Europe/Oslo")
+	workers = flag.Int("j", runtime.NumCPU(), "number of files to decode in parallel")
+)
+
+func main() {
+	l := log.New(os.Stderr, "fitstat: ", 0)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: fitstat [flags] path...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		l.Fatal(err)
+	}
+
+	paths, err := findFiles(flag.Args())
+	if err != nil {
+		l.Fatal(err)
+	}
+
+	s := newStats(loc)
+	ids := make([]fileID, len(paths))
+	parallel.Do(len(paths), *workers, func(i int) {
+		ids[i] = readFileID(paths[i])
+	})
+	activities := s.classify(paths, ids)
+
+	results := make([]*activity, len(activities))
+	parallel.Do(len(activities), *workers, func(i int) {
+		results[i] = analyze(activities[i], *ftp)
+	})
+	for i, a := range results {
+		if a.err != nil {
+			s.addError(activities[i], a.err)
+			continue
+		}
+		s.add(activities[i], a)
+	}
+	s.Files = len(paths)
+	s.finish()
+
+	w := bufio.NewWriter(os.Stdout)
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(s)
+	} else {
+		err = s.write(w)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		l.Fatal(err)
+	}
+	if len(s.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// findFiles returns the files given by args, and the .fit files in the
+// directories given by args, in lexical order per argument.
+func findFiles(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		err = filepath.Walk(arg, func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fi.IsDir() && strings.EqualFold(filepath.Ext(path), ".fit") {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// fileKey identifies a file independently of its name.
+type fileKey struct {
+	serial  uint32
+	created time.Time
+}
+
+func (k fileKey) valid() bool {
+	return k.serial != 0 && !k.created.IsZero() && !fit.IsBaseTime(k.created)
+}
+
+type fileID struct {
+	fileType fit.FileType
+	key      fileKey
+	err      error
+}
+
+// readFileID reads the file id of the FIT file at path.
+func readFileID(path string) fileID {
+	r, err := os.Open(path)
+	if err != nil {
+		return fileID{err: err}
+	}
+	defer r.Close()
+	_, id, err := fit.DecodeHeaderAndFileID(bufio.NewReader(r))
+	if err != nil {
+		return fileID{err: err}
+	}
+	return fileID{
+		fileType: id.Type,
+		key:      fileKey{serial: id.SerialNumber, created: id.TimeCreated.UTC()},
+	}
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys(m map[string]*totals) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+// activity holds the sessions of an activity file.
+type activity struct {
+	sessions []session
+	err      error
+}
+
+type session struct {
+	sport    string
+	start    time.Time
+	distance float64 // m
+	time     float64 // s, timer time
+	ascent   float64 // m
+	tss      float64
+	efforts  []analysis.BestEffort
+}
+
+// analyze decodes the activity file at path and computes the statistics of
+// its sessions. Values missing from a session are computed from its
+// records. An activity without sessions is treated as one generic session.
+func analyze(path string, ftp float64) *activity {
+	r, err := os.Open(path)
+	if err != nil {
+		return &activity{err: err}
+	}
+	defer r.Close()
+	f, err := fit.Decode(bufio.NewReader(r))
+	if err != nil {
+		return &activity{err: err}
+	}
+	act, err := f.Activity()
+	if err != nil {
+		return &activity{err: err}
+	}
+
+	sessions := act.Sessions
+	if len(sessions) == 0 {
+		s := fit.NewSessionMsg()
+		s.Sport = fit.SportGeneric
+		sessions = []*fit.SessionMsg{s}
+	}
+	a := new(activity)
+	for _, s := range sessions {
+		recs := act.Records
+		if len(sessions) > 1 {
+			recs = fit.RecordsBetween(act.Records, s.StartTime, s.Timestamp)
+		}
+		var sum *analysis.Summary
+		summary := func() *analysis.Summary {
+			if sum == nil {
+				sum = analysis.Summarize(recs, analysis.WithTimerEvents(act.Events))
+			}
+			return sum
+		}
+
+		ss := session{
+			sport:    s.Sport.String(),
+			start:    s.StartTime,
+			distance: s.GetTotalDistanceScaled(),
+			time:     s.GetTotalTimerTimeScaled(),
+			ascent:   math.NaN(),
+			tss:      s.GetTrainingStressScoreScaled(),
+			efforts:  analysis.BestEfforts(recs, analysis.StandardDistances, analysis.WithTimerEvents(act.Events)),
+		}
+		if !validTime(ss.start) {
+			ss.start = summary().StartTime
+		}
+		if !validTime(ss.start) {
+			ss.start = f.FileId.TimeCreated
+		}
+		if math.IsNaN(ss.distance) {
+			ss.distance = summary().TotalDistance
+		}
+		if math.IsNaN(ss.time) {
+			ss.time = summary().TotalTimerTime
+		}
+		if s.TotalAscent != 0xFFFF {
+			ss.ascent = float64(s.TotalAscent)
+		} else {
+			ss.ascent = summary().TotalAscent
+		}
+		if math.IsNaN(ss.tss) {
+			ss.tss = analysis.SessionPower(act, s, ftp).TrainingStressScore
+		}
+		a.sessions = append(a.sessions, ss)
+	}
+	return a
+}
+
+func validTime(t time.Time) bool {
+	return !t.IsZero() && !fit.IsBaseTime(t)
+}
+
+// totals are the sums over the sessions of a sport or period.
+type totals struct {
+	Key      string  `json:"key"`
+	Sessions int     `json:"sessions"`
+	Distance float64 `json:"distance_m"`
+	Time     float64 `json:"time_s"`
+	Ascent   float64 `json:"ascent_m"`
+	TSS      float64 `json:"tss"`
+}
+
+func (t *totals) add(s session) {
+	t.Sessions++
+	t.Distance += orZero(s.distance)
+	t.Time += orZero(s.time)
+	t.Ascent += orZero(s.ascent)
+	t.TSS += orZero(s.tss)
+}
+
+func orZero(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return v
+}
+
+// pr is the fastest time over a distance in a sport.
+type pr struct {
+	Sport    string    `json:"sport"`
+	Distance float64   `json:"distance_m"`
+	Time     float64   `json:"time_s"`
+	Start    time.Time `json:"start"`
+	Path     string    `json:"path"`
+}
+
+type duplicate struct {
+	Path string `json:"path"`
+	Of   string `json:"of"`
+}
+
+type fileError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+type prKey struct {
+	sport    string
+	distance float64
+}
+
+// stats are the statistics over all files.
+type stats struct {
+	Files      int         `json:"files"`
+	Activities int         `json:"activities"`
+	Skipped    int         `json:"skipped"`
+	Duplicates []duplicate `json:"duplicates"`
+	Errors     []fileError `json:"errors"`
+	Sports     []*totals   `json:"sports"`
+	Weeks      []*totals   `json:"weeks"`
+	Months     []*totals   `json:"months"`
+	PRs        []*pr       `json:"prs"`
+
+	loc    *time.Location
+	sports map[string]*totals
+	weeks  map[string]*totals
+	months map[string]*totals
+	prs    map[prKey]*pr
+}
+
+func newStats(loc *time.Location) *stats {
+	return &stats{
+		Duplicates: []duplicate{},
+		Errors:     []fileError{},
+		loc:        loc,
+		sports:     make(map[string]*totals),
+		weeks:      make(map[string]*totals),
+		months:     make(map[string]*totals),
+		prs:        make(map[prKey]*pr),
+	}
+}
+
+func (s *stats) addError(path string, err error) {
+	s.Errors = append(s.Errors, fileError{Path: path, Error: err.Error()})
+}
+
+// classify counts the files at paths, given their ids, as errors, skipped
+// files or duplicates, and returns the paths of the remaining activities.
+// A file is a duplicate of an earlier one with the same valid key.
+func (s *stats) classify(paths []string, ids []fileID) []string {
+	var activities []string
+	seen := make(map[fileKey]string)
+	for i, id := range ids {
+		path := paths[i]
+		switch {
+		case id.err != nil:
+			s.addError(path, id.err)
+		case id.fileType != fit.FileTypeActivity:
+			s.Skipped++
+		default:
+			if id.key.valid() {
+				if orig, ok := seen[id.key]; ok {
+					s.Duplicates = append(s.Duplicates, duplicate{Path: path, Of: orig})
+					continue
+				}
+				seen[id.key] = path
+			}
+			activities = append(activities, path)
+		}
+	}
+	return activities
+}
+
+func (s *stats) add(path string, a *activity) {
+	s.Activities++
+	for _, ss := range a.sessions {
+		t := ss.start.In(s.loc)
+		year, week := t.ISOWeek()
+		group(s.sports, ss.sport).add(ss)
+		group(s.weeks, fmt.Sprintf("%d-W%02d", year, week)).add(ss)
+		group(s.months, t.Format("2006-01")).add(ss)
+		for _, e := range ss.efforts {
+			if e.Duration <= 0 {
+				// A distance jump between records, not an effort.
+				continue
+			}
+			k := prKey{ss.sport, e.Distance}
+			if p, ok := s.prs[k]; ok && p.Time <= e.Duration.Seconds() {
+				continue
+			}
+			s.prs[k] = &pr{
+				Sport:    ss.sport,
+				Distance: e.Distance,
+				Time:     e.Duration.Seconds(),
+				Start:    e.Start.In(s.loc),
+				Path:     path,
+			}
+		}
+	}
+}
+
+func group(m map[string]*totals, key string) *totals {
+	t, ok := m[key]
+	if !ok {
+		t = &totals{Key: key}
+		m[key] = t
+	}
+	return t
+}
+
+// finish sorts the totals and PRs into the exported fields.
+func (s *stats) finish() {
+	for _, g := range []struct {
+		m   map[string]*totals
+		dst *[]*totals
+	}{{s.sports, &s.Sports}, {s.weeks, &s.Weeks}, {s.months, &s.Months}} {
+		*g.dst = []*totals{}
+		for _, k := range sortedKeys(g.m) {
+			*g.dst = append(*g.dst, g.m[k])
+		}
+	}
+	s.PRs = []*pr{}
+	for _, p := range s.prs {
+		s.PRs = append(s.PRs, p)
+	}
+	sort.Slice(s.PRs, func(i, j int) bool {
+		if s.PRs[i].Sport != s.PRs[j].Sport {
+			return s.PRs[i].Sport < s.PRs[j].Sport
+		}
+		return s.PRs[i].Distance < s.PRs[j].Distance
+	})
+}
+
+// write writes the statistics as tables.
+func (s *stats) write(w io.Writer) error {
+	fmt.Fprintf(w, "%d files: %d activities, %d duplicates, %d other files, %d errors\n",
+		s.Files, s.Activities, len(s.Duplicates), s.Skipped, len(s.Errors))
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, t := range []struct {
+		name string
+		rows []*totals
+	}{{"SPORT", s.Sports}, {"WEEK", s.Weeks}, {"MONTH", s.Months}} {
+		if len(t.rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s\tSESSIONS\tDISTANCE\tTIME\tASCENT\tTSS\n", t.name)
+		for _, r := range t.rows {
+			fmt.Fprintf(tw, "%s\t%d\t%.1f km\t%s\t%.0f m\t%.0f\n",
+				r.Key, r.Sessions, r.Distance/1000, formatDuration(r.Time), r.Ascent, r.TSS)
+		}
+	}
+	if len(s.PRs) > 0 {
+		fmt.Fprintf(tw, "\nPR\tDISTANCE\tTIME\tDATE\tFILE\n")
+		for _, p := range s.PRs {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				p.Sport, formatDistance(p.Distance), formatDuration(p.Time), p.Start.Format("2006-01-02"), p.Path)
+		}
+	}
+	if len(s.Duplicates) > 0 {
+		fmt.Fprintf(tw, "\nDUPLICATE\tOF\n")
+		for _, d := range s.Duplicates {
+			fmt.Fprintf(tw, "%s\t%s\n", d.Path, d.Of)
+		}
+	}
+	if len(s.Errors) > 0 {
+		fmt.Fprintf(tw, "\nERROR\t\n")
+		for _, e := range s.Errors {
+			fmt.Fprintf(tw, "%s\t%s\n", e.Path, e.Error)
+		}
+	}
+	return tw.Flush()
+}
+
+// formatDuration formats seconds as h:mm:ss.
+func formatDuration(sec float64) string {
+	d := int(math.Round(sec))
+	return fmt.Sprintf("%d:%02d:%02d", d/3600, d/60%60, d%60)
+}
+
+func formatDistance(m float64) string {
+	switch m {
+	case 1609.344:
+		return "1 mile"
+	case 21097.5:
+		return "half marathon"
+	case 42195:
+		return "marathon"
+	}
+	if m < 1000 {
+		return fmt.Sprintf("%g m", m)
+	}
+	return fmt.Sprintf("%g km", m/1000)
+}
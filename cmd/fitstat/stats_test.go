@@ -0,0 +1,231 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestClassify(t *testing.T) {
+	baseTime := time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2015, 8, 15, 14, 45, 0, 0, time.UTC)
+	activity := func(serial uint32, created time.Time) fileID {
+		return fileID{fileType: fit.FileTypeActivity, key: fileKey{serial, created}}
+	}
+	tests := []struct {
+		name       string
+		ids        []fileID
+		activities []string
+		duplicates []duplicate
+		skipped    int
+		errors     int
+	}{
+		{
+			name:       "copies",
+			ids:        []fileID{activity(1, created), activity(1, created), activity(1, created)},
+			activities: []string{"0"},
+			duplicates: []duplicate{{"1", "0"}, {"2", "0"}},
+		},
+		{
+			name:       "different keys",
+			ids:        []fileID{activity(1, created), activity(2, created), activity(1, created.Add(time.Second))},
+			activities: []string{"0", "1", "2"},
+		},
+		{
+			name:       "no serial",
+			ids:        []fileID{activity(0, created), activity(0, created)},
+			activities: []string{"0", "1"},
+		},
+		{
+			name:       "no creation time",
+			ids:        []fileID{activity(1, time.Time{}), activity(1, time.Time{}), activity(1, baseTime), activity(1, baseTime)},
+			activities: []string{"0", "1", "2", "3"},
+		},
+		{
+			name:       "other files",
+			ids:        []fileID{{fileType: fit.FileTypeSettings}, {err: errors.New("bad")}, activity(1, created)},
+			activities: []string{"2"},
+			skipped:    1,
+			errors:     1,
+		},
+	}
+	for _, test := range tests {
+		paths := make([]string, len(test.ids))
+		for i := range paths {
+			paths[i] = string(rune('0' + i))
+		}
+		s := newStats(time.UTC)
+		activities := s.classify(paths, test.ids)
+		if !reflect.DeepEqual(activities, test.activities) {
+			t.Errorf("%s: got activities %v, want %v", test.name, activities, test.activities)
+		}
+		if len(s.Duplicates) != len(test.duplicates) || len(test.duplicates) > 0 && !reflect.DeepEqual(s.Duplicates, test.duplicates) {
+			t.Errorf("%s: got duplicates %v, want %v", test.name, s.Duplicates, test.duplicates)
+		}
+		if s.Skipped != test.skipped || len(s.Errors) != test.errors {
+			t.Errorf("%s: got %d skipped and %d errors, want %d and %d", test.name, s.Skipped, len(s.Errors), test.skipped, test.errors)
+		}
+	}
+}
+
+func TestReadFileID(t *testing.T) {
+	tests := []struct {
+		path     []string
+		fileType fit.FileType
+		valid    bool
+	}{
+		{[]string{"me", "activity-small-fenix2-run.fit"}, fit.FileTypeActivity, true},
+		{[]string{"fitsdk", "Activity.fit"}, fit.FileTypeActivity, true},
+		{[]string{"fitsdk", "Settings.fit"}, fit.FileTypeSettings, false},
+	}
+	for _, test := range tests {
+		id := readFileID(fittest.Path(test.path...))
+		if id.err != nil {
+			t.Errorf("%v: %v", test.path, id.err)
+			continue
+		}
+		if id.fileType != test.fileType || id.key.valid() != test.valid {
+			t.Errorf("%v: got type %v and valid key %t, want %v and %t", test.path, id.fileType, id.key.valid(), test.fileType, test.valid)
+		}
+	}
+	if id := readFileID(fittest.Path("missing.fit")); id.err == nil {
+		t.Error("got no error for a missing file")
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	oslo := time.FixedZone("CET", 3600)
+	tests := []struct {
+		name   string
+		loc    *time.Location
+		starts []time.Time
+		weeks  []string
+		months []string
+	}{
+		{
+			name:   "same week",
+			loc:    time.UTC,
+			starts: []time.Time{time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2021, 3, 7, 20, 0, 0, 0, time.UTC)},
+			weeks:  []string{"2021-W09"},
+			months: []string{"2021-03"},
+		},
+		{
+			name:   "iso year",
+			loc:    time.UTC,
+			starts: []time.Time{time.Date(2021, 1, 3, 8, 0, 0, 0, time.UTC), time.Date(2021, 1, 4, 8, 0, 0, 0, time.UTC)},
+			weeks:  []string{"2020-W53", "2021-W01"},
+			months: []string{"2021-01"},
+		},
+		{
+			name:   "time zone",
+			loc:    oslo,
+			starts: []time.Time{time.Date(2021, 1, 31, 23, 30, 0, 0, time.UTC), time.Date(2021, 2, 1, 8, 0, 0, 0, time.UTC)},
+			weeks:  []string{"2021-W05"},
+			months: []string{"2021-02"},
+		},
+	}
+	for _, test := range tests {
+		s := newStats(test.loc)
+		for _, start := range test.starts {
+			s.add("a.fit", &activity{sessions: []session{{sport: "Running", start: start, distance: 1000, time: 300, ascent: math.NaN(), tss: 10}}})
+		}
+		s.finish()
+		for _, g := range []struct {
+			kind string
+			rows []*totals
+			want []string
+		}{{"weeks", s.Weeks, test.weeks}, {"months", s.Months, test.months}} {
+			var keys []string
+			var sessions int
+			for _, r := range g.rows {
+				keys = append(keys, r.Key)
+				sessions += r.Sessions
+				if r.Distance != float64(r.Sessions)*1000 || r.Time != float64(r.Sessions)*300 || r.Ascent != 0 || r.TSS != float64(r.Sessions)*10 {
+					t.Errorf("%s: %s %s: got totals %+v", test.name, g.kind, r.Key, *r)
+				}
+			}
+			if !reflect.DeepEqual(keys, g.want) || sessions != len(test.starts) {
+				t.Errorf("%s: got %s %v with %d sessions, want %v with %d", test.name, g.kind, keys, sessions, g.want, len(test.starts))
+			}
+		}
+	}
+}
+
+func TestPRs(t *testing.T) {
+	start := time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC)
+	effort := func(distance float64, sec int) analysis.BestEffort {
+		return analysis.BestEffort{Distance: distance, Duration: time.Duration(sec) * time.Second, Start: start}
+	}
+	s := newStats(time.UTC)
+	for _, a := range []struct {
+		path    string
+		sport   string
+		efforts []analysis.BestEffort
+	}{
+		{"a.fit", "Running", []analysis.BestEffort{effort(1000, 240), effort(5000, 1300)}},
+		{"b.fit", "Running", []analysis.BestEffort{effort(1000, 230), effort(5000, 1350)}},
+		{"c.fit", "Running", []analysis.BestEffort{effort(1000, 0)}},
+		{"d.fit", "Cycling", []analysis.BestEffort{effort(1000, 90)}},
+		{"e.fit", "Running", []analysis.BestEffort{effort(1000, 230)}},
+	} {
+		s.add(a.path, &activity{sessions: []session{{sport: a.sport, start: start, efforts: a.efforts}}})
+	}
+	s.finish()
+
+	want := []pr{
+		{"Cycling", 1000, 90, start, "d.fit"},
+		{"Running", 1000, 230, start, "b.fit"},
+		{"Running", 5000, 1300, start, "a.fit"},
+	}
+	if len(s.PRs) != len(want) {
+		t.Fatalf("got %d PRs, want %d", len(s.PRs), len(want))
+	}
+	for i, p := range s.PRs {
+		if *p != want[i] {
+			t.Errorf("PR %d: got %+v, want %+v", i, *p, want[i])
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	path := fittest.Path("me", "activity-small-fenix2-run.fit")
+	a := analyze(path, 0)
+	if a.err != nil {
+		t.Fatal(a.err)
+	}
+	_, act := fittest.DecodeActivity(t, "me", "activity-small-fenix2-run.fit")
+	if len(a.sessions) != len(act.Sessions) {
+		t.Fatalf("got %d sessions, want %d", len(a.sessions), len(act.Sessions))
+	}
+	ss, want := a.sessions[0], act.Sessions[0]
+	if ss.sport != want.Sport.String() || !ss.start.Equal(want.StartTime) || ss.distance != want.GetTotalDistanceScaled() {
+		t.Errorf("got session %s at %v over %.2f m, want %v at %v over %.2f m",
+			ss.sport, ss.start, ss.distance, want.Sport, want.StartTime, want.GetTotalDistanceScaled())
+	}
+
+	s := newStats(time.UTC)
+	s.add(path, a)
+	s.finish()
+	var km *pr
+	for _, p := range s.PRs {
+		if p.Distance == 1000 {
+			km = p
+		}
+		if p.Distance > ss.distance {
+			t.Errorf("got PR over %g m in a %.0f m session", p.Distance, ss.distance)
+		}
+	}
+	if km == nil || km.Path != path || km.Time <= 0 || km.Time > ss.time {
+		t.Errorf("got 1 km PR %+v", km)
+	}
+
+	if a := analyze(fittest.Path("fitsdk", "Settings.fit"), 0); a.err == nil {
+		t.Error("got no error analyzing a settings file")
+	}
+}
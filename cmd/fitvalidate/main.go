@@ -0,0 +1,120 @@
+// Command fitvalidate checks FIT files for conformance with the FIT protocol
+// and profile, and reports the issues found with their severity and byte
+// offset.
+//
+// Usage:
+//
+//	fitvalidate [flags] file.fit...
+//
+// Issues are printed as "file:offset: severity: message", or as one JSON
+// object per line with -json. Issues concerning a file as a whole have no
+// offset. The exit status is 1 if any file has an issue of severity error.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/tormoder/fit"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs fitvalidate with the arguments args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	l := log.New(stderr, "fitvalidate: ", 0)
+
+	flags := flag.NewFlagSet("fitvalidate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		jsonOut = flags.Bool("json", false, "output one JSON object per line")
+		level   = flags.String("level", "warning", "minimum severity to report: info, warning or error")
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: fitvalidate [flags] file.fit...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	min, err := parseSeverity(*level)
+	if err != nil {
+		l.Print(err)
+		return 1
+	}
+
+	w := bufio.NewWriter(stdout)
+	enc := json.NewEncoder(w)
+	failed := false
+	for _, path := range flags.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			w.Flush()
+			l.Print(err)
+			failed = true
+			continue
+		}
+		issues := fit.Validate(bufio.NewReader(f))
+		f.Close()
+		for _, issue := range issues {
+			if issue.Severity == fit.SeverityError {
+				failed = true
+			}
+			if issue.Severity < min {
+				continue
+			}
+			if *jsonOut {
+				enc.Encode(jsonIssue{
+					File:     path,
+					Offset:   issue.Offset,
+					Severity: issue.Severity.String(),
+					Mesg:     issue.MesgNum.String(),
+					Message:  issue.Message,
+				})
+				continue
+			}
+			if issue.Offset < 0 {
+				fmt.Fprintf(w, "%s: %v: %s\n", path, issue.Severity, issue.Message)
+			} else {
+				fmt.Fprintf(w, "%s:%d: %v: %s\n", path, issue.Offset, issue.Severity, issue.Message)
+			}
+		}
+	}
+	w.Flush()
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+type jsonIssue struct {
+	File     string `json:"file"`
+	Offset   int64  `json:"offset"`
+	Severity string `json:"severity"`
+	Mesg     string `json:"mesg"`
+	Message  string `json:"message"`
+}
+
+func parseSeverity(s string) (fit.Severity, error) {
+	for _, sev := range []fit.Severity{fit.SeverityInfo, fit.SeverityWarning, fit.SeverityError} {
+		if sev.String() == s {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
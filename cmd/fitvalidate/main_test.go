@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func TestRun(t *testing.T) {
+	valid := fittest.Path("fitsdk", "Activity.fit")
+	corrupt := fittest.Path("corrupt", "activity-filecrc.fit")
+	tests := []struct {
+		args   []string
+		status int
+		out    string
+	}{
+		{[]string{valid}, 0, ""},
+		{[]string{"-level", "info", valid}, 0, valid + ":92: info: Event.Data: base type enum, profile has uint32\n" +
+			valid + ":92: info: Event.Data: size 1, profile type uint32 has size 4\n"},
+		{[]string{corrupt}, 1, corrupt + ": error: integrity error: file checksum failed\n"},
+		{[]string{valid, corrupt, valid}, 1, corrupt + ": error: integrity error: file checksum failed\n"},
+		{[]string{fittest.Path("no-such-file.fit")}, 1, ""},
+		{[]string{"-level", "fatal", valid}, 1, ""},
+		{[]string{}, 2, ""},
+		{[]string{"-no-such-flag", valid}, 2, ""},
+	}
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		if status := run(test.args, &stdout, &stderr); status != test.status {
+			t.Errorf("%q: got exit status %d, want %d; stderr:\n%s", test.args, status, test.status, stderr.String())
+		}
+		if got := stdout.String(); got != test.out {
+			t.Errorf("%q: got output\n%s\nwant\n%s", test.args, got, test.out)
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	path := fittest.Path("corrupt", "activity-filecrc.fit")
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"-json", path}, &stdout, &stderr); status != 1 {
+		t.Errorf("got exit status %d, want 1", status)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d issues, want 1:\n%s", len(lines), stdout.String())
+	}
+	var got jsonIssue
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := jsonIssue{File: path, Offset: -1, Severity: "error", Mesg: "Invalid", Message: "integrity error: file checksum failed"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
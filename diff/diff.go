@@ -0,0 +1,438 @@
+// Package diff compares decoded FIT files semantically.
+//
+// Messages are compared by the values of their fields, not by how they are
+// encoded: local message numbers, compressed timestamps, field order and
+// byte order do not matter, and neither do the file header and CRC. Field
+// values are compared scaled, as in the JSON representation of messages,
+// with configurable tolerances.
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// Kind is the kind of a difference.
+type Kind int
+
+// Kinds of differences.
+const (
+	Removed Kind = iota // The message is only in the first file.
+	Added               // The message is only in the second file.
+	Changed             // Fields of the message differ.
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Removed:
+		return "removed"
+	case Added:
+		return "added"
+	case Changed:
+		return "changed"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// A Difference is a message that was removed, added or changed.
+type Difference struct {
+	Kind    Kind
+	MesgNum fit.MesgNum
+
+	// Index is the index of the message among the messages of its type in
+	// the first file, or in the second file for added messages.
+	Index int
+
+	// Time is the timestamp of the message, or the zero time if it has
+	// none.
+	Time time.Time
+
+	// Fields are the fields that differ in changed messages.
+	Fields []Field
+
+	// A and B are the messages in the first and second file, such as a
+	// *fit.RecordMsg, or nil if the message is not in the file.
+	A, B interface{}
+}
+
+// A Field is a field that differs between two messages. A and B are the
+// scaled values, with units if the field has any, or empty if the field is
+// invalid.
+type Field struct {
+	Name string
+	A, B string
+}
+
+func (d Difference) String() string {
+	var sb strings.Builder
+	switch d.Kind {
+	case Removed:
+		sb.WriteString("- ")
+	case Added:
+		sb.WriteString("+ ")
+	default:
+		sb.WriteString("~ ")
+	}
+	fmt.Fprintf(&sb, "%v[%d]", d.MesgNum, d.Index)
+	if !d.Time.IsZero() {
+		fmt.Fprintf(&sb, " %s", d.Time.UTC().Format(time.RFC3339))
+	}
+	for i, f := range d.Fields {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s %s -> %s", f.Name, orInvalid(f.A), orInvalid(f.B))
+	}
+	return sb.String()
+}
+
+func orInvalid(s string) string {
+	if s == "" {
+		return "invalid"
+	}
+	return s
+}
+
+type options struct {
+	abs    map[string]float64
+	rel    float64
+	time   time.Duration
+	ignore map[string]bool
+}
+
+// Option configures a comparison.
+type Option func(*options)
+
+// WithTolerance sets the absolute tolerance of a field, given as a field
+// name such as "Altitude", or a message and field name such as
+// "Record.Altitude". Field values differing by at most abs are equal.
+func WithTolerance(field string, abs float64) Option {
+	return func(o *options) {
+		o.abs[field] = abs
+	}
+}
+
+// WithRelativeTolerance sets the relative tolerance of all numeric fields.
+// Field values differing by at most rel times the larger absolute value are
+// equal.
+func WithRelativeTolerance(rel float64) Option {
+	return func(o *options) {
+		o.rel = rel
+	}
+}
+
+// WithTimeTolerance sets the tolerance used to align messages by timestamp
+// and to compare time fields. The default is zero.
+func WithTimeTolerance(d time.Duration) Option {
+	return func(o *options) {
+		o.time = d
+	}
+}
+
+// WithIgnore ignores messages or fields, given as a message name such as
+// "DeviceInfo", a field name such as "Speed", or a message and field name
+// such as "Record.Speed".
+func WithIgnore(names ...string) Option {
+	return func(o *options) {
+		for _, n := range names {
+			o.ignore[n] = true
+		}
+	}
+}
+
+// message is a message prepared for comparison.
+type message struct {
+	msg    interface{}
+	index  int
+	time   time.Time
+	fields map[string]json.RawMessage
+}
+
+// Files returns the differences between the messages of a and b. Messages
+// of each type are aligned by timestamp if they have one, by message index
+// if they have one, and by their order in the files otherwise. The
+// differences are grouped by message type, in the order the types first
+// appear in a and then b.
+func Files(a, b *fit.File, opts ...Option) ([]Difference, error) {
+	o := options{abs: make(map[string]float64), ignore: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	ma, order, err := messages(a, nil, o)
+	if err != nil {
+		return nil, err
+	}
+	mb, order, err := messages(b, order, o)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []Difference
+	for _, mn := range order {
+		diffs = append(diffs, o.compareType(mn, ma[mn], mb[mn])...)
+	}
+	return diffs, nil
+}
+
+// messages groups the messages of f by type, and appends types not in
+// order to it.
+func messages(f *fit.File, order []fit.MesgNum, o options) (map[fit.MesgNum][]*message, []fit.MesgNum, error) {
+	byType := make(map[fit.MesgNum][]*message)
+	seen := make(map[fit.MesgNum]bool)
+	for _, mn := range order {
+		seen[mn] = true
+	}
+	for _, msg := range f.Messages() {
+		mn := fit.MesgNumOf(msg)
+		if o.ignore[mn.String()] {
+			continue
+		}
+		b, err := json.Marshal(msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		m := &message{msg: msg, index: len(byType[mn])}
+		if err := json.Unmarshal(b, &m.fields); err != nil {
+			return nil, nil, err
+		}
+		if ts, ok := m.fields["Timestamp"]; ok {
+			m.time, _ = parseTime(ts)
+		}
+		byType[mn] = append(byType[mn], m)
+		if !seen[mn] {
+			seen[mn] = true
+			order = append(order, mn)
+		}
+	}
+	return byType, order, nil
+}
+
+func parseTime(b json.RawMessage) (time.Time, bool) {
+	var s string
+	if json.Unmarshal(b, &s) != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+// compareType aligns and compares the messages of one type.
+func (o options) compareType(mn fit.MesgNum, as, bs []*message) []Difference {
+	var diffs []Difference
+	pair := func(a, b *message) {
+		switch {
+		case b == nil:
+			diffs = append(diffs, Difference{Kind: Removed, MesgNum: mn, Index: a.index, Time: a.time, A: a.msg})
+		case a == nil:
+			diffs = append(diffs, Difference{Kind: Added, MesgNum: mn, Index: b.index, Time: b.time, B: b.msg})
+		default:
+			if fields := o.compareFields(mn, a, b); len(fields) > 0 {
+				diffs = append(diffs, Difference{
+					Kind: Changed, MesgNum: mn, Index: a.index, Time: a.time,
+					Fields: fields, A: a.msg, B: b.msg,
+				})
+			}
+		}
+	}
+
+	timedA, otherA := splitTimed(as)
+	timedB, otherB := splitTimed(bs)
+	o.alignByTime(timedA, timedB, pair)
+	alignByIndex(otherA, otherB, pair)
+	return diffs
+}
+
+func splitTimed(ms []*message) (timed, other []*message) {
+	for _, m := range ms {
+		if m.time.IsZero() {
+			other = append(other, m)
+		} else {
+			timed = append(timed, m)
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].time.Before(timed[j].time) })
+	return timed, other
+}
+
+// alignByTime pairs messages with timestamps within the time tolerance.
+func (o options) alignByTime(as, bs []*message, pair func(a, b *message)) {
+	i, j := 0, 0
+	for i < len(as) && j < len(bs) {
+		a, b := as[i], bs[j]
+		d := b.time.Sub(a.time)
+		switch {
+		case d <= o.time && d >= -o.time:
+			pair(a, b)
+			i++
+			j++
+		case d > 0:
+			pair(a, nil)
+			i++
+		default:
+			pair(nil, b)
+			j++
+		}
+	}
+	for ; i < len(as); i++ {
+		pair(as[i], nil)
+	}
+	for ; j < len(bs); j++ {
+		pair(nil, bs[j])
+	}
+}
+
+// alignByIndex pairs messages by message index if all messages have one,
+// and by order otherwise.
+func alignByIndex(as, bs []*message, pair func(a, b *message)) {
+	key := func(ms []*message) map[string]*message {
+		m := make(map[string]*message)
+		for _, msg := range ms {
+			mi, ok := msg.fields["MessageIndex"]
+			if !ok {
+				return nil
+			}
+			m[string(mi)] = msg
+		}
+		return m
+	}
+	ka, kb := key(as), key(bs)
+	if ka != nil && kb != nil && len(ka) == len(as) && len(kb) == len(bs) {
+		for _, a := range as {
+			pair(a, kb[string(a.fields["MessageIndex"])])
+		}
+		for _, b := range bs {
+			if ka[string(b.fields["MessageIndex"])] == nil {
+				pair(nil, b)
+			}
+		}
+		return
+	}
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var a, b *message
+		if i < len(as) {
+			a = as[i]
+		}
+		if i < len(bs) {
+			b = bs[i]
+		}
+		pair(a, b)
+	}
+}
+
+// compareFields returns the fields that differ between a and b, sorted by
+// name.
+func (o options) compareFields(mn fit.MesgNum, a, b *message) []Field {
+	names := make(map[string]bool)
+	for n := range a.fields {
+		names[n] = true
+	}
+	for n := range b.fields {
+		names[n] = true
+	}
+	var fields []Field
+	for n := range names {
+		qualified := mn.String() + "." + n
+		if o.ignore[n] || o.ignore[qualified] {
+			continue
+		}
+		va, oka := a.fields[n]
+		vb, okb := b.fields[n]
+		if oka && okb && o.equal(qualified, n, va, vb) {
+			continue
+		}
+		f := Field{Name: n}
+		if oka {
+			f.A = format(va)
+		}
+		if okb {
+			f.B = format(vb)
+		}
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+// value is a decoded JSON field value.
+type value struct {
+	nums  []float64 // Numeric values, nil if the value is not numeric.
+	units string
+	raw   json.RawMessage
+}
+
+func parseValue(b json.RawMessage) value {
+	v := value{raw: b}
+	var withUnits struct {
+		Value json.RawMessage
+		Units string
+	}
+	if len(b) > 0 && b[0] == '{' && json.Unmarshal(b, &withUnits) == nil && withUnits.Value != nil {
+		v.units = withUnits.Units
+		b = withUnits.Value
+	}
+	var f float64
+	var fs []float64
+	switch {
+	case json.Unmarshal(b, &f) == nil:
+		v.nums = []float64{f}
+	case json.Unmarshal(b, &fs) == nil && len(fs) > 0:
+		v.nums = fs
+	}
+	return v
+}
+
+func (o options) equal(qualified, name string, a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	if ta, ok := parseTime(a); ok {
+		tb, ok := parseTime(b)
+		d := tb.Sub(ta)
+		return ok && d <= o.time && d >= -o.time
+	}
+	va, vb := parseValue(a), parseValue(b)
+	if va.nums == nil || vb.nums == nil || len(va.nums) != len(vb.nums) || va.units != vb.units {
+		return false
+	}
+	abs, ok := o.abs[qualified]
+	if !ok {
+		abs = o.abs[name]
+	}
+	for i, x := range va.nums {
+		y := vb.nums[i]
+		d := math.Abs(x - y)
+		if d > abs && d > o.rel*math.Max(math.Abs(x), math.Abs(y)) {
+			return false
+		}
+	}
+	return true
+}
+
+// format formats a JSON field value for display.
+func format(b json.RawMessage) string {
+	v := parseValue(b)
+	var s string
+	if len(v.raw) > 0 && v.raw[0] == '{' && v.units != "" {
+		var withUnits struct{ Value json.RawMessage }
+		json.Unmarshal(v.raw, &withUnits)
+		s = string(withUnits.Value)
+	} else {
+		s = string(v.raw)
+	}
+	var str string
+	if json.Unmarshal([]byte(s), &str) == nil {
+		s = str
+	}
+	if v.units != "" {
+		s += " " + v.units
+	}
+	return s
+}
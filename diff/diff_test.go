@@ -0,0 +1,96 @@
+package diff_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/diff"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func decode(t *testing.T, data []byte) *fit.File {
+	t.Helper()
+	f, err := fit.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFilesEncoding(t *testing.T) {
+	for _, path := range [][]string{
+		{"fitsdk", "Activity.fit"},
+		{"fitsdk", "WorkoutRepeatSteps.fit"},
+		{"dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit"},
+	} {
+		data := fittest.Read(t, path...)
+		a := decode(t, data)
+		var buf bytes.Buffer
+		if err := fit.Encode(&buf, a, fit.WithBigEndian()); err != nil {
+			t.Fatal(err)
+		}
+		b := decode(t, buf.Bytes())
+		diffs, err := diff.Files(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range diffs {
+			t.Errorf("%s: %v", filepath.Join(path...), d)
+		}
+	}
+}
+
+func TestFilesChanges(t *testing.T) {
+	data := fittest.Read(t, "dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit")
+	a := decode(t, data)
+	b := decode(t, data)
+	act, _ := b.Activity()
+	act.Records[10].HeartRate++
+	act.Records[20].Altitude += 2
+	act.Records = append(act.Records[:30], act.Records[31:]...)
+
+	diffs, err := diff.Files(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 3 {
+		t.Fatalf("got %d differences, want 3: %v", len(diffs), diffs)
+	}
+	want := []struct {
+		kind  diff.Kind
+		index int
+		field string
+	}{
+		{diff.Changed, 10, "HeartRate"},
+		{diff.Changed, 20, "Altitude"},
+		{diff.Removed, 30, ""},
+	}
+	for i, w := range want {
+		d := diffs[i]
+		if d.Kind != w.kind || d.MesgNum != fit.MesgNumRecord || d.Index != w.index {
+			t.Errorf("difference %d: got %v", i, d)
+			continue
+		}
+		if w.field != "" && (len(d.Fields) != 1 || d.Fields[0].Name != w.field) {
+			t.Errorf("difference %d: got fields %v, want %s", i, d.Fields, w.field)
+		}
+	}
+
+	diffs, err = diff.Files(a, b,
+		diff.WithTolerance("Record.HeartRate", 1),
+		diff.WithTolerance("Altitude", 0.5),
+		diff.WithIgnore("Record"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("got differences with tolerances: %v", diffs)
+	}
+	diffs, _ = diff.Files(a, b, diff.WithTolerance("Record.HeartRate", 1), diff.WithTolerance("Altitude", 0.5))
+	if len(diffs) != 1 || diffs[0].Kind != diff.Removed {
+		t.Errorf("got %v, want only the removed record", diffs)
+	}
+}
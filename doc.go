@@ -1,4 +1,4 @@
-// Package fit implements decoding of the Flexible and Interoperable Data
-// Transfer (FIT) Protocol. For more information see
+// Package fit implements decoding and encoding of the Flexible and
+// Interoperable Data Transfer (FIT) Protocol. For more information see
 // https://github.com/tormoder/fit.
 package fit
@@ -0,0 +1,160 @@
+// Package elevation corrects and analyzes the altitude of FIT activity
+// records. Altitudes can be smoothed, replaced by values from a digital
+// elevation model (DEM) such as SRTM .hgt tiles or GeoTIFF files, and used to
+// compute the total ascent and descent.
+package elevation
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+// DefaultHysteresis is the hysteresis in metres used by TotalAscent when
+// a negative hysteresis is given. It is the same as the default used by
+// the analysis package.
+const DefaultHysteresis = analysis.DefaultAscentHysteresis
+
+// Altitudes returns the altitude in metres of each record, preferring
+// EnhancedAltitude over Altitude. Invalid altitudes are NaN.
+func Altitudes(records []*fit.RecordMsg) []float64 {
+	alts := make([]float64, len(records))
+	for i, r := range records {
+		alts[i] = r.GetEnhancedAltitudeScaled()
+		if math.IsNaN(alts[i]) {
+			alts[i] = r.GetAltitudeScaled()
+		}
+	}
+	return alts
+}
+
+// SetAltitudes sets both Altitude and EnhancedAltitude of each record to
+// the corresponding altitude in metres of alts. NaN, and altitudes that do
+// not fit a field, set the field to its invalid value. alts must have the
+// same length as records.
+func SetAltitudes(records []*fit.RecordMsg, alts []float64) {
+	if len(alts) != len(records) {
+		panic("elevation: altitude and record count mismatch")
+	}
+	for i, r := range records {
+		setAltitude(r, alts[i])
+	}
+}
+
+func setAltitude(r *fit.RecordMsg, alt float64) {
+	r.Altitude, r.EnhancedAltitude = 0xFFFF, 0xFFFFFFFF
+	if math.IsNaN(alt) {
+		return
+	}
+	v := math.Round((alt + 500) * 5)
+	if v >= 0 && v < 0xFFFF {
+		r.Altitude = uint16(v)
+	}
+	if v >= 0 && v < 0xFFFFFFFF {
+		r.EnhancedAltitude = uint32(v)
+	}
+}
+
+// Smooth replaces the altitude of each record with the mean of the valid
+// altitudes of the records within window/2 of it in time, removing the
+// noise of GPS altitude and the steps of barometric altitude. Records must
+// be ordered by timestamp. Records without a valid altitude are left
+// unchanged, as are all records if window is zero or less.
+func Smooth(records []*fit.RecordMsg, window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	alts := Altitudes(records)
+	smoothed := smooth(records, alts, window)
+	SetAltitudes(records, smoothed)
+}
+
+// smooth returns the centered moving average of alts over window. The sum
+// is maintained over a sliding range of records.
+func smooth(records []*fit.RecordMsg, alts []float64, window time.Duration) []float64 {
+	out := make([]float64, len(alts))
+	half := window / 2
+	var (
+		sum       float64
+		n         int
+		low, high int
+	)
+	for i, r := range records {
+		for high < len(records) && !records[high].Timestamp.After(r.Timestamp.Add(half)) {
+			if !math.IsNaN(alts[high]) {
+				sum += alts[high]
+				n++
+			}
+			high++
+		}
+		for records[low].Timestamp.Before(r.Timestamp.Add(-half)) {
+			if !math.IsNaN(alts[low]) {
+				sum -= alts[low]
+				n--
+			}
+			low++
+		}
+		if math.IsNaN(alts[i]) || n == 0 {
+			out[i] = alts[i]
+			continue
+		}
+		out[i] = sum / float64(n)
+	}
+	return out
+}
+
+// TotalAscent returns the total ascent and descent of records in whole
+// metres, as stored in the TotalAscent and TotalDescent fields of session
+// and lap messages. The direction only changes after the altitude has
+// moved at least hysteresis metres; see analysis.Ascent. A hysteresis of
+// zero counts every change, and a negative hysteresis means
+// DefaultHysteresis. Both values are invalid (0xFFFF) if no record has a
+// valid altitude.
+func TotalAscent(records []*fit.RecordMsg, hysteresis float64) (ascent, descent uint16) {
+	if hysteresis < 0 {
+		hysteresis = DefaultHysteresis
+	}
+	alts := Altitudes(records)
+	valid := false
+	for _, a := range alts {
+		if !math.IsNaN(a) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return 0xFFFF, 0xFFFF
+	}
+	a, d := analysis.Ascent(alts, hysteresis)
+	return clampUint16(a), clampUint16(d)
+}
+
+func clampUint16(v float64) uint16 {
+	return uint16(math.Min(math.Round(v), 0xFFFE))
+}
+
+// A DEM is a digital elevation model.
+type DEM interface {
+	// Elevation returns the elevation in metres above sea level at p, or
+	// NaN if p is invalid or not covered by the model.
+	Elevation(p fit.Position) float64
+}
+
+// Correct replaces the altitude of every record with a valid position
+// covered by dem with the elevation of the model at that position, and
+// returns the number of corrected records. Other records are left
+// unchanged.
+func Correct(records []*fit.RecordMsg, dem DEM) int {
+	n := 0
+	for _, r := range records {
+		e := dem.Elevation(fit.NewPosition(r.PositionLat, r.PositionLong))
+		if math.IsNaN(e) {
+			continue
+		}
+		setAltitude(r, e)
+		n++
+	}
+	return n
+}
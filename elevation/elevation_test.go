@@ -0,0 +1,292 @@
+package elevation_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/elevation"
+)
+
+var t0 = time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+
+// records returns one record per second with the given altitudes. NaN
+// altitudes are left invalid.
+func records(alts ...float64) []*fit.RecordMsg {
+	rs := make([]*fit.RecordMsg, len(alts))
+	for i, a := range alts {
+		rs[i] = fit.NewRecordMsg()
+		rs[i].Timestamp = t0.Add(time.Duration(i) * time.Second)
+		if !math.IsNaN(a) {
+			rs[i].EnhancedAltitude = uint32((a + 500) * 5)
+		}
+	}
+	return rs
+}
+
+func TestSetAltitudes(t *testing.T) {
+	rs := records(0, 0, 0)
+	elevation.SetAltitudes(rs, []float64{100, math.NaN(), 20000})
+	if rs[0].Altitude != 3000 || rs[0].EnhancedAltitude != 3000 {
+		t.Errorf("100 m: got %d, %d, want 3000, 3000", rs[0].Altitude, rs[0].EnhancedAltitude)
+	}
+	if rs[1].Altitude != 0xFFFF || rs[1].EnhancedAltitude != 0xFFFFFFFF {
+		t.Errorf("NaN: got %d, %d, want invalid", rs[1].Altitude, rs[1].EnhancedAltitude)
+	}
+	if rs[2].Altitude != 0xFFFF || rs[2].GetEnhancedAltitudeScaled() != 20000 {
+		t.Errorf("20000 m: got %d, %v, want invalid, 20000", rs[2].Altitude, rs[2].GetEnhancedAltitudeScaled())
+	}
+}
+
+func TestSmooth(t *testing.T) {
+	alts := make([]float64, 60)
+	for i := range alts {
+		alts[i] = 100 + float64(2*(i%2))
+	}
+	alts[30] = math.NaN()
+	rs := records(alts...)
+	elevation.Smooth(rs, 10*time.Second)
+	got := elevation.Altitudes(rs)
+	for i := 10; i < 50; i++ {
+		if i == 30 {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("record %d: got %v, want NaN", i, got[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-101) > 0.25 {
+			t.Errorf("record %d: got %v, want about 101", i, got[i])
+		}
+	}
+
+	for _, window := range []time.Duration{0, -10 * time.Second} {
+		rs := records(alts...)
+		elevation.Smooth(rs, window)
+		for i, a := range elevation.Altitudes(rs) {
+			if a != alts[i] && !(math.IsNaN(a) && math.IsNaN(alts[i])) {
+				t.Errorf("window %v: record %d: got %v, want %v", window, i, a, alts[i])
+			}
+		}
+	}
+}
+
+func TestTotalAscent(t *testing.T) {
+	var alts []float64
+	for i := 0; i <= 100; i++ {
+		// A steady climb of 100 m with 1 m of noise, and back down.
+		alts = append(alts, float64(i)+float64(i%2))
+	}
+	for i := 100; i >= 0; i-- {
+		alts = append(alts, float64(i))
+	}
+	asc, desc := elevation.TotalAscent(records(alts...), -1)
+	if asc < 100 || asc > 101 || desc < 100 || desc > 101 {
+		t.Errorf("got ascent %d, descent %d, want about 100, 100", asc, desc)
+	}
+	// Without hysteresis every change counts.
+	noise := records(0, 1, 0, 1, 0)
+	if asc, desc = elevation.TotalAscent(noise, -1); asc != 0 || desc != 0 {
+		t.Errorf("noise: got ascent %d, descent %d, want 0, 0", asc, desc)
+	}
+	if asc, desc = elevation.TotalAscent(noise, 0); asc != 2 || desc != 2 {
+		t.Errorf("noise without hysteresis: got ascent %d, descent %d, want 2, 2", asc, desc)
+	}
+	asc, desc = elevation.TotalAscent(records(math.NaN(), math.NaN()), -1)
+	if asc != 0xFFFF || desc != 0xFFFF {
+		t.Errorf("no altitudes: got %d, %d, want invalid", asc, desc)
+	}
+}
+
+// hgt returns a 3×3 sample .hgt tile.
+func hgt(samples ...int16) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, samples)
+	return buf.Bytes()
+}
+
+func TestReadHGT(t *testing.T) {
+	g, err := elevation.ReadHGT(bytes.NewReader(hgt(
+		100, 200, 300,
+		100, 200, -32768,
+		100, 200, 300,
+	)), "N59E010.hgt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		lat, lng float64
+		want     float64
+	}{
+		{60, 10, 100},
+		{59, 11, 300},
+		{59.5, 10.25, 150},
+		{59.75, 10.5, 200},
+		// Next to the void the remaining samples are used.
+		{59.5, 10.75, 200},
+		{58.5, 10.5, math.NaN()},
+	} {
+		got := g.Elevation(fit.NewPositionDegrees(tt.lat, tt.lng))
+		if math.Abs(got-tt.want) > 1e-3 || math.IsNaN(got) != math.IsNaN(tt.want) {
+			t.Errorf("%v, %v: got %v, want %v", tt.lat, tt.lng, got, tt.want)
+		}
+	}
+
+	if _, err := elevation.ReadHGT(bytes.NewReader(hgt(1, 2, 3)), "N59E010.hgt"); err == nil {
+		t.Error("odd size: got no error")
+	}
+	if _, err := elevation.ReadHGT(bytes.NewReader(hgt(1, 2, 3, 4)), "tile.hgt"); err == nil {
+		t.Error("invalid name: got no error")
+	}
+}
+
+func TestHGTDirCorrect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elevation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tile := hgt(
+		10, 10, 10,
+		20, 20, 20,
+		30, 30, 30,
+	)
+	if err := ioutil.WriteFile(filepath.Join(dir, "S34W071.hgt"), tile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	dem := elevation.NewHGTDir(dir)
+
+	rs := records(500, 500, 500)
+	p := fit.NewPositionDegrees(-33.5, -70.5)
+	rs[0].PositionLat, rs[0].PositionLong = p.Lat, p.Long
+	p = fit.NewPositionDegrees(10, 10) // Missing tile.
+	rs[1].PositionLat, rs[1].PositionLong = p.Lat, p.Long
+
+	if n := elevation.Correct(rs, dem); n != 1 {
+		t.Errorf("got %d corrected records, want 1", n)
+	}
+	got := elevation.Altitudes(rs)
+	if want := []float64{20, 500, 500}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("got altitudes %v, want %v", got, want)
+	}
+}
+
+// geoTIFF returns a little-endian GeoTIFF with width×height int16 samples
+// in a single strip, covering 10°E to 10°E+width·0.5° and 60°N to
+// 60°N-height·0.5°, with -9999 as no data value.
+func geoTIFF(width, height int, samples []int16, deflate bool) []byte {
+	var img bytes.Buffer
+	binary.Write(&img, binary.LittleEndian, samples)
+	compression := uint16(1)
+	if deflate {
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		zw.Write(img.Bytes())
+		zw.Close()
+		img, compression = z, 8
+	}
+
+	type entry struct {
+		tag, typ uint16
+		count    uint32
+		data     []byte
+	}
+	le := binary.LittleEndian
+	short := func(vs ...uint16) []byte {
+		var b bytes.Buffer
+		binary.Write(&b, le, vs)
+		return b.Bytes()
+	}
+	long := func(v uint32) []byte {
+		b := make([]byte, 4)
+		le.PutUint32(b, v)
+		return b
+	}
+	double := func(vs ...float64) []byte {
+		var b bytes.Buffer
+		binary.Write(&b, le, vs)
+		return b.Bytes()
+	}
+	geoKeys := short(1, 1, 0, 2, 1024, 0, 1, 2, 1025, 0, 1, 1)
+	entries := []entry{
+		{256, 3, 1, short(uint16(width))},
+		{257, 3, 1, short(uint16(height))},
+		{258, 3, 1, short(16)},
+		{259, 3, 1, short(compression)},
+		{273, 4, 1, long(8)},
+		{277, 3, 1, short(1)},
+		{278, 3, 1, short(uint16(height))},
+		{279, 4, 1, long(uint32(img.Len()))},
+		{339, 3, 1, short(2)},
+		{33550, 12, 3, double(0.5, 0.5, 0)},
+		{33922, 12, 6, double(0, 0, 0, 10, 60, 0)},
+		{34735, 3, uint32(len(geoKeys) / 2), geoKeys},
+		{42113, 2, 6, []byte("-9999\x00")},
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].tag < entries[j].tag })
+
+	var out bytes.Buffer
+	ifd := 8 + img.Len()
+	out.WriteString("II*\x00")
+	out.Write(long(uint32(ifd)))
+	out.Write(img.Bytes())
+	extra := ifd + 2 + 12*len(entries) + 4
+	var extraData bytes.Buffer
+	out.Write(short(uint16(len(entries))))
+	for _, e := range entries {
+		out.Write(short(e.tag, e.typ))
+		out.Write(long(e.count))
+		if len(e.data) <= 4 {
+			out.Write(append(e.data, make([]byte, 4-len(e.data))...))
+			continue
+		}
+		out.Write(long(uint32(extra + extraData.Len())))
+		extraData.Write(e.data)
+	}
+	out.Write(long(0))
+	out.Write(extraData.Bytes())
+	return out.Bytes()
+}
+
+func TestReadGeoTIFF(t *testing.T) {
+	samples := []int16{
+		100, 200, 300,
+		400, -9999, 600,
+	}
+	for _, deflate := range []bool{false, true} {
+		g, err := elevation.ReadGeoTIFF(bytes.NewReader(geoTIFF(3, 2, samples, deflate)))
+		if err != nil {
+			t.Fatalf("deflate %t: %v", deflate, err)
+		}
+		// Samples are at the pixel centers.
+		for _, tt := range []struct {
+			lat, lng float64
+			want     float64
+		}{
+			{59.75, 10.25, 100},
+			{59.75, 11.25, 300},
+			{59.25, 10.25, 400},
+			{59.75, 10.5, 150},
+			// Between rows, next to the void.
+			{59.5, 11, (200 + 300 + 600) / 3.0},
+			{59.25, 10.75, 400},
+			{60, 10, math.NaN()},
+		} {
+			got := g.Elevation(fit.NewPositionDegrees(tt.lat, tt.lng))
+			if math.Abs(got-tt.want) > 1e-3 || math.IsNaN(got) != math.IsNaN(tt.want) {
+				t.Errorf("deflate %t: %v, %v: got %v, want %v", deflate, tt.lat, tt.lng, got, tt.want)
+			}
+		}
+	}
+
+	if _, err := elevation.ReadGeoTIFF(bytes.NewReader([]byte("not a tiff"))); err == nil {
+		t.Error("invalid file: got no error")
+	}
+}
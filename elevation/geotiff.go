@@ -0,0 +1,331 @@
+package elevation
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// TIFF and GeoTIFF tags used by ReadGeoTIFF.
+const (
+	tagImageWidth      = 256
+	tagImageLength     = 257
+	tagBitsPerSample   = 258
+	tagCompression     = 259
+	tagStripOffsets    = 273
+	tagSamplesPerPixel = 277
+	tagRowsPerStrip    = 278
+	tagStripByteCounts = 279
+	tagPredictor       = 317
+	tagTileWidth       = 322
+	tagTileLength      = 323
+	tagTileOffsets     = 324
+	tagTileByteCounts  = 325
+	tagSampleFormat    = 339
+	tagModelPixelScale = 33550
+	tagModelTiepoint   = 33922
+	tagGeoKeyDirectory = 34735
+	tagGDALNoData      = 42113
+)
+
+// GeoTIFF keys used by ReadGeoTIFF.
+const (
+	keyModelType      = 1024
+	keyRasterType     = 1025
+	modelTypeGeograph = 2
+	rasterPixelIsArea = 1
+)
+
+// TIFF compression schemes supported by ReadGeoTIFF.
+const (
+	compressionNone         = 1
+	compressionDeflate      = 8
+	compressionDeflateAdobe = 32946
+)
+
+// maxSamples limits the size of the images read by ReadGeoTIFF.
+const maxSamples = 1 << 28
+
+var errNotTIFF = errors.New("elevation: not a TIFF file")
+
+// ReadGeoTIFF reads a single band GeoTIFF DEM from r. The raster must be
+// in geographic latitude/longitude coordinates, uncompressed or deflate
+// compressed, and stored in strips or tiles of 8, 16, 32 or 64 bit integer
+// or floating point samples. Samples equal to the GDAL no data value are
+// voids. BigTIFF is not supported.
+func ReadGeoTIFF(r io.Reader) (*Grid, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("elevation: reading GeoTIFF: %v", err)
+	}
+	t, err := parseTIFF(b)
+	if err != nil {
+		return nil, err
+	}
+	return t.grid()
+}
+
+// OpenGeoTIFF reads the GeoTIFF DEM in the named file. See ReadGeoTIFF.
+func OpenGeoTIFF(path string) (*Grid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGeoTIFF(f)
+}
+
+// tiff holds the raw tags of the first image of a TIFF file.
+type tiff struct {
+	b     []byte
+	order binary.ByteOrder
+	tags  map[uint16][]float64
+	ascii map[uint16]string
+}
+
+func parseTIFF(b []byte) (*tiff, error) {
+	if len(b) < 8 {
+		return nil, errNotTIFF
+	}
+	t := &tiff{
+		b:     b,
+		tags:  make(map[uint16][]float64),
+		ascii: make(map[uint16]string),
+	}
+	switch string(b[:4]) {
+	case "II*\x00":
+		t.order = binary.LittleEndian
+	case "MM\x00*":
+		t.order = binary.BigEndian
+	default:
+		return nil, errNotTIFF
+	}
+	off := int(t.order.Uint32(b[4:]))
+	if off < 8 || off+2 > len(b) {
+		return nil, errors.New("elevation: invalid TIFF directory offset")
+	}
+	n := int(t.order.Uint16(b[off:]))
+	if off+2+12*n > len(b) {
+		return nil, errors.New("elevation: truncated TIFF directory")
+	}
+	for i := 0; i < n; i++ {
+		if err := t.parseEntry(b[off+2+12*i:]); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// parseEntry parses the 12 byte directory entry e.
+func (t *tiff) parseEntry(e []byte) error {
+	tag := t.order.Uint16(e)
+	typ := t.order.Uint16(e[2:])
+	count := int(t.order.Uint32(e[4:]))
+	size := map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}[typ]
+	if size == 0 {
+		return nil // Unknown types must be ignored.
+	}
+	if count < 0 || count > len(t.b)/size {
+		return fmt.Errorf("elevation: invalid count for TIFF tag %d", tag)
+	}
+	data := e[8:12]
+	if count*size > 4 {
+		off := int(t.order.Uint32(e[8:]))
+		if off < 0 || off+count*size > len(t.b) {
+			return fmt.Errorf("elevation: invalid offset for TIFF tag %d", tag)
+		}
+		data = t.b[off : off+count*size]
+	}
+	if typ == 2 {
+		t.ascii[tag] = strings.TrimRight(string(data[:count]), "\x00")
+		return nil
+	}
+	vs := make([]float64, count)
+	for i := range vs {
+		d := data[i*size:]
+		switch typ {
+		case 1, 7:
+			vs[i] = float64(d[0])
+		case 6:
+			vs[i] = float64(int8(d[0]))
+		case 3:
+			vs[i] = float64(t.order.Uint16(d))
+		case 8:
+			vs[i] = float64(int16(t.order.Uint16(d)))
+		case 4:
+			vs[i] = float64(t.order.Uint32(d))
+		case 9:
+			vs[i] = float64(int32(t.order.Uint32(d)))
+		case 5:
+			vs[i] = float64(t.order.Uint32(d)) / float64(t.order.Uint32(d[4:]))
+		case 10:
+			vs[i] = float64(int32(t.order.Uint32(d))) / float64(int32(t.order.Uint32(d[4:])))
+		case 11:
+			vs[i] = float64(math.Float32frombits(t.order.Uint32(d)))
+		case 12:
+			vs[i] = math.Float64frombits(t.order.Uint64(d))
+		}
+	}
+	t.tags[tag] = vs
+	return nil
+}
+
+// value returns the first value of tag, or def if the tag is missing.
+func (t *tiff) value(tag uint16, def float64) float64 {
+	if vs := t.tags[tag]; len(vs) > 0 {
+		return vs[0]
+	}
+	return def
+}
+
+// geoKey returns the value of the GeoTIFF key id, or def if it is missing.
+func (t *tiff) geoKey(id int, def float64) float64 {
+	dir := t.tags[tagGeoKeyDirectory]
+	if len(dir) < 4 {
+		return def
+	}
+	for i := 0; i < int(dir[3]) && 4+4*i+3 < len(dir); i++ {
+		k := dir[4+4*i:]
+		if int(k[0]) == id && k[1] == 0 {
+			return k[3]
+		}
+	}
+	return def
+}
+
+func (t *tiff) grid() (*Grid, error) {
+	width, height := int(t.value(tagImageWidth, 0)), int(t.value(tagImageLength, 0))
+	if width < 1 || height < 1 || width*height > maxSamples {
+		return nil, errors.New("elevation: invalid TIFF image size")
+	}
+	if spp := t.value(tagSamplesPerPixel, 1); spp != 1 {
+		return nil, fmt.Errorf("elevation: unsupported GeoTIFF with %v samples per pixel", spp)
+	}
+	if p := t.value(tagPredictor, 1); p != 1 {
+		return nil, fmt.Errorf("elevation: unsupported TIFF predictor %v", p)
+	}
+	if t.geoKey(keyModelType, modelTypeGeograph) != modelTypeGeograph {
+		return nil, errors.New("elevation: GeoTIFF is not in geographic coordinates")
+	}
+	scale, tie := t.tags[tagModelPixelScale], t.tags[tagModelTiepoint]
+	if len(scale) < 2 || len(tie) < 6 || scale[0] <= 0 || scale[1] <= 0 {
+		return nil, errors.New("elevation: GeoTIFF has no pixel scale and tie point")
+	}
+
+	bits := int(t.value(tagBitsPerSample, 1))
+	format := int(t.value(tagSampleFormat, 1))
+	sample, err := t.sampleDecoder(bits, format)
+	if err != nil {
+		return nil, err
+	}
+	nodata := math.NaN()
+	if s, ok := t.ascii[tagGDALNoData]; ok {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			nodata = v
+		}
+	}
+
+	data := make([]float32, width*height)
+	// Strips are tiles spanning the full width of the image.
+	tw, th := width, int(t.value(tagRowsPerStrip, float64(height)))
+	offsets, counts := t.tags[tagStripOffsets], t.tags[tagStripByteCounts]
+	if _, ok := t.tags[tagTileWidth]; ok {
+		tw, th = int(t.value(tagTileWidth, 0)), int(t.value(tagTileLength, 0))
+		offsets, counts = t.tags[tagTileOffsets], t.tags[tagTileByteCounts]
+	}
+	if tw < 1 || th < 1 || len(offsets) == 0 || len(offsets) != len(counts) {
+		return nil, errors.New("elevation: invalid TIFF strip or tile layout")
+	}
+	across := (width + tw - 1) / tw
+	size := bits / 8
+	for i := range offsets {
+		block, err := t.block(int(offsets[i]), int(counts[i]), tw*th*size)
+		if err != nil {
+			return nil, err
+		}
+		x0, y0 := (i%across)*tw, (i/across)*th
+		for y := 0; y < th && y0+y < height; y++ {
+			for x := 0; x < tw && x0+x < width; x++ {
+				j := (y*tw + x) * size
+				if j+size > len(block) {
+					return nil, errors.New("elevation: truncated TIFF image data")
+				}
+				v := sample(block[j:])
+				if v == nodata {
+					v = math.NaN()
+				}
+				data[(y0+y)*width+x0+x] = float32(v)
+			}
+		}
+	}
+
+	// The tie point maps raster coordinates to model coordinates. With
+	// PixelIsArea, the default, raster coordinates refer to the corner of
+	// a pixel rather than its center, where the sample is.
+	center := 0.0
+	if t.geoKey(keyRasterType, rasterPixelIsArea) == rasterPixelIsArea {
+		center = 0.5
+	}
+	west := tie[3] + (center-tie[0])*scale[0]
+	north := tie[4] - (center-tie[1])*scale[1]
+	return newGrid(width, height, north, west, scale[1], scale[0], data), nil
+}
+
+// block returns the decompressed strip or tile of n bytes at off.
+// Decompressed data beyond max, the size of a strip or tile, is ignored.
+func (t *tiff) block(off, n, max int) ([]byte, error) {
+	if off < 0 || n < 0 || off+n > len(t.b) {
+		return nil, errors.New("elevation: invalid TIFF strip or tile offset")
+	}
+	b := t.b[off : off+n]
+	switch c := t.value(tagCompression, compressionNone); c {
+	case compressionNone:
+		return b, nil
+	case compressionDeflate, compressionDeflateAdobe:
+		zr, err := zlib.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("elevation: decompressing TIFF data: %v", err)
+		}
+		defer zr.Close()
+		d, err := ioutil.ReadAll(io.LimitReader(zr, int64(max)))
+		if err != nil {
+			return nil, fmt.Errorf("elevation: decompressing TIFF data: %v", err)
+		}
+		return d, nil
+	default:
+		return nil, fmt.Errorf("elevation: unsupported TIFF compression %v", c)
+	}
+}
+
+// sampleDecoder returns a function decoding a sample with the given number
+// of bits and TIFF sample format (1 unsigned, 2 signed, 3 floating point).
+func (t *tiff) sampleDecoder(bits, format int) (func([]byte) float64, error) {
+	o := t.order
+	switch {
+	case format == 1 && bits == 8:
+		return func(b []byte) float64 { return float64(b[0]) }, nil
+	case format == 2 && bits == 8:
+		return func(b []byte) float64 { return float64(int8(b[0])) }, nil
+	case format == 1 && bits == 16:
+		return func(b []byte) float64 { return float64(o.Uint16(b)) }, nil
+	case format == 2 && bits == 16:
+		return func(b []byte) float64 { return float64(int16(o.Uint16(b))) }, nil
+	case format == 1 && bits == 32:
+		return func(b []byte) float64 { return float64(o.Uint32(b)) }, nil
+	case format == 2 && bits == 32:
+		return func(b []byte) float64 { return float64(int32(o.Uint32(b))) }, nil
+	case format == 3 && bits == 32:
+		return func(b []byte) float64 { return float64(math.Float32frombits(o.Uint32(b))) }, nil
+	case format == 3 && bits == 64:
+		return func(b []byte) float64 { return math.Float64frombits(o.Uint64(b)) }, nil
+	}
+	return nil, fmt.Errorf("elevation: unsupported GeoTIFF sample format %d with %d bits", format, bits)
+}
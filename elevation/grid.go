@@ -0,0 +1,92 @@
+package elevation
+
+import (
+	"math"
+
+	"github.com/tormoder/fit"
+)
+
+// A Grid is a DEM of elevations sampled on a regular latitude/longitude
+// grid. Elevations between samples are interpolated bilinearly.
+type Grid struct {
+	width, height int
+	north, west   float64 // Position of the first sample in degrees.
+	latStep       float64 // Degrees between rows, going south.
+	lngStep       float64 // Degrees between columns, going east.
+	data          []float32
+}
+
+// newGrid returns a grid of width×height samples, with the first sample at
+// north, west. Voids in data must be NaN.
+func newGrid(width, height int, north, west, latStep, lngStep float64, data []float32) *Grid {
+	return &Grid{
+		width:   width,
+		height:  height,
+		north:   north,
+		west:    west,
+		latStep: latStep,
+		lngStep: lngStep,
+		data:    data,
+	}
+}
+
+// Bounds returns the bounding box spanned by the samples of g.
+func (g *Grid) Bounds() fit.BoundingBox {
+	return fit.BoundingBox{
+		Min: fit.NewPositionDegrees(g.north-float64(g.height-1)*g.latStep, g.west),
+		Max: fit.NewPositionDegrees(g.north, g.west+float64(g.width-1)*g.lngStep),
+	}
+}
+
+// Elevation returns the elevation at p, interpolated bilinearly from the
+// four surrounding samples. Voids are ignored as long as one of the four
+// samples is valid. NaN is returned if p is invalid or outside the grid.
+func (g *Grid) Elevation(p fit.Position) float64 {
+	if p.Invalid() {
+		return math.NaN()
+	}
+	// Allow for the rounding of positions to semicircles at the edges.
+	const eps = 1e-6
+	dLat, dLng := g.north-p.Lat.Degrees(), p.Long.Degrees()-g.west
+	if dLat < -eps || dLng < -eps ||
+		dLat > float64(g.height-1)*g.latStep+eps || dLng > float64(g.width-1)*g.lngStep+eps {
+		return math.NaN()
+	}
+	y, x := dLat/g.latStep, dLng/g.lngStep
+	y = math.Max(0, math.Min(y, float64(g.height-1)))
+	x = math.Max(0, math.Min(x, float64(g.width-1)))
+
+	row, col := int(y), int(x)
+	if row == g.height-1 && row > 0 {
+		row--
+	}
+	if col == g.width-1 && col > 0 {
+		col--
+	}
+	fy, fx := y-float64(row), x-float64(col)
+
+	var sum, weight float64
+	for _, s := range [4]struct {
+		row, col int
+		w        float64
+	}{
+		{row, col, (1 - fy) * (1 - fx)},
+		{row, col + 1, (1 - fy) * fx},
+		{row + 1, col, fy * (1 - fx)},
+		{row + 1, col + 1, fy * fx},
+	} {
+		if s.row >= g.height || s.col >= g.width || s.w == 0 {
+			continue
+		}
+		v := float64(g.data[s.row*g.width+s.col])
+		if math.IsNaN(v) {
+			continue
+		}
+		sum += s.w * v
+		weight += s.w
+	}
+	if weight == 0 {
+		return math.NaN()
+	}
+	return sum / weight
+}
@@ -0,0 +1,134 @@
+package elevation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tormoder/fit"
+)
+
+// hgtVoid is the value of SRTM samples with no data.
+const hgtVoid = -32768
+
+// ReadHGT reads an SRTM .hgt tile from r. Tiles cover one degree of
+// latitude and longitude and have no header; the position of a tile is
+// given by its file name, such as N59E010.hgt for the tile with its
+// south-west corner at 59°N 10°E. Both 3 (1201×1201 samples) and 1 (3601×3601
+// samples) arc-second tiles are supported.
+func ReadHGT(r io.Reader, name string) (*Grid, error) {
+	south, west, err := parseHGTName(name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("elevation: reading %s: %v", name, err)
+	}
+	n := int(math.Sqrt(float64(len(b) / 2)))
+	if n < 2 || 2*n*n != len(b) {
+		return nil, fmt.Errorf("elevation: %s: %d bytes is not a square tile", name, len(b))
+	}
+	data := make([]float32, n*n)
+	for i := range data {
+		v := int16(binary.BigEndian.Uint16(b[2*i:]))
+		if v == hgtVoid {
+			data[i] = float32(math.NaN())
+			continue
+		}
+		data[i] = float32(v)
+	}
+	step := 1 / float64(n-1)
+	return newGrid(n, n, float64(south+1), float64(west), step, step, data), nil
+}
+
+// OpenHGT reads the SRTM .hgt tile in the named file. See ReadHGT.
+func OpenHGT(path string) (*Grid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadHGT(f, filepath.Base(path))
+}
+
+// parseHGTName returns the south-west corner of the tile with the given
+// file name.
+func parseHGTName(name string) (south, west int, err error) {
+	base := strings.ToUpper(strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)))
+	if len(base) != 7 || (base[0] != 'N' && base[0] != 'S') || (base[3] != 'E' && base[3] != 'W') {
+		return 0, 0, fmt.Errorf("elevation: invalid tile name %q", name)
+	}
+	lat, err1 := strconv.Atoi(base[1:3])
+	lng, err2 := strconv.Atoi(base[4:7])
+	if err1 != nil || err2 != nil || lat > 90 || lng > 180 {
+		return 0, 0, fmt.Errorf("elevation: invalid tile name %q", name)
+	}
+	if base[0] == 'S' {
+		lat = -lat
+	}
+	if base[3] == 'W' {
+		lng = -lng
+	}
+	return lat, lng, nil
+}
+
+// hgtName returns the file name of the tile with its south-west corner at
+// south, west.
+func hgtName(south, west int) string {
+	ns, ew := 'N', 'E'
+	if south < 0 {
+		ns, south = 'S', -south
+	}
+	if west < 0 {
+		ew, west = 'W', -west
+	}
+	return fmt.Sprintf("%c%02d%c%03d.hgt", ns, south, ew, west)
+}
+
+// HGTDir is a DEM backed by a directory of SRTM .hgt tiles, named as
+// described for ReadHGT. Tiles are read when first needed and kept in
+// memory. Positions in missing or unreadable tiles have no elevation. It is
+// safe for concurrent use.
+type HGTDir struct {
+	dir string
+
+	mu    sync.Mutex
+	tiles map[string]*Grid
+}
+
+// NewHGTDir returns a DEM reading tiles from dir.
+func NewHGTDir(dir string) *HGTDir {
+	return &HGTDir{dir: dir, tiles: make(map[string]*Grid)}
+}
+
+// Elevation returns the elevation at p. See Grid.Elevation.
+func (d *HGTDir) Elevation(p fit.Position) float64 {
+	if p.Invalid() {
+		return math.NaN()
+	}
+	t := d.tile(int(math.Floor(p.Lat.Degrees())), int(math.Floor(p.Long.Degrees())))
+	if t == nil {
+		return math.NaN()
+	}
+	return t.Elevation(p)
+}
+
+func (d *HGTDir) tile(south, west int) *Grid {
+	name := hgtName(south, west)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t, ok := d.tiles[name]
+	if !ok {
+		t, _ = OpenHGT(filepath.Join(d.dir, name))
+		d.tiles[name] = t
+	}
+	return t
+}
@@ -0,0 +1,453 @@
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit/dyncrc16"
+	"github.com/tormoder/fit/internal/types"
+)
+
+// endianAbility is set in the base type of multi-byte field definitions.
+const endianAbility = 0x80
+
+// NewFile returns a file of type t for encoding. Its FileId message has all
+// fields invalid except the type, and its file type specific message
+// container, such as the one returned by Activity for activity files, is
+// empty.
+func NewFile(t FileType) (*File, error) {
+	f := &File{FileId: *NewFileIdMsg()}
+	f.FileId.Type = t
+	if err := f.initFileType(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Encode writes f to w as a FIT file. The header and CRC of f are ignored;
+// new ones are computed. Messages are written with the FileId message first,
+// followed by the common messages and then the file type specific messages.
+// The messages of activity files are ordered by timestamp, with laps,
+// sessions and the activity message after the records they summarize.
+//
+// Only fields with a valid value are written. Unknown messages and fields,
+// and fields without a field number in the profile, such as those expanded
+// from components, are not written.
+func Encode(w io.Writer, f *File, opts ...EncodeOption) error {
+	o := encodeOptions{arch: le}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if f.msgAdder == nil {
+		return fmt.Errorf("encoding file: no messages for file type %v", f.Type())
+	}
+
+	e := &encoder{arch: o.arch}
+	for _, msg := range f.messages() {
+		if err := e.writeMessage(msg); err != nil {
+			return err
+		}
+	}
+
+	h := Header{
+		Size:            headerSizeCRC,
+		ProtocolVersion: byte(currentProtocolVersion),
+		ProfileVersion:  ProfileMajorVersion*100 + ProfileMinorVersion,
+		DataSize:        uint32(e.buf.Len()),
+	}
+	copy(h.DataType[:], fitDataTypeString)
+	hdr := make([]byte, headerSizeCRC)
+	hdr[0] = h.Size
+	hdr[1] = h.ProtocolVersion
+	le.PutUint16(hdr[2:4], h.ProfileVersion)
+	le.PutUint32(hdr[4:8], h.DataSize)
+	copy(hdr[8:12], h.DataType[:])
+	le.PutUint16(hdr[12:14], dyncrc16.Checksum(hdr[:12]))
+
+	crc := dyncrc16.New()
+	crc.Write(hdr)
+	crc.Write(e.buf.Bytes())
+	var sum [bytesForCRC]byte
+	le.PutUint16(sum[:], crc.Sum16())
+
+	for _, b := range [][]byte{hdr, e.buf.Bytes(), sum[:]} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Messages returns pointers to the messages of f, such as a *RecordMsg, in
+// the order Encode writes them. Changes to the messages change f.
+func (f *File) Messages() []interface{} {
+	var msgs []interface{}
+	for _, v := range f.messages() {
+		msgs = append(msgs, v.Addr().Interface())
+	}
+	return msgs
+}
+
+// MesgNumOf returns the message number of msg, a message such as a
+// RecordMsg or a pointer to one. MesgNumInvalid is returned for other
+// values.
+func MesgNumOf(msg interface{}) MesgNum {
+	t := reflect.TypeOf(msg)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if mn, ok := msgNums[t]; ok {
+		return mn
+	}
+	return MesgNumInvalid
+}
+
+// messages returns the messages of f in the order they should be encoded.
+func (f *File) messages() []reflect.Value {
+	msgs := []reflect.Value{reflect.ValueOf(&f.FileId).Elem()}
+	common := []interface{}{f.FileCreator, f.TimestampCorrelation}
+	if len(f.DeviceInfos) == 0 {
+		common = append(common, f.DeviceInfo)
+	}
+	for _, di := range f.DeviceInfos {
+		common = append(common, di)
+	}
+	for _, m := range common {
+		if v := reflect.ValueOf(m); !v.IsNil() {
+			msgs = append(msgs, v.Elem())
+		}
+	}
+	if f.msgAdder == nil {
+		return msgs
+	}
+	return append(msgs, f.fileMessages()...)
+}
+
+// fileMessages returns the messages of the file type specific message
+// container of f, in the order they should be encoded.
+func (f *File) fileMessages() []reflect.Value {
+	c := reflect.ValueOf(f.msgAdder).Elem()
+	var lists [][]reflect.Value
+	for i := 0; i < c.NumField(); i++ {
+		var l []reflect.Value
+		fv := c.Field(i)
+		switch fv.Kind() {
+		case reflect.Ptr:
+			if !fv.IsNil() {
+				l = append(l, fv.Elem())
+			}
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				if m := fv.Index(j); !m.IsNil() {
+					l = append(l, m.Elem())
+				}
+			}
+		}
+		lists = append(lists, l)
+	}
+	if f.Type() == FileTypeActivity {
+		return mergeByTime(lists)
+	}
+	var msgs []reflect.Value
+	for _, l := range lists {
+		msgs = append(msgs, l...)
+	}
+	return msgs
+}
+
+// mergeByTime merges lists of messages by timestamp, keeping the order of
+// each list. Messages without a timestamp are taken as soon as they are
+// first in their list.
+func mergeByTime(lists [][]reflect.Value) []reflect.Value {
+	var msgs []reflect.Value
+	for {
+		next := -1
+		for i, l := range lists {
+			if len(l) == 0 {
+				continue
+			}
+			if next < 0 || before(l[0], lists[next][0]) {
+				next = i
+			}
+		}
+		if next < 0 {
+			return msgs
+		}
+		msgs = append(msgs, lists[next][0])
+		lists[next] = lists[next][1:]
+	}
+}
+
+func before(a, b reflect.Value) bool {
+	ta, tb := msgTime(a), msgTime(b)
+	if !ta.Equal(tb) {
+		return ta.Before(tb)
+	}
+	return encodeRank[a.Type()] < encodeRank[b.Type()]
+}
+
+// encodeRank orders messages of activity files with the same timestamp.
+var encodeRank = map[reflect.Type]int{
+	reflect.TypeOf(EventMsg{}):    -1,
+	reflect.TypeOf(LapMsg{}):      1,
+	reflect.TypeOf(SessionMsg{}):  2,
+	reflect.TypeOf(ActivityMsg{}): 3,
+}
+
+// msgTime returns the timestamp of msg, or the zero time if it has none.
+func msgTime(msg reflect.Value) time.Time {
+	if f := msg.FieldByName("Timestamp"); f.IsValid() {
+		if t, ok := f.Interface().(time.Time); ok && !IsBaseTime(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+type encodeOptions struct {
+	arch binary.ByteOrder
+}
+
+type encoder struct {
+	buf    bytes.Buffer
+	arch   binary.ByteOrder
+	locals [maxLocalMesgs]string // Definition keys of local messages.
+	next   int                   // Next local message to replace.
+}
+
+// accumulated maps compressed fields to the field they are accumulated
+// into when decoded. A compressed field is not encoded if its accumulated
+// field is valid, as decoding would then accumulate it a second time.
+var accumulated = map[MesgNum]map[byte]byte{
+	MesgNumRecord: {8: 5, 18: 19, 28: 29},
+}
+
+// writeMessage writes the data message msg, preceded by a definition
+// message unless a local message already has the required definition. The
+// timestamp field is written first, as local timestamps are decoded
+// relative to it.
+func (e *encoder) writeMessage(msg reflect.Value) error {
+	mn := msgNums[msg.Type()]
+	var encoded [256][]byte
+	for num := range encoded {
+		pfield, ok := getField(mn, byte(num))
+		if !ok {
+			continue
+		}
+		b, err := e.encodeField(msg.Field(pfield.sindex), pfield)
+		if err != nil {
+			return fmt.Errorf("encoding %v message: field %d: %v", mn, num, err)
+		}
+		encoded[num] = b
+	}
+	for compressed, acc := range accumulated[mn] {
+		if encoded[acc] != nil {
+			encoded[compressed] = nil
+		}
+	}
+
+	var (
+		defs   []fieldDef
+		values [][]byte
+	)
+	nums := make([]int, 0, len(encoded))
+	nums = append(nums, fieldNumTimeStamp)
+	for num := range encoded {
+		if num != fieldNumTimeStamp {
+			nums = append(nums, num)
+		}
+	}
+	for _, num := range nums {
+		b := encoded[num]
+		if b == nil {
+			continue
+		}
+		pfield, _ := getField(mn, byte(num))
+		bt := pfield.t.BaseType()
+		dbt := byte(bt)
+		if bt.Size() > 1 {
+			dbt |= endianAbility
+		}
+		defs = append(defs, fieldDef{num: byte(num), size: byte(len(b)), btype: types.Base(dbt)})
+		values = append(values, b)
+	}
+
+	e.writeHeader(mn, defs)
+	for _, b := range values {
+		e.buf.Write(b)
+	}
+	return nil
+}
+
+// writeHeader writes the record header of a data message with the given
+// definition, preceded by a definition message if no local message has the
+// definition. Definitions are assigned to local messages round-robin.
+func (e *encoder) writeHeader(mn MesgNum, defs []fieldDef) {
+	var key strings.Builder
+	fmt.Fprintf(&key, "%d", mn)
+	for _, d := range defs {
+		fmt.Fprintf(&key, ",%d:%d:%d", d.num, d.size, d.btype)
+	}
+	k := key.String()
+	for i, l := range e.locals {
+		if l == k {
+			e.buf.WriteByte(mesgHeaderMask | byte(i))
+			return
+		}
+	}
+
+	local := byte(e.next)
+	e.next = (e.next + 1) % maxLocalMesgs
+	e.locals[local] = k
+
+	arch := byte(littleEndian)
+	if e.arch == be {
+		arch = bigEndian
+	}
+	e.buf.WriteByte(mesgDefinitionMask | local)
+	e.buf.WriteByte(0) // Reserved.
+	e.buf.WriteByte(arch)
+	var num [2]byte
+	e.arch.PutUint16(num[:], uint16(mn))
+	e.buf.Write(num[:])
+	e.buf.WriteByte(byte(len(defs)))
+	for _, d := range defs {
+		e.buf.Write([]byte{d.num, d.size, byte(d.btype)})
+	}
+	e.buf.WriteByte(mesgHeaderMask | local)
+}
+
+// encodeField returns the encoding of the message field fv, or nil if it
+// has an invalid value.
+func (e *encoder) encodeField(fv reflect.Value, pfield *field) ([]byte, error) {
+	bt := pfield.t.BaseType()
+	switch pfield.t.Kind() {
+	case types.TimeUTC, types.TimeLocal:
+		t := fv.Interface().(time.Time)
+		if t.IsZero() || IsBaseTime(t) || t.Before(timeBase) {
+			return nil, nil
+		}
+		u32 := encodeTime(t)
+		if pfield.t.Kind() == types.TimeLocal {
+			_, offset := t.Zone()
+			u32 += uint32(offset)
+		}
+		return e.uint(bt, uint64(u32)), nil
+	case types.Lat:
+		l := fv.Interface().(Latitude)
+		if l.Invalid() {
+			return nil, nil
+		}
+		return e.uint(bt, uint64(uint32(l.Semicircles()))), nil
+	case types.Lng:
+		l := fv.Interface().(Longitude)
+		if l.Invalid() {
+			return nil, nil
+		}
+		return e.uint(bt, uint64(uint32(l.Semicircles()))), nil
+	}
+
+	if !pfield.t.Array() {
+		b := e.value(bt, fv)
+		if b == nil || invalidBytes(bt, e.arch, b) {
+			return nil, nil
+		}
+		return b, nil
+	}
+
+	if fv.Len() == 0 {
+		return nil, nil
+	}
+	var buf []byte
+	switch {
+	case bt == types.BaseByte:
+		buf = append(buf, fv.Bytes()...)
+	case bt == types.BaseString:
+		for i := 0; i < fv.Len(); i++ {
+			buf = append(buf, fv.Index(i).String()...)
+			buf = append(buf, 0x00)
+		}
+	default:
+		for i := 0; i < fv.Len(); i++ {
+			buf = append(buf, e.value(bt, fv.Index(i))...)
+		}
+	}
+	if len(buf) > maxFieldSize {
+		return nil, fmt.Errorf("array of %d bytes exceeds the maximum field size", len(buf))
+	}
+	if bt != types.BaseString && invalidArray(bt, e.arch, buf) {
+		return nil, nil
+	}
+	return buf, nil
+}
+
+func invalidArray(bt types.Base, arch binary.ByteOrder, b []byte) bool {
+	for i := 0; i+bt.Size() <= len(b); i += bt.Size() {
+		if !invalidBytes(bt, arch, b[i:i+bt.Size()]) {
+			return false
+		}
+	}
+	return true
+}
+
+// value returns the encoding of the single value v of base type bt, or nil
+// if v is invalid.
+func (e *encoder) value(bt types.Base, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return e.uint(bt, v.Uint())
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.uint(bt, uint64(v.Int()))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) {
+			return nil
+		}
+		if bt == types.BaseFloat32 {
+			return e.uint(bt, uint64(math.Float32bits(float32(f))))
+		}
+		return e.uint(bt, math.Float64bits(f))
+	case reflect.String:
+		s := v.String()
+		if s == "" {
+			return nil
+		}
+		if len(s) > maxFieldSize-1 {
+			s = s[:maxFieldSize-1]
+		}
+		return append([]byte(s), 0x00)
+	}
+	return nil
+}
+
+func (e *encoder) uint(bt types.Base, u uint64) []byte {
+	b := make([]byte, bt.Size())
+	switch len(b) {
+	case 1:
+		b[0] = byte(u)
+	case 2:
+		e.arch.PutUint16(b, uint16(u))
+	case 4:
+		e.arch.PutUint32(b, uint32(u))
+	case 8:
+		e.arch.PutUint64(b, u)
+	}
+	return b
+}
+
+// msgNums maps message types to their message number.
+var msgNums = func() map[reflect.Type]MesgNum {
+	m := make(map[reflect.Type]MesgNum)
+	for mn, t := range msgsTypes {
+		if t != nil {
+			m[t] = MesgNum(mn)
+		}
+	}
+	return m
+}()
@@ -0,0 +1,137 @@
+package fit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	files := [][]string{
+		{"fitsdk", "Activity.fit"},
+		{"fitsdk", "MonitoringFile.fit"},
+		{"fitsdk", "Settings.fit"},
+		{"fitsdk", "WeightScaleMultiUser.fit"},
+		{"fitsdk", "WorkoutRepeatSteps.fit"},
+		{"dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit"},
+		{"me", "activity-large-fenxi2-multisport.fit"},
+		{"sram", "Settings2.fit"},
+	}
+	for _, path := range files {
+		name := filepath.Join(path...)
+		data, err := ioutil.ReadFile(filepath.Join(append([]string{"testdata"}, path...)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := fit.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, opts := range [][]fit.EncodeOption{nil, {fit.WithBigEndian()}} {
+			var buf bytes.Buffer
+			if err := fit.Encode(&buf, f, opts...); err != nil {
+				t.Fatalf("%s: encode: %v", name, err)
+			}
+			if err := fit.CheckIntegrity(bytes.NewReader(buf.Bytes()), false); err != nil {
+				t.Fatalf("%s: integrity: %v", name, err)
+			}
+			g, err := fit.Decode(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("%s: decode: %v", name, err)
+			}
+			g.Header, g.CRC = f.Header, f.CRC
+			want, _ := json.Marshal(f)
+			got, _ := json.Marshal(g)
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: decoded file differs from original", name)
+			}
+		}
+	}
+}
+
+func TestEncodeNewFile(t *testing.T) {
+	f, err := fit.NewFile(fit.FileTypeCourse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.FileId.Manufacturer = fit.ManufacturerDevelopment
+	f.FileId.TimeCreated = time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	course, err := f.Course()
+	if err != nil {
+		t.Fatal(err)
+	}
+	course.Course = fit.NewCourseMsg()
+	course.Course.Name = "Loop"
+	course.Course.Sport = fit.SportCycling
+	for i := 0; i < 3; i++ {
+		r := fit.NewRecordMsg()
+		r.Timestamp = f.FileId.TimeCreated.Add(time.Duration(i) * time.Second)
+		r.PositionLat = fit.NewLatitudeDegrees(59.9 + float64(i)*0.001)
+		r.PositionLong = fit.NewLongitudeDegrees(10.7)
+		r.Distance = uint32(i * 11100)
+		course.Records = append(course.Records, r)
+	}
+
+	var buf bytes.Buffer
+	if err := fit.Encode(&buf, f); err != nil {
+		t.Fatal(err)
+	}
+	g, err := fit.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc, err := g.Course()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.FileId.Manufacturer != fit.ManufacturerDevelopment || !g.FileId.TimeCreated.Equal(f.FileId.TimeCreated) {
+		t.Errorf("got file id %+v", g.FileId)
+	}
+	if gc.Course == nil || gc.Course.Name != "Loop" || gc.Course.Sport != fit.SportCycling {
+		t.Errorf("got course %+v", gc.Course)
+	}
+	if len(gc.Records) != 3 || gc.Records[2].Distance != 22200 || gc.Records[2].PositionLat != course.Records[2].PositionLat {
+		t.Errorf("got records %+v", gc.Records)
+	}
+	for _, issue := range fit.Validate(bytes.NewReader(buf.Bytes())) {
+		if issue.Severity == fit.SeverityError {
+			t.Errorf("got validation error %v", issue)
+		}
+	}
+}
+
+func TestMessages(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fitsdk", "Activity.fit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := fit.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, _ := f.Activity()
+	msgs := f.Messages()
+	if fid, ok := msgs[0].(*fit.FileIdMsg); !ok || fid != &f.FileId {
+		t.Fatalf("got first message %T, want *fit.FileIdMsg of file", msgs[0])
+	}
+	var records int
+	for _, msg := range msgs {
+		if fit.MesgNumOf(msg) == fit.MesgNumRecord {
+			records++
+		}
+	}
+	if records != len(act.Records) {
+		t.Errorf("got %d records, want %d", records, len(act.Records))
+	}
+	if mn := fit.MesgNumOf(fit.LapMsg{}); mn != fit.MesgNumLap {
+		t.Errorf("got message number %v for lap, want %v", mn, fit.MesgNumLap)
+	}
+	if mn := fit.MesgNumOf(42); mn != fit.MesgNumInvalid {
+		t.Errorf("got message number %v for int, want %v", mn, fit.MesgNumInvalid)
+	}
+}
@@ -1,6 +1,7 @@
 package fit
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
@@ -21,6 +22,11 @@ type File struct {
 	TimestampCorrelation *TimestampCorrelationMsg
 	DeviceInfo           *DeviceInfoMsg
 
+	// DeviceInfos holds every device info message of the file, one per
+	// device or sensor, in the order decoded. DeviceInfo is the last of
+	// them. If DeviceInfos is empty, DeviceInfo alone is encoded.
+	DeviceInfos []*DeviceInfoMsg
+
 	// UnknownMessages is a slice of unknown messages encountered during
 	// decoding. It is sorted by message number.
 	UnknownMessages []UnknownMessage
@@ -68,11 +74,175 @@ func (f *File) add(msg reflect.Value) {
 	case DeviceInfoMsg:
 		tmp := x.(DeviceInfoMsg)
 		f.DeviceInfo = &tmp
+		f.DeviceInfos = append(f.DeviceInfos, &tmp)
 	default:
 		f.msgAdder.add(msg)
 	}
 }
 
+// initFileType allocates the file type specific message container for f
+// according to the type found in its FileId message.
+func (f *File) initFileType() error {
+	t := f.FileId.Type
+	switch t {
+	case FileTypeActivity:
+		f.activity = new(ActivityFile)
+		f.msgAdder = f.activity
+	case FileTypeDevice:
+		f.device = new(DeviceFile)
+		f.msgAdder = f.device
+	case FileTypeSettings:
+		f.settings = new(SettingsFile)
+		f.msgAdder = f.settings
+	case FileTypeSport:
+		f.sport = new(SportFile)
+		f.msgAdder = f.sport
+	case FileTypeWorkout:
+		f.workout = new(WorkoutFile)
+		f.msgAdder = f.workout
+	case FileTypeCourse:
+		f.course = new(CourseFile)
+		f.msgAdder = f.course
+	case FileTypeSchedules:
+		f.schedules = new(SchedulesFile)
+		f.msgAdder = f.schedules
+	case FileTypeWeight:
+		f.weight = new(WeightFile)
+		f.msgAdder = f.weight
+	case FileTypeTotals:
+		f.totals = new(TotalsFile)
+		f.msgAdder = f.totals
+	case FileTypeGoals:
+		f.goals = new(GoalsFile)
+		f.msgAdder = f.goals
+	case FileTypeBloodPressure:
+		f.bloodPressure = new(BloodPressureFile)
+		f.msgAdder = f.bloodPressure
+	case FileTypeMonitoringA:
+		f.monitoringA = new(MonitoringAFile)
+		f.msgAdder = f.monitoringA
+	case FileTypeActivitySummary:
+		f.activitySummary = new(ActivitySummaryFile)
+		f.msgAdder = f.activitySummary
+	case FileTypeMonitoringDaily:
+		f.monitoringDaily = new(MonitoringDailyFile)
+		f.msgAdder = f.monitoringDaily
+	case FileTypeMonitoringB:
+		f.monitoringB = new(MonitoringBFile)
+		f.msgAdder = f.monitoringB
+	case FileTypeSegment:
+		f.segment = new(SegmentFile)
+		f.msgAdder = f.segment
+	case FileTypeSegmentList:
+		f.segmentList = new(SegmentListFile)
+		f.msgAdder = f.segmentList
+	case FileTypeInvalid:
+		return FormatError("file type was set invalid")
+	default:
+		switch {
+		case t > FileTypeMonitoringB && t < FileTypeMfgRangeMin:
+			return FormatError(
+				fmt.Sprintf("unknown file type: %v", t),
+			)
+		case t >= FileTypeMfgRangeMin && t <= FileTypeMfgRangeMax:
+			return NotSupportedError("manufacturer specific file types")
+		default:
+			return FormatError(
+				fmt.Sprintf("unknown file type: %v", t),
+			)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The file type specific
+// message container is included as a member named after the file type, e.g.
+// "Activity" for an activity file.
+func (f File) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	members := []struct {
+		name string
+		v    interface{}
+		omit bool
+	}{
+		{"Header", f.Header, false},
+		{"CRC", f.CRC, false},
+		{"FileId", f.FileId, false},
+		{"FileCreator", f.FileCreator, f.FileCreator == nil},
+		{"TimestampCorrelation", f.TimestampCorrelation, f.TimestampCorrelation == nil},
+		{"DeviceInfo", f.DeviceInfo, f.DeviceInfo == nil},
+		{"DeviceInfos", f.DeviceInfos, len(f.DeviceInfos) == 0},
+		{f.Type().String(), f.msgAdder, f.msgAdder == nil},
+		{"UnknownMessages", f.UnknownMessages, len(f.UnknownMessages) == 0},
+		{"UnknownFields", f.UnknownFields, len(f.UnknownFields) == 0},
+	}
+	for _, m := range members {
+		if m.omit {
+			continue
+		}
+		b, err := json.Marshal(m.v)
+		if err != nil {
+			return nil, err
+		}
+		e.field(m.name)
+		e.Write(b)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+type jsonFile struct {
+	Header               Header
+	CRC                  uint16
+	FileId               FileIdMsg
+	FileCreator          *FileCreatorMsg
+	TimestampCorrelation *TimestampCorrelationMsg
+	DeviceInfo           *DeviceInfoMsg
+	DeviceInfos          []*DeviceInfoMsg
+	UnknownMessages      []UnknownMessage
+	UnknownFields        []UnknownField
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the
+// output of MarshalJSON.
+func (f *File) UnmarshalJSON(b []byte) error {
+	jf := jsonFile{
+		FileId: getMesgAllInvalid(MesgNumFileId).Interface().(FileIdMsg),
+	}
+	if err := json.Unmarshal(b, &jf); err != nil {
+		return err
+	}
+
+	nf := File{
+		Header:               jf.Header,
+		CRC:                  jf.CRC,
+		FileId:               jf.FileId,
+		FileCreator:          jf.FileCreator,
+		TimestampCorrelation: jf.TimestampCorrelation,
+		DeviceInfo:           jf.DeviceInfo,
+		DeviceInfos:          jf.DeviceInfos,
+		UnknownMessages:      jf.UnknownMessages,
+		UnknownFields:        jf.UnknownFields,
+	}
+	if err := nf.initFileType(); err != nil {
+		return err
+	}
+
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	if v, found := fields[nf.Type().String()]; found {
+		if err = json.Unmarshal(v, nf.msgAdder); err != nil {
+			return err
+		}
+	}
+
+	*f = nf
+	return nil
+}
+
 // Type returns the FIT file type.
 func (f *File) Type() FileType {
 	return f.FileId.Type
@@ -0,0 +1,133 @@
+package geo_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/geo"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+func activitySmall(t *testing.T) *fit.ActivityFile {
+	_, act := fittest.DecodeActivity(t, "me", "activity-small-fenix2-run.fit")
+	return act
+}
+
+func countPoints(fc *geo.FeatureCollection) int {
+	n := 0
+	for _, f := range fc.Features {
+		if coords, ok := f.Geometry.Coordinates.([][]float64); ok {
+			n += len(coords)
+		}
+	}
+	return n
+}
+
+func TestActivity(t *testing.T) {
+	act := activitySmall(t)
+	fc, err := geo.Activity(act)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc.Features) != len(act.Laps) {
+		t.Fatalf("features: got %d, want %d (one per lap)", len(fc.Features), len(act.Laps))
+	}
+	for i, f := range fc.Features {
+		if f.Geometry.Type != geo.TypeLineString {
+			t.Errorf("feature %d: got geometry type %q, want %q", i, f.Geometry.Type, geo.TypeLineString)
+		}
+		for _, c := range f.Geometry.Coordinates.([][]float64) {
+			// The activity is located around 58.9N, 5.7E.
+			if c[0] < 5 || c[0] > 6.5 || c[1] < 58.5 || c[1] > 59.5 {
+				t.Fatalf("feature %d: coordinate %v out of expected area", i, c)
+			}
+		}
+		if _, found := f.Properties["TotalDistance"]; !found {
+			t.Errorf("feature %d: lap property TotalDistance not found", i)
+		}
+	}
+
+	simplified, err := geo.Activity(act, geo.WithSimplification(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, max := countPoints(simplified), countPoints(fc); got >= max {
+		t.Errorf("simplification: got %d points, want less than %d", got, max)
+	}
+
+	if _, err = json.Marshal(fc); err != nil {
+		t.Errorf("geojson: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = geo.WriteKML(&buf, simplified); err != nil {
+		t.Fatalf("kml: %v", err)
+	}
+	var doc struct {
+		Placemarks []struct {
+			Name string `xml:"name"`
+		} `xml:"Document>Placemark"`
+	}
+	if err = xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("kml: invalid xml: %v", err)
+	}
+	if len(doc.Placemarks) != len(fc.Features) || doc.Placemarks[0].Name != "Lap 1" {
+		t.Errorf("kml: got placemarks %+v, want %d starting with \"Lap 1\"", doc.Placemarks, len(fc.Features))
+	}
+}
+
+func TestCourseAndSegment(t *testing.T) {
+	course := &fit.CourseFile{
+		Records: []*fit.RecordMsg{
+			{PositionLat: fit.NewLatitudeDegrees(60), PositionLong: fit.NewLongitudeDegrees(10)},
+			{PositionLat: fit.NewLatitudeInvalid(), PositionLong: fit.NewLongitudeInvalid()},
+			{PositionLat: fit.NewLatitudeDegrees(60.01), PositionLong: fit.NewLongitudeDegrees(10.01)},
+		},
+		CoursePoints: []*fit.CoursePointMsg{
+			{PositionLat: fit.NewLatitudeDegrees(60.01), PositionLong: fit.NewLongitudeDegrees(10.01), Name: "Summit"},
+		},
+	}
+	fc, err := geo.Course(course)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc.Features) != 2 {
+		t.Fatalf("course features: got %d, want 2", len(fc.Features))
+	}
+	if n := len(fc.Features[0].Geometry.Coordinates.([][]float64)); n != 2 {
+		t.Errorf("course line string: got %d points, want 2 (invalid position skipped)", n)
+	}
+	if fc.Features[1].Geometry.Type != geo.TypePoint || fc.Features[1].Properties["name"] != "Summit" {
+		t.Errorf("course point: got %+v", fc.Features[1])
+	}
+
+	segment := &fit.SegmentFile{
+		SegmentPoints: []*fit.SegmentPointMsg{
+			{PositionLat: fit.NewLatitudeDegrees(60), PositionLong: fit.NewLongitudeDegrees(10), LeaderTime: []uint32{0, 0}},
+			{PositionLat: fit.NewLatitudeDegrees(60.01), PositionLong: fit.NewLongitudeDegrees(10), LeaderTime: []uint32{60000, 65000}},
+		},
+	}
+	fc, err = geo.Segment(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc.Features) != 1 {
+		t.Fatalf("segment features: got %d, want 1", len(fc.Features))
+	}
+	lt := fc.Features[0].Properties["LeaderTimes"].([][]float64)
+	if len(lt) != 2 || lt[1][1] != 65 {
+		t.Errorf("segment leader times: got %v, want [[0 0] [60 65]]", lt)
+	}
+
+	var buf bytes.Buffer
+	if err = geo.WriteKML(&buf, fc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), " 10,60.01</coordinates>") {
+		t.Errorf("kml: coordinates not found in output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,279 @@
+// Package geo converts the geographical data of FIT activity, course and
+// segment files to GeoJSON and KML.
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// GeoJSON object types used by this package.
+const (
+	TypeFeatureCollection = "FeatureCollection"
+	TypeFeature           = "Feature"
+	TypeLineString        = "LineString"
+	TypePoint             = "Point"
+)
+
+// A FeatureCollection is a GeoJSON feature collection.
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+// A Feature is a GeoJSON feature. Properties holds the JSON representation
+// of the FIT message the feature was created from, and a "name" property
+// suitable for display.
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// A Geometry is a GeoJSON Point or LineString geometry. Coordinates is a
+// []float64 position for points and a [][]float64 for line strings.
+// Positions are given as longitude, latitude in degrees.
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type options struct {
+	tolerance float64
+}
+
+// Option configures a conversion.
+type Option func(*options)
+
+// WithSimplification configures the conversion to simplify line strings
+// using the Douglas-Peucker algorithm. Points closer than tolerance metres
+// to the simplified line are removed. Points are never removed if tolerance
+// is zero or negative.
+func WithSimplification(tolerance float64) Option {
+	return func(o *options) {
+		o.tolerance = tolerance
+	}
+}
+
+func newFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{Type: TypeFeatureCollection, Features: []*Feature{}}
+}
+
+// Activity returns a feature collection with one LineString feature per lap
+// of act. Each line string holds the positions of the records logged during
+// the lap, and the lap message as properties. If act has no laps, a single
+// line string for all records is returned. Records with an invalid position
+// are skipped, as are laps with less than two valid positions.
+func Activity(act *fit.ActivityFile, opts ...Option) (*FeatureCollection, error) {
+	o := newOptions(opts)
+	fc := newFeatureCollection()
+	if len(act.Laps) == 0 {
+		coords := recordCoordinates(act.Records, time.Time{}, time.Time{}, o)
+		if len(coords) < 2 {
+			return fc, nil
+		}
+		props := map[string]interface{}{"name": "Activity"}
+		fc.Features = append(fc.Features, lineString(coords, props))
+		return fc, nil
+	}
+	for i, lap := range act.Laps {
+		coords := recordCoordinates(act.Records, lap.StartTime, lap.Timestamp, o)
+		if len(coords) < 2 {
+			continue
+		}
+		props, err := properties(lap, fmt.Sprintf("Lap %d", i+1))
+		if err != nil {
+			return nil, err
+		}
+		fc.Features = append(fc.Features, lineString(coords, props))
+	}
+	return fc, nil
+}
+
+// Course returns a feature collection with a LineString feature for the
+// records of c, followed by a Point feature for every course point. Records
+// and course points with an invalid position are skipped.
+func Course(c *fit.CourseFile, opts ...Option) (*FeatureCollection, error) {
+	o := newOptions(opts)
+	fc := newFeatureCollection()
+	if coords := recordCoordinates(c.Records, time.Time{}, time.Time{}, o); len(coords) >= 2 {
+		name := "Course"
+		if c.Course != nil && c.Course.Name != "" {
+			name = c.Course.Name
+		}
+		var (
+			props map[string]interface{}
+			err   error
+		)
+		if c.Course != nil {
+			props, err = properties(c.Course, name)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			props = map[string]interface{}{"name": name}
+		}
+		fc.Features = append(fc.Features, lineString(coords, props))
+	}
+	for i, cp := range c.CoursePoints {
+		if cp.PositionLat.Invalid() || cp.PositionLong.Invalid() {
+			continue
+		}
+		name := cp.Name
+		if name == "" {
+			name = fmt.Sprintf("%v %d", cp.Type, i+1)
+		}
+		props, err := properties(cp, name)
+		if err != nil {
+			return nil, err
+		}
+		fc.Features = append(fc.Features, &Feature{
+			Type: TypeFeature,
+			Geometry: Geometry{
+				Type:        TypePoint,
+				Coordinates: coordinate(fit.NewPosition(cp.PositionLat, cp.PositionLong)),
+			},
+			Properties: props,
+		})
+	}
+	return fc, nil
+}
+
+// Segment returns a feature collection with a single LineString feature for
+// the segment points of s. The segment id message is used as properties, and
+// the leader times of every point are added as the property "LeaderTimes",
+// an array with one array of times in seconds per point. Points with an
+// invalid position are skipped.
+func Segment(s *fit.SegmentFile, opts ...Option) (*FeatureCollection, error) {
+	o := newOptions(opts)
+	fc := newFeatureCollection()
+
+	var (
+		positions   []fit.Position
+		coords      [][]float64
+		leaderTimes [][]float64
+	)
+	for _, sp := range s.SegmentPoints {
+		p := fit.NewPosition(sp.PositionLat, sp.PositionLong)
+		if p.Invalid() {
+			continue
+		}
+		positions = append(positions, p)
+		coords = append(coords, coordinate(p))
+		leaderTimes = append(leaderTimes, sp.GetLeaderTimeScaled())
+	}
+	if len(coords) < 2 {
+		return fc, nil
+	}
+	if o.tolerance > 0 {
+		idx := fit.SimplifyIndices(positions, o.tolerance)
+		coords = pick(coords, idx)
+		leaderTimes = pick(leaderTimes, idx)
+	}
+
+	name := "Segment"
+	if s.SegmentId != nil && s.SegmentId.Name != "" {
+		name = s.SegmentId.Name
+	}
+	var (
+		props map[string]interface{}
+		err   error
+	)
+	if s.SegmentId != nil {
+		props, err = properties(s.SegmentId, name)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		props = map[string]interface{}{"name": name}
+	}
+	props["LeaderTimes"] = leaderTimes
+	fc.Features = append(fc.Features, lineString(coords, props))
+	return fc, nil
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// recordCoordinates returns the coordinates of records with a valid
+// position. If start or end is non-zero, only records with a timestamp in
+// the closed interval [start, end] are included.
+func recordCoordinates(records []*fit.RecordMsg, start, end time.Time, o options) [][]float64 {
+	var positions []fit.Position
+	for _, r := range records {
+		p := fit.NewPosition(r.PositionLat, r.PositionLong)
+		if p.Invalid() {
+			continue
+		}
+		if !start.IsZero() && r.Timestamp.Before(start) {
+			continue
+		}
+		if !end.IsZero() && r.Timestamp.After(end) {
+			continue
+		}
+		positions = append(positions, p)
+	}
+	if o.tolerance > 0 {
+		positions = fit.Simplify(positions, o.tolerance)
+	}
+	var coords [][]float64
+	for _, p := range positions {
+		coords = append(coords, coordinate(p))
+	}
+	return coords
+}
+
+// coordinate returns a GeoJSON position for lat and lng. Degrees are rounded
+// to 7 decimal places (about 1 cm), which is more than the precision of any
+// GPS receiver and removes noise from the semicircle conversion.
+func coordinate(p fit.Position) []float64 {
+	return []float64{round7(p.Long.Degrees()), round7(p.Lat.Degrees())}
+}
+
+func round7(deg float64) float64 {
+	return math.Round(deg*1e7) / 1e7
+}
+
+// pick returns the elements of s at the indices idx.
+func pick(s [][]float64, idx []int) [][]float64 {
+	out := make([][]float64, len(idx))
+	for i, j := range idx {
+		out[i] = s[j]
+	}
+	return out
+}
+
+func lineString(coords [][]float64, props map[string]interface{}) *Feature {
+	return &Feature{
+		Type: TypeFeature,
+		Geometry: Geometry{
+			Type:        TypeLineString,
+			Coordinates: coords,
+		},
+		Properties: props,
+	}
+}
+
+// properties returns the JSON representation of msg as a map, with the
+// "name" property set.
+func properties(msg json.Marshaler, name string) (map[string]interface{}, error) {
+	b, err := msg.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	props := make(map[string]interface{})
+	if err = json.Unmarshal(b, &props); err != nil {
+		return nil, err
+	}
+	props["name"] = name
+	return props, nil
+}
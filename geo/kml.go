@@ -0,0 +1,132 @@
+package geo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+type kml struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name         string           `xml:"name"`
+	ExtendedData *kmlExtendedData `xml:"ExtendedData,omitempty"`
+	LineString   *kmlGeometry     `xml:"LineString,omitempty"`
+	Point        *kmlGeometry     `xml:"Point,omitempty"`
+}
+
+type kmlGeometry struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlExtendedData struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// WriteKML writes fc to w as a KML document. Every feature is written as a
+// placemark named after its "name" property, with the remaining properties
+// as extended data.
+func WriteKML(w io.Writer, fc *FeatureCollection) error {
+	doc := kml{Xmlns: kmlNamespace}
+	for _, f := range fc.Features {
+		pm := kmlPlacemark{
+			Name:         fmt.Sprint(f.Properties["name"]),
+			ExtendedData: kmlProperties(f.Properties),
+		}
+		switch coords := f.Geometry.Coordinates.(type) {
+		case []float64:
+			pm.Point = &kmlGeometry{kmlCoordinate(coords)}
+		case [][]float64:
+			parts := make([]string, len(coords))
+			for i, c := range coords {
+				parts[i] = kmlCoordinate(c)
+			}
+			pm.LineString = &kmlGeometry{strings.Join(parts, " ")}
+		default:
+			return fmt.Errorf("geo: unsupported coordinates type %T for %s", coords, f.Geometry.Type)
+		}
+		doc.Document.Placemarks = append(doc.Document.Placemarks, pm)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func kmlCoordinate(c []float64) string {
+	parts := make([]string, len(c))
+	for i, v := range c {
+		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+func kmlProperties(props map[string]interface{}) *kmlExtendedData {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		if name == "name" {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	ed := new(kmlExtendedData)
+	for _, name := range names {
+		ed.Data = append(ed.Data, kmlData{name, kmlValue(props[name])})
+	}
+	return ed
+}
+
+// kmlValue formats a property value. Values with units are formatted as
+// "value units", composite values as JSON.
+func kmlValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}:
+		if units, ok := v["Units"].(string); ok {
+			if units == "" {
+				return kmlValue(v["Value"])
+			}
+			return kmlValue(v["Value"]) + " " + units
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
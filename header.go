@@ -2,6 +2,7 @@ package fit
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -146,6 +147,30 @@ func (h Header) MarshalJSON() ([]byte, error) {
 	return e.Bytes(), nil
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *Header) UnmarshalJSON(b []byte) error {
+	var jh struct {
+		Size            byte
+		ProtocolVersion byte
+		ProfileVersion  uint16
+		DataSize        uint32
+		DataType        string
+		CRC             uint16
+	}
+	if err := json.Unmarshal(b, &jh); err != nil {
+		return err
+	}
+	*h = Header{
+		Size:            jh.Size,
+		ProtocolVersion: jh.ProtocolVersion,
+		ProfileVersion:  jh.ProfileVersion,
+		DataSize:        jh.DataSize,
+		CRC:             jh.CRC,
+	}
+	copy(h.DataType[:], jh.DataType)
+	return nil
+}
+
 func (h Header) String() string {
 	return fmt.Sprintf(
 		"size: %d | protover: %d | profver: %d | dsize: %d | dtype: %s | crc: 0x%x",
@@ -0,0 +1,125 @@
+// Package hrv analyzes heart rate variability from the beat-to-beat (RR)
+// intervals recorded in the HRV messages of FIT activity files.
+package hrv
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// A Beat is a heartbeat and the RR interval ending at it.
+type Beat struct {
+	Timestamp time.Time
+	RR        float64 // ms
+}
+
+// Intervals flattens the RR intervals of hrvs into beats. The first
+// interval is assumed to start at start, and the timestamp of each beat is
+// the sum of the intervals up to and including it. Invalid intervals, which
+// pad the last message of a recording, are skipped.
+func Intervals(hrvs []*fit.HrvMsg, start time.Time) []Beat {
+	var (
+		beats   []Beat
+		elapsed time.Duration
+	)
+	for _, h := range hrvs {
+		if h == nil {
+			continue
+		}
+		for _, v := range h.Time {
+			if v == 0xFFFF {
+				continue
+			}
+			elapsed += time.Duration(v) * time.Millisecond
+			beats = append(beats, Beat{
+				Timestamp: start.Add(elapsed),
+				RR:        float64(v),
+			})
+		}
+	}
+	return beats
+}
+
+// FromActivity returns the beats of act, with timestamps aligned to its
+// records. HRV messages carry no timestamps, so recording is assumed to
+// start with the first timer start event, or with the first record if act
+// has no such event.
+func FromActivity(act *fit.ActivityFile) []Beat {
+	var start time.Time
+	for _, e := range act.Events {
+		if e.Event == fit.EventTimer && e.EventType == fit.EventTypeStart {
+			start = e.Timestamp
+			break
+		}
+	}
+	if start.IsZero() && len(act.Records) > 0 {
+		start = act.Records[0].Timestamp
+	}
+	return Intervals(act.Hrvs, start)
+}
+
+// Limits used by Clean.
+const (
+	MinRR            = 300  // ms, 200 bpm
+	MaxRR            = 2000 // ms, 30 bpm
+	EctopicTolerance = 0.2  // Relative deviation from the local median.
+)
+
+// cleanWindow is the number of beats on each side of a beat used to compute
+// the local median in Clean.
+const cleanWindow = 5
+
+// Clean corrects artifacts and ectopic beats in beats and returns the
+// corrected beats together with the number of corrections. An interval is
+// corrected if it is outside [MinRR, MaxRR], or differs by
+// more than EctopicTolerance from the median of the surrounding
+// intervals. Corrected intervals are replaced by that median; timestamps
+// are kept, so beats stay aligned with the records. beats is not modified.
+func Clean(beats []Beat) ([]Beat, int) {
+	cleaned := make([]Beat, len(beats))
+	copy(cleaned, beats)
+	corrected := 0
+	window := make([]float64, 0, 2*cleanWindow)
+	for i, b := range beats {
+		window = window[:0]
+		for j := i - cleanWindow; j <= i+cleanWindow; j++ {
+			if j < 0 || j >= len(beats) || j == i {
+				continue
+			}
+			if rr := beats[j].RR; rr >= MinRR && rr <= MaxRR {
+				window = append(window, rr)
+			}
+		}
+		if len(window) == 0 {
+			continue
+		}
+		med := median(window)
+		if b.RR < MinRR || b.RR > MaxRR || math.Abs(b.RR-med) > EctopicTolerance*med {
+			cleaned[i].RR = med
+			corrected++
+		}
+	}
+	return cleaned, corrected
+}
+
+// median returns the median of vs, reordering vs.
+func median(vs []float64) float64 {
+	sort.Float64s(vs)
+	n := len(vs)
+	if n%2 == 1 {
+		return vs[n/2]
+	}
+	return (vs[n/2-1] + vs[n/2]) / 2
+}
+
+// rrs returns the RR intervals of beats.
+func rrs(beats []Beat) []float64 {
+	rr := make([]float64, len(beats))
+	for i, b := range beats {
+		rr[i] = b.RR
+	}
+	return rr
+}
@@ -0,0 +1,165 @@
+package hrv_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/hrv"
+)
+
+var t0 = time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+
+// beats returns beats with the given intervals, starting at t0.
+func beats(rr []float64) []hrv.Beat {
+	bs := make([]hrv.Beat, len(rr))
+	var elapsed float64
+	for i, v := range rr {
+		elapsed += v
+		bs[i] = hrv.Beat{
+			Timestamp: t0.Add(time.Duration(elapsed * float64(time.Millisecond))),
+			RR:        v,
+		}
+	}
+	return bs
+}
+
+func TestIntervals(t *testing.T) {
+	hrvs := []*fit.HrvMsg{
+		{Time: []uint16{800, 0xFFFF, 0xFFFF}},
+		{Time: []uint16{1000, 900}},
+	}
+	got := hrv.Intervals(hrvs, t0)
+	want := []hrv.Beat{
+		{t0.Add(800 * time.Millisecond), 800},
+		{t0.Add(1800 * time.Millisecond), 1000},
+		{t0.Add(2700 * time.Millisecond), 900},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Timestamp.Equal(want[i].Timestamp) || got[i].RR != want[i].RR {
+			t.Errorf("%d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFromActivity(t *testing.T) {
+	start := fit.NewEventMsg()
+	start.Timestamp, start.Event, start.EventType = t0, fit.EventTimer, fit.EventTypeStart
+	rec := fit.NewRecordMsg()
+	rec.Timestamp = t0.Add(time.Second)
+	act := &fit.ActivityFile{
+		Events:  []*fit.EventMsg{start},
+		Records: []*fit.RecordMsg{rec},
+		Hrvs:    []*fit.HrvMsg{{Time: []uint16{1000}}},
+	}
+	got := hrv.FromActivity(act)
+	if len(got) != 1 || !got[0].Timestamp.Equal(rec.Timestamp) {
+		t.Errorf("got %v, want one beat at %v", got, rec.Timestamp)
+	}
+}
+
+func TestClean(t *testing.T) {
+	rr := []float64{800, 810, 790, 800, 400, 800, 810, 3000, 790, 800, 805}
+	got, n := hrv.Clean(beats(rr))
+	if n != 2 {
+		t.Errorf("got %d corrections, want 2", n)
+	}
+	for _, i := range []int{4, 7} {
+		if got[i].RR < 790 || got[i].RR > 810 {
+			t.Errorf("beat %d: got %v, want corrected to about 800", i, got[i].RR)
+		}
+	}
+	if got[0].RR != 800 || got[10].RR != 805 {
+		t.Errorf("valid beats changed: got %v", got)
+	}
+}
+
+func TestTimeDomain(t *testing.T) {
+	rr := []float64{800, 850, 780, 900}
+	if got, want := hrv.RMSSD(rr), math.Sqrt((50*50+70*70+120*120)/3.0); math.Abs(got-want) > 1e-9 {
+		t.Errorf("RMSSD: got %v, want %v", got, want)
+	}
+	if got, want := hrv.PNN50(rr), 200/3.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("PNN50: got %v, want %v", got, want)
+	}
+	if got := hrv.SDNN([]float64{800, 800, 800}); got != 0 {
+		t.Errorf("SDNN: got %v, want 0", got)
+	}
+	if got := hrv.RMSSD(rr[:1]); !math.IsNaN(got) {
+		t.Errorf("RMSSD of one interval: got %v, want NaN", got)
+	}
+}
+
+// oscillating returns n intervals around 1000 ms that oscillate with the
+// given frequency in Hz.
+func oscillating(n int, freq float64) []float64 {
+	rr := make([]float64, n)
+	var elapsed float64
+	for i := range rr {
+		rr[i] = 1000 + 50*math.Sin(2*math.Pi*freq*elapsed)
+		elapsed += rr[i] / 1000
+	}
+	return rr
+}
+
+func TestFrequencyBands(t *testing.T) {
+	lf, hf := hrv.FrequencyBands(beats(oscillating(300, 0.1)))
+	if lf <= 10*hf {
+		t.Errorf("0.1 Hz oscillation: got LF %v, HF %v, want LF dominant", lf, hf)
+	}
+	lf, hf = hrv.FrequencyBands(beats(oscillating(300, 0.25)))
+	if hf <= 10*lf {
+		t.Errorf("0.25 Hz oscillation: got LF %v, HF %v, want HF dominant", lf, hf)
+	}
+}
+
+func TestDFAAlpha1(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	noise := make([]float64, 2000)
+	for i := range noise {
+		noise[i] = 800 + 30*r.NormFloat64()
+	}
+	if a := hrv.DFAAlpha1(noise); math.Abs(a-0.5) > 0.1 {
+		t.Errorf("white noise: got %v, want about 0.5", a)
+	}
+	walk := make([]float64, 2000)
+	v := 800.0
+	for i := range walk {
+		v += 5 * r.NormFloat64()
+		walk[i] = v
+	}
+	if a := hrv.DFAAlpha1(walk); math.Abs(a-1.5) > 0.15 {
+		t.Errorf("random walk: got %v, want about 1.5", a)
+	}
+	if a := hrv.DFAAlpha1(noise[:31]); !math.IsNaN(a) {
+		t.Errorf("31 intervals: got %v, want NaN", a)
+	}
+}
+
+func TestRolling(t *testing.T) {
+	rr := make([]float64, 600)
+	for i := range rr {
+		rr[i] = 1000
+	}
+	ms := hrv.Rolling(beats(rr), 2*time.Minute, time.Minute)
+	if len(ms) != 10 {
+		t.Fatalf("got %d windows, want 10", len(ms))
+	}
+	for i, m := range ms {
+		// The first beat is one second after t0.
+		if want := t0.Add(time.Second + time.Duration(i)*time.Minute); !m.Start.Equal(want) {
+			t.Errorf("window %d: got start %v, want %v", i, m.Start, want)
+		}
+		if m.MeanHR != 60 || m.RMSSD != 0 {
+			t.Errorf("window %d: got mean HR %v, RMSSD %v, want 60, 0", i, m.MeanHR, m.RMSSD)
+		}
+	}
+	if ms[0].Beats != 120 || ms[9].Beats != 60 {
+		t.Errorf("got %d and %d beats in the first and last window, want 120 and 60", ms[0].Beats, ms[9].Beats)
+	}
+}
@@ -0,0 +1,284 @@
+package hrv
+
+import (
+	"math"
+	"time"
+)
+
+// Frequency bands in Hz.
+const (
+	lfLow  = 0.04
+	lfHigh = 0.15
+	hfHigh = 0.4
+
+	spectrumMin  = 0.0033
+	spectrumStep = 0.001
+)
+
+// Box sizes in beats for the short-term scaling exponent of DFA.
+const (
+	dfaMinBox = 4
+	dfaMaxBox = 16
+)
+
+// Metrics holds heart rate variability metrics for a sequence of beats.
+// Values are NaN if there are too few beats to compute them.
+type Metrics struct {
+	Start time.Time // Start of the analyzed period.
+	End   time.Time // End of the analyzed period.
+	Beats int
+
+	MeanRR float64 // ms
+	MeanHR float64 // bpm
+
+	RMSSD float64 // ms, root mean square of successive differences
+	SDNN  float64 // ms, standard deviation of the intervals
+	PNN50 float64 // %, successive differences larger than 50 ms
+
+	LF   float64 // ms², power in the 0.04-0.15 Hz band
+	HF   float64 // ms², power in the 0.15-0.4 Hz band
+	LFHF float64 // LF / HF
+
+	DFAAlpha1 float64 // short-term scaling exponent of detrended fluctuation analysis
+}
+
+// Analyze computes the metrics of beats, which should be cleaned first;
+// see Clean. The analyzed period is from the first to the last beat.
+func Analyze(beats []Beat) Metrics {
+	m := Metrics{Beats: len(beats)}
+	if len(beats) > 0 {
+		m.Start, m.End = beats[0].Timestamp, beats[len(beats)-1].Timestamp
+	}
+	rr := rrs(beats)
+	m.MeanRR = mean(rr)
+	m.MeanHR = 60000 / m.MeanRR
+	m.RMSSD = RMSSD(rr)
+	m.SDNN = SDNN(rr)
+	m.PNN50 = PNN50(rr)
+	m.LF, m.HF = FrequencyBands(beats)
+	m.LFHF = m.LF / m.HF
+	m.DFAAlpha1 = DFAAlpha1(rr)
+	return m
+}
+
+// Rolling computes the metrics of beats over windows of the given length,
+// starting at the first beat and then every step. The analyzed period of
+// each window is the full window, even if it extends beyond the last beat.
+func Rolling(beats []Beat, window, step time.Duration) []Metrics {
+	if len(beats) == 0 || window <= 0 || step <= 0 {
+		return nil
+	}
+	var (
+		ms         []Metrics
+		first, end int
+	)
+	last := beats[len(beats)-1].Timestamp
+	for start := beats[0].Timestamp; !start.After(last); start = start.Add(step) {
+		for first < len(beats) && beats[first].Timestamp.Before(start) {
+			first++
+		}
+		if end < first {
+			end = first
+		}
+		for end < len(beats) && beats[end].Timestamp.Before(start.Add(window)) {
+			end++
+		}
+		m := Analyze(beats[first:end])
+		m.Start, m.End = start, start.Add(window)
+		ms = append(ms, m)
+	}
+	return ms
+}
+
+func mean(vs []float64) float64 {
+	if len(vs) == 0 {
+		return math.NaN()
+	}
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum / float64(len(vs))
+}
+
+// RMSSD returns the root mean square of the successive differences of the
+// intervals rr.
+func RMSSD(rr []float64) float64 {
+	if len(rr) < 2 {
+		return math.NaN()
+	}
+	var sum float64
+	for i := 1; i < len(rr); i++ {
+		d := rr[i] - rr[i-1]
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(rr)-1))
+}
+
+// SDNN returns the sample standard deviation of the intervals rr.
+func SDNN(rr []float64) float64 {
+	if len(rr) < 2 {
+		return math.NaN()
+	}
+	m := mean(rr)
+	var sum float64
+	for _, v := range rr {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(rr)-1))
+}
+
+// PNN50 returns the percentage of successive differences of the intervals
+// rr that are larger than 50 ms.
+func PNN50(rr []float64) float64 {
+	if len(rr) < 2 {
+		return math.NaN()
+	}
+	n := 0
+	for i := 1; i < len(rr); i++ {
+		if math.Abs(rr[i]-rr[i-1]) > 50 {
+			n++
+		}
+	}
+	return 100 * float64(n) / float64(len(rr)-1)
+}
+
+// FrequencyBands returns the power of the low (0.04-0.15 Hz) and high
+// (0.15-0.4 Hz) frequency bands of beats in ms². The spectrum is estimated
+// with the Lomb-Scargle periodogram, which does not require the unevenly
+// spaced beats to be resampled, and scaled so that its total power equals
+// the variance of the intervals.
+func FrequencyBands(beats []Beat) (lf, hf float64) {
+	if len(beats) < 3 {
+		return math.NaN(), math.NaN()
+	}
+	t := make([]float64, len(beats))
+	for i, b := range beats {
+		t[i] = b.Timestamp.Sub(beats[0].Timestamp).Seconds()
+	}
+	rr := rrs(beats)
+	m := mean(rr)
+	variance := 0.0
+	for i := range rr {
+		rr[i] -= m
+		variance += rr[i] * rr[i]
+	}
+	variance /= float64(len(rr) - 1)
+
+	var total float64
+	for f := spectrumMin; f <= hfHigh; f += spectrumStep {
+		p := lombScargle(t, rr, f) * spectrumStep
+		total += p
+		switch {
+		case f >= lfLow && f < lfHigh:
+			lf += p
+		case f >= lfHigh:
+			hf += p
+		}
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return lf * variance / total, hf * variance / total
+}
+
+// lombScargle returns the unnormalized Lomb-Scargle periodogram of the
+// zero-mean samples x at times t (in seconds) for frequency f (in Hz).
+func lombScargle(t, x []float64, f float64) float64 {
+	w := 2 * math.Pi * f
+	var s2, c2 float64
+	for _, ti := range t {
+		s2 += math.Sin(2 * w * ti)
+		c2 += math.Cos(2 * w * ti)
+	}
+	tau := math.Atan2(s2, c2) / (2 * w)
+	var xc, xs, cc, ss float64
+	for i, ti := range t {
+		c, s := math.Cos(w*(ti-tau)), math.Sin(w*(ti-tau))
+		xc += x[i] * c
+		xs += x[i] * s
+		cc += c * c
+		ss += s * s
+	}
+	var p float64
+	if cc > 0 {
+		p += xc * xc / cc
+	}
+	if ss > 0 {
+		p += xs * xs / ss
+	}
+	return p / 2
+}
+
+// DFAAlpha1 returns the short-term scaling exponent α1 of detrended
+// fluctuation analysis of the intervals rr, using box sizes of 4 to 16
+// beats. Values around 0.75 are associated with the aerobic threshold,
+// and around 0.5 with the anaerobic threshold. NaN is returned for less
+// than 32 intervals.
+func DFAAlpha1(rr []float64) float64 {
+	if len(rr) < 2*dfaMaxBox {
+		return math.NaN()
+	}
+	// Integrated, mean-centered series.
+	m := mean(rr)
+	y := make([]float64, len(rr))
+	var sum float64
+	for i, v := range rr {
+		sum += v - m
+		y[i] = sum
+	}
+
+	var logN, logF []float64
+	for n := dfaMinBox; n <= dfaMaxBox; n++ {
+		boxes := len(y) / n
+		var sq float64
+		for b := 0; b < boxes; b++ {
+			sq += detrendedSquares(y[b*n : (b+1)*n])
+		}
+		f := math.Sqrt(sq / float64(boxes*n))
+		if f == 0 {
+			continue
+		}
+		logN = append(logN, math.Log(float64(n)))
+		logF = append(logF, math.Log(f))
+	}
+	if len(logN) < 2 {
+		return math.NaN()
+	}
+	slope, _ := linearFit(logN, logF)
+	return slope
+}
+
+// detrendedSquares returns the sum of squared residuals of y after
+// subtracting its least-squares linear fit over the sample index.
+func detrendedSquares(y []float64) float64 {
+	x := make([]float64, len(y))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	slope, intercept := linearFit(x, y)
+	var sum float64
+	for i, v := range y {
+		r := v - (intercept + slope*x[i])
+		sum += r * r
+	}
+	return sum
+}
+
+// linearFit returns the least-squares line through the points x, y.
+func linearFit(x, y []float64) (slope, intercept float64) {
+	n := float64(len(x))
+	var sx, sy, sxx, sxy float64
+	for i := range x {
+		sx += x[i]
+		sy += y[i]
+		sxx += x[i] * x[i]
+		sxy += x[i] * y[i]
+	}
+	d := n*sxx - sx*sx
+	if d == 0 {
+		return 0, sy / n
+	}
+	slope = (n*sxy - sx*sy) / d
+	return slope, (sy - slope*sx) / n
+}
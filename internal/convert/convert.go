@@ -0,0 +1,117 @@
+// Package convert converts between FIT files and other formats, for the
+// fitconv and fitserver commands. Formats are named by their usual file
+// extension, such as ".gpx".
+package convert
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/geo"
+)
+
+// Readers read a format into a FIT file. GPX input is read into a course
+// file, and TCX input into an activity or a course file.
+var Readers = map[string]func(r io.Reader) (*fit.File, error){
+	".fit":  readFIT,
+	".json": readJSON,
+	".gpx":  readGPX,
+	".tcx":  readTCX,
+}
+
+// Writers write a FIT file in a format. All formats but FIT and JSON
+// require an activity or course file, and GeoJSON and KML also accept
+// segment files.
+var Writers = map[string]func(w io.Writer, f *fit.File) error{
+	".fit":     writeFIT,
+	".json":    writeJSON,
+	".gpx":     writeGPX,
+	".tcx":     writeTCX,
+	".csv":     writeCSV,
+	".geojson": writeGeoJSON,
+	".kml":     writeKML,
+}
+
+// ContentTypes are the media types of the formats.
+var ContentTypes = map[string]string{
+	".fit":     "application/vnd.ant.fit",
+	".json":    "application/json",
+	".gpx":     "application/gpx+xml",
+	".tcx":     "application/vnd.garmin.tcx+xml",
+	".csv":     "text/csv",
+	".geojson": "application/geo+json",
+	".kml":     "application/vnd.google-earth.kml+xml",
+}
+
+func readFIT(r io.Reader) (*fit.File, error) {
+	return fit.Decode(bufio.NewReader(r))
+}
+
+func readJSON(r io.Reader) (*fit.File, error) {
+	f := new(fit.File)
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func writeFIT(w io.Writer, f *fit.File) error {
+	bw := bufio.NewWriter(w)
+	if err := fit.Encode(bw, f); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeJSON(w io.Writer, f *fit.File) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// FeatureCollection returns the track of an activity, course or segment
+// file.
+func FeatureCollection(f *fit.File) (*geo.FeatureCollection, error) {
+	switch f.Type() {
+	case fit.FileTypeActivity:
+		act, _ := f.Activity()
+		return geo.Activity(act)
+	case fit.FileTypeCourse:
+		course, _ := f.Course()
+		return geo.Course(course)
+	case fit.FileTypeSegment:
+		seg, _ := f.Segment()
+		return geo.Segment(seg)
+	}
+	return nil, fmt.Errorf("%v files have no track", f.Type())
+}
+
+func writeGeoJSON(w io.Writer, f *fit.File) error {
+	fc, err := FeatureCollection(f)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(fc)
+}
+
+func writeKML(w io.Writer, f *fit.File) error {
+	fc, err := FeatureCollection(f)
+	if err != nil {
+		return err
+	}
+	return geo.WriteKML(w, fc)
+}
+
+// ParseSport returns the sport with the name s, ignoring case.
+func ParseSport(s string) (fit.Sport, error) {
+	for i := 0; i < 0xFF; i++ {
+		if sp := fit.Sport(i); strings.EqualFold(sp.String(), s) {
+			return sp, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown sport %q", s)
+}
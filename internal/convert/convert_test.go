@@ -0,0 +1,189 @@
+package convert_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/convert"
+	"github.com/tormoder/fit/internal/fittest"
+)
+
+var activityPath = []string{"me", "activity-small-fenix2-run.fit"}
+
+// roundTrip writes f in the format ext and reads it back.
+func roundTrip(t *testing.T, f *fit.File, ext string) *fit.File {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := convert.Writers[ext](&buf, f); err != nil {
+		t.Fatalf("write %s: %v", ext, err)
+	}
+	got, err := convert.Readers[ext](&buf)
+	if err != nil {
+		t.Fatalf("read %s: %v", ext, err)
+	}
+	return got
+}
+
+// checkFIT checks that f encodes to a FIT file that validates without
+// errors.
+func checkFIT(t *testing.T, f *fit.File) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := convert.Writers[".fit"](&buf, f); err != nil {
+		t.Fatalf("write fit: %v", err)
+	}
+	for _, issue := range fit.Validate(&buf) {
+		if issue.Severity == fit.SeverityError {
+			t.Errorf("validate: %v", issue)
+		}
+	}
+}
+
+func TestGPXCourse(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, activityPath...)
+	f := roundTrip(t, fittest.Decode(t, activityPath...), ".gpx")
+	course, err := f.Course()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []*fit.RecordMsg
+	for _, r := range act.Records {
+		if !fit.NewPosition(r.PositionLat, r.PositionLong).Invalid() {
+			want = append(want, r)
+		}
+	}
+	if len(course.Records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(course.Records), len(want))
+	}
+	for i, r := range course.Records {
+		w := want[i]
+		if !r.Timestamp.Equal(w.Timestamp) {
+			t.Errorf("record %d: got time %v, want %v", i, r.Timestamp, w.Timestamp)
+		}
+		if d := fit.NewPosition(r.PositionLat, r.PositionLong).Haversine(fit.NewPosition(w.PositionLat, w.PositionLong)); d > 0.1 {
+			t.Errorf("record %d: position %.2f m off", i, d)
+		}
+		if r.HeartRate != w.HeartRate {
+			t.Errorf("record %d: got heart rate %d, want %d", i, r.HeartRate, w.HeartRate)
+		}
+	}
+	if course.Course.Sport != act.Sessions[0].Sport {
+		t.Errorf("got sport %v, want %v", course.Course.Sport, act.Sessions[0].Sport)
+	}
+	if len(course.Laps) != 1 {
+		t.Errorf("got %d laps, want 1", len(course.Laps))
+	}
+	checkFIT(t, f)
+}
+
+func TestTCXActivity(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, activityPath...)
+	f := roundTrip(t, fittest.Decode(t, activityPath...), ".tcx")
+	got, err := f.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Laps) != len(act.Laps) {
+		t.Fatalf("got %d laps, want %d", len(got.Laps), len(act.Laps))
+	}
+	for i, l := range got.Laps {
+		w := act.Laps[i]
+		if d := l.GetTotalDistanceScaled() - w.GetTotalDistanceScaled(); math.Abs(d) > 0.01 {
+			t.Errorf("lap %d: got distance %.2f m, want %.2f m", i, l.GetTotalDistanceScaled(), w.GetTotalDistanceScaled())
+		}
+		if d := l.GetTotalTimerTimeScaled() - w.GetTotalTimerTimeScaled(); math.Abs(d) > 0.001 {
+			t.Errorf("lap %d: got timer time %.3f s, want %.3f s", i, l.GetTotalTimerTimeScaled(), w.GetTotalTimerTimeScaled())
+		}
+	}
+	if len(got.Sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(got.Sessions))
+	}
+	s, w := got.Sessions[0], act.Sessions[0]
+	if s.Sport != w.Sport {
+		t.Errorf("got sport %v, want %v", s.Sport, w.Sport)
+	}
+	if d := s.GetTotalDistanceScaled() - w.GetTotalDistanceScaled(); math.Abs(d) > 0.01*float64(len(got.Laps)) {
+		t.Errorf("got session distance %.2f m, want %.2f m", s.GetTotalDistanceScaled(), w.GetTotalDistanceScaled())
+	}
+	// TCX keeps the records within laps.
+	var records int
+	for _, l := range act.Laps {
+		for _, r := range act.Records {
+			if !r.Timestamp.Before(l.StartTime) && !r.Timestamp.After(l.Timestamp) {
+				records++
+			}
+		}
+	}
+	if len(got.Records) != records {
+		t.Errorf("got %d records, want %d", len(got.Records), records)
+	}
+	checkFIT(t, f)
+}
+
+func TestTCXCourse(t *testing.T) {
+	course := roundTrip(t, fittest.Decode(t, activityPath...), ".gpx")
+	f := roundTrip(t, course, ".tcx")
+	if f.Type() != fit.FileTypeCourse {
+		t.Fatalf("got file type %v, want course", f.Type())
+	}
+	got, _ := f.Course()
+	want, _ := course.Course()
+	if len(got.Records) != len(want.Records) {
+		t.Errorf("got %d records, want %d", len(got.Records), len(want.Records))
+	}
+	if got.Course.Name != want.Course.Name {
+		t.Errorf("got name %q, want %q", got.Course.Name, want.Course.Name)
+	}
+	checkFIT(t, f)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	f := fittest.Decode(t, activityPath...)
+	got := roundTrip(t, f, ".json")
+	act, _ := f.Activity()
+	gotAct, err := got.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotAct.Records) != len(act.Records) {
+		t.Errorf("got %d records, want %d", len(gotAct.Records), len(act.Records))
+	}
+	checkFIT(t, got)
+}
+
+func TestCSV(t *testing.T) {
+	_, act := fittest.DecodeActivity(t, activityPath...)
+	var buf bytes.Buffer
+	if err := convert.Writers[".csv"](&buf, fittest.Decode(t, activityPath...)); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(act.Records)+1 {
+		t.Fatalf("got %d rows, want %d", len(rows), len(act.Records)+1)
+	}
+	if rows[0][0] != "timestamp" {
+		t.Errorf("got header %v", rows[0])
+	}
+	for i, row := range rows[1:] {
+		if len(row) != len(rows[0]) {
+			t.Errorf("row %d: got %d cells, want %d", i, len(row), len(rows[0]))
+		}
+	}
+}
+
+func TestWriteUnsupported(t *testing.T) {
+	f := fittest.Decode(t, "fitsdk", "Settings.fit")
+	for _, ext := range []string{".gpx", ".tcx", ".csv", ".geojson", ".kml"} {
+		if err := convert.Writers[ext](new(bytes.Buffer), f); err == nil {
+			t.Errorf("%s: got no error for settings file", ext)
+		}
+	}
+}
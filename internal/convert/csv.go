@@ -0,0 +1,67 @@
+package convert
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+var csvHeader = []string{
+	"timestamp", "latitude", "longitude", "altitude_m", "distance_m", "speed_m_s",
+	"heart_rate_bpm", "cadence_rpm", "power_w", "temperature_c",
+}
+
+// writeCSV writes the records of an activity or course file, one per row.
+// Invalid values are written as empty cells.
+func writeCSV(w io.Writer, f *fit.File) error {
+	recs, err := Records(f)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+	cw.Write(csvHeader)
+	for _, r := range recs {
+		row := make([]string, len(csvHeader))
+		if !fit.IsBaseTime(r.Timestamp) {
+			row[0] = r.Timestamp.UTC().Format(time.RFC3339)
+		}
+		if pos := fit.NewPosition(r.PositionLat, r.PositionLong); !pos.Invalid() {
+			row[1] = formatFloat(pos.Lat.Degrees(), 7)
+			row[2] = formatFloat(pos.Long.Degrees(), 7)
+		}
+		row[3] = formatFloat(recordAltitude(r), 1)
+		row[4] = formatFloat(r.GetDistanceScaled(), 2)
+		row[5] = formatFloat(recordSpeed(r), 3)
+		if r.HeartRate != 0xFF {
+			row[6] = strconv.Itoa(int(r.HeartRate))
+		}
+		if r.Cadence != 0xFF {
+			row[7] = strconv.Itoa(int(r.Cadence))
+		}
+		if r.Power != 0xFFFF {
+			row[8] = strconv.Itoa(int(r.Power))
+		}
+		if r.Temperature != 0x7F {
+			row[9] = strconv.Itoa(int(r.Temperature))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func formatFloat(v float64, prec int) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', prec, 64)
+}
@@ -0,0 +1,195 @@
+package convert
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+type gpx struct {
+	XMLName xml.Name   `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name,omitempty"`
+	Type     string       `xml:"type,omitempty"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat        float64        `xml:"lat,attr"`
+	Lon        float64        `xml:"lon,attr"`
+	Ele        *float64       `xml:"ele"`
+	Time       *time.Time     `xml:"time"`
+	Extensions *gpxExtensions `xml:"extensions"`
+}
+
+type gpxExtensions struct {
+	TrackPoint *gpxTrackPointExtension `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v1 TrackPointExtension"`
+}
+
+type gpxTrackPointExtension struct {
+	Temp *float64 `xml:"atemp"`
+	HR   *uint8   `xml:"hr"`
+	Cad  *uint8   `xml:"cad"`
+}
+
+// readGPX reads the tracks of a GPX file into a course file. All tracks and
+// track segments are joined. Points without a time are given one a second
+// after the previous point.
+func readGPX(r io.Reader) (*fit.File, error) {
+	var g gpx
+	if err := xml.NewDecoder(r).Decode(&g); err != nil {
+		return nil, err
+	}
+
+	f := newFile(fit.FileTypeCourse)
+	course, _ := f.Course()
+	course.Course = fit.NewCourseMsg()
+	course.Course.Sport = fit.SportGeneric
+
+	var (
+		t        time.Time
+		distance float64
+		prev     = fit.NewPositionInvalid()
+	)
+	for _, trk := range g.Tracks {
+		if course.Course.Name == "" {
+			course.Course.Name = trk.Name
+			course.Course.Sport = gpxSport(trk.Type)
+		}
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				r := fit.NewRecordMsg()
+				switch {
+				case p.Time != nil:
+					t = p.Time.UTC()
+				case !t.IsZero():
+					t = t.Add(time.Second)
+				default:
+					t = time.Now().UTC().Truncate(time.Second)
+				}
+				r.Timestamp = t
+				pos := fit.NewPositionDegrees(p.Lat, p.Lon)
+				r.PositionLat, r.PositionLong = pos.Lat, pos.Long
+				if !prev.Invalid() {
+					distance += prev.Haversine(pos)
+				}
+				prev = pos
+				setUint32(&r.Distance, distance, 100, 0)
+				if p.Ele != nil {
+					setUint16(&r.Altitude, *p.Ele, 5, 500)
+					setUint32(&r.EnhancedAltitude, *p.Ele, 5, 500)
+				}
+				if ext := p.Extensions; ext != nil && ext.TrackPoint != nil {
+					tp := ext.TrackPoint
+					if tp.HR != nil {
+						r.HeartRate = *tp.HR
+					}
+					if tp.Cad != nil {
+						r.Cadence = *tp.Cad
+					}
+					if tp.Temp != nil {
+						r.Temperature = int8(math.Round(*tp.Temp))
+					}
+				}
+				course.Records = append(course.Records, r)
+			}
+		}
+	}
+	if len(course.Records) == 0 {
+		return nil, errors.New("gpx file has no track points")
+	}
+	f.FileId.TimeCreated = course.Records[0].Timestamp
+	course.Laps = []*fit.LapMsg{summaryLap(course.Records, course.Course.Sport)}
+	return f, nil
+}
+
+// gpxSport returns the sport for a GPX track type, which is free text.
+func gpxSport(typ string) fit.Sport {
+	typ = strings.ToLower(typ)
+	switch {
+	case strings.Contains(typ, "run"):
+		return fit.SportRunning
+	case strings.Contains(typ, "bik"), strings.Contains(typ, "cycl"), strings.Contains(typ, "rid"):
+		return fit.SportCycling
+	case strings.Contains(typ, "walk"):
+		return fit.SportWalking
+	case strings.Contains(typ, "hik"):
+		return fit.SportHiking
+	case strings.Contains(typ, "swim"):
+		return fit.SportSwimming
+	}
+	if s, err := ParseSport(typ); err == nil {
+		return s
+	}
+	return fit.SportGeneric
+}
+
+// writeGPX writes the records of an activity or course file as a GPX track
+// with one segment. Records without a position are skipped.
+func writeGPX(w io.Writer, f *fit.File) error {
+	recs, err := Records(f)
+	if err != nil {
+		return err
+	}
+	trk := gpxTrack{Name: trackName(f), Type: strings.ToLower(fileSport(f).String())}
+	var seg gpxSegment
+	for _, r := range recs {
+		pos := fit.NewPosition(r.PositionLat, r.PositionLong)
+		if pos.Invalid() {
+			continue
+		}
+		p := gpxPoint{Lat: round(pos.Lat.Degrees(), 7), Lon: round(pos.Long.Degrees(), 7)}
+		if alt := recordAltitude(r); !math.IsNaN(alt) {
+			alt = round(alt, 1)
+			p.Ele = &alt
+		}
+		if !fit.IsBaseTime(r.Timestamp) {
+			t := r.Timestamp.UTC()
+			p.Time = &t
+		}
+		var ext gpxTrackPointExtension
+		if r.HeartRate != 0xFF {
+			hr := r.HeartRate
+			ext.HR = &hr
+		}
+		if r.Cadence != 0xFF {
+			cad := r.Cadence
+			ext.Cad = &cad
+		}
+		if r.Temperature != 0x7F {
+			temp := float64(r.Temperature)
+			ext.Temp = &temp
+		}
+		if ext.HR != nil || ext.Cad != nil || ext.Temp != nil {
+			p.Extensions = &gpxExtensions{TrackPoint: &ext}
+		}
+		seg.Points = append(seg.Points, p)
+	}
+	trk.Segments = []gpxSegment{seg}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	err = enc.Encode(gpx{Version: "1.1", Creator: "fitconv", Tracks: []gpxTrack{trk}})
+	if err != nil {
+		return err
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
@@ -0,0 +1,109 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+// newFile returns a new FIT file of type t, created by a development
+// device.
+func newFile(t fit.FileType) *fit.File {
+	f, err := fit.NewFile(t)
+	if err != nil {
+		panic(err)
+	}
+	f.FileId.Manufacturer = fit.ManufacturerDevelopment
+	return f
+}
+
+// Records returns the records of an activity or course file.
+func Records(f *fit.File) ([]*fit.RecordMsg, error) {
+	switch f.Type() {
+	case fit.FileTypeActivity:
+		act, _ := f.Activity()
+		return act.Records, nil
+	case fit.FileTypeCourse:
+		course, _ := f.Course()
+		return course.Records, nil
+	}
+	return nil, fmt.Errorf("%v files have no records", f.Type())
+}
+
+// trackName returns the name of a course, or a name from the time an
+// activity was created.
+func trackName(f *fit.File) string {
+	if course, err := f.Course(); err == nil && course.Course != nil && course.Course.Name != "" {
+		return course.Course.Name
+	}
+	if !fit.IsBaseTime(f.FileId.TimeCreated) {
+		return f.FileId.TimeCreated.UTC().Format("2006-01-02 15:04")
+	}
+	return ""
+}
+
+// fileSport returns the sport of the first session of an activity or of a
+// course.
+func fileSport(f *fit.File) fit.Sport {
+	switch f.Type() {
+	case fit.FileTypeActivity:
+		act, _ := f.Activity()
+		if len(act.Sessions) > 0 {
+			return act.Sessions[0].Sport
+		}
+	case fit.FileTypeCourse:
+		course, _ := f.Course()
+		if course.Course != nil && course.Course.Sport != fit.SportInvalid {
+			return course.Course.Sport
+		}
+	}
+	return fit.SportGeneric
+}
+
+// summaryLap returns a lap summarizing recs.
+func summaryLap(recs []*fit.RecordMsg, sport fit.Sport) *fit.LapMsg {
+	lap := fit.NewLapMsg()
+	analysis.Summarize(recs).SetLap(lap)
+	lap.MessageIndex = 0
+	lap.Sport = sport
+	lap.Event, lap.EventType = fit.EventLap, fit.EventTypeStop
+	lap.LapTrigger = fit.LapTriggerManual
+	return lap
+}
+
+// recordAltitude returns the altitude of r in metres, or NaN.
+func recordAltitude(r *fit.RecordMsg) float64 {
+	if alt := r.GetEnhancedAltitudeScaled(); !math.IsNaN(alt) {
+		return alt
+	}
+	return r.GetAltitudeScaled()
+}
+
+// recordSpeed returns the speed of r in m/s, or NaN.
+func recordSpeed(r *fit.RecordMsg) float64 {
+	if s := r.GetEnhancedSpeedScaled(); !math.IsNaN(s) {
+		return s
+	}
+	return r.GetSpeedScaled()
+}
+
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
+
+// setUint16 and setUint32 set dst to the raw value of the scaled value v,
+// unless it is out of range.
+func setUint16(dst *uint16, v, scale, offset float64) {
+	if r := math.Round((v + offset) * scale); r >= 0 && r < 0xFFFF {
+		*dst = uint16(r)
+	}
+}
+
+func setUint32(dst *uint32, v, scale, offset float64) {
+	if r := math.Round((v + offset) * scale); r >= 0 && r < 0xFFFFFFFF {
+		*dst = uint32(r)
+	}
+}
@@ -0,0 +1,383 @@
+package convert
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+type tcx struct {
+	XMLName    xml.Name       `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 TrainingCenterDatabase"`
+	Activities *tcxActivities `xml:"Activities,omitempty"`
+	Courses    *tcxCourses    `xml:"Courses,omitempty"`
+}
+
+type tcxActivities struct {
+	Activities []tcxActivity `xml:"Activity"`
+}
+
+type tcxActivity struct {
+	Sport string   `xml:"Sport,attr"`
+	ID    string   `xml:"Id"`
+	Laps  []tcxLap `xml:"Lap"`
+}
+
+type tcxCourses struct {
+	Courses []tcxCourse `xml:"Course"`
+}
+
+type tcxCourse struct {
+	Name  string    `xml:"Name"`
+	Laps  []tcxLap  `xml:"Lap"`
+	Track *tcxTrack `xml:"Track"`
+}
+
+type tcxLap struct {
+	StartTime        string       `xml:"StartTime,attr,omitempty"`
+	TotalTimeSeconds float64      `xml:"TotalTimeSeconds"`
+	DistanceMeters   float64      `xml:"DistanceMeters"`
+	BeginPosition    *tcxPosition `xml:"BeginPosition,omitempty"`
+	EndPosition      *tcxPosition `xml:"EndPosition,omitempty"`
+	MaximumSpeed     *float64     `xml:"MaximumSpeed,omitempty"`
+	Calories         *uint16      `xml:"Calories,omitempty"`
+	AverageHeartRate *tcxValue    `xml:"AverageHeartRateBpm,omitempty"`
+	MaximumHeartRate *tcxValue    `xml:"MaximumHeartRateBpm,omitempty"`
+	Intensity        string       `xml:"Intensity"`
+	Cadence          *uint8       `xml:"Cadence,omitempty"`
+	TriggerMethod    string       `xml:"TriggerMethod,omitempty"`
+	Track            *tcxTrack    `xml:"Track,omitempty"`
+}
+
+type tcxValue struct {
+	Value uint8 `xml:"Value"`
+}
+
+type tcxPosition struct {
+	Lat float64 `xml:"LatitudeDegrees"`
+	Lng float64 `xml:"LongitudeDegrees"`
+}
+
+type tcxTrack struct {
+	Points []tcxTrackpoint `xml:"Trackpoint"`
+}
+
+type tcxTrackpoint struct {
+	Time       time.Time      `xml:"Time"`
+	Position   *tcxPosition   `xml:"Position,omitempty"`
+	Altitude   *float64       `xml:"AltitudeMeters,omitempty"`
+	Distance   *float64       `xml:"DistanceMeters,omitempty"`
+	HeartRate  *tcxValue      `xml:"HeartRateBpm,omitempty"`
+	Cadence    *uint8         `xml:"Cadence,omitempty"`
+	Extensions *tcxExtensions `xml:"Extensions,omitempty"`
+}
+
+type tcxExtensions struct {
+	TPX *tcxTPX `xml:"http://www.garmin.com/xmlschemas/ActivityExtension/v2 TPX"`
+}
+
+type tcxTPX struct {
+	Speed *float64 `xml:"Speed,omitempty"`
+	Watts *uint16  `xml:"Watts,omitempty"`
+}
+
+// tcxSports maps TCX sports to FIT sports.
+var tcxSports = map[string]fit.Sport{
+	"Running": fit.SportRunning,
+	"Biking":  fit.SportCycling,
+	"Other":   fit.SportGeneric,
+}
+
+func tcxSport(s fit.Sport) string {
+	for name, sport := range tcxSports {
+		if sport == s && name != "Other" {
+			return name
+		}
+	}
+	return "Other"
+}
+
+// readTCX reads the first activity of a TCX file into an activity file, or
+// the first course if the file has no activities.
+func readTCX(r io.Reader) (*fit.File, error) {
+	var t tcx
+	if err := xml.NewDecoder(r).Decode(&t); err != nil {
+		return nil, err
+	}
+	switch {
+	case t.Activities != nil && len(t.Activities.Activities) > 0:
+		return tcxActivityFile(t.Activities.Activities[0])
+	case t.Courses != nil && len(t.Courses.Courses) > 0:
+		return tcxCourseFile(t.Courses.Courses[0])
+	}
+	return nil, errors.New("tcx file has no activities or courses")
+}
+
+func tcxActivityFile(a tcxActivity) (*fit.File, error) {
+	f := newFile(fit.FileTypeActivity)
+	act, _ := f.Activity()
+	sport, ok := tcxSports[a.Sport]
+	if !ok {
+		sport = fit.SportGeneric
+	}
+
+	for _, tl := range a.Laps {
+		if tl.Track == nil {
+			continue
+		}
+		recs := tcxRecords(tl.Track.Points)
+		if len(recs) == 0 {
+			continue
+		}
+		act.Records = append(act.Records, recs...)
+		lap := summaryLap(recs, sport)
+		lap.MessageIndex = fit.MessageIndex(len(act.Laps))
+		setUint32(&lap.TotalTimerTime, tl.TotalTimeSeconds, 1000, 0)
+		setUint32(&lap.TotalDistance, tl.DistanceMeters, 100, 0)
+		if tl.Calories != nil {
+			lap.TotalCalories = *tl.Calories
+		}
+		switch tl.Intensity {
+		case "Resting":
+			lap.Intensity = fit.IntensityRest
+		default:
+			lap.Intensity = fit.IntensityActive
+		}
+		act.Laps = append(act.Laps, lap)
+	}
+	if len(act.Records) == 0 {
+		return nil, errors.New("tcx activity has no track points")
+	}
+	first, last := act.Records[0], act.Records[len(act.Records)-1]
+	f.FileId.TimeCreated = first.Timestamp
+
+	session := fit.NewSessionMsg()
+	analysis.Summarize(act.Records).SetSession(session)
+	session.Sport = sport
+	session.Event, session.EventType = fit.EventSession, fit.EventTypeStop
+	session.MessageIndex = 0
+	session.FirstLapIndex, session.NumLaps = 0, uint16(len(act.Laps))
+	var timer, distance, calories float64
+	for _, l := range act.Laps {
+		timer += l.GetTotalTimerTimeScaled()
+		distance += l.GetTotalDistanceScaled()
+		if l.TotalCalories != 0xFFFF {
+			calories += float64(l.TotalCalories)
+		}
+	}
+	setUint32(&session.TotalTimerTime, timer, 1000, 0)
+	setUint32(&session.TotalDistance, distance, 100, 0)
+	if calories > 0 {
+		setUint16(&session.TotalCalories, calories, 1, 0)
+	}
+	act.Sessions = []*fit.SessionMsg{session}
+
+	for _, e := range []struct {
+		t   time.Time
+		typ fit.EventType
+	}{{first.Timestamp, fit.EventTypeStart}, {last.Timestamp, fit.EventTypeStopAll}} {
+		ev := fit.NewEventMsg()
+		ev.Timestamp, ev.Event, ev.EventType, ev.EventGroup = e.t, fit.EventTimer, e.typ, 0
+		act.Events = append(act.Events, ev)
+	}
+
+	act.Activity = fit.NewActivityMsg()
+	act.Activity.Timestamp = last.Timestamp
+	act.Activity.TotalTimerTime = session.TotalTimerTime
+	act.Activity.NumSessions = 1
+	act.Activity.Type = fit.ActivityModeManual
+	act.Activity.Event, act.Activity.EventType = fit.EventActivity, fit.EventTypeStop
+	return f, nil
+}
+
+func tcxCourseFile(c tcxCourse) (*fit.File, error) {
+	if c.Track == nil {
+		return nil, errors.New("tcx course has no track")
+	}
+	f := newFile(fit.FileTypeCourse)
+	course, _ := f.Course()
+	course.Course = fit.NewCourseMsg()
+	course.Course.Name = c.Name
+	course.Course.Sport = fit.SportGeneric
+	course.Records = tcxRecords(c.Track.Points)
+	if len(course.Records) == 0 {
+		return nil, errors.New("tcx course has no track points")
+	}
+	f.FileId.TimeCreated = course.Records[0].Timestamp
+	course.Laps = []*fit.LapMsg{summaryLap(course.Records, course.Course.Sport)}
+	return f, nil
+}
+
+func tcxRecords(points []tcxTrackpoint) []*fit.RecordMsg {
+	var recs []*fit.RecordMsg
+	for _, p := range points {
+		if p.Time.IsZero() {
+			continue
+		}
+		r := fit.NewRecordMsg()
+		r.Timestamp = p.Time.UTC()
+		if p.Position != nil {
+			pos := fit.NewPositionDegrees(p.Position.Lat, p.Position.Lng)
+			r.PositionLat, r.PositionLong = pos.Lat, pos.Long
+		}
+		if p.Altitude != nil {
+			setUint16(&r.Altitude, *p.Altitude, 5, 500)
+			setUint32(&r.EnhancedAltitude, *p.Altitude, 5, 500)
+		}
+		if p.Distance != nil {
+			setUint32(&r.Distance, *p.Distance, 100, 0)
+		}
+		if p.HeartRate != nil {
+			r.HeartRate = p.HeartRate.Value
+		}
+		if p.Cadence != nil {
+			r.Cadence = *p.Cadence
+		}
+		if ext := p.Extensions; ext != nil && ext.TPX != nil {
+			if ext.TPX.Speed != nil {
+				setUint16(&r.Speed, *ext.TPX.Speed, 1000, 0)
+				setUint32(&r.EnhancedSpeed, *ext.TPX.Speed, 1000, 0)
+			}
+			if ext.TPX.Watts != nil {
+				r.Power = *ext.TPX.Watts
+			}
+		}
+		recs = append(recs, r)
+	}
+	return recs
+}
+
+// writeTCX writes an activity file as a TCX activity with a lap per FIT
+// lap, or a course file as a TCX course.
+func writeTCX(w io.Writer, f *fit.File) error {
+	var out tcx
+	switch f.Type() {
+	case fit.FileTypeActivity:
+		act, _ := f.Activity()
+		a := tcxActivity{Sport: tcxSport(fileSport(f))}
+		if len(act.Records) > 0 {
+			a.ID = act.Records[0].Timestamp.UTC().Format(time.RFC3339)
+		}
+		laps := act.Laps
+		if len(laps) == 0 && len(act.Records) > 0 {
+			laps = []*fit.LapMsg{summaryLap(act.Records, fileSport(f))}
+		}
+		for _, l := range laps {
+			tl := tcxLapOf(l)
+			tl.StartTime = l.StartTime.UTC().Format(time.RFC3339)
+			tl.Track = tcxTrackOf(fit.RecordsBetween(act.Records, l.StartTime, l.Timestamp))
+			a.Laps = append(a.Laps, tl)
+		}
+		out.Activities = &tcxActivities{Activities: []tcxActivity{a}}
+	case fit.FileTypeCourse:
+		course, _ := f.Course()
+		c := tcxCourse{Name: trackName(f), Track: tcxTrackOf(course.Records)}
+		if len(course.Records) > 0 {
+			lap := summaryLap(course.Records, fileSport(f))
+			c.Laps = []tcxLap{tcxLapOf(lap)}
+		}
+		out.Courses = &tcxCourses{Courses: []tcxCourse{c}}
+	default:
+		return errors.New("tcx output requires an activity or course file")
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
+
+func tcxLapOf(l *fit.LapMsg) tcxLap {
+	tl := tcxLap{Intensity: "Active", TriggerMethod: "Manual"}
+	if v := l.GetTotalTimerTimeScaled(); !math.IsNaN(v) {
+		tl.TotalTimeSeconds = round(v, 3)
+	}
+	if v := l.GetTotalDistanceScaled(); !math.IsNaN(v) {
+		tl.DistanceMeters = round(v, 2)
+	}
+	if p := fit.NewPosition(l.StartPositionLat, l.StartPositionLong); !p.Invalid() {
+		tl.BeginPosition = &tcxPosition{round(p.Lat.Degrees(), 7), round(p.Long.Degrees(), 7)}
+	}
+	if p := fit.NewPosition(l.EndPositionLat, l.EndPositionLong); !p.Invalid() {
+		tl.EndPosition = &tcxPosition{round(p.Lat.Degrees(), 7), round(p.Long.Degrees(), 7)}
+	}
+	if v := l.GetEnhancedMaxSpeedScaled(); !math.IsNaN(v) {
+		tl.MaximumSpeed = &v
+	}
+	if l.TotalCalories != 0xFFFF {
+		c := l.TotalCalories
+		tl.Calories = &c
+	}
+	if l.AvgHeartRate != 0xFF {
+		tl.AverageHeartRate = &tcxValue{l.AvgHeartRate}
+	}
+	if l.MaxHeartRate != 0xFF {
+		tl.MaximumHeartRate = &tcxValue{l.MaxHeartRate}
+	}
+	if l.AvgCadence != 0xFF {
+		c := l.AvgCadence
+		tl.Cadence = &c
+	}
+	if l.Intensity == fit.IntensityRest {
+		tl.Intensity = "Resting"
+	}
+	switch l.LapTrigger {
+	case fit.LapTriggerDistance:
+		tl.TriggerMethod = "Distance"
+	case fit.LapTriggerPositionStart, fit.LapTriggerPositionLap, fit.LapTriggerPositionWaypoint, fit.LapTriggerPositionMarked:
+		tl.TriggerMethod = "Location"
+	case fit.LapTriggerTime:
+		tl.TriggerMethod = "Time"
+	}
+	return tl
+}
+
+func tcxTrackOf(recs []*fit.RecordMsg) *tcxTrack {
+	t := new(tcxTrack)
+	for _, r := range recs {
+		if fit.IsBaseTime(r.Timestamp) {
+			continue
+		}
+		p := tcxTrackpoint{Time: r.Timestamp.UTC()}
+		if pos := fit.NewPosition(r.PositionLat, r.PositionLong); !pos.Invalid() {
+			p.Position = &tcxPosition{round(pos.Lat.Degrees(), 7), round(pos.Long.Degrees(), 7)}
+		}
+		if alt := recordAltitude(r); !math.IsNaN(alt) {
+			alt = round(alt, 1)
+			p.Altitude = &alt
+		}
+		if d := r.GetDistanceScaled(); !math.IsNaN(d) {
+			p.Distance = &d
+		}
+		if r.HeartRate != 0xFF {
+			p.HeartRate = &tcxValue{r.HeartRate}
+		}
+		if r.Cadence != 0xFF {
+			c := r.Cadence
+			p.Cadence = &c
+		}
+		var tpx tcxTPX
+		if s := recordSpeed(r); !math.IsNaN(s) {
+			tpx.Speed = &s
+		}
+		if r.Power != 0xFFFF {
+			pw := r.Power
+			tpx.Watts = &pw
+		}
+		if tpx.Speed != nil || tpx.Watts != nil {
+			p.Extensions = &tcxExtensions{TPX: &tpx}
+		}
+		t.Points = append(t.Points, p)
+	}
+	return t
+}
@@ -0,0 +1,53 @@
+// Package fittest provides helpers for tests using the FIT files in the
+// testdata folder at the root of the repository.
+package fittest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tormoder/fit"
+)
+
+// Path returns the path of the test data file with the given path
+// elements, such as "fitsdk", "Activity.fit".
+func Path(elem ...string) string {
+	_, file, _, _ := runtime.Caller(0)
+	root := filepath.Join(filepath.Dir(file), "..", "..", "testdata")
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+// Read returns the contents of the test data file at path, failing t on
+// error.
+func Read(t testing.TB, path ...string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(Path(path...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// Decode decodes the test data file at path, failing t on error.
+func Decode(t testing.TB, path ...string) *fit.File {
+	t.Helper()
+	f, err := fit.Decode(bytes.NewReader(Read(t, path...)))
+	if err != nil {
+		t.Fatalf("%s: %v", filepath.Join(path...), err)
+	}
+	return f
+}
+
+// DecodeActivity decodes the activity file at path, failing t on error.
+func DecodeActivity(t testing.TB, path ...string) (*fit.File, *fit.ActivityFile) {
+	t.Helper()
+	f := Decode(t, path...)
+	act, err := f.Activity()
+	if err != nil {
+		t.Fatalf("%s: %v", filepath.Join(path...), err)
+	}
+	return f, act
+}
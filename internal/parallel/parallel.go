@@ -0,0 +1,30 @@
+// Package parallel runs work on a fixed number of goroutines, for the
+// commands processing many files.
+package parallel
+
+import "sync"
+
+// Do calls fn for every i from 0 through count-1 using n goroutines, and
+// returns when all calls have returned. Calls with different i may run
+// concurrently. An n less than one is treated as one.
+func Do(count, n int, fn func(i int)) {
+	if n < 1 {
+		n = 1
+	}
+	var wg sync.WaitGroup
+	idx := make(chan int)
+	for j := 0; j < n; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+}
@@ -0,0 +1,27 @@
+package parallel_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/tormoder/fit/internal/parallel"
+)
+
+func TestDo(t *testing.T) {
+	for _, n := range []int{-1, 0, 1, 3, 20} {
+		var calls int32
+		got := make([]int, 10)
+		parallel.Do(len(got), n, func(i int) {
+			atomic.AddInt32(&calls, 1)
+			got[i] = i * i
+		})
+		if calls != int32(len(got)) {
+			t.Errorf("n %d: got %d calls, want %d", n, calls, len(got))
+		}
+		for i, v := range got {
+			if v != i*i {
+				t.Errorf("n %d: got result %d for %d, want %d", n, v, i, i*i)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package summary
+
+import "github.com/tormoder/fit/internal/timer"
+
+// Conversions of FIT messages, set by package fit when it is initialized.
+// They are implemented there, as fit cannot import a package that imports
+// it, so that fit and the analysis package convert records and events the
+// same way.
+var (
+	// Points returns the points of records, a []*fit.RecordMsg without
+	// nil records.
+	Points func(records interface{}) []Point
+
+	// Timer returns the timer of the timer start and stop events in
+	// events, a []*fit.EventMsg; see timer.New.
+	Timer func(events interface{}) *timer.Timer
+)
@@ -0,0 +1,378 @@
+// Package summary computes aggregate values, such as those of sessions and
+// laps, from activity records. It is shared by the fit and analysis
+// packages, and so works on Points rather than fit record messages, which
+// package fit converts; see Points.
+package summary
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// Defaults of the analysis package, also used by the fit package.
+const (
+	DefaultMovingSpeed = 0.5 // m/s
+	DefaultHysteresis  = 3.0 // m
+)
+
+// A Point holds the values of a record used by Compute. Values are NaN if
+// invalid.
+type Point struct {
+	Time        time.Time
+	Distance    float64 // m, accumulated
+	HasPosition bool
+	Speed       float64 // m/s
+	HeartRate   float64 // bpm
+	Cadence     float64 // rpm
+	Power       float64 // W
+	Altitude    float64 // m
+	Temperature float64 // C
+	Calories    float64 // kcal, accumulated
+}
+
+// Options configures Compute.
+type Options struct {
+	Timer       *timer.Timer
+	MovingSpeed float64 // m/s
+	Hysteresis  float64 // m, see Ascent
+	NonZero     bool    // Exclude zero cadence and power from averages.
+
+	// Dist returns the distance in metres between the positions of points
+	// i and j. It is used if no point has an accumulated distance.
+	Dist func(i, j int) float64
+}
+
+// A Summary holds the aggregate values of a sequence of points, named
+// after the corresponding SessionMsg and LapMsg fields. A value is NaN if
+// it could not be computed.
+type Summary struct {
+	StartTime, Timestamp time.Time
+
+	// StartPosition and EndPosition are the indices of the first and last
+	// point with a position logged while the timer was running, or -1.
+	StartPosition, EndPosition int
+
+	TotalElapsedTime float64 // s
+	TotalTimerTime   float64 // s
+	TotalMovingTime  float64 // s
+	TotalDistance    float64 // m
+	AvgSpeed         float64 // m/s
+	MaxSpeed         float64 // m/s
+	AvgHeartRate     float64 // bpm
+	MaxHeartRate     float64 // bpm
+	MinHeartRate     float64 // bpm
+	AvgCadence       float64 // rpm
+	MaxCadence       float64 // rpm
+	AvgPower         float64 // W
+	MaxPower         float64 // W
+	TotalWork        float64 // J
+	TotalAscent      float64 // m
+	TotalDescent     float64 // m
+	TotalCalories    float64 // kcal
+	AvgAltitude      float64 // m
+	MinAltitude      float64 // m
+	MaxAltitude      float64 // m
+	AvgTemperature   float64 // C
+	MaxTemperature   float64 // C
+}
+
+// Compute computes the summary of points, which must be ordered by time.
+//
+// Only points logged while the timer was running contribute to the
+// summary. Averages are weighted by the timer time between a point and the
+// one before it, and fall back to the plain mean of the samples if no timer
+// time elapsed. The distance is computed from the accumulated distance if
+// present, and from the positions otherwise. Calories are the difference
+// between the accumulated calories of the last and first point.
+func Compute(points []Point, o Options) *Summary {
+	s := &Summary{StartPosition: -1, EndPosition: -1}
+	if len(points) == 0 {
+		s.setNaN()
+		return s
+	}
+
+	var (
+		speed, hr, cad, power, alt, temp stat
+		alts                             []float64
+		distance, timerTime, moving      float64
+		work                             float64
+		haveDistance, haveWork           bool
+		maxDist                          float64
+		prevTime                         time.Time
+		firstCal, lastCal                = math.NaN(), math.NaN()
+	)
+	s.StartTime, s.Timestamp = points[0].Time, points[len(points)-1].Time
+	for i, p := range points {
+		var dt float64
+		if i > 0 {
+			prevTime = points[i-1].Time
+			dt = o.Timer.Overlap(prevTime, p.Time).Seconds()
+			timerTime += dt
+		}
+		if !o.Timer.Running(p.Time) {
+			continue
+		}
+
+		delta := math.NaN()
+		if !math.IsNaN(p.Distance) {
+			// Only count progress beyond the furthest distance seen, so
+			// that a glitch in the accumulated distance is not counted
+			// twice.
+			delta = 0
+			if haveDistance && p.Distance > maxDist {
+				delta = p.Distance - maxDist
+				distance += delta
+			}
+			if !haveDistance || p.Distance > maxDist {
+				maxDist = p.Distance
+			}
+			haveDistance = true
+		}
+		if p.HasPosition {
+			if s.StartPosition < 0 {
+				s.StartPosition = i
+			}
+			if !haveDistance && s.EndPosition >= 0 && o.Dist != nil {
+				distance += o.Dist(s.EndPosition, i)
+			}
+			s.EndPosition = i
+		}
+
+		v := p.Speed
+		speed.add(v, dt)
+		if math.IsNaN(v) && !math.IsNaN(delta) && p.Time.After(prevTime) {
+			v = delta / p.Time.Sub(prevTime).Seconds()
+		}
+		if v >= o.MovingSpeed {
+			moving += dt
+		}
+
+		hr.add(p.HeartRate, dt)
+		if !(o.NonZero && p.Cadence == 0) {
+			cad.add(p.Cadence, dt)
+		}
+		if !math.IsNaN(p.Power) {
+			if !(o.NonZero && p.Power == 0) {
+				power.add(p.Power, dt)
+			}
+			work += p.Power * dt
+			haveWork = true
+		}
+		if !math.IsNaN(p.Altitude) {
+			alt.add(p.Altitude, dt)
+			alts = append(alts, p.Altitude)
+		}
+		temp.add(p.Temperature, dt)
+		if !math.IsNaN(p.Calories) {
+			if math.IsNaN(firstCal) {
+				firstCal = p.Calories
+			}
+			lastCal = p.Calories
+		}
+	}
+
+	s.TotalElapsedTime = s.Timestamp.Sub(s.StartTime).Seconds()
+	s.TotalTimerTime = timerTime
+	s.TotalMovingTime = moving
+	s.TotalDistance = math.NaN()
+	if haveDistance || s.EndPosition >= 0 {
+		s.TotalDistance = distance
+	}
+	s.AvgSpeed = math.NaN()
+	if !math.IsNaN(s.TotalDistance) && timerTime > 0 {
+		s.AvgSpeed = s.TotalDistance / timerTime
+	}
+	s.MaxSpeed = speed.max()
+	s.AvgHeartRate, s.MaxHeartRate, s.MinHeartRate = hr.avg(), hr.max(), hr.min()
+	s.AvgCadence, s.MaxCadence = cad.avg(), cad.max()
+	s.AvgPower, s.MaxPower = power.avg(), power.max()
+	s.TotalWork = math.NaN()
+	if haveWork {
+		s.TotalWork = work
+	}
+	s.TotalAscent, s.TotalDescent = math.NaN(), math.NaN()
+	if len(alts) > 0 {
+		s.TotalAscent, s.TotalDescent = Ascent(alts, o.Hysteresis)
+	}
+	s.TotalCalories = lastCal - firstCal
+	s.AvgAltitude, s.MinAltitude, s.MaxAltitude = alt.avg(), alt.min(), alt.max()
+	s.AvgTemperature, s.MaxTemperature = temp.avg(), temp.max()
+	return s
+}
+
+func (s *Summary) setNaN() {
+	nan := math.NaN()
+	s.TotalElapsedTime, s.TotalTimerTime, s.TotalMovingTime, s.TotalDistance = nan, nan, nan, nan
+	s.AvgSpeed, s.MaxSpeed = nan, nan
+	s.AvgHeartRate, s.MaxHeartRate, s.MinHeartRate = nan, nan, nan
+	s.AvgCadence, s.MaxCadence = nan, nan
+	s.AvgPower, s.MaxPower, s.TotalWork = nan, nan, nan
+	s.TotalAscent, s.TotalDescent, s.TotalCalories = nan, nan, nan
+	s.AvgAltitude, s.MinAltitude, s.MaxAltitude = nan, nan, nan
+	s.AvgTemperature, s.MaxTemperature = nan, nan
+}
+
+// Ascent returns the total ascent and descent in metres of the altitude
+// profile alts. Small fluctuations are ignored: the direction (climbing or
+// descending) only changes after the altitude has moved at least hysteresis
+// metres away from the last turning point. Once a direction is established,
+// every further change in that direction counts. NaN altitudes are skipped.
+func Ascent(alts []float64, hysteresis float64) (ascent, descent float64) {
+	var (
+		ref   float64
+		dir   int
+		first = true
+	)
+	for _, a := range alts {
+		if math.IsNaN(a) {
+			continue
+		}
+		if first {
+			ref, first = a, false
+			continue
+		}
+		d := a - ref
+		switch {
+		case d > 0 && (dir > 0 || d >= hysteresis):
+			ascent += d
+			ref, dir = a, 1
+		case d < 0 && (dir < 0 || -d >= hysteresis):
+			descent -= d
+			ref, dir = a, -1
+		}
+	}
+	return ascent, descent
+}
+
+// stat accumulates a time weighted average and the extremes of a series of
+// samples.
+type stat struct {
+	n           int
+	sum, weight float64
+	plainSum    float64
+	lo, hi      float64
+}
+
+// add adds the sample v, valid for the duration w seconds. NaN samples are
+// ignored.
+func (s *stat) add(v, w float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	if s.n == 0 || v < s.lo {
+		s.lo = v
+	}
+	if s.n == 0 || v > s.hi {
+		s.hi = v
+	}
+	s.n++
+	s.sum += v * w
+	s.weight += w
+	s.plainSum += v
+}
+
+func (s *stat) avg() float64 {
+	switch {
+	case s.weight > 0:
+		return s.sum / s.weight
+	case s.n > 0:
+		return s.plainSum / float64(s.n)
+	}
+	return math.NaN()
+}
+
+func (s *stat) min() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.lo
+}
+
+func (s *stat) max() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.hi
+}
+
+// msgFields lists the SessionMsg and LapMsg fields set by SetMsg, the
+// summary field each is set from, and the scale and offset of the field.
+var msgFields = []struct {
+	msg, summary  string
+	scale, offset float64
+}{
+	{"TotalElapsedTime", "TotalElapsedTime", 1000, 0},
+	{"TotalTimerTime", "TotalTimerTime", 1000, 0},
+	{"TotalMovingTime", "TotalMovingTime", 1000, 0},
+	{"TotalDistance", "TotalDistance", 100, 0},
+	{"AvgSpeed", "AvgSpeed", 1000, 0},
+	{"MaxSpeed", "MaxSpeed", 1000, 0},
+	{"EnhancedAvgSpeed", "AvgSpeed", 1000, 0},
+	{"EnhancedMaxSpeed", "MaxSpeed", 1000, 0},
+	{"AvgHeartRate", "AvgHeartRate", 1, 0},
+	{"MaxHeartRate", "MaxHeartRate", 1, 0},
+	{"MinHeartRate", "MinHeartRate", 1, 0},
+	{"AvgCadence", "AvgCadence", 1, 0},
+	{"MaxCadence", "MaxCadence", 1, 0},
+	{"AvgPower", "AvgPower", 1, 0},
+	{"MaxPower", "MaxPower", 1, 0},
+	{"TotalWork", "TotalWork", 1, 0},
+	{"TotalAscent", "TotalAscent", 1, 0},
+	{"TotalDescent", "TotalDescent", 1, 0},
+	{"TotalCalories", "TotalCalories", 1, 0},
+	{"AvgAltitude", "AvgAltitude", 5, 500},
+	{"MinAltitude", "MinAltitude", 5, 500},
+	{"MaxAltitude", "MaxAltitude", 5, 500},
+	{"EnhancedAvgAltitude", "AvgAltitude", 5, 500},
+	{"EnhancedMinAltitude", "MinAltitude", 5, 500},
+	{"EnhancedMaxAltitude", "MaxAltitude", 5, 500},
+	{"AvgTemperature", "AvgTemperature", 1, 0},
+	{"MaxTemperature", "MaxTemperature", 1, 0},
+}
+
+// SetMsg sets the start time, timestamp and aggregate fields of msg, a
+// SessionMsg or LapMsg struct value, from sum, a Summary or a struct with
+// the same float64 fields. Fields that are NaN in sum are left unchanged.
+func SetMsg(msg, sum reflect.Value) {
+	msg.FieldByName("StartTime").Set(sum.FieldByName("StartTime"))
+	msg.FieldByName("Timestamp").Set(sum.FieldByName("Timestamp"))
+	for _, f := range msgFields {
+		SetRaw(msg.FieldByName(f.msg), sum.FieldByName(f.summary).Float(), f.scale, f.offset)
+	}
+}
+
+// Raw converts the scaled value v to a raw field value, i.e. the inverse of
+// the generated GetXScaled methods. It reports false if v is NaN or the raw
+// value is not in the range [0, max), where max is the invalid value.
+func Raw(v, scale, offset, max float64) (float64, bool) {
+	if math.IsNaN(v) {
+		return 0, false
+	}
+	r := math.Round((v + offset) * scale)
+	if r < 0 || r >= max {
+		return 0, false
+	}
+	return r, true
+}
+
+// SetRaw sets the integer field dst to the raw value of v, unless v is NaN
+// or out of range.
+func SetRaw(dst reflect.Value, v, scale, offset float64) {
+	switch dst.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		invalid := float64(uint64(1)<<(8*dst.Type().Size()) - 1)
+		if r, ok := Raw(v, scale, offset, invalid); ok {
+			dst.SetUint(uint64(r))
+		}
+	case reflect.Int8:
+		// Shift the valid values [-128, 0x7F) to [0, 0xFF).
+		if r, ok := Raw(v+128/scale, scale, offset, 0xFF); ok {
+			dst.SetInt(int64(r) - 128)
+		}
+	default:
+		panic("SetRaw: unhandled kind " + dst.Kind().String())
+	}
+}
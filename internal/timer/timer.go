@@ -0,0 +1,117 @@
+// Package timer reports when the timer of an activity was running, from its
+// timer start and stop events. It is shared by the fit and analysis
+// packages, and so takes events as plain values rather than fit messages.
+package timer
+
+import (
+	"sort"
+	"time"
+)
+
+// Values of the FIT event_type type used by timer events.
+const (
+	typeStart          = 0
+	typeStop           = 1
+	typeStopAll        = 4
+	typeStopDisable    = 8
+	typeStopDisableAll = 9
+)
+
+// An Event is a timer event.
+type Event struct {
+	Time time.Time
+	Type uint8 // FIT event_type
+}
+
+// span is a closed time range during which the timer was running. A zero
+// start or end leaves it unbounded in that direction.
+type span struct {
+	start, end time.Time
+}
+
+// A Timer reports when the activity timer was running.
+type Timer struct {
+	spans []span
+}
+
+// New returns a timer built from the timer start and stop events in
+// events. If events holds no start or stop events the timer is always
+// running. If the first of them is a stop event, the timer is assumed to
+// have been running from the beginning.
+func New(events []Event) *Timer {
+	var es []Event
+	for _, e := range events {
+		if e.Type == typeStart || isStop(e.Type) {
+			es = append(es, e)
+		}
+	}
+	if len(es) == 0 {
+		return &Timer{spans: []span{{}}}
+	}
+	sort.SliceStable(es, func(i, j int) bool {
+		return es[i].Time.Before(es[j].Time)
+	})
+
+	var (
+		t       Timer
+		running bool
+		start   time.Time
+	)
+	for i, e := range es {
+		switch {
+		case e.Type == typeStart:
+			if !running {
+				start, running = e.Time, true
+			}
+		case isStop(e.Type):
+			if i == 0 {
+				// Started before the first event we know of.
+				start, running = time.Time{}, true
+			}
+			if running {
+				t.spans = append(t.spans, span{start, e.Time})
+				running = false
+			}
+		}
+	}
+	if running {
+		t.spans = append(t.spans, span{start: start})
+	}
+	return &t
+}
+
+func isStop(et uint8) bool {
+	switch et {
+	case typeStop, typeStopAll, typeStopDisable, typeStopDisableAll:
+		return true
+	}
+	return false
+}
+
+// Running reports whether the timer was running at ts.
+func (t *Timer) Running(ts time.Time) bool {
+	for _, s := range t.spans {
+		if (s.start.IsZero() || !ts.Before(s.start)) && (s.end.IsZero() || !ts.After(s.end)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlap returns for how long the timer was running between from and to.
+func (t *Timer) Overlap(from, to time.Time) time.Duration {
+	var d time.Duration
+	for _, s := range t.spans {
+		a, b := from, to
+		if !s.start.IsZero() && s.start.After(a) {
+			a = s.start
+		}
+		if !s.end.IsZero() && s.end.Before(b) {
+			b = s.end
+		}
+		if b.After(a) {
+			d += b.Sub(a)
+		}
+	}
+	return d
+}
@@ -2,13 +2,20 @@ package fit
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 type jsonEncodeState struct {
 	bytes.Buffer
 	scratch [64]byte
+	nfields int
 }
 
 var jsonEncodeStatePool sync.Pool
@@ -29,18 +36,146 @@ func (j *jsonEncodeState) writeFieldName(s string) {
 	j.WriteByte(':')
 }
 
+// field writes the name of the next object member, preceded by a comma if
+// it is not the first member written since open.
+func (j *jsonEncodeState) field(s string) {
+	if j.nfields > 0 {
+		j.c()
+	}
+	j.nfields++
+	j.writeFieldName(s)
+}
+
 func (j *jsonEncodeState) writeStringBytes(b []byte) {
 	j.WriteByte('"')
 	j.Write(b)
 	j.WriteByte('"')
 }
 
+func (j *jsonEncodeState) writeString(s string) {
+	// Strings from FIT files are not guaranteed to be valid UTF-8 or free
+	// of control characters, so let the standard library do the escaping.
+	b, _ := json.Marshal(s)
+	j.Write(b)
+}
+
+func (j *jsonEncodeState) writeStrings(s []string) {
+	j.openArray()
+	for i, v := range s {
+		if i > 0 {
+			j.c()
+		}
+		j.writeString(v)
+	}
+	j.closeArray()
+}
+
 func (j *jsonEncodeState) writeUint(u uint64) {
 	b := strconv.AppendUint(j.scratch[:0], u, 10)
 	j.Write(b)
 }
 
+func (j *jsonEncodeState) writeInt(i int64) {
+	b := strconv.AppendInt(j.scratch[:0], i, 10)
+	j.Write(b)
+}
+
+func (j *jsonEncodeState) writeFloat(f float64) {
+	b := strconv.AppendFloat(j.scratch[:0], f, 'f', -1, 64)
+	j.Write(b)
+}
+
+func (j *jsonEncodeState) writeBool(b bool) {
+	if b {
+		j.WriteString("true")
+		return
+	}
+	j.WriteString("false")
+}
+
+func (j *jsonEncodeState) writeNull() {
+	j.WriteString("null")
+}
+
+func (j *jsonEncodeState) writeTime(t time.Time) {
+	b := t.AppendFormat(j.scratch[:0], `"`+time.RFC3339+`"`)
+	j.Write(b)
+}
+
+// writeEnum writes v as its name if the type's String method produced one,
+// and as a number otherwise.
+func (j *jsonEncodeState) writeEnum(name string, v uint64) {
+	if strings.IndexByte(name, '(') >= 0 {
+		j.writeUint(v)
+		return
+	}
+	j.WriteByte('"')
+	j.WriteString(name)
+	j.WriteByte('"')
+}
+
+func (j *jsonEncodeState) writeBoolEnum(b Bool) {
+	switch b {
+	case BoolFalse:
+		j.writeBool(false)
+	case BoolTrue:
+		j.writeBool(true)
+	default:
+		j.writeUint(uint64(b))
+	}
+}
+
+// openUnits starts a {"Value":x,"Units":u} object if units is non-empty.
+func (j *jsonEncodeState) openUnits(units string) {
+	if units != "" {
+		j.openValue()
+	}
+}
+
+// closeUnits ends an object started by openUnits.
+func (j *jsonEncodeState) closeUnits(units string) {
+	if units != "" {
+		j.closeValue(units)
+	}
+}
+
+// openValue starts a {"Value":x,"Units":u} object.
+func (j *jsonEncodeState) openValue() {
+	j.WriteByte('{')
+	j.writeFieldName("Value")
+}
+
+// closeValue ends an object started by openValue.
+func (j *jsonEncodeState) closeValue(units string) {
+	j.c()
+	j.writeFieldName("Units")
+	j.writeString(units)
+	j.WriteByte('}')
+}
+
+// writeScaled writes the value of a scaled field. Scaled values are always
+// written as {"Value":x,"Units":u} objects, also if they have no units, so
+// that their shape does not depend on the field.
+func (j *jsonEncodeState) writeScaled(f float64, units string) {
+	j.openValue()
+	j.writeFloat(f)
+	j.closeValue(units)
+}
+
+func (j *jsonEncodeState) writeUintUnits(u uint64, units string) {
+	j.openUnits(units)
+	j.writeUint(u)
+	j.closeUnits(units)
+}
+
+func (j *jsonEncodeState) writeIntUnits(i int64, units string) {
+	j.openUnits(units)
+	j.writeInt(i)
+	j.closeUnits(units)
+}
+
 func (j *jsonEncodeState) open() {
+	j.nfields = 0
 	j.WriteByte('{')
 }
 
@@ -48,6 +183,141 @@ func (j *jsonEncodeState) close() {
 	j.WriteByte('}')
 }
 
+func (j *jsonEncodeState) openArray() {
+	j.WriteByte('[')
+}
+
+func (j *jsonEncodeState) closeArray() {
+	j.WriteByte(']')
+}
+
 func (j *jsonEncodeState) c() {
 	j.WriteByte(',')
 }
+
+func isValidTime(t time.Time) bool {
+	return !t.IsZero() && !IsBaseTime(t)
+}
+
+var jsonNull = []byte("null")
+
+func isJSONNull(b []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(b), jsonNull)
+}
+
+// jsonFieldError reports a field of a FIT message that could not be decoded
+// from JSON.
+type jsonFieldError struct {
+	msg, field string
+	err        error
+}
+
+func (e jsonFieldError) Error() string {
+	return fmt.Sprintf("json: %s.%s: %v", e.msg, e.field, e.err)
+}
+
+// unknownEnumNameError reports a JSON string that is not the name of any
+// value of a FIT type.
+type unknownEnumNameError struct {
+	typ, name string
+}
+
+func (e unknownEnumNameError) Error() string {
+	return fmt.Sprintf("json: unknown %s value %q", e.typ, e.name)
+}
+
+func unmarshalJSONObject(b []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func unmarshalJSONValue(b []byte, v interface{}) error {
+	return json.Unmarshal(b, v)
+}
+
+// unmarshalJSONEnum decodes an enum value written by writeEnum. Either the
+// name or the numeric value is returned, depending on the JSON type. A JSON
+// null is reported as ok == false.
+func unmarshalJSONEnum(b []byte) (name string, v uint64, ok bool, err error) {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0:
+		return "", 0, false, errors.New("json: empty enum value")
+	case bytes.Equal(b, jsonNull):
+		return "", 0, false, nil
+	case b[0] == '"':
+		err = json.Unmarshal(b, &name)
+		return name, 0, err == nil, err
+	default:
+		err = json.Unmarshal(b, &v)
+		return "", v, err == nil, err
+	}
+}
+
+type jsonUnits struct {
+	Value json.RawMessage
+	Units string
+}
+
+// unwrapJSONUnits returns the value of a {"Value":x,"Units":u} object written
+// by openUnits/closeUnits, or b as is if it is not such an object.
+func unwrapJSONUnits(b []byte) ([]byte, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '{' {
+		return b, nil
+	}
+	var u jsonUnits
+	if err := json.Unmarshal(b, &u); err != nil {
+		return nil, err
+	}
+	if u.Value == nil {
+		return jsonNull, nil
+	}
+	return u.Value, nil
+}
+
+// unmarshalJSONScaled decodes a number written by writeScaled (or
+// writeUintUnits/writeIntUnits with scale 1 and offset 0) and returns the
+// raw (unscaled) field value. JSON null decodes to invalid.
+func unmarshalJSONScaled(b []byte, scale, offset, invalid float64) (float64, error) {
+	b, err := unwrapJSONUnits(b)
+	if err != nil {
+		return invalid, err
+	}
+	if bytes.Equal(b, jsonNull) {
+		return invalid, nil
+	}
+	var f float64
+	if err = json.Unmarshal(b, &f); err != nil {
+		return invalid, err
+	}
+	return math.Round((f + offset) * scale), nil
+}
+
+// unmarshalJSONScaledArray is like unmarshalJSONScaled, but for arrays. JSON
+// null elements decode to invalid.
+func unmarshalJSONScaledArray(b []byte, scale, offset, invalid float64) ([]float64, error) {
+	b, err := unwrapJSONUnits(b)
+	if err != nil {
+		return nil, err
+	}
+	var elems []*float64
+	if err = json.Unmarshal(b, &elems); err != nil {
+		return nil, err
+	}
+	if elems == nil {
+		return nil, nil
+	}
+	fs := make([]float64, len(elems))
+	for i, f := range elems {
+		if f == nil {
+			fs[i] = invalid
+			continue
+		}
+		fs[i] = math.Round((*f + offset) * scale)
+	}
+	return fs, nil
+}
@@ -0,0 +1,139 @@
+package fit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	for _, file := range decodeTestFiles {
+		file := file // Capture range variable.
+		if file.wantErr {
+			continue
+		}
+		t.Run(file.name, func(t *testing.T) {
+			t.Parallel()
+			fitFile, err := decodeTestFile(filepath.Join(tdfolder, file.folder, file.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			first, err := json.Marshal(fitFile)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var got fit.File
+			if err = json.Unmarshal(first, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			second, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("marshal after unmarshal: %v", err)
+			}
+			if !bytes.Equal(first, second) {
+				t.Errorf("json output differs after round trip")
+			}
+		})
+	}
+}
+
+func TestMarshalJSONRecord(t *testing.T) {
+	fitFile, err := decodeTestFile(activitySmallPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := fitFile.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rec *fit.RecordMsg
+	for _, r := range act.Records {
+		if !r.PositionLat.Invalid() {
+			rec = r
+			break
+		}
+	}
+	if rec == nil {
+		t.Fatal("no record with position found")
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if lat, ok := got["PositionLat"].(float64); !ok || lat != rec.PositionLat.Degrees() {
+		t.Errorf("PositionLat: got %v, want %v", got["PositionLat"], rec.PositionLat.Degrees())
+	}
+	ts, err := time.Parse(time.RFC3339, got["Timestamp"].(string))
+	if err != nil || !ts.Equal(rec.Timestamp) {
+		t.Errorf("Timestamp: got %v, want %v", got["Timestamp"], rec.Timestamp)
+	}
+	for _, want := range []struct {
+		field string
+		value float64
+		units string
+	}{
+		{"Distance", rec.GetDistanceScaled(), "m"},
+		{"Speed", rec.GetSpeedScaled(), "m/s"},
+		{"Altitude", rec.GetAltitudeScaled(), "m"},
+	} {
+		v, ok := got[want.field].(map[string]interface{})
+		if !ok {
+			t.Errorf("%s: got %v, want object with value and units", want.field, got[want.field])
+			continue
+		}
+		if v["Value"] != want.value || v["Units"] != want.units {
+			t.Errorf("%s: got %v, want %v %s", want.field, v, want.value, want.units)
+		}
+	}
+	if _, found := got["Power"]; found && rec.Power == 0xFFFF {
+		t.Errorf("Power: invalid value included in output")
+	}
+	if strings.Contains(string(b), "65535") {
+		t.Errorf("output contains invalid value sentinel: %s", b)
+	}
+}
+
+var enumJSONTests = []struct {
+	in   fit.Sport
+	want string
+}{
+	{fit.SportRunning, `"Running"`},
+	{fit.SportInvalid, `"Invalid"`},
+	{fit.Sport(200), `200`},
+}
+
+func TestEnumJSON(t *testing.T) {
+	for _, test := range enumJSONTests {
+		b, err := json.Marshal(test.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != test.want {
+			t.Errorf("%d: got %s, want %s", test.in, b, test.want)
+		}
+		var got fit.Sport
+		if err = json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != test.in {
+			t.Errorf("%s: round trip: got %d, want %d", b, got, test.in)
+		}
+	}
+
+	var s fit.Sport
+	if err := json.Unmarshal([]byte(`"NoSuchSport"`), &s); err == nil {
+		t.Errorf("unknown name: got no error")
+	}
+}
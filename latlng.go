@@ -1,6 +1,7 @@
 package fit
 
 import (
+	"encoding/json"
 	"math"
 	"strconv"
 )
@@ -131,3 +132,64 @@ func (l Longitude) String() string {
 	}
 	return strconv.FormatFloat(l.Degrees(), 'f', precision, 32)
 }
+
+// MarshalJSON implements the json.Marshaler interface. The latitude is
+// encoded as a number in degrees, or null if l is invalid.
+func (l Latitude) MarshalJSON() ([]byte, error) {
+	return marshalJSONDegrees(l.Invalid(), l.Degrees())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The latitude must
+// be given in degrees. JSON null decodes to an invalid latitude.
+func (l *Latitude) UnmarshalJSON(b []byte) error {
+	semicircles, err := unmarshalJSONDegrees(b)
+	if err != nil {
+		return err
+	}
+	*l = NewLatitude(semicircles)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The longitude is
+// encoded as a number in degrees, or null if l is invalid.
+func (l Longitude) MarshalJSON() ([]byte, error) {
+	return marshalJSONDegrees(l.Invalid(), l.Degrees())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The longitude must
+// be given in degrees. JSON null decodes to an invalid longitude.
+func (l *Longitude) UnmarshalJSON(b []byte) error {
+	semicircles, err := unmarshalJSONDegrees(b)
+	if err != nil {
+		return err
+	}
+	*l = NewLongitude(semicircles)
+	return nil
+}
+
+func marshalJSONDegrees(invalid bool, degrees float64) ([]byte, error) {
+	e := newJSONEncodeState()
+	if invalid {
+		e.writeNull()
+	} else {
+		e.writeFloat(degrees)
+	}
+	return e.Bytes(), nil
+}
+
+// unmarshalJSONDegrees decodes degrees and returns them in semicircles,
+// rounded to make a round trip through MarshalJSON lossless.
+func unmarshalJSONDegrees(b []byte) (int32, error) {
+	if isJSONNull(b) {
+		return sint32Invalid, nil
+	}
+	var degrees float64
+	if err := json.Unmarshal(b, &degrees); err != nil {
+		return 0, err
+	}
+	semicircles := math.Round(degrees * degToSemiFactor)
+	if semicircles > math.MaxInt32 || semicircles < math.MinInt32 {
+		return sint32Invalid, nil
+	}
+	return int32(semicircles), nil
+}
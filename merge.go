@@ -0,0 +1,301 @@
+package fit
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+type mergeOptions struct {
+	maxSkew   time.Duration
+	skew      time.Duration
+	fixedSkew bool
+	tolerance time.Duration
+}
+
+// MergeOption configures Merge.
+type MergeOption func(*mergeOptions)
+
+// WithMaxClockSkew sets the largest clock skew between the primary and
+// secondary recordings that Merge searches for. The default is two
+// minutes. A maximum of zero disables the search.
+func WithMaxClockSkew(max time.Duration) MergeOption {
+	return func(o *mergeOptions) {
+		o.maxSkew = max
+	}
+}
+
+// WithClockSkew configures Merge to add skew to the timestamps of the
+// secondary recording instead of searching for the clock skew.
+func WithClockSkew(skew time.Duration) MergeOption {
+	return func(o *mergeOptions) {
+		o.skew, o.fixedSkew = skew, true
+	}
+}
+
+// WithMatchTolerance sets how far apart, after correcting the clock skew,
+// the timestamps of a primary and a secondary record may be for Merge to
+// consider them the same sample. The default is one second.
+func WithMatchTolerance(d time.Duration) MergeOption {
+	return func(o *mergeOptions) {
+		o.tolerance = d
+	}
+}
+
+// skewFields are the record fields compared by ClockSkew, in order of
+// preference. Each returns NaN if the field is invalid.
+var skewFields = []func(*RecordMsg) float64{
+	func(r *RecordMsg) float64 { return invalidNaN(float64(r.HeartRate), r.HeartRate == 0xFF) },
+	func(r *RecordMsg) float64 { return invalidNaN(float64(r.Power), r.Power == 0xFFFF) },
+	func(r *RecordMsg) float64 { return invalidNaN(float64(r.Cadence), r.Cadence == 0xFF) },
+	(*RecordMsg).GetSpeedScaled,
+	(*RecordMsg).GetAltitudeScaled,
+}
+
+func invalidNaN(v float64, invalid bool) float64 {
+	if invalid {
+		return math.NaN()
+	}
+	return v
+}
+
+// minSkewOverlap is the least number of samples ClockSkew compares for an
+// offset to be considered.
+const minSkewOverlap = 30
+
+// ClockSkew returns the offset, in whole seconds within ±max, that best
+// aligns the secondary records with the primary records when added to the
+// secondary timestamps. The first of heart rate, power, cadence, speed and
+// altitude recorded by both is compared, and the offset with the least mean
+// absolute difference wins; ties go to the smallest offset. Zero is
+// returned if the records share no field or do not overlap.
+func ClockSkew(primary, secondary []*RecordMsg, max time.Duration) time.Duration {
+	for _, field := range skewFields {
+		p, s := secondSamples(primary, field), secondSamples(secondary, field)
+		if len(p) == 0 || len(s) == 0 {
+			continue
+		}
+		var (
+			best     time.Duration
+			bestDiff = math.Inf(1)
+		)
+		maxSecs := int64(max / time.Second)
+		for k := int64(0); k <= 2*maxSecs; k++ {
+			// 0, 1, -1, 2, -2, ...: smallest offsets first.
+			off := (k + 1) / 2
+			if k%2 == 0 {
+				off = -off
+			}
+			var sum float64
+			var n int
+			for sec, sv := range s {
+				if pv, ok := p[sec+off]; ok {
+					sum += math.Abs(pv - sv)
+					n++
+				}
+			}
+			if n < minSkewOverlap && n < len(s)/2 {
+				continue
+			}
+			if n > 0 && sum/float64(n) < bestDiff {
+				best, bestDiff = time.Duration(off)*time.Second, sum/float64(n)
+			}
+		}
+		return best
+	}
+	return 0
+}
+
+// secondSamples returns the valid values of field in records keyed by Unix
+// second.
+func secondSamples(records []*RecordMsg, field func(*RecordMsg) float64) map[int64]float64 {
+	m := make(map[int64]float64)
+	for _, r := range records {
+		if r == nil || IsBaseTime(r.Timestamp) {
+			continue
+		}
+		if v := field(r); !math.IsNaN(v) {
+			m[r.Timestamp.Unix()] = v
+		}
+	}
+	return m
+}
+
+// Merge combines two recordings of the same activity, such as a watch
+// recording heart rate and a bike computer recording power. The result is a
+// copy of primary where every invalid record field is filled from the
+// secondary record closest in time, after correcting the clock skew between
+// the two; see WithMaxClockSkew, WithClockSkew and WithMatchTolerance.
+// Secondary records without a primary counterpart are dropped. Heart rate
+// variability messages are taken from secondary if primary has none.
+//
+// The device info messages of both files are kept, those of secondary
+// after those of primary and with the clock skew corrected.
+//
+// The sessions and laps of primary are recomputed from the merged records,
+// honouring the timer events of primary, the way analysis.Summarize does.
+// Aggregate fields the records do not support are kept. Neither primary
+// nor secondary is modified. An error is returned if either file is not an
+// activity file.
+func Merge(primary, secondary *File, opts ...MergeOption) (*File, error) {
+	o := mergeOptions{maxSkew: 2 * time.Minute, tolerance: time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	pa, err := primary.Activity()
+	if err != nil {
+		return nil, err
+	}
+	sa, err := secondary.Activity()
+	if err != nil {
+		return nil, err
+	}
+	if pa == nil || sa == nil {
+		return nil, errors.New("fit: merge: activity file has no messages")
+	}
+
+	skew := o.skew
+	if !o.fixedSkew {
+		skew = ClockSkew(pa.Records, sa.Records, o.maxSkew)
+	}
+
+	act := new(ActivityFile)
+	if pa.Activity != nil {
+		tmp := *pa.Activity
+		act.Activity = &tmp
+	}
+	for _, l := range pa.Lengths {
+		if l != nil {
+			tmp := *l
+			act.Lengths = append(act.Lengths, &tmp)
+		}
+	}
+	for _, e := range pa.Events {
+		if e != nil {
+			tmp := *e
+			act.Events = append(act.Events, &tmp)
+		}
+	}
+	hrvs := pa.Hrvs
+	if len(hrvs) == 0 {
+		hrvs = sa.Hrvs
+	}
+	for _, h := range hrvs {
+		if h != nil {
+			act.Hrvs = append(act.Hrvs, &HrvMsg{Time: append([]uint16(nil), h.Time...)})
+		}
+	}
+
+	srecs := make([]*RecordMsg, 0, len(sa.Records))
+	for _, r := range sa.Records {
+		if r != nil && !IsBaseTime(r.Timestamp) {
+			srecs = append(srecs, r)
+		}
+	}
+	sort.SliceStable(srecs, func(i, j int) bool {
+		return srecs[i].Timestamp.Before(srecs[j].Timestamp)
+	})
+	invalid := reflect.ValueOf(NewRecordMsg()).Elem()
+	act.Records = make([]*RecordMsg, 0, len(pa.Records))
+	for _, r := range pa.Records {
+		if r == nil {
+			continue
+		}
+		tmp := *r
+		if !IsBaseTime(r.Timestamp) {
+			if s := nearestRecord(srecs, r.Timestamp.Add(-skew), o.tolerance); s != nil {
+				fillInvalid(reflect.ValueOf(&tmp).Elem(), reflect.ValueOf(s).Elem(), invalid)
+			}
+		}
+		act.Records = append(act.Records, &tmp)
+	}
+
+	t := newTimer(act.Events)
+	for _, s := range pa.Sessions {
+		if s == nil {
+			continue
+		}
+		tmp := *s
+		if bounded(tmp.StartTime) && bounded(tmp.Timestamp) {
+			summarize(&tmp, act.Records, tmp.StartTime, tmp.Timestamp, t)
+		}
+		act.Sessions = append(act.Sessions, &tmp)
+	}
+	for _, l := range pa.Laps {
+		if l == nil {
+			continue
+		}
+		tmp := *l
+		if bounded(tmp.StartTime) && bounded(tmp.Timestamp) {
+			summarize(&tmp, act.Records, tmp.StartTime, tmp.Timestamp, t)
+		}
+		act.Laps = append(act.Laps, &tmp)
+	}
+
+	merged := *primary
+	merged.activity = act
+	merged.msgAdder = act
+	merged.DeviceInfo, merged.DeviceInfos = nil, nil
+	for _, f := range []*File{primary, secondary} {
+		dis := f.DeviceInfos
+		if len(dis) == 0 && f.DeviceInfo != nil {
+			dis = []*DeviceInfoMsg{f.DeviceInfo}
+		}
+		for _, di := range dis {
+			tmp := *di
+			if f == secondary && bounded(tmp.Timestamp) {
+				tmp.Timestamp = tmp.Timestamp.Add(skew)
+			}
+			merged.DeviceInfos = append(merged.DeviceInfos, &tmp)
+			merged.DeviceInfo = &tmp
+		}
+	}
+	return &merged, nil
+}
+
+// nearestRecord returns the record of the sorted records closest to t, or
+// nil if none is within tolerance.
+func nearestRecord(records []*RecordMsg, t time.Time, tolerance time.Duration) *RecordMsg {
+	i := sort.Search(len(records), func(i int) bool {
+		return !records[i].Timestamp.Before(t)
+	})
+	var best *RecordMsg
+	bestDiff := tolerance
+	for _, j := range []int{i - 1, i} {
+		if j < 0 || j >= len(records) {
+			continue
+		}
+		d := records[j].Timestamp.Sub(t)
+		if d < 0 {
+			d = -d
+		}
+		if d <= bestDiff {
+			best, bestDiff = records[j], d
+		}
+	}
+	return best
+}
+
+// fillInvalid sets every field of dst that equals the corresponding field
+// of invalid, or is an empty slice, to the field of src. The timestamp is
+// never changed.
+func fillInvalid(dst, src, invalid reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Type().Field(i).Name == "Timestamp" {
+			continue
+		}
+		df, sf, inv := dst.Field(i), src.Field(i), invalid.Field(i)
+		if isInvalidField(df, inv) && !isInvalidField(sf, inv) {
+			df.Set(sf)
+		}
+	}
+}
+
+func isInvalidField(v, invalid reflect.Value) bool {
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return v.Interface() == invalid.Interface()
+}
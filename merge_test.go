@@ -0,0 +1,104 @@
+package fit_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+func TestMerge(t *testing.T) {
+	const skew = 7 * time.Second
+	path := []string{"dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit"}
+	_, orig := decodeFile(t, path...)
+
+	// The primary recording lacks heart rate, the secondary power, and the
+	// secondary clock is behind.
+	primary, pa := decodeFile(t, path...)
+	for _, r := range pa.Records {
+		r.HeartRate = 0xFF
+	}
+	pa.Sessions[0].AvgHeartRate, pa.Sessions[0].MaxHeartRate = 0xFF, 0xFF
+	secondary, sa := decodeFile(t, path...)
+	for _, r := range sa.Records {
+		r.Power = 0xFFFF
+		r.Timestamp = r.Timestamp.Add(-skew)
+	}
+
+	if got := fit.ClockSkew(pa.Records, sa.Records, time.Minute); got != skew {
+		t.Errorf("ClockSkew: got %v, want %v", got, skew)
+	}
+
+	merged, err := fit.Merge(primary, secondary, fit.WithMaxClockSkew(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := merged.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(act.Records) != len(orig.Records) {
+		t.Fatalf("got %d records, want %d", len(act.Records), len(orig.Records))
+	}
+	for i, r := range act.Records {
+		o := orig.Records[i]
+		if r.HeartRate != o.HeartRate || r.Power != o.Power || !r.Timestamp.Equal(o.Timestamp) {
+			t.Fatalf("record %d: got heart rate %d, power %d at %v, want %d, %d at %v",
+				i, r.HeartRate, r.Power, r.Timestamp, o.HeartRate, o.Power, o.Timestamp)
+		}
+	}
+	got, want := act.Sessions[0], orig.Sessions[0]
+	if got.MaxHeartRate != want.MaxHeartRate || absDiff(got.AvgHeartRate, want.AvgHeartRate) > 3 {
+		t.Errorf("session: got heart rate avg %d, max %d, want about %d, %d",
+			got.AvgHeartRate, got.MaxHeartRate, want.AvgHeartRate, want.MaxHeartRate)
+	}
+	for i, l := range act.Laps {
+		sum := analysis.Summarize(fit.RecordsBetween(act.Records, l.StartTime, l.Timestamp),
+			analysis.WithTimerEvents(act.Events))
+		if l.AvgHeartRate != uint8(math.Round(sum.AvgHeartRate)) || l.MinHeartRate != uint8(sum.MinHeartRate) {
+			t.Errorf("lap %d: got heart rate avg %d, min %d, want %.0f, %.0f",
+				i, l.AvgHeartRate, l.MinHeartRate, sum.AvgHeartRate, sum.MinHeartRate)
+		}
+	}
+
+	if len(act.Events) != len(pa.Events) || act.Events[0] == pa.Events[0] {
+		t.Errorf("got %d events, want %d copies", len(act.Events), len(pa.Events))
+	}
+	if n := len(primary.DeviceInfos) + len(secondary.DeviceInfos); len(merged.DeviceInfos) != n {
+		t.Errorf("got %d device infos, want %d", len(merged.DeviceInfos), n)
+	}
+	sdi, mdi := secondary.DeviceInfos[0], merged.DeviceInfos[len(primary.DeviceInfos)]
+	if !mdi.Timestamp.Equal(sdi.Timestamp.Add(skew)) || mdi == sdi {
+		t.Errorf("secondary device info: got copy %t at %v, want copy at %v", mdi != sdi, mdi.Timestamp, sdi.Timestamp.Add(skew))
+	}
+
+	var buf bytes.Buffer
+	if err := fit.Encode(&buf, merged); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if dec, err := fit.Decode(&buf); err != nil || len(dec.DeviceInfos) != len(merged.DeviceInfos) {
+		t.Errorf("decode merged: got error %v, want %d device infos", err, len(merged.DeviceInfos))
+	}
+
+	if pa.Records[0].HeartRate != 0xFF || pa.Sessions[0].AvgHeartRate != 0xFF {
+		t.Error("primary modified")
+	}
+	// With a far off clock skew, no secondary record matches.
+	merged, err = fit.Merge(primary, secondary, fit.WithClockSkew(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act, _ := merged.Activity(); act.Records[0].HeartRate != 0xFF {
+		t.Errorf("skewed merge: got heart rate %d, want invalid", act.Records[0].HeartRate)
+	}
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
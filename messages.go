@@ -33,16 +33,177 @@ func (x *FileIdMsg) GetProduct() interface{} {
 	}
 }
 
+// NewFileIdMsg returns a file_id FIT message
+// initialized to all-invalid values.
+func NewFileIdMsg() *FileIdMsg {
+	msg := msgsAllInvalid[MesgNumFileId].Interface().(FileIdMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x FileIdMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.Manufacturer != 0xFFFF {
+		e.field("Manufacturer")
+		e.writeEnum(x.Manufacturer.String(), uint64(x.Manufacturer))
+	}
+	if x.Product != 0xFFFF {
+		e.field("Product")
+		e.writeUintUnits(uint64(x.Product), "")
+	}
+	if x.SerialNumber != 0x00000000 {
+		e.field("SerialNumber")
+		e.writeUintUnits(uint64(x.SerialNumber), "")
+	}
+	if isValidTime(x.TimeCreated) {
+		e.field("TimeCreated")
+		e.writeTime(x.TimeCreated)
+	}
+	if x.Number != 0xFFFF {
+		e.field("Number")
+		e.writeUintUnits(uint64(x.Number), "")
+	}
+	if x.ProductName != "" {
+		e.field("ProductName")
+		e.writeString(x.ProductName)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *FileIdMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumFileId].Interface().(FileIdMsg)
+	for name, v := range fields {
+		switch name {
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "Manufacturer":
+			err = unmarshalJSONValue(v, &x.Manufacturer)
+		case "Product":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Product = uint16(f)
+		case "SerialNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00000000)
+			x.SerialNumber = uint32(f)
+		case "TimeCreated":
+			err = unmarshalJSONValue(v, &x.TimeCreated)
+		case "Number":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Number = uint16(f)
+		case "ProductName":
+			err = unmarshalJSONValue(v, &x.ProductName)
+		}
+		if err != nil {
+			return jsonFieldError{"FileIdMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // FileCreatorMsg represents the file_creator FIT message type.
 type FileCreatorMsg struct {
 	SoftwareVersion uint16
 	HardwareVersion uint8
 }
 
+// NewFileCreatorMsg returns a file_creator FIT message
+// initialized to all-invalid values.
+func NewFileCreatorMsg() *FileCreatorMsg {
+	msg := msgsAllInvalid[MesgNumFileCreator].Interface().(FileCreatorMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x FileCreatorMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.SoftwareVersion != 0xFFFF {
+		e.field("SoftwareVersion")
+		e.writeUintUnits(uint64(x.SoftwareVersion), "")
+	}
+	if x.HardwareVersion != 0xFF {
+		e.field("HardwareVersion")
+		e.writeUintUnits(uint64(x.HardwareVersion), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *FileCreatorMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumFileCreator].Interface().(FileCreatorMsg)
+	for name, v := range fields {
+		switch name {
+		case "SoftwareVersion":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.SoftwareVersion = uint16(f)
+		case "HardwareVersion":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HardwareVersion = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"FileCreatorMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // TimestampCorrelationMsg represents the timestamp_correlation FIT message type.
 type TimestampCorrelationMsg struct {
 }
 
+// NewTimestampCorrelationMsg returns a timestamp_correlation FIT message
+// initialized to all-invalid values.
+func NewTimestampCorrelationMsg() *TimestampCorrelationMsg {
+	msg := msgsAllInvalid[MesgNumTimestampCorrelation].Interface().(TimestampCorrelationMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x TimestampCorrelationMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *TimestampCorrelationMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // SoftwareMsg represents the software FIT message type.
 type SoftwareMsg struct {
 	MessageIndex MessageIndex
@@ -60,6 +221,62 @@ func (x *SoftwareMsg) GetVersionScaled() float64 {
 	return float64(x.Version) / 100
 }
 
+// NewSoftwareMsg returns a software FIT message
+// initialized to all-invalid values.
+func NewSoftwareMsg() *SoftwareMsg {
+	msg := msgsAllInvalid[MesgNumSoftware].Interface().(SoftwareMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SoftwareMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Version != 0xFFFF {
+		e.field("Version")
+		e.writeScaled(float64(x.Version)/100, "")
+	}
+	if x.PartNumber != "" {
+		e.field("PartNumber")
+		e.writeString(x.PartNumber)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SoftwareMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSoftware].Interface().(SoftwareMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Version":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.Version = uint16(f)
+		case "PartNumber":
+			err = unmarshalJSONValue(v, &x.PartNumber)
+		}
+		if err != nil {
+			return jsonFieldError{"SoftwareMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SlaveDeviceMsg represents the slave_device FIT message type.
 type SlaveDeviceMsg struct {
 	Manufacturer Manufacturer
@@ -79,6 +296,56 @@ func (x *SlaveDeviceMsg) GetProduct() interface{} {
 	}
 }
 
+// NewSlaveDeviceMsg returns a slave_device FIT message
+// initialized to all-invalid values.
+func NewSlaveDeviceMsg() *SlaveDeviceMsg {
+	msg := msgsAllInvalid[MesgNumSlaveDevice].Interface().(SlaveDeviceMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SlaveDeviceMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Manufacturer != 0xFFFF {
+		e.field("Manufacturer")
+		e.writeEnum(x.Manufacturer.String(), uint64(x.Manufacturer))
+	}
+	if x.Product != 0xFFFF {
+		e.field("Product")
+		e.writeUintUnits(uint64(x.Product), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SlaveDeviceMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSlaveDevice].Interface().(SlaveDeviceMsg)
+	for name, v := range fields {
+		switch name {
+		case "Manufacturer":
+			err = unmarshalJSONValue(v, &x.Manufacturer)
+		case "Product":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Product = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"SlaveDeviceMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // CapabilitiesMsg represents the capabilities FIT message type.
 type CapabilitiesMsg struct {
 	Languages             []uint8      // Use language_bits_x types where x is index of array.
@@ -87,6 +354,94 @@ type CapabilitiesMsg struct {
 	ConnectivitySupported ConnectivityCapabilities
 }
 
+// NewCapabilitiesMsg returns a capabilities FIT message
+// initialized to all-invalid values.
+func NewCapabilitiesMsg() *CapabilitiesMsg {
+	msg := msgsAllInvalid[MesgNumCapabilities].Interface().(CapabilitiesMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x CapabilitiesMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if len(x.Languages) != 0 {
+		e.field("Languages")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.Languages {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x00:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if len(x.Sports) != 0 {
+		e.field("Sports")
+		e.openArray()
+		for i, v := range x.Sports {
+			if i > 0 {
+				e.c()
+			}
+			e.writeEnum(v.String(), uint64(v))
+		}
+		e.closeArray()
+	}
+	if x.WorkoutsSupported != 0x00000000 {
+		e.field("WorkoutsSupported")
+		e.writeEnum(x.WorkoutsSupported.String(), uint64(x.WorkoutsSupported))
+	}
+	if x.ConnectivitySupported != 0x00000000 {
+		e.field("ConnectivitySupported")
+		e.writeEnum(x.ConnectivitySupported.String(), uint64(x.ConnectivitySupported))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *CapabilitiesMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumCapabilities].Interface().(CapabilitiesMsg)
+	for name, v := range fields {
+		switch name {
+		case "Languages":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0x00)
+			if fs != nil {
+				x.Languages = make([]uint8, len(fs))
+				for i, f := range fs {
+					x.Languages[i] = uint8(f)
+				}
+			}
+		case "Sports":
+			err = unmarshalJSONValue(v, &x.Sports)
+		case "WorkoutsSupported":
+			err = unmarshalJSONValue(v, &x.WorkoutsSupported)
+		case "ConnectivitySupported":
+			err = unmarshalJSONValue(v, &x.ConnectivitySupported)
+		}
+		if err != nil {
+			return jsonFieldError{"CapabilitiesMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // FileCapabilitiesMsg represents the file_capabilities FIT message type.
 type FileCapabilitiesMsg struct {
 	MessageIndex MessageIndex
@@ -97,6 +452,82 @@ type FileCapabilitiesMsg struct {
 	MaxSize      uint32
 }
 
+// NewFileCapabilitiesMsg returns a file_capabilities FIT message
+// initialized to all-invalid values.
+func NewFileCapabilitiesMsg() *FileCapabilitiesMsg {
+	msg := msgsAllInvalid[MesgNumFileCapabilities].Interface().(FileCapabilitiesMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x FileCapabilitiesMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.Flags != 0x00 {
+		e.field("Flags")
+		e.writeEnum(x.Flags.String(), uint64(x.Flags))
+	}
+	if x.Directory != "" {
+		e.field("Directory")
+		e.writeString(x.Directory)
+	}
+	if x.MaxCount != 0xFFFF {
+		e.field("MaxCount")
+		e.writeUintUnits(uint64(x.MaxCount), "")
+	}
+	if x.MaxSize != 0xFFFFFFFF {
+		e.field("MaxSize")
+		e.writeUintUnits(uint64(x.MaxSize), "bytes")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *FileCapabilitiesMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumFileCapabilities].Interface().(FileCapabilitiesMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "Flags":
+			err = unmarshalJSONValue(v, &x.Flags)
+		case "Directory":
+			err = unmarshalJSONValue(v, &x.Directory)
+		case "MaxCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MaxCount = uint16(f)
+		case "MaxSize":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.MaxSize = uint32(f)
+		}
+		if err != nil {
+			return jsonFieldError{"FileCapabilitiesMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // MesgCapabilitiesMsg represents the mesg_capabilities FIT message type.
 type MesgCapabilitiesMsg struct {
 	MessageIndex MessageIndex
@@ -123,6 +554,74 @@ func (x *MesgCapabilitiesMsg) GetCount() interface{} {
 	}
 }
 
+// NewMesgCapabilitiesMsg returns a mesg_capabilities FIT message
+// initialized to all-invalid values.
+func NewMesgCapabilitiesMsg() *MesgCapabilitiesMsg {
+	msg := msgsAllInvalid[MesgNumMesgCapabilities].Interface().(MesgCapabilitiesMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x MesgCapabilitiesMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.File != 0xFF {
+		e.field("File")
+		e.writeEnum(x.File.String(), uint64(x.File))
+	}
+	if x.MesgNum != 0xFFFF {
+		e.field("MesgNum")
+		e.writeEnum(x.MesgNum.String(), uint64(x.MesgNum))
+	}
+	if x.CountType != 0xFF {
+		e.field("CountType")
+		e.writeEnum(x.CountType.String(), uint64(x.CountType))
+	}
+	if x.Count != 0xFFFF {
+		e.field("Count")
+		e.writeUintUnits(uint64(x.Count), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *MesgCapabilitiesMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumMesgCapabilities].Interface().(MesgCapabilitiesMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "File":
+			err = unmarshalJSONValue(v, &x.File)
+		case "MesgNum":
+			err = unmarshalJSONValue(v, &x.MesgNum)
+		case "CountType":
+			err = unmarshalJSONValue(v, &x.CountType)
+		case "Count":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Count = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"MesgCapabilitiesMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // FieldCapabilitiesMsg represents the field_capabilities FIT message type.
 type FieldCapabilitiesMsg struct {
 	MessageIndex MessageIndex
@@ -132,6 +631,76 @@ type FieldCapabilitiesMsg struct {
 	Count        uint16
 }
 
+// NewFieldCapabilitiesMsg returns a field_capabilities FIT message
+// initialized to all-invalid values.
+func NewFieldCapabilitiesMsg() *FieldCapabilitiesMsg {
+	msg := msgsAllInvalid[MesgNumFieldCapabilities].Interface().(FieldCapabilitiesMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x FieldCapabilitiesMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.File != 0xFF {
+		e.field("File")
+		e.writeEnum(x.File.String(), uint64(x.File))
+	}
+	if x.MesgNum != 0xFFFF {
+		e.field("MesgNum")
+		e.writeEnum(x.MesgNum.String(), uint64(x.MesgNum))
+	}
+	if x.FieldNum != 0xFF {
+		e.field("FieldNum")
+		e.writeUintUnits(uint64(x.FieldNum), "")
+	}
+	if x.Count != 0xFFFF {
+		e.field("Count")
+		e.writeUintUnits(uint64(x.Count), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *FieldCapabilitiesMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumFieldCapabilities].Interface().(FieldCapabilitiesMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "File":
+			err = unmarshalJSONValue(v, &x.File)
+		case "MesgNum":
+			err = unmarshalJSONValue(v, &x.MesgNum)
+		case "FieldNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.FieldNum = uint8(f)
+		case "Count":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Count = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"FieldCapabilitiesMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // DeviceSettingsMsg represents the device_settings FIT message type.
 type DeviceSettingsMsg struct {
 	ActiveTimeZone         uint8         // Index into time zone arrays.
@@ -166,6 +735,237 @@ func (x *DeviceSettingsMsg) GetTimeZoneOffsetScaled() []float64 {
 	return s
 }
 
+// NewDeviceSettingsMsg returns a device_settings FIT message
+// initialized to all-invalid values.
+func NewDeviceSettingsMsg() *DeviceSettingsMsg {
+	msg := msgsAllInvalid[MesgNumDeviceSettings].Interface().(DeviceSettingsMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x DeviceSettingsMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.ActiveTimeZone != 0xFF {
+		e.field("ActiveTimeZone")
+		e.writeUintUnits(uint64(x.ActiveTimeZone), "")
+	}
+	if x.UtcOffset != 0xFFFFFFFF {
+		e.field("UtcOffset")
+		e.writeUintUnits(uint64(x.UtcOffset), "")
+	}
+	if len(x.TimeOffset) != 0 {
+		e.field("TimeOffset")
+		e.openUnits("s")
+		e.openArray()
+		for i, v := range x.TimeOffset {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("s")
+	}
+	if len(x.TimeMode) != 0 {
+		e.field("TimeMode")
+		e.openArray()
+		for i, v := range x.TimeMode {
+			if i > 0 {
+				e.c()
+			}
+			e.writeEnum(v.String(), uint64(v))
+		}
+		e.closeArray()
+	}
+	if len(x.TimeZoneOffset) != 0 {
+		e.field("TimeZoneOffset")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeZoneOffset {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x7F:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 4)
+			}
+		}
+		e.closeArray()
+		e.closeValue("hr")
+	}
+	if x.BacklightMode != 0xFF {
+		e.field("BacklightMode")
+		e.writeEnum(x.BacklightMode.String(), uint64(x.BacklightMode))
+	}
+	if x.ActivityTrackerEnabled != 0xFF {
+		e.field("ActivityTrackerEnabled")
+		e.writeBoolEnum(x.ActivityTrackerEnabled)
+	}
+	if isValidTime(x.ClockTime) {
+		e.field("ClockTime")
+		e.writeTime(x.ClockTime)
+	}
+	if len(x.PagesEnabled) != 0 {
+		e.field("PagesEnabled")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.PagesEnabled {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.MoveAlertEnabled != 0xFF {
+		e.field("MoveAlertEnabled")
+		e.writeBoolEnum(x.MoveAlertEnabled)
+	}
+	if x.DateMode != 0xFF {
+		e.field("DateMode")
+		e.writeEnum(x.DateMode.String(), uint64(x.DateMode))
+	}
+	if x.DisplayOrientation != 0xFF {
+		e.field("DisplayOrientation")
+		e.writeEnum(x.DisplayOrientation.String(), uint64(x.DisplayOrientation))
+	}
+	if x.MountingSide != 0xFF {
+		e.field("MountingSide")
+		e.writeEnum(x.MountingSide.String(), uint64(x.MountingSide))
+	}
+	if len(x.DefaultPage) != 0 {
+		e.field("DefaultPage")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.DefaultPage {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.AutosyncMinSteps != 0xFFFF {
+		e.field("AutosyncMinSteps")
+		e.writeUintUnits(uint64(x.AutosyncMinSteps), "steps")
+	}
+	if x.AutosyncMinTime != 0xFFFF {
+		e.field("AutosyncMinTime")
+		e.writeUintUnits(uint64(x.AutosyncMinTime), "minutes")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *DeviceSettingsMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumDeviceSettings].Interface().(DeviceSettingsMsg)
+	for name, v := range fields {
+		switch name {
+		case "ActiveTimeZone":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ActiveTimeZone = uint8(f)
+		case "UtcOffset":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.UtcOffset = uint32(f)
+		case "TimeOffset":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeOffset = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeOffset[i] = uint32(f)
+				}
+			}
+		case "TimeMode":
+			err = unmarshalJSONValue(v, &x.TimeMode)
+		case "TimeZoneOffset":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 4, 0, 0x7F)
+			if fs != nil {
+				x.TimeZoneOffset = make([]int8, len(fs))
+				for i, f := range fs {
+					x.TimeZoneOffset[i] = int8(f)
+				}
+			}
+		case "BacklightMode":
+			err = unmarshalJSONValue(v, &x.BacklightMode)
+		case "ActivityTrackerEnabled":
+			err = unmarshalJSONValue(v, &x.ActivityTrackerEnabled)
+		case "ClockTime":
+			err = unmarshalJSONValue(v, &x.ClockTime)
+		case "PagesEnabled":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.PagesEnabled = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.PagesEnabled[i] = uint16(f)
+				}
+			}
+		case "MoveAlertEnabled":
+			err = unmarshalJSONValue(v, &x.MoveAlertEnabled)
+		case "DateMode":
+			err = unmarshalJSONValue(v, &x.DateMode)
+		case "DisplayOrientation":
+			err = unmarshalJSONValue(v, &x.DisplayOrientation)
+		case "MountingSide":
+			err = unmarshalJSONValue(v, &x.MountingSide)
+		case "DefaultPage":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.DefaultPage = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.DefaultPage[i] = uint16(f)
+				}
+			}
+		case "AutosyncMinSteps":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.AutosyncMinSteps = uint16(f)
+		case "AutosyncMinTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.AutosyncMinTime = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"DeviceSettingsMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // UserProfileMsg represents the user_profile FIT message type.
 type UserProfileMsg struct {
 	MessageIndex               MessageIndex
@@ -239,6 +1039,231 @@ func (x *UserProfileMsg) GetUserWalkingStepLengthScaled() float64 {
 	return float64(x.UserWalkingStepLength) / 1000
 }
 
+// NewUserProfileMsg returns a user_profile FIT message
+// initialized to all-invalid values.
+func NewUserProfileMsg() *UserProfileMsg {
+	msg := msgsAllInvalid[MesgNumUserProfile].Interface().(UserProfileMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x UserProfileMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.FriendlyName != "" {
+		e.field("FriendlyName")
+		e.writeString(x.FriendlyName)
+	}
+	if x.Gender != 0xFF {
+		e.field("Gender")
+		e.writeEnum(x.Gender.String(), uint64(x.Gender))
+	}
+	if x.Age != 0xFF {
+		e.field("Age")
+		e.writeUintUnits(uint64(x.Age), "years")
+	}
+	if x.Height != 0xFF {
+		e.field("Height")
+		e.writeScaled(float64(x.Height)/100, "m")
+	}
+	if x.Weight != 0xFFFF {
+		e.field("Weight")
+		e.writeScaled(float64(x.Weight)/10, "kg")
+	}
+	if x.Language != 0xFF {
+		e.field("Language")
+		e.writeEnum(x.Language.String(), uint64(x.Language))
+	}
+	if x.ElevSetting != 0xFF {
+		e.field("ElevSetting")
+		e.writeEnum(x.ElevSetting.String(), uint64(x.ElevSetting))
+	}
+	if x.WeightSetting != 0xFF {
+		e.field("WeightSetting")
+		e.writeEnum(x.WeightSetting.String(), uint64(x.WeightSetting))
+	}
+	if x.RestingHeartRate != 0xFF {
+		e.field("RestingHeartRate")
+		e.writeUintUnits(uint64(x.RestingHeartRate), "bpm")
+	}
+	if x.DefaultMaxRunningHeartRate != 0xFF {
+		e.field("DefaultMaxRunningHeartRate")
+		e.writeUintUnits(uint64(x.DefaultMaxRunningHeartRate), "bpm")
+	}
+	if x.DefaultMaxBikingHeartRate != 0xFF {
+		e.field("DefaultMaxBikingHeartRate")
+		e.writeUintUnits(uint64(x.DefaultMaxBikingHeartRate), "bpm")
+	}
+	if x.DefaultMaxHeartRate != 0xFF {
+		e.field("DefaultMaxHeartRate")
+		e.writeUintUnits(uint64(x.DefaultMaxHeartRate), "bpm")
+	}
+	if x.HrSetting != 0xFF {
+		e.field("HrSetting")
+		e.writeEnum(x.HrSetting.String(), uint64(x.HrSetting))
+	}
+	if x.SpeedSetting != 0xFF {
+		e.field("SpeedSetting")
+		e.writeEnum(x.SpeedSetting.String(), uint64(x.SpeedSetting))
+	}
+	if x.DistSetting != 0xFF {
+		e.field("DistSetting")
+		e.writeEnum(x.DistSetting.String(), uint64(x.DistSetting))
+	}
+	if x.PowerSetting != 0xFF {
+		e.field("PowerSetting")
+		e.writeEnum(x.PowerSetting.String(), uint64(x.PowerSetting))
+	}
+	if x.ActivityClass != 0xFF {
+		e.field("ActivityClass")
+		e.writeEnum(x.ActivityClass.String(), uint64(x.ActivityClass))
+	}
+	if x.PositionSetting != 0xFF {
+		e.field("PositionSetting")
+		e.writeEnum(x.PositionSetting.String(), uint64(x.PositionSetting))
+	}
+	if x.TemperatureSetting != 0xFF {
+		e.field("TemperatureSetting")
+		e.writeEnum(x.TemperatureSetting.String(), uint64(x.TemperatureSetting))
+	}
+	if x.LocalId != 0xFFFF {
+		e.field("LocalId")
+		e.writeEnum(x.LocalId.String(), uint64(x.LocalId))
+	}
+	if len(x.GlobalId) != 0 {
+		e.field("GlobalId")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.GlobalId {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.HeightSetting != 0xFF {
+		e.field("HeightSetting")
+		e.writeEnum(x.HeightSetting.String(), uint64(x.HeightSetting))
+	}
+	if x.UserRunningStepLength != 0xFFFF {
+		e.field("UserRunningStepLength")
+		e.writeScaled(float64(x.UserRunningStepLength)/1000, "m")
+	}
+	if x.UserWalkingStepLength != 0xFFFF {
+		e.field("UserWalkingStepLength")
+		e.writeScaled(float64(x.UserWalkingStepLength)/1000, "m")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *UserProfileMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumUserProfile].Interface().(UserProfileMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "FriendlyName":
+			err = unmarshalJSONValue(v, &x.FriendlyName)
+		case "Gender":
+			err = unmarshalJSONValue(v, &x.Gender)
+		case "Age":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Age = uint8(f)
+		case "Height":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFF)
+			x.Height = uint8(f)
+		case "Weight":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.Weight = uint16(f)
+		case "Language":
+			err = unmarshalJSONValue(v, &x.Language)
+		case "ElevSetting":
+			err = unmarshalJSONValue(v, &x.ElevSetting)
+		case "WeightSetting":
+			err = unmarshalJSONValue(v, &x.WeightSetting)
+		case "RestingHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.RestingHeartRate = uint8(f)
+		case "DefaultMaxRunningHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DefaultMaxRunningHeartRate = uint8(f)
+		case "DefaultMaxBikingHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DefaultMaxBikingHeartRate = uint8(f)
+		case "DefaultMaxHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DefaultMaxHeartRate = uint8(f)
+		case "HrSetting":
+			err = unmarshalJSONValue(v, &x.HrSetting)
+		case "SpeedSetting":
+			err = unmarshalJSONValue(v, &x.SpeedSetting)
+		case "DistSetting":
+			err = unmarshalJSONValue(v, &x.DistSetting)
+		case "PowerSetting":
+			err = unmarshalJSONValue(v, &x.PowerSetting)
+		case "ActivityClass":
+			err = unmarshalJSONValue(v, &x.ActivityClass)
+		case "PositionSetting":
+			err = unmarshalJSONValue(v, &x.PositionSetting)
+		case "TemperatureSetting":
+			err = unmarshalJSONValue(v, &x.TemperatureSetting)
+		case "LocalId":
+			err = unmarshalJSONValue(v, &x.LocalId)
+		case "GlobalId":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.GlobalId = make([]byte, len(fs))
+				for i, f := range fs {
+					x.GlobalId[i] = byte(f)
+				}
+			}
+		case "HeightSetting":
+			err = unmarshalJSONValue(v, &x.HeightSetting)
+		case "UserRunningStepLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.UserRunningStepLength = uint16(f)
+		case "UserWalkingStepLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.UserWalkingStepLength = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"UserProfileMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // HrmProfileMsg represents the hrm_profile FIT message type.
 type HrmProfileMsg struct {
 	MessageIndex      MessageIndex
@@ -248,6 +1273,76 @@ type HrmProfileMsg struct {
 	HrmAntIdTransType uint8
 }
 
+// NewHrmProfileMsg returns a hrm_profile FIT message
+// initialized to all-invalid values.
+func NewHrmProfileMsg() *HrmProfileMsg {
+	msg := msgsAllInvalid[MesgNumHrmProfile].Interface().(HrmProfileMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x HrmProfileMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Enabled != 0xFF {
+		e.field("Enabled")
+		e.writeBoolEnum(x.Enabled)
+	}
+	if x.HrmAntId != 0x0000 {
+		e.field("HrmAntId")
+		e.writeUintUnits(uint64(x.HrmAntId), "")
+	}
+	if x.LogHrv != 0xFF {
+		e.field("LogHrv")
+		e.writeBoolEnum(x.LogHrv)
+	}
+	if x.HrmAntIdTransType != 0x00 {
+		e.field("HrmAntIdTransType")
+		e.writeUintUnits(uint64(x.HrmAntIdTransType), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *HrmProfileMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumHrmProfile].Interface().(HrmProfileMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Enabled":
+			err = unmarshalJSONValue(v, &x.Enabled)
+		case "HrmAntId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.HrmAntId = uint16(f)
+		case "LogHrv":
+			err = unmarshalJSONValue(v, &x.LogHrv)
+		case "HrmAntIdTransType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.HrmAntIdTransType = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"HrmProfileMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SdmProfileMsg represents the sdm_profile FIT message type.
 type SdmProfileMsg struct {
 	MessageIndex      MessageIndex
@@ -282,6 +1377,100 @@ func (x *SdmProfileMsg) GetOdometerScaled() float64 {
 	return float64(x.Odometer) / 100
 }
 
+// NewSdmProfileMsg returns a sdm_profile FIT message
+// initialized to all-invalid values.
+func NewSdmProfileMsg() *SdmProfileMsg {
+	msg := msgsAllInvalid[MesgNumSdmProfile].Interface().(SdmProfileMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SdmProfileMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Enabled != 0xFF {
+		e.field("Enabled")
+		e.writeBoolEnum(x.Enabled)
+	}
+	if x.SdmAntId != 0x0000 {
+		e.field("SdmAntId")
+		e.writeUintUnits(uint64(x.SdmAntId), "")
+	}
+	if x.SdmCalFactor != 0xFFFF {
+		e.field("SdmCalFactor")
+		e.writeScaled(float64(x.SdmCalFactor)/10, "%")
+	}
+	if x.Odometer != 0xFFFFFFFF {
+		e.field("Odometer")
+		e.writeScaled(float64(x.Odometer)/100, "m")
+	}
+	if x.SpeedSource != 0xFF {
+		e.field("SpeedSource")
+		e.writeBoolEnum(x.SpeedSource)
+	}
+	if x.SdmAntIdTransType != 0x00 {
+		e.field("SdmAntIdTransType")
+		e.writeUintUnits(uint64(x.SdmAntIdTransType), "")
+	}
+	if x.OdometerRollover != 0xFF {
+		e.field("OdometerRollover")
+		e.writeUintUnits(uint64(x.OdometerRollover), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SdmProfileMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSdmProfile].Interface().(SdmProfileMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Enabled":
+			err = unmarshalJSONValue(v, &x.Enabled)
+		case "SdmAntId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.SdmAntId = uint16(f)
+		case "SdmCalFactor":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.SdmCalFactor = uint16(f)
+		case "Odometer":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.Odometer = uint32(f)
+		case "SpeedSource":
+			err = unmarshalJSONValue(v, &x.SpeedSource)
+		case "SdmAntIdTransType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.SdmAntIdTransType = uint8(f)
+		case "OdometerRollover":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.OdometerRollover = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"SdmProfileMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // BikeProfileMsg represents the bike_profile FIT message type.
 type BikeProfileMsg struct {
 	MessageIndex             MessageIndex
@@ -384,6 +1573,312 @@ func (x *BikeProfileMsg) GetCrankLengthScaled() float64 {
 	return float64(x.CrankLength)/2 - -110
 }
 
+// NewBikeProfileMsg returns a bike_profile FIT message
+// initialized to all-invalid values.
+func NewBikeProfileMsg() *BikeProfileMsg {
+	msg := msgsAllInvalid[MesgNumBikeProfile].Interface().(BikeProfileMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x BikeProfileMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.Odometer != 0xFFFFFFFF {
+		e.field("Odometer")
+		e.writeScaled(float64(x.Odometer)/100, "m")
+	}
+	if x.BikeSpdAntId != 0x0000 {
+		e.field("BikeSpdAntId")
+		e.writeUintUnits(uint64(x.BikeSpdAntId), "")
+	}
+	if x.BikeCadAntId != 0x0000 {
+		e.field("BikeCadAntId")
+		e.writeUintUnits(uint64(x.BikeCadAntId), "")
+	}
+	if x.BikeSpdcadAntId != 0x0000 {
+		e.field("BikeSpdcadAntId")
+		e.writeUintUnits(uint64(x.BikeSpdcadAntId), "")
+	}
+	if x.BikePowerAntId != 0x0000 {
+		e.field("BikePowerAntId")
+		e.writeUintUnits(uint64(x.BikePowerAntId), "")
+	}
+	if x.CustomWheelsize != 0xFFFF {
+		e.field("CustomWheelsize")
+		e.writeScaled(float64(x.CustomWheelsize)/1000, "m")
+	}
+	if x.AutoWheelsize != 0xFFFF {
+		e.field("AutoWheelsize")
+		e.writeScaled(float64(x.AutoWheelsize)/1000, "m")
+	}
+	if x.BikeWeight != 0xFFFF {
+		e.field("BikeWeight")
+		e.writeScaled(float64(x.BikeWeight)/10, "kg")
+	}
+	if x.PowerCalFactor != 0xFFFF {
+		e.field("PowerCalFactor")
+		e.writeScaled(float64(x.PowerCalFactor)/10, "%")
+	}
+	if x.AutoWheelCal != 0xFF {
+		e.field("AutoWheelCal")
+		e.writeBoolEnum(x.AutoWheelCal)
+	}
+	if x.AutoPowerZero != 0xFF {
+		e.field("AutoPowerZero")
+		e.writeBoolEnum(x.AutoPowerZero)
+	}
+	if x.Id != 0xFF {
+		e.field("Id")
+		e.writeUintUnits(uint64(x.Id), "")
+	}
+	if x.SpdEnabled != 0xFF {
+		e.field("SpdEnabled")
+		e.writeBoolEnum(x.SpdEnabled)
+	}
+	if x.CadEnabled != 0xFF {
+		e.field("CadEnabled")
+		e.writeBoolEnum(x.CadEnabled)
+	}
+	if x.SpdcadEnabled != 0xFF {
+		e.field("SpdcadEnabled")
+		e.writeBoolEnum(x.SpdcadEnabled)
+	}
+	if x.PowerEnabled != 0xFF {
+		e.field("PowerEnabled")
+		e.writeBoolEnum(x.PowerEnabled)
+	}
+	if x.CrankLength != 0xFF {
+		e.field("CrankLength")
+		e.writeScaled(float64(x.CrankLength)/2 - -110, "mm")
+	}
+	if x.Enabled != 0xFF {
+		e.field("Enabled")
+		e.writeBoolEnum(x.Enabled)
+	}
+	if x.BikeSpdAntIdTransType != 0x00 {
+		e.field("BikeSpdAntIdTransType")
+		e.writeUintUnits(uint64(x.BikeSpdAntIdTransType), "")
+	}
+	if x.BikeCadAntIdTransType != 0x00 {
+		e.field("BikeCadAntIdTransType")
+		e.writeUintUnits(uint64(x.BikeCadAntIdTransType), "")
+	}
+	if x.BikeSpdcadAntIdTransType != 0x00 {
+		e.field("BikeSpdcadAntIdTransType")
+		e.writeUintUnits(uint64(x.BikeSpdcadAntIdTransType), "")
+	}
+	if x.BikePowerAntIdTransType != 0x00 {
+		e.field("BikePowerAntIdTransType")
+		e.writeUintUnits(uint64(x.BikePowerAntIdTransType), "")
+	}
+	if x.OdometerRollover != 0xFF {
+		e.field("OdometerRollover")
+		e.writeUintUnits(uint64(x.OdometerRollover), "")
+	}
+	if x.FrontGearNum != 0x00 {
+		e.field("FrontGearNum")
+		e.writeUintUnits(uint64(x.FrontGearNum), "")
+	}
+	if len(x.FrontGear) != 0 {
+		e.field("FrontGear")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.FrontGear {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x00:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.RearGearNum != 0x00 {
+		e.field("RearGearNum")
+		e.writeUintUnits(uint64(x.RearGearNum), "")
+	}
+	if len(x.RearGear) != 0 {
+		e.field("RearGear")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.RearGear {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x00:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.ShimanoDi2Enabled != 0xFF {
+		e.field("ShimanoDi2Enabled")
+		e.writeBoolEnum(x.ShimanoDi2Enabled)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *BikeProfileMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumBikeProfile].Interface().(BikeProfileMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "Odometer":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.Odometer = uint32(f)
+		case "BikeSpdAntId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.BikeSpdAntId = uint16(f)
+		case "BikeCadAntId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.BikeCadAntId = uint16(f)
+		case "BikeSpdcadAntId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.BikeSpdcadAntId = uint16(f)
+		case "BikePowerAntId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.BikePowerAntId = uint16(f)
+		case "CustomWheelsize":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.CustomWheelsize = uint16(f)
+		case "AutoWheelsize":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AutoWheelsize = uint16(f)
+		case "BikeWeight":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.BikeWeight = uint16(f)
+		case "PowerCalFactor":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.PowerCalFactor = uint16(f)
+		case "AutoWheelCal":
+			err = unmarshalJSONValue(v, &x.AutoWheelCal)
+		case "AutoPowerZero":
+			err = unmarshalJSONValue(v, &x.AutoPowerZero)
+		case "Id":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Id = uint8(f)
+		case "SpdEnabled":
+			err = unmarshalJSONValue(v, &x.SpdEnabled)
+		case "CadEnabled":
+			err = unmarshalJSONValue(v, &x.CadEnabled)
+		case "SpdcadEnabled":
+			err = unmarshalJSONValue(v, &x.SpdcadEnabled)
+		case "PowerEnabled":
+			err = unmarshalJSONValue(v, &x.PowerEnabled)
+		case "CrankLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, -110, 0xFF)
+			x.CrankLength = uint8(f)
+		case "Enabled":
+			err = unmarshalJSONValue(v, &x.Enabled)
+		case "BikeSpdAntIdTransType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.BikeSpdAntIdTransType = uint8(f)
+		case "BikeCadAntIdTransType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.BikeCadAntIdTransType = uint8(f)
+		case "BikeSpdcadAntIdTransType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.BikeSpdcadAntIdTransType = uint8(f)
+		case "BikePowerAntIdTransType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.BikePowerAntIdTransType = uint8(f)
+		case "OdometerRollover":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.OdometerRollover = uint8(f)
+		case "FrontGearNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.FrontGearNum = uint8(f)
+		case "FrontGear":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0x00)
+			if fs != nil {
+				x.FrontGear = make([]uint8, len(fs))
+				for i, f := range fs {
+					x.FrontGear[i] = uint8(f)
+				}
+			}
+		case "RearGearNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.RearGearNum = uint8(f)
+		case "RearGear":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0x00)
+			if fs != nil {
+				x.RearGear = make([]uint8, len(fs))
+				for i, f := range fs {
+					x.RearGear[i] = uint8(f)
+				}
+			}
+		case "ShimanoDi2Enabled":
+			err = unmarshalJSONValue(v, &x.ShimanoDi2Enabled)
+		}
+		if err != nil {
+			return jsonFieldError{"BikeProfileMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // ConnectivityMsg represents the connectivity FIT message type.
 type ConnectivityMsg struct {
 	BluetoothEnabled            Bool // Use Bluetooth for connectivity features
@@ -401,14 +1896,178 @@ type ConnectivityMsg struct {
 	GrouptrackEnabled           Bool
 }
 
+// NewConnectivityMsg returns a connectivity FIT message
+// initialized to all-invalid values.
+func NewConnectivityMsg() *ConnectivityMsg {
+	msg := msgsAllInvalid[MesgNumConnectivity].Interface().(ConnectivityMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ConnectivityMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.BluetoothEnabled != 0xFF {
+		e.field("BluetoothEnabled")
+		e.writeBoolEnum(x.BluetoothEnabled)
+	}
+	if x.BluetoothLeEnabled != 0xFF {
+		e.field("BluetoothLeEnabled")
+		e.writeBoolEnum(x.BluetoothLeEnabled)
+	}
+	if x.AntEnabled != 0xFF {
+		e.field("AntEnabled")
+		e.writeBoolEnum(x.AntEnabled)
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.LiveTrackingEnabled != 0xFF {
+		e.field("LiveTrackingEnabled")
+		e.writeBoolEnum(x.LiveTrackingEnabled)
+	}
+	if x.WeatherConditionsEnabled != 0xFF {
+		e.field("WeatherConditionsEnabled")
+		e.writeBoolEnum(x.WeatherConditionsEnabled)
+	}
+	if x.WeatherAlertsEnabled != 0xFF {
+		e.field("WeatherAlertsEnabled")
+		e.writeBoolEnum(x.WeatherAlertsEnabled)
+	}
+	if x.AutoActivityUploadEnabled != 0xFF {
+		e.field("AutoActivityUploadEnabled")
+		e.writeBoolEnum(x.AutoActivityUploadEnabled)
+	}
+	if x.CourseDownloadEnabled != 0xFF {
+		e.field("CourseDownloadEnabled")
+		e.writeBoolEnum(x.CourseDownloadEnabled)
+	}
+	if x.WorkoutDownloadEnabled != 0xFF {
+		e.field("WorkoutDownloadEnabled")
+		e.writeBoolEnum(x.WorkoutDownloadEnabled)
+	}
+	if x.GpsEphemerisDownloadEnabled != 0xFF {
+		e.field("GpsEphemerisDownloadEnabled")
+		e.writeBoolEnum(x.GpsEphemerisDownloadEnabled)
+	}
+	if x.IncidentDetectionEnabled != 0xFF {
+		e.field("IncidentDetectionEnabled")
+		e.writeBoolEnum(x.IncidentDetectionEnabled)
+	}
+	if x.GrouptrackEnabled != 0xFF {
+		e.field("GrouptrackEnabled")
+		e.writeBoolEnum(x.GrouptrackEnabled)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ConnectivityMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumConnectivity].Interface().(ConnectivityMsg)
+	for name, v := range fields {
+		switch name {
+		case "BluetoothEnabled":
+			err = unmarshalJSONValue(v, &x.BluetoothEnabled)
+		case "BluetoothLeEnabled":
+			err = unmarshalJSONValue(v, &x.BluetoothLeEnabled)
+		case "AntEnabled":
+			err = unmarshalJSONValue(v, &x.AntEnabled)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "LiveTrackingEnabled":
+			err = unmarshalJSONValue(v, &x.LiveTrackingEnabled)
+		case "WeatherConditionsEnabled":
+			err = unmarshalJSONValue(v, &x.WeatherConditionsEnabled)
+		case "WeatherAlertsEnabled":
+			err = unmarshalJSONValue(v, &x.WeatherAlertsEnabled)
+		case "AutoActivityUploadEnabled":
+			err = unmarshalJSONValue(v, &x.AutoActivityUploadEnabled)
+		case "CourseDownloadEnabled":
+			err = unmarshalJSONValue(v, &x.CourseDownloadEnabled)
+		case "WorkoutDownloadEnabled":
+			err = unmarshalJSONValue(v, &x.WorkoutDownloadEnabled)
+		case "GpsEphemerisDownloadEnabled":
+			err = unmarshalJSONValue(v, &x.GpsEphemerisDownloadEnabled)
+		case "IncidentDetectionEnabled":
+			err = unmarshalJSONValue(v, &x.IncidentDetectionEnabled)
+		case "GrouptrackEnabled":
+			err = unmarshalJSONValue(v, &x.GrouptrackEnabled)
+		}
+		if err != nil {
+			return jsonFieldError{"ConnectivityMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WatchfaceSettingsMsg represents the watchface_settings FIT message type.
 type WatchfaceSettingsMsg struct {
 }
 
+// NewWatchfaceSettingsMsg returns a watchface_settings FIT message
+// initialized to all-invalid values.
+func NewWatchfaceSettingsMsg() *WatchfaceSettingsMsg {
+	msg := msgsAllInvalid[MesgNumWatchfaceSettings].Interface().(WatchfaceSettingsMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WatchfaceSettingsMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WatchfaceSettingsMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // OhrSettingsMsg represents the ohr_settings FIT message type.
 type OhrSettingsMsg struct {
 }
 
+// NewOhrSettingsMsg returns a ohr_settings FIT message
+// initialized to all-invalid values.
+func NewOhrSettingsMsg() *OhrSettingsMsg {
+	msg := msgsAllInvalid[MesgNumOhrSettings].Interface().(OhrSettingsMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x OhrSettingsMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *OhrSettingsMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // ZonesTargetMsg represents the zones_target FIT message type.
 type ZonesTargetMsg struct {
 	MaxHeartRate             uint8
@@ -418,6 +2077,78 @@ type ZonesTargetMsg struct {
 	PwrCalcType              PwrZoneCalc
 }
 
+// NewZonesTargetMsg returns a zones_target FIT message
+// initialized to all-invalid values.
+func NewZonesTargetMsg() *ZonesTargetMsg {
+	msg := msgsAllInvalid[MesgNumZonesTarget].Interface().(ZonesTargetMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ZonesTargetMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MaxHeartRate != 0xFF {
+		e.field("MaxHeartRate")
+		e.writeUintUnits(uint64(x.MaxHeartRate), "")
+	}
+	if x.ThresholdHeartRate != 0xFF {
+		e.field("ThresholdHeartRate")
+		e.writeUintUnits(uint64(x.ThresholdHeartRate), "")
+	}
+	if x.FunctionalThresholdPower != 0xFFFF {
+		e.field("FunctionalThresholdPower")
+		e.writeUintUnits(uint64(x.FunctionalThresholdPower), "")
+	}
+	if x.HrCalcType != 0xFF {
+		e.field("HrCalcType")
+		e.writeEnum(x.HrCalcType.String(), uint64(x.HrCalcType))
+	}
+	if x.PwrCalcType != 0xFF {
+		e.field("PwrCalcType")
+		e.writeEnum(x.PwrCalcType.String(), uint64(x.PwrCalcType))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ZonesTargetMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumZonesTarget].Interface().(ZonesTargetMsg)
+	for name, v := range fields {
+		switch name {
+		case "MaxHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxHeartRate = uint8(f)
+		case "ThresholdHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ThresholdHeartRate = uint8(f)
+		case "FunctionalThresholdPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.FunctionalThresholdPower = uint16(f)
+		case "HrCalcType":
+			err = unmarshalJSONValue(v, &x.HrCalcType)
+		case "PwrCalcType":
+			err = unmarshalJSONValue(v, &x.PwrCalcType)
+		}
+		if err != nil {
+			return jsonFieldError{"ZonesTargetMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SportMsg represents the sport FIT message type.
 type SportMsg struct {
 	Sport    Sport
@@ -425,6 +2156,60 @@ type SportMsg struct {
 	Name     string
 }
 
+// NewSportMsg returns a sport FIT message
+// initialized to all-invalid values.
+func NewSportMsg() *SportMsg {
+	msg := msgsAllInvalid[MesgNumSport].Interface().(SportMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SportMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SportMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSport].Interface().(SportMsg)
+	for name, v := range fields {
+		switch name {
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		}
+		if err != nil {
+			return jsonFieldError{"SportMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // HrZoneMsg represents the hr_zone FIT message type.
 type HrZoneMsg struct {
 	MessageIndex MessageIndex
@@ -432,6 +2217,62 @@ type HrZoneMsg struct {
 	Name         string
 }
 
+// NewHrZoneMsg returns a hr_zone FIT message
+// initialized to all-invalid values.
+func NewHrZoneMsg() *HrZoneMsg {
+	msg := msgsAllInvalid[MesgNumHrZone].Interface().(HrZoneMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x HrZoneMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.HighBpm != 0xFF {
+		e.field("HighBpm")
+		e.writeUintUnits(uint64(x.HighBpm), "bpm")
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *HrZoneMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumHrZone].Interface().(HrZoneMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "HighBpm":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HighBpm = uint8(f)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		}
+		if err != nil {
+			return jsonFieldError{"HrZoneMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SpeedZoneMsg represents the speed_zone FIT message type.
 type SpeedZoneMsg struct {
 	MessageIndex MessageIndex
@@ -450,6 +2291,62 @@ func (x *SpeedZoneMsg) GetHighValueScaled() float64 {
 	return float64(x.HighValue) / 1000
 }
 
+// NewSpeedZoneMsg returns a speed_zone FIT message
+// initialized to all-invalid values.
+func NewSpeedZoneMsg() *SpeedZoneMsg {
+	msg := msgsAllInvalid[MesgNumSpeedZone].Interface().(SpeedZoneMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SpeedZoneMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.HighValue != 0xFFFF {
+		e.field("HighValue")
+		e.writeScaled(float64(x.HighValue)/1000, "m/s")
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SpeedZoneMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSpeedZone].Interface().(SpeedZoneMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "HighValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.HighValue = uint16(f)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		}
+		if err != nil {
+			return jsonFieldError{"SpeedZoneMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // CadenceZoneMsg represents the cadence_zone FIT message type.
 type CadenceZoneMsg struct {
 	MessageIndex MessageIndex
@@ -457,6 +2354,62 @@ type CadenceZoneMsg struct {
 	Name         string
 }
 
+// NewCadenceZoneMsg returns a cadence_zone FIT message
+// initialized to all-invalid values.
+func NewCadenceZoneMsg() *CadenceZoneMsg {
+	msg := msgsAllInvalid[MesgNumCadenceZone].Interface().(CadenceZoneMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x CadenceZoneMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.HighValue != 0xFF {
+		e.field("HighValue")
+		e.writeUintUnits(uint64(x.HighValue), "rpm")
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *CadenceZoneMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumCadenceZone].Interface().(CadenceZoneMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "HighValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HighValue = uint8(f)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		}
+		if err != nil {
+			return jsonFieldError{"CadenceZoneMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // PowerZoneMsg represents the power_zone FIT message type.
 type PowerZoneMsg struct {
 	MessageIndex MessageIndex
@@ -464,6 +2417,62 @@ type PowerZoneMsg struct {
 	Name         string
 }
 
+// NewPowerZoneMsg returns a power_zone FIT message
+// initialized to all-invalid values.
+func NewPowerZoneMsg() *PowerZoneMsg {
+	msg := msgsAllInvalid[MesgNumPowerZone].Interface().(PowerZoneMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x PowerZoneMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.HighValue != 0xFFFF {
+		e.field("HighValue")
+		e.writeUintUnits(uint64(x.HighValue), "watts")
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *PowerZoneMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumPowerZone].Interface().(PowerZoneMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "HighValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.HighValue = uint16(f)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		}
+		if err != nil {
+			return jsonFieldError{"PowerZoneMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // MetZoneMsg represents the met_zone FIT message type.
 type MetZoneMsg struct {
 	MessageIndex MessageIndex
@@ -494,6 +2503,72 @@ func (x *MetZoneMsg) GetFatCaloriesScaled() float64 {
 	return float64(x.FatCalories) / 10
 }
 
+// NewMetZoneMsg returns a met_zone FIT message
+// initialized to all-invalid values.
+func NewMetZoneMsg() *MetZoneMsg {
+	msg := msgsAllInvalid[MesgNumMetZone].Interface().(MetZoneMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x MetZoneMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.HighBpm != 0xFF {
+		e.field("HighBpm")
+		e.writeUintUnits(uint64(x.HighBpm), "")
+	}
+	if x.Calories != 0xFFFF {
+		e.field("Calories")
+		e.writeScaled(float64(x.Calories)/10, "kcal / min")
+	}
+	if x.FatCalories != 0xFF {
+		e.field("FatCalories")
+		e.writeScaled(float64(x.FatCalories)/10, "kcal / min")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *MetZoneMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumMetZone].Interface().(MetZoneMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "HighBpm":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HighBpm = uint8(f)
+		case "Calories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.Calories = uint16(f)
+		case "FatCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFF)
+			x.FatCalories = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"MetZoneMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // GoalMsg represents the goal FIT message type.
 type GoalMsg struct {
 	MessageIndex    MessageIndex
@@ -511,6 +2586,126 @@ type GoalMsg struct {
 	Source          GoalSource
 }
 
+// NewGoalMsg returns a goal FIT message
+// initialized to all-invalid values.
+func NewGoalMsg() *GoalMsg {
+	msg := msgsAllInvalid[MesgNumGoal].Interface().(GoalMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x GoalMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if isValidTime(x.StartDate) {
+		e.field("StartDate")
+		e.writeTime(x.StartDate)
+	}
+	if isValidTime(x.EndDate) {
+		e.field("EndDate")
+		e.writeTime(x.EndDate)
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.Value != 0xFFFFFFFF {
+		e.field("Value")
+		e.writeUintUnits(uint64(x.Value), "")
+	}
+	if x.Repeat != 0xFF {
+		e.field("Repeat")
+		e.writeBoolEnum(x.Repeat)
+	}
+	if x.TargetValue != 0xFFFFFFFF {
+		e.field("TargetValue")
+		e.writeUintUnits(uint64(x.TargetValue), "")
+	}
+	if x.Recurrence != 0xFF {
+		e.field("Recurrence")
+		e.writeEnum(x.Recurrence.String(), uint64(x.Recurrence))
+	}
+	if x.RecurrenceValue != 0xFFFF {
+		e.field("RecurrenceValue")
+		e.writeUintUnits(uint64(x.RecurrenceValue), "")
+	}
+	if x.Enabled != 0xFF {
+		e.field("Enabled")
+		e.writeBoolEnum(x.Enabled)
+	}
+	if x.Source != 0xFF {
+		e.field("Source")
+		e.writeEnum(x.Source.String(), uint64(x.Source))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *GoalMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumGoal].Interface().(GoalMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "StartDate":
+			err = unmarshalJSONValue(v, &x.StartDate)
+		case "EndDate":
+			err = unmarshalJSONValue(v, &x.EndDate)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "Value":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.Value = uint32(f)
+		case "Repeat":
+			err = unmarshalJSONValue(v, &x.Repeat)
+		case "TargetValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TargetValue = uint32(f)
+		case "Recurrence":
+			err = unmarshalJSONValue(v, &x.Recurrence)
+		case "RecurrenceValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.RecurrenceValue = uint16(f)
+		case "Enabled":
+			err = unmarshalJSONValue(v, &x.Enabled)
+		case "Source":
+			err = unmarshalJSONValue(v, &x.Source)
+		}
+		if err != nil {
+			return jsonFieldError{"GoalMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // ActivityMsg represents the activity FIT message type.
 type ActivityMsg struct {
 	Timestamp      time.Time
@@ -534,6 +2729,96 @@ func (x *ActivityMsg) GetTotalTimerTimeScaled() float64 {
 	return float64(x.TotalTimerTime) / 1000
 }
 
+// NewActivityMsg returns a activity FIT message
+// initialized to all-invalid values.
+func NewActivityMsg() *ActivityMsg {
+	msg := msgsAllInvalid[MesgNumActivity].Interface().(ActivityMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ActivityMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.TotalTimerTime != 0xFFFFFFFF {
+		e.field("TotalTimerTime")
+		e.writeScaled(float64(x.TotalTimerTime)/1000, "s")
+	}
+	if x.NumSessions != 0xFFFF {
+		e.field("NumSessions")
+		e.writeUintUnits(uint64(x.NumSessions), "")
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.Event != 0xFF {
+		e.field("Event")
+		e.writeEnum(x.Event.String(), uint64(x.Event))
+	}
+	if x.EventType != 0xFF {
+		e.field("EventType")
+		e.writeEnum(x.EventType.String(), uint64(x.EventType))
+	}
+	if isValidTime(x.LocalTimestamp) {
+		e.field("LocalTimestamp")
+		e.writeTime(x.LocalTimestamp)
+	}
+	if x.EventGroup != 0xFF {
+		e.field("EventGroup")
+		e.writeUintUnits(uint64(x.EventGroup), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ActivityMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumActivity].Interface().(ActivityMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "TotalTimerTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalTimerTime = uint32(f)
+		case "NumSessions":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumSessions = uint16(f)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "Event":
+			err = unmarshalJSONValue(v, &x.Event)
+		case "EventType":
+			err = unmarshalJSONValue(v, &x.EventType)
+		case "LocalTimestamp":
+			err = unmarshalJSONValue(v, &x.LocalTimestamp)
+		case "EventGroup":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.EventGroup = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"ActivityMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SessionMsg represents the session FIT message type.
 type SessionMsg struct {
 	MessageIndex                 MessageIndex // Selected bit is set for the current session.
@@ -1189,88 +3474,922 @@ func (x *SessionMsg) expandComponents() {
 	}
 }
 
-// LapMsg represents the lap FIT message type.
-type LapMsg struct {
-	MessageIndex                  MessageIndex
-	Timestamp                     time.Time // Lap end time.
-	Event                         Event
-	EventType                     EventType
-	StartTime                     time.Time
-	StartPositionLat              Latitude
-	StartPositionLong             Longitude
-	EndPositionLat                Latitude
-	EndPositionLong               Longitude
-	TotalElapsedTime              uint32 // Time (includes pauses)
-	TotalTimerTime                uint32 // Timer Time (excludes pauses)
-	TotalDistance                 uint32
-	TotalCycles                   uint32
-	TotalCalories                 uint16
-	TotalFatCalories              uint16 // If New Leaf
-	AvgSpeed                      uint16
-	MaxSpeed                      uint16
-	AvgHeartRate                  uint8
-	MaxHeartRate                  uint8
-	AvgCadence                    uint8 // total_cycles / total_timer_time if non_zero_avg_cadence otherwise total_cycles / total_elapsed_time
-	MaxCadence                    uint8
-	AvgPower                      uint16 // total_power / total_timer_time if non_zero_avg_power otherwise total_power / total_elapsed_time
-	MaxPower                      uint16
-	TotalAscent                   uint16
-	TotalDescent                  uint16
-	Intensity                     Intensity
-	LapTrigger                    LapTrigger
-	Sport                         Sport
-	EventGroup                    uint8
-	NumLengths                    uint16 // # of lengths of swim pool
-	NormalizedPower               uint16
-	LeftRightBalance              LeftRightBalance100
-	FirstLengthIndex              uint16
-	AvgStrokeDistance             uint16
-	SwimStroke                    SwimStroke
-	SubSport                      SubSport
-	NumActiveLengths              uint16 // # of active lengths of swim pool
-	TotalWork                     uint32
-	AvgAltitude                   uint16
-	MaxAltitude                   uint16
-	GpsAccuracy                   uint8
-	AvgGrade                      int16
-	AvgPosGrade                   int16
-	AvgNegGrade                   int16
-	MaxPosGrade                   int16
-	MaxNegGrade                   int16
-	AvgTemperature                int8
-	MaxTemperature                int8
-	TotalMovingTime               uint32
-	AvgPosVerticalSpeed           int16
-	AvgNegVerticalSpeed           int16
-	MaxPosVerticalSpeed           int16
-	MaxNegVerticalSpeed           int16
-	TimeInHrZone                  []uint32
-	TimeInSpeedZone               []uint32
-	TimeInCadenceZone             []uint32
-	TimeInPowerZone               []uint32
-	RepetitionNum                 uint16
-	MinAltitude                   uint16
-	MinHeartRate                  uint8
-	WktStepIndex                  MessageIndex
-	OpponentScore                 uint16
-	StrokeCount                   []uint16 // stroke_type enum used as the index
-	ZoneCount                     []uint16 // zone number used as the index
-	AvgVerticalOscillation        uint16
-	AvgStanceTimePercent          uint16
-	AvgStanceTime                 uint16
-	AvgFractionalCadence          uint8 // fractional part of the avg_cadence
-	MaxFractionalCadence          uint8 // fractional part of the max_cadence
-	TotalFractionalCycles         uint8 // fractional part of the total_cycles
-	PlayerScore                   uint16
-	AvgTotalHemoglobinConc        []uint16 // Avg saturated and unsaturated hemoglobin
-	MinTotalHemoglobinConc        []uint16 // Min saturated and unsaturated hemoglobin
-	MaxTotalHemoglobinConc        []uint16 // Max saturated and unsaturated hemoglobin
-	AvgSaturatedHemoglobinPercent []uint16 // Avg percentage of hemoglobin saturated with oxygen
-	MinSaturatedHemoglobinPercent []uint16 // Min percentage of hemoglobin saturated with oxygen
-	MaxSaturatedHemoglobinPercent []uint16 // Max percentage of hemoglobin saturated with oxygen
-	EnhancedAvgSpeed              uint32
-	EnhancedMaxSpeed              uint32
-	EnhancedAvgAltitude           uint32
+// NewSessionMsg returns a session FIT message
+// initialized to all-invalid values.
+func NewSessionMsg() *SessionMsg {
+	msg := msgsAllInvalid[MesgNumSession].Interface().(SessionMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SessionMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Event != 0xFF {
+		e.field("Event")
+		e.writeEnum(x.Event.String(), uint64(x.Event))
+	}
+	if x.EventType != 0xFF {
+		e.field("EventType")
+		e.writeEnum(x.EventType.String(), uint64(x.EventType))
+	}
+	if isValidTime(x.StartTime) {
+		e.field("StartTime")
+		e.writeTime(x.StartTime)
+	}
+	if !x.StartPositionLat.Invalid() {
+		e.field("StartPositionLat")
+		e.writeFloat(x.StartPositionLat.Degrees())
+	}
+	if !x.StartPositionLong.Invalid() {
+		e.field("StartPositionLong")
+		e.writeFloat(x.StartPositionLong.Degrees())
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.TotalElapsedTime != 0xFFFFFFFF {
+		e.field("TotalElapsedTime")
+		e.writeScaled(float64(x.TotalElapsedTime)/1000, "s")
+	}
+	if x.TotalTimerTime != 0xFFFFFFFF {
+		e.field("TotalTimerTime")
+		e.writeScaled(float64(x.TotalTimerTime)/1000, "s")
+	}
+	if x.TotalDistance != 0xFFFFFFFF {
+		e.field("TotalDistance")
+		e.writeScaled(float64(x.TotalDistance)/100, "m")
+	}
+	if x.TotalCycles != 0xFFFFFFFF {
+		e.field("TotalCycles")
+		e.writeUintUnits(uint64(x.TotalCycles), "cycles")
+	}
+	if x.TotalCalories != 0xFFFF {
+		e.field("TotalCalories")
+		e.writeUintUnits(uint64(x.TotalCalories), "kcal")
+	}
+	if x.TotalFatCalories != 0xFFFF {
+		e.field("TotalFatCalories")
+		e.writeUintUnits(uint64(x.TotalFatCalories), "kcal")
+	}
+	if x.AvgSpeed != 0xFFFF {
+		e.field("AvgSpeed")
+		e.writeScaled(float64(x.AvgSpeed)/1000, "m/s")
+	}
+	if x.MaxSpeed != 0xFFFF {
+		e.field("MaxSpeed")
+		e.writeScaled(float64(x.MaxSpeed)/1000, "m/s")
+	}
+	if x.AvgHeartRate != 0xFF {
+		e.field("AvgHeartRate")
+		e.writeUintUnits(uint64(x.AvgHeartRate), "bpm")
+	}
+	if x.MaxHeartRate != 0xFF {
+		e.field("MaxHeartRate")
+		e.writeUintUnits(uint64(x.MaxHeartRate), "bpm")
+	}
+	if x.AvgCadence != 0xFF {
+		e.field("AvgCadence")
+		e.writeUintUnits(uint64(x.AvgCadence), "rpm")
+	}
+	if x.MaxCadence != 0xFF {
+		e.field("MaxCadence")
+		e.writeUintUnits(uint64(x.MaxCadence), "rpm")
+	}
+	if x.AvgPower != 0xFFFF {
+		e.field("AvgPower")
+		e.writeUintUnits(uint64(x.AvgPower), "watts")
+	}
+	if x.MaxPower != 0xFFFF {
+		e.field("MaxPower")
+		e.writeUintUnits(uint64(x.MaxPower), "watts")
+	}
+	if x.TotalAscent != 0xFFFF {
+		e.field("TotalAscent")
+		e.writeUintUnits(uint64(x.TotalAscent), "m")
+	}
+	if x.TotalDescent != 0xFFFF {
+		e.field("TotalDescent")
+		e.writeUintUnits(uint64(x.TotalDescent), "m")
+	}
+	if x.TotalTrainingEffect != 0xFF {
+		e.field("TotalTrainingEffect")
+		e.writeScaled(float64(x.TotalTrainingEffect)/10, "")
+	}
+	if x.FirstLapIndex != 0xFFFF {
+		e.field("FirstLapIndex")
+		e.writeUintUnits(uint64(x.FirstLapIndex), "")
+	}
+	if x.NumLaps != 0xFFFF {
+		e.field("NumLaps")
+		e.writeUintUnits(uint64(x.NumLaps), "")
+	}
+	if x.EventGroup != 0xFF {
+		e.field("EventGroup")
+		e.writeUintUnits(uint64(x.EventGroup), "")
+	}
+	if x.Trigger != 0xFF {
+		e.field("Trigger")
+		e.writeEnum(x.Trigger.String(), uint64(x.Trigger))
+	}
+	if !x.NecLat.Invalid() {
+		e.field("NecLat")
+		e.writeFloat(x.NecLat.Degrees())
+	}
+	if !x.NecLong.Invalid() {
+		e.field("NecLong")
+		e.writeFloat(x.NecLong.Degrees())
+	}
+	if !x.SwcLat.Invalid() {
+		e.field("SwcLat")
+		e.writeFloat(x.SwcLat.Degrees())
+	}
+	if !x.SwcLong.Invalid() {
+		e.field("SwcLong")
+		e.writeFloat(x.SwcLong.Degrees())
+	}
+	if x.NormalizedPower != 0xFFFF {
+		e.field("NormalizedPower")
+		e.writeUintUnits(uint64(x.NormalizedPower), "watts")
+	}
+	if x.TrainingStressScore != 0xFFFF {
+		e.field("TrainingStressScore")
+		e.writeScaled(float64(x.TrainingStressScore)/10, "tss")
+	}
+	if x.IntensityFactor != 0xFFFF {
+		e.field("IntensityFactor")
+		e.writeScaled(float64(x.IntensityFactor)/1000, "if")
+	}
+	if x.LeftRightBalance != 0xFFFF {
+		e.field("LeftRightBalance")
+		e.writeEnum(x.LeftRightBalance.String(), uint64(x.LeftRightBalance))
+	}
+	if x.AvgStrokeCount != 0xFFFFFFFF {
+		e.field("AvgStrokeCount")
+		e.writeScaled(float64(x.AvgStrokeCount)/10, "strokes/lap")
+	}
+	if x.AvgStrokeDistance != 0xFFFF {
+		e.field("AvgStrokeDistance")
+		e.writeScaled(float64(x.AvgStrokeDistance)/100, "m")
+	}
+	if x.SwimStroke != 0xFF {
+		e.field("SwimStroke")
+		e.writeEnum(x.SwimStroke.String(), uint64(x.SwimStroke))
+	}
+	if x.PoolLength != 0xFFFF {
+		e.field("PoolLength")
+		e.writeScaled(float64(x.PoolLength)/100, "m")
+	}
+	if x.ThresholdPower != 0xFFFF {
+		e.field("ThresholdPower")
+		e.writeUintUnits(uint64(x.ThresholdPower), "watts")
+	}
+	if x.PoolLengthUnit != 0xFF {
+		e.field("PoolLengthUnit")
+		e.writeEnum(x.PoolLengthUnit.String(), uint64(x.PoolLengthUnit))
+	}
+	if x.NumActiveLengths != 0xFFFF {
+		e.field("NumActiveLengths")
+		e.writeUintUnits(uint64(x.NumActiveLengths), "lengths")
+	}
+	if x.TotalWork != 0xFFFFFFFF {
+		e.field("TotalWork")
+		e.writeUintUnits(uint64(x.TotalWork), "J")
+	}
+	if x.AvgAltitude != 0xFFFF {
+		e.field("AvgAltitude")
+		e.writeScaled(float64(x.AvgAltitude)/5-500, "m")
+	}
+	if x.MaxAltitude != 0xFFFF {
+		e.field("MaxAltitude")
+		e.writeScaled(float64(x.MaxAltitude)/5-500, "m")
+	}
+	if x.GpsAccuracy != 0xFF {
+		e.field("GpsAccuracy")
+		e.writeUintUnits(uint64(x.GpsAccuracy), "m")
+	}
+	if x.AvgGrade != 0x7FFF {
+		e.field("AvgGrade")
+		e.writeScaled(float64(x.AvgGrade)/100, "%")
+	}
+	if x.AvgPosGrade != 0x7FFF {
+		e.field("AvgPosGrade")
+		e.writeScaled(float64(x.AvgPosGrade)/100, "%")
+	}
+	if x.AvgNegGrade != 0x7FFF {
+		e.field("AvgNegGrade")
+		e.writeScaled(float64(x.AvgNegGrade)/100, "%")
+	}
+	if x.MaxPosGrade != 0x7FFF {
+		e.field("MaxPosGrade")
+		e.writeScaled(float64(x.MaxPosGrade)/100, "%")
+	}
+	if x.MaxNegGrade != 0x7FFF {
+		e.field("MaxNegGrade")
+		e.writeScaled(float64(x.MaxNegGrade)/100, "%")
+	}
+	if x.AvgTemperature != 0x7F {
+		e.field("AvgTemperature")
+		e.writeIntUnits(int64(x.AvgTemperature), "C")
+	}
+	if x.MaxTemperature != 0x7F {
+		e.field("MaxTemperature")
+		e.writeIntUnits(int64(x.MaxTemperature), "C")
+	}
+	if x.TotalMovingTime != 0xFFFFFFFF {
+		e.field("TotalMovingTime")
+		e.writeScaled(float64(x.TotalMovingTime)/1000, "s")
+	}
+	if x.AvgPosVerticalSpeed != 0x7FFF {
+		e.field("AvgPosVerticalSpeed")
+		e.writeScaled(float64(x.AvgPosVerticalSpeed)/1000, "m/s")
+	}
+	if x.AvgNegVerticalSpeed != 0x7FFF {
+		e.field("AvgNegVerticalSpeed")
+		e.writeScaled(float64(x.AvgNegVerticalSpeed)/1000, "m/s")
+	}
+	if x.MaxPosVerticalSpeed != 0x7FFF {
+		e.field("MaxPosVerticalSpeed")
+		e.writeScaled(float64(x.MaxPosVerticalSpeed)/1000, "m/s")
+	}
+	if x.MaxNegVerticalSpeed != 0x7FFF {
+		e.field("MaxNegVerticalSpeed")
+		e.writeScaled(float64(x.MaxNegVerticalSpeed)/1000, "m/s")
+	}
+	if x.MinHeartRate != 0xFF {
+		e.field("MinHeartRate")
+		e.writeUintUnits(uint64(x.MinHeartRate), "bpm")
+	}
+	if len(x.TimeInHrZone) != 0 {
+		e.field("TimeInHrZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInHrZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInSpeedZone) != 0 {
+		e.field("TimeInSpeedZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInSpeedZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInCadenceZone) != 0 {
+		e.field("TimeInCadenceZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInCadenceZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInPowerZone) != 0 {
+		e.field("TimeInPowerZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInPowerZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if x.AvgLapTime != 0xFFFFFFFF {
+		e.field("AvgLapTime")
+		e.writeScaled(float64(x.AvgLapTime)/1000, "s")
+	}
+	if x.BestLapIndex != 0xFFFF {
+		e.field("BestLapIndex")
+		e.writeUintUnits(uint64(x.BestLapIndex), "")
+	}
+	if x.MinAltitude != 0xFFFF {
+		e.field("MinAltitude")
+		e.writeScaled(float64(x.MinAltitude)/5-500, "m")
+	}
+	if x.PlayerScore != 0xFFFF {
+		e.field("PlayerScore")
+		e.writeUintUnits(uint64(x.PlayerScore), "")
+	}
+	if x.OpponentScore != 0xFFFF {
+		e.field("OpponentScore")
+		e.writeUintUnits(uint64(x.OpponentScore), "")
+	}
+	if x.OpponentName != "" {
+		e.field("OpponentName")
+		e.writeString(x.OpponentName)
+	}
+	if len(x.StrokeCount) != 0 {
+		e.field("StrokeCount")
+		e.openUnits("counts")
+		e.openArray()
+		for i, v := range x.StrokeCount {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("counts")
+	}
+	if len(x.ZoneCount) != 0 {
+		e.field("ZoneCount")
+		e.openUnits("counts")
+		e.openArray()
+		for i, v := range x.ZoneCount {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("counts")
+	}
+	if x.MaxBallSpeed != 0xFFFF {
+		e.field("MaxBallSpeed")
+		e.writeScaled(float64(x.MaxBallSpeed)/100, "m/s")
+	}
+	if x.AvgBallSpeed != 0xFFFF {
+		e.field("AvgBallSpeed")
+		e.writeScaled(float64(x.AvgBallSpeed)/100, "m/s")
+	}
+	if x.AvgVerticalOscillation != 0xFFFF {
+		e.field("AvgVerticalOscillation")
+		e.writeScaled(float64(x.AvgVerticalOscillation)/10, "mm")
+	}
+	if x.AvgStanceTimePercent != 0xFFFF {
+		e.field("AvgStanceTimePercent")
+		e.writeScaled(float64(x.AvgStanceTimePercent)/100, "percent")
+	}
+	if x.AvgStanceTime != 0xFFFF {
+		e.field("AvgStanceTime")
+		e.writeScaled(float64(x.AvgStanceTime)/10, "ms")
+	}
+	if x.AvgFractionalCadence != 0xFF {
+		e.field("AvgFractionalCadence")
+		e.writeScaled(float64(x.AvgFractionalCadence)/128, "rpm")
+	}
+	if x.MaxFractionalCadence != 0xFF {
+		e.field("MaxFractionalCadence")
+		e.writeScaled(float64(x.MaxFractionalCadence)/128, "rpm")
+	}
+	if x.TotalFractionalCycles != 0xFF {
+		e.field("TotalFractionalCycles")
+		e.writeScaled(float64(x.TotalFractionalCycles)/128, "cycles")
+	}
+	if x.SportIndex != 0xFF {
+		e.field("SportIndex")
+		e.writeUintUnits(uint64(x.SportIndex), "")
+	}
+	if x.EnhancedAvgSpeed != 0xFFFFFFFF {
+		e.field("EnhancedAvgSpeed")
+		e.writeScaled(float64(x.EnhancedAvgSpeed)/1000, "m/s")
+	}
+	if x.EnhancedMaxSpeed != 0xFFFFFFFF {
+		e.field("EnhancedMaxSpeed")
+		e.writeScaled(float64(x.EnhancedMaxSpeed)/1000, "m/s")
+	}
+	if x.EnhancedAvgAltitude != 0xFFFFFFFF {
+		e.field("EnhancedAvgAltitude")
+		e.writeScaled(float64(x.EnhancedAvgAltitude)/5-500, "m")
+	}
+	if x.EnhancedMinAltitude != 0xFFFFFFFF {
+		e.field("EnhancedMinAltitude")
+		e.writeScaled(float64(x.EnhancedMinAltitude)/5-500, "m")
+	}
+	if x.EnhancedMaxAltitude != 0xFFFFFFFF {
+		e.field("EnhancedMaxAltitude")
+		e.writeScaled(float64(x.EnhancedMaxAltitude)/5-500, "m")
+	}
+	if x.TotalAnaerobicTrainingEffect != 0xFF {
+		e.field("TotalAnaerobicTrainingEffect")
+		e.writeScaled(float64(x.TotalAnaerobicTrainingEffect)/10, "")
+	}
+	if x.AvgVam != 0xFFFF {
+		e.field("AvgVam")
+		e.writeScaled(float64(x.AvgVam)/1000, "m/s")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SessionMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSession].Interface().(SessionMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Event":
+			err = unmarshalJSONValue(v, &x.Event)
+		case "EventType":
+			err = unmarshalJSONValue(v, &x.EventType)
+		case "StartTime":
+			err = unmarshalJSONValue(v, &x.StartTime)
+		case "StartPositionLat":
+			err = unmarshalJSONValue(v, &x.StartPositionLat)
+		case "StartPositionLong":
+			err = unmarshalJSONValue(v, &x.StartPositionLong)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "TotalElapsedTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalElapsedTime = uint32(f)
+		case "TotalTimerTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalTimerTime = uint32(f)
+		case "TotalDistance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.TotalDistance = uint32(f)
+		case "TotalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalCycles = uint32(f)
+		case "TotalCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalCalories = uint16(f)
+		case "TotalFatCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalFatCalories = uint16(f)
+		case "AvgSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AvgSpeed = uint16(f)
+		case "MaxSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.MaxSpeed = uint16(f)
+		case "AvgHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgHeartRate = uint8(f)
+		case "MaxHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxHeartRate = uint8(f)
+		case "AvgCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgCadence = uint8(f)
+		case "MaxCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxCadence = uint8(f)
+		case "AvgPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.AvgPower = uint16(f)
+		case "MaxPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MaxPower = uint16(f)
+		case "TotalAscent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalAscent = uint16(f)
+		case "TotalDescent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalDescent = uint16(f)
+		case "TotalTrainingEffect":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFF)
+			x.TotalTrainingEffect = uint8(f)
+		case "FirstLapIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.FirstLapIndex = uint16(f)
+		case "NumLaps":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumLaps = uint16(f)
+		case "EventGroup":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.EventGroup = uint8(f)
+		case "Trigger":
+			err = unmarshalJSONValue(v, &x.Trigger)
+		case "NecLat":
+			err = unmarshalJSONValue(v, &x.NecLat)
+		case "NecLong":
+			err = unmarshalJSONValue(v, &x.NecLong)
+		case "SwcLat":
+			err = unmarshalJSONValue(v, &x.SwcLat)
+		case "SwcLong":
+			err = unmarshalJSONValue(v, &x.SwcLong)
+		case "NormalizedPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NormalizedPower = uint16(f)
+		case "TrainingStressScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.TrainingStressScore = uint16(f)
+		case "IntensityFactor":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.IntensityFactor = uint16(f)
+		case "LeftRightBalance":
+			err = unmarshalJSONValue(v, &x.LeftRightBalance)
+		case "AvgStrokeCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFFFFFF)
+			x.AvgStrokeCount = uint32(f)
+		case "AvgStrokeDistance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.AvgStrokeDistance = uint16(f)
+		case "SwimStroke":
+			err = unmarshalJSONValue(v, &x.SwimStroke)
+		case "PoolLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.PoolLength = uint16(f)
+		case "ThresholdPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.ThresholdPower = uint16(f)
+		case "PoolLengthUnit":
+			err = unmarshalJSONValue(v, &x.PoolLengthUnit)
+		case "NumActiveLengths":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumActiveLengths = uint16(f)
+		case "TotalWork":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalWork = uint32(f)
+		case "AvgAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.AvgAltitude = uint16(f)
+		case "MaxAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.MaxAltitude = uint16(f)
+		case "GpsAccuracy":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.GpsAccuracy = uint8(f)
+		case "AvgGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgGrade = int16(f)
+		case "AvgPosGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgPosGrade = int16(f)
+		case "AvgNegGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgNegGrade = int16(f)
+		case "MaxPosGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.MaxPosGrade = int16(f)
+		case "MaxNegGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.MaxNegGrade = int16(f)
+		case "AvgTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.AvgTemperature = int8(f)
+		case "MaxTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.MaxTemperature = int8(f)
+		case "TotalMovingTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalMovingTime = uint32(f)
+		case "AvgPosVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.AvgPosVerticalSpeed = int16(f)
+		case "AvgNegVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.AvgNegVerticalSpeed = int16(f)
+		case "MaxPosVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.MaxPosVerticalSpeed = int16(f)
+		case "MaxNegVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.MaxNegVerticalSpeed = int16(f)
+		case "MinHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MinHeartRate = uint8(f)
+		case "TimeInHrZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInHrZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInHrZone[i] = uint32(f)
+				}
+			}
+		case "TimeInSpeedZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInSpeedZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInSpeedZone[i] = uint32(f)
+				}
+			}
+		case "TimeInCadenceZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInCadenceZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInCadenceZone[i] = uint32(f)
+				}
+			}
+		case "TimeInPowerZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInPowerZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInPowerZone[i] = uint32(f)
+				}
+			}
+		case "AvgLapTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.AvgLapTime = uint32(f)
+		case "BestLapIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.BestLapIndex = uint16(f)
+		case "MinAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.MinAltitude = uint16(f)
+		case "PlayerScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.PlayerScore = uint16(f)
+		case "OpponentScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.OpponentScore = uint16(f)
+		case "OpponentName":
+			err = unmarshalJSONValue(v, &x.OpponentName)
+		case "StrokeCount":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.StrokeCount = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.StrokeCount[i] = uint16(f)
+				}
+			}
+		case "ZoneCount":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.ZoneCount = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.ZoneCount[i] = uint16(f)
+				}
+			}
+		case "MaxBallSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.MaxBallSpeed = uint16(f)
+		case "AvgBallSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.AvgBallSpeed = uint16(f)
+		case "AvgVerticalOscillation":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.AvgVerticalOscillation = uint16(f)
+		case "AvgStanceTimePercent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.AvgStanceTimePercent = uint16(f)
+		case "AvgStanceTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.AvgStanceTime = uint16(f)
+		case "AvgFractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.AvgFractionalCadence = uint8(f)
+		case "MaxFractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.MaxFractionalCadence = uint8(f)
+		case "TotalFractionalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.TotalFractionalCycles = uint8(f)
+		case "SportIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.SportIndex = uint8(f)
+		case "EnhancedAvgSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.EnhancedAvgSpeed = uint32(f)
+		case "EnhancedMaxSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.EnhancedMaxSpeed = uint32(f)
+		case "EnhancedAvgAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedAvgAltitude = uint32(f)
+		case "EnhancedMinAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedMinAltitude = uint32(f)
+		case "EnhancedMaxAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedMaxAltitude = uint32(f)
+		case "TotalAnaerobicTrainingEffect":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFF)
+			x.TotalAnaerobicTrainingEffect = uint8(f)
+		case "AvgVam":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AvgVam = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"SessionMsg", name, err}
+		}
+	}
+	return nil
+}
+
+// LapMsg represents the lap FIT message type.
+type LapMsg struct {
+	MessageIndex                  MessageIndex
+	Timestamp                     time.Time // Lap end time.
+	Event                         Event
+	EventType                     EventType
+	StartTime                     time.Time
+	StartPositionLat              Latitude
+	StartPositionLong             Longitude
+	EndPositionLat                Latitude
+	EndPositionLong               Longitude
+	TotalElapsedTime              uint32 // Time (includes pauses)
+	TotalTimerTime                uint32 // Timer Time (excludes pauses)
+	TotalDistance                 uint32
+	TotalCycles                   uint32
+	TotalCalories                 uint16
+	TotalFatCalories              uint16 // If New Leaf
+	AvgSpeed                      uint16
+	MaxSpeed                      uint16
+	AvgHeartRate                  uint8
+	MaxHeartRate                  uint8
+	AvgCadence                    uint8 // total_cycles / total_timer_time if non_zero_avg_cadence otherwise total_cycles / total_elapsed_time
+	MaxCadence                    uint8
+	AvgPower                      uint16 // total_power / total_timer_time if non_zero_avg_power otherwise total_power / total_elapsed_time
+	MaxPower                      uint16
+	TotalAscent                   uint16
+	TotalDescent                  uint16
+	Intensity                     Intensity
+	LapTrigger                    LapTrigger
+	Sport                         Sport
+	EventGroup                    uint8
+	NumLengths                    uint16 // # of lengths of swim pool
+	NormalizedPower               uint16
+	LeftRightBalance              LeftRightBalance100
+	FirstLengthIndex              uint16
+	AvgStrokeDistance             uint16
+	SwimStroke                    SwimStroke
+	SubSport                      SubSport
+	NumActiveLengths              uint16 // # of active lengths of swim pool
+	TotalWork                     uint32
+	AvgAltitude                   uint16
+	MaxAltitude                   uint16
+	GpsAccuracy                   uint8
+	AvgGrade                      int16
+	AvgPosGrade                   int16
+	AvgNegGrade                   int16
+	MaxPosGrade                   int16
+	MaxNegGrade                   int16
+	AvgTemperature                int8
+	MaxTemperature                int8
+	TotalMovingTime               uint32
+	AvgPosVerticalSpeed           int16
+	AvgNegVerticalSpeed           int16
+	MaxPosVerticalSpeed           int16
+	MaxNegVerticalSpeed           int16
+	TimeInHrZone                  []uint32
+	TimeInSpeedZone               []uint32
+	TimeInCadenceZone             []uint32
+	TimeInPowerZone               []uint32
+	RepetitionNum                 uint16
+	MinAltitude                   uint16
+	MinHeartRate                  uint8
+	WktStepIndex                  MessageIndex
+	OpponentScore                 uint16
+	StrokeCount                   []uint16 // stroke_type enum used as the index
+	ZoneCount                     []uint16 // zone number used as the index
+	AvgVerticalOscillation        uint16
+	AvgStanceTimePercent          uint16
+	AvgStanceTime                 uint16
+	AvgFractionalCadence          uint8 // fractional part of the avg_cadence
+	MaxFractionalCadence          uint8 // fractional part of the max_cadence
+	TotalFractionalCycles         uint8 // fractional part of the total_cycles
+	PlayerScore                   uint16
+	AvgTotalHemoglobinConc        []uint16 // Avg saturated and unsaturated hemoglobin
+	MinTotalHemoglobinConc        []uint16 // Min saturated and unsaturated hemoglobin
+	MaxTotalHemoglobinConc        []uint16 // Max saturated and unsaturated hemoglobin
+	AvgSaturatedHemoglobinPercent []uint16 // Avg percentage of hemoglobin saturated with oxygen
+	MinSaturatedHemoglobinPercent []uint16 // Min percentage of hemoglobin saturated with oxygen
+	MaxSaturatedHemoglobinPercent []uint16 // Max percentage of hemoglobin saturated with oxygen
+	EnhancedAvgSpeed              uint32
+	EnhancedMaxSpeed              uint32
+	EnhancedAvgAltitude           uint32
 	EnhancedMinAltitude           uint32
 	EnhancedMaxAltitude           uint32
 	AvgVam                        uint16
@@ -1824,6 +4943,902 @@ func (x *LapMsg) expandComponents() {
 	}
 }
 
+// NewLapMsg returns a lap FIT message
+// initialized to all-invalid values.
+func NewLapMsg() *LapMsg {
+	msg := msgsAllInvalid[MesgNumLap].Interface().(LapMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x LapMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Event != 0xFF {
+		e.field("Event")
+		e.writeEnum(x.Event.String(), uint64(x.Event))
+	}
+	if x.EventType != 0xFF {
+		e.field("EventType")
+		e.writeEnum(x.EventType.String(), uint64(x.EventType))
+	}
+	if isValidTime(x.StartTime) {
+		e.field("StartTime")
+		e.writeTime(x.StartTime)
+	}
+	if !x.StartPositionLat.Invalid() {
+		e.field("StartPositionLat")
+		e.writeFloat(x.StartPositionLat.Degrees())
+	}
+	if !x.StartPositionLong.Invalid() {
+		e.field("StartPositionLong")
+		e.writeFloat(x.StartPositionLong.Degrees())
+	}
+	if !x.EndPositionLat.Invalid() {
+		e.field("EndPositionLat")
+		e.writeFloat(x.EndPositionLat.Degrees())
+	}
+	if !x.EndPositionLong.Invalid() {
+		e.field("EndPositionLong")
+		e.writeFloat(x.EndPositionLong.Degrees())
+	}
+	if x.TotalElapsedTime != 0xFFFFFFFF {
+		e.field("TotalElapsedTime")
+		e.writeScaled(float64(x.TotalElapsedTime)/1000, "s")
+	}
+	if x.TotalTimerTime != 0xFFFFFFFF {
+		e.field("TotalTimerTime")
+		e.writeScaled(float64(x.TotalTimerTime)/1000, "s")
+	}
+	if x.TotalDistance != 0xFFFFFFFF {
+		e.field("TotalDistance")
+		e.writeScaled(float64(x.TotalDistance)/100, "m")
+	}
+	if x.TotalCycles != 0xFFFFFFFF {
+		e.field("TotalCycles")
+		e.writeUintUnits(uint64(x.TotalCycles), "cycles")
+	}
+	if x.TotalCalories != 0xFFFF {
+		e.field("TotalCalories")
+		e.writeUintUnits(uint64(x.TotalCalories), "kcal")
+	}
+	if x.TotalFatCalories != 0xFFFF {
+		e.field("TotalFatCalories")
+		e.writeUintUnits(uint64(x.TotalFatCalories), "kcal")
+	}
+	if x.AvgSpeed != 0xFFFF {
+		e.field("AvgSpeed")
+		e.writeScaled(float64(x.AvgSpeed)/1000, "m/s")
+	}
+	if x.MaxSpeed != 0xFFFF {
+		e.field("MaxSpeed")
+		e.writeScaled(float64(x.MaxSpeed)/1000, "m/s")
+	}
+	if x.AvgHeartRate != 0xFF {
+		e.field("AvgHeartRate")
+		e.writeUintUnits(uint64(x.AvgHeartRate), "bpm")
+	}
+	if x.MaxHeartRate != 0xFF {
+		e.field("MaxHeartRate")
+		e.writeUintUnits(uint64(x.MaxHeartRate), "bpm")
+	}
+	if x.AvgCadence != 0xFF {
+		e.field("AvgCadence")
+		e.writeUintUnits(uint64(x.AvgCadence), "rpm")
+	}
+	if x.MaxCadence != 0xFF {
+		e.field("MaxCadence")
+		e.writeUintUnits(uint64(x.MaxCadence), "rpm")
+	}
+	if x.AvgPower != 0xFFFF {
+		e.field("AvgPower")
+		e.writeUintUnits(uint64(x.AvgPower), "watts")
+	}
+	if x.MaxPower != 0xFFFF {
+		e.field("MaxPower")
+		e.writeUintUnits(uint64(x.MaxPower), "watts")
+	}
+	if x.TotalAscent != 0xFFFF {
+		e.field("TotalAscent")
+		e.writeUintUnits(uint64(x.TotalAscent), "m")
+	}
+	if x.TotalDescent != 0xFFFF {
+		e.field("TotalDescent")
+		e.writeUintUnits(uint64(x.TotalDescent), "m")
+	}
+	if x.Intensity != 0xFF {
+		e.field("Intensity")
+		e.writeEnum(x.Intensity.String(), uint64(x.Intensity))
+	}
+	if x.LapTrigger != 0xFF {
+		e.field("LapTrigger")
+		e.writeEnum(x.LapTrigger.String(), uint64(x.LapTrigger))
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.EventGroup != 0xFF {
+		e.field("EventGroup")
+		e.writeUintUnits(uint64(x.EventGroup), "")
+	}
+	if x.NumLengths != 0xFFFF {
+		e.field("NumLengths")
+		e.writeUintUnits(uint64(x.NumLengths), "lengths")
+	}
+	if x.NormalizedPower != 0xFFFF {
+		e.field("NormalizedPower")
+		e.writeUintUnits(uint64(x.NormalizedPower), "watts")
+	}
+	if x.LeftRightBalance != 0xFFFF {
+		e.field("LeftRightBalance")
+		e.writeEnum(x.LeftRightBalance.String(), uint64(x.LeftRightBalance))
+	}
+	if x.FirstLengthIndex != 0xFFFF {
+		e.field("FirstLengthIndex")
+		e.writeUintUnits(uint64(x.FirstLengthIndex), "")
+	}
+	if x.AvgStrokeDistance != 0xFFFF {
+		e.field("AvgStrokeDistance")
+		e.writeScaled(float64(x.AvgStrokeDistance)/100, "m")
+	}
+	if x.SwimStroke != 0xFF {
+		e.field("SwimStroke")
+		e.writeEnum(x.SwimStroke.String(), uint64(x.SwimStroke))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.NumActiveLengths != 0xFFFF {
+		e.field("NumActiveLengths")
+		e.writeUintUnits(uint64(x.NumActiveLengths), "lengths")
+	}
+	if x.TotalWork != 0xFFFFFFFF {
+		e.field("TotalWork")
+		e.writeUintUnits(uint64(x.TotalWork), "J")
+	}
+	if x.AvgAltitude != 0xFFFF {
+		e.field("AvgAltitude")
+		e.writeScaled(float64(x.AvgAltitude)/5-500, "m")
+	}
+	if x.MaxAltitude != 0xFFFF {
+		e.field("MaxAltitude")
+		e.writeScaled(float64(x.MaxAltitude)/5-500, "m")
+	}
+	if x.GpsAccuracy != 0xFF {
+		e.field("GpsAccuracy")
+		e.writeUintUnits(uint64(x.GpsAccuracy), "m")
+	}
+	if x.AvgGrade != 0x7FFF {
+		e.field("AvgGrade")
+		e.writeScaled(float64(x.AvgGrade)/100, "%")
+	}
+	if x.AvgPosGrade != 0x7FFF {
+		e.field("AvgPosGrade")
+		e.writeScaled(float64(x.AvgPosGrade)/100, "%")
+	}
+	if x.AvgNegGrade != 0x7FFF {
+		e.field("AvgNegGrade")
+		e.writeScaled(float64(x.AvgNegGrade)/100, "%")
+	}
+	if x.MaxPosGrade != 0x7FFF {
+		e.field("MaxPosGrade")
+		e.writeScaled(float64(x.MaxPosGrade)/100, "%")
+	}
+	if x.MaxNegGrade != 0x7FFF {
+		e.field("MaxNegGrade")
+		e.writeScaled(float64(x.MaxNegGrade)/100, "%")
+	}
+	if x.AvgTemperature != 0x7F {
+		e.field("AvgTemperature")
+		e.writeIntUnits(int64(x.AvgTemperature), "C")
+	}
+	if x.MaxTemperature != 0x7F {
+		e.field("MaxTemperature")
+		e.writeIntUnits(int64(x.MaxTemperature), "C")
+	}
+	if x.TotalMovingTime != 0xFFFFFFFF {
+		e.field("TotalMovingTime")
+		e.writeScaled(float64(x.TotalMovingTime)/1000, "s")
+	}
+	if x.AvgPosVerticalSpeed != 0x7FFF {
+		e.field("AvgPosVerticalSpeed")
+		e.writeScaled(float64(x.AvgPosVerticalSpeed)/1000, "m/s")
+	}
+	if x.AvgNegVerticalSpeed != 0x7FFF {
+		e.field("AvgNegVerticalSpeed")
+		e.writeScaled(float64(x.AvgNegVerticalSpeed)/1000, "m/s")
+	}
+	if x.MaxPosVerticalSpeed != 0x7FFF {
+		e.field("MaxPosVerticalSpeed")
+		e.writeScaled(float64(x.MaxPosVerticalSpeed)/1000, "m/s")
+	}
+	if x.MaxNegVerticalSpeed != 0x7FFF {
+		e.field("MaxNegVerticalSpeed")
+		e.writeScaled(float64(x.MaxNegVerticalSpeed)/1000, "m/s")
+	}
+	if len(x.TimeInHrZone) != 0 {
+		e.field("TimeInHrZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInHrZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInSpeedZone) != 0 {
+		e.field("TimeInSpeedZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInSpeedZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInCadenceZone) != 0 {
+		e.field("TimeInCadenceZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInCadenceZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInPowerZone) != 0 {
+		e.field("TimeInPowerZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInPowerZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if x.RepetitionNum != 0xFFFF {
+		e.field("RepetitionNum")
+		e.writeUintUnits(uint64(x.RepetitionNum), "")
+	}
+	if x.MinAltitude != 0xFFFF {
+		e.field("MinAltitude")
+		e.writeScaled(float64(x.MinAltitude)/5-500, "m")
+	}
+	if x.MinHeartRate != 0xFF {
+		e.field("MinHeartRate")
+		e.writeUintUnits(uint64(x.MinHeartRate), "bpm")
+	}
+	if x.WktStepIndex != 0xFFFF {
+		e.field("WktStepIndex")
+		e.writeEnum(x.WktStepIndex.String(), uint64(x.WktStepIndex))
+	}
+	if x.OpponentScore != 0xFFFF {
+		e.field("OpponentScore")
+		e.writeUintUnits(uint64(x.OpponentScore), "")
+	}
+	if len(x.StrokeCount) != 0 {
+		e.field("StrokeCount")
+		e.openUnits("counts")
+		e.openArray()
+		for i, v := range x.StrokeCount {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("counts")
+	}
+	if len(x.ZoneCount) != 0 {
+		e.field("ZoneCount")
+		e.openUnits("counts")
+		e.openArray()
+		for i, v := range x.ZoneCount {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("counts")
+	}
+	if x.AvgVerticalOscillation != 0xFFFF {
+		e.field("AvgVerticalOscillation")
+		e.writeScaled(float64(x.AvgVerticalOscillation)/10, "mm")
+	}
+	if x.AvgStanceTimePercent != 0xFFFF {
+		e.field("AvgStanceTimePercent")
+		e.writeScaled(float64(x.AvgStanceTimePercent)/100, "percent")
+	}
+	if x.AvgStanceTime != 0xFFFF {
+		e.field("AvgStanceTime")
+		e.writeScaled(float64(x.AvgStanceTime)/10, "ms")
+	}
+	if x.AvgFractionalCadence != 0xFF {
+		e.field("AvgFractionalCadence")
+		e.writeScaled(float64(x.AvgFractionalCadence)/128, "rpm")
+	}
+	if x.MaxFractionalCadence != 0xFF {
+		e.field("MaxFractionalCadence")
+		e.writeScaled(float64(x.MaxFractionalCadence)/128, "rpm")
+	}
+	if x.TotalFractionalCycles != 0xFF {
+		e.field("TotalFractionalCycles")
+		e.writeScaled(float64(x.TotalFractionalCycles)/128, "cycles")
+	}
+	if x.PlayerScore != 0xFFFF {
+		e.field("PlayerScore")
+		e.writeUintUnits(uint64(x.PlayerScore), "")
+	}
+	if len(x.AvgTotalHemoglobinConc) != 0 {
+		e.field("AvgTotalHemoglobinConc")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.AvgTotalHemoglobinConc {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 100)
+			}
+		}
+		e.closeArray()
+		e.closeValue("g/dL")
+	}
+	if len(x.MinTotalHemoglobinConc) != 0 {
+		e.field("MinTotalHemoglobinConc")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.MinTotalHemoglobinConc {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 100)
+			}
+		}
+		e.closeArray()
+		e.closeValue("g/dL")
+	}
+	if len(x.MaxTotalHemoglobinConc) != 0 {
+		e.field("MaxTotalHemoglobinConc")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.MaxTotalHemoglobinConc {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 100)
+			}
+		}
+		e.closeArray()
+		e.closeValue("g/dL")
+	}
+	if len(x.AvgSaturatedHemoglobinPercent) != 0 {
+		e.field("AvgSaturatedHemoglobinPercent")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.AvgSaturatedHemoglobinPercent {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 10)
+			}
+		}
+		e.closeArray()
+		e.closeValue("%")
+	}
+	if len(x.MinSaturatedHemoglobinPercent) != 0 {
+		e.field("MinSaturatedHemoglobinPercent")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.MinSaturatedHemoglobinPercent {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 10)
+			}
+		}
+		e.closeArray()
+		e.closeValue("%")
+	}
+	if len(x.MaxSaturatedHemoglobinPercent) != 0 {
+		e.field("MaxSaturatedHemoglobinPercent")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.MaxSaturatedHemoglobinPercent {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 10)
+			}
+		}
+		e.closeArray()
+		e.closeValue("%")
+	}
+	if x.EnhancedAvgSpeed != 0xFFFFFFFF {
+		e.field("EnhancedAvgSpeed")
+		e.writeScaled(float64(x.EnhancedAvgSpeed)/1000, "m/s")
+	}
+	if x.EnhancedMaxSpeed != 0xFFFFFFFF {
+		e.field("EnhancedMaxSpeed")
+		e.writeScaled(float64(x.EnhancedMaxSpeed)/1000, "m/s")
+	}
+	if x.EnhancedAvgAltitude != 0xFFFFFFFF {
+		e.field("EnhancedAvgAltitude")
+		e.writeScaled(float64(x.EnhancedAvgAltitude)/5-500, "m")
+	}
+	if x.EnhancedMinAltitude != 0xFFFFFFFF {
+		e.field("EnhancedMinAltitude")
+		e.writeScaled(float64(x.EnhancedMinAltitude)/5-500, "m")
+	}
+	if x.EnhancedMaxAltitude != 0xFFFFFFFF {
+		e.field("EnhancedMaxAltitude")
+		e.writeScaled(float64(x.EnhancedMaxAltitude)/5-500, "m")
+	}
+	if x.AvgVam != 0xFFFF {
+		e.field("AvgVam")
+		e.writeScaled(float64(x.AvgVam)/1000, "m/s")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *LapMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumLap].Interface().(LapMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Event":
+			err = unmarshalJSONValue(v, &x.Event)
+		case "EventType":
+			err = unmarshalJSONValue(v, &x.EventType)
+		case "StartTime":
+			err = unmarshalJSONValue(v, &x.StartTime)
+		case "StartPositionLat":
+			err = unmarshalJSONValue(v, &x.StartPositionLat)
+		case "StartPositionLong":
+			err = unmarshalJSONValue(v, &x.StartPositionLong)
+		case "EndPositionLat":
+			err = unmarshalJSONValue(v, &x.EndPositionLat)
+		case "EndPositionLong":
+			err = unmarshalJSONValue(v, &x.EndPositionLong)
+		case "TotalElapsedTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalElapsedTime = uint32(f)
+		case "TotalTimerTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalTimerTime = uint32(f)
+		case "TotalDistance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.TotalDistance = uint32(f)
+		case "TotalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalCycles = uint32(f)
+		case "TotalCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalCalories = uint16(f)
+		case "TotalFatCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalFatCalories = uint16(f)
+		case "AvgSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AvgSpeed = uint16(f)
+		case "MaxSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.MaxSpeed = uint16(f)
+		case "AvgHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgHeartRate = uint8(f)
+		case "MaxHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxHeartRate = uint8(f)
+		case "AvgCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgCadence = uint8(f)
+		case "MaxCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxCadence = uint8(f)
+		case "AvgPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.AvgPower = uint16(f)
+		case "MaxPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MaxPower = uint16(f)
+		case "TotalAscent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalAscent = uint16(f)
+		case "TotalDescent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalDescent = uint16(f)
+		case "Intensity":
+			err = unmarshalJSONValue(v, &x.Intensity)
+		case "LapTrigger":
+			err = unmarshalJSONValue(v, &x.LapTrigger)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "EventGroup":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.EventGroup = uint8(f)
+		case "NumLengths":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumLengths = uint16(f)
+		case "NormalizedPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NormalizedPower = uint16(f)
+		case "LeftRightBalance":
+			err = unmarshalJSONValue(v, &x.LeftRightBalance)
+		case "FirstLengthIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.FirstLengthIndex = uint16(f)
+		case "AvgStrokeDistance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.AvgStrokeDistance = uint16(f)
+		case "SwimStroke":
+			err = unmarshalJSONValue(v, &x.SwimStroke)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "NumActiveLengths":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumActiveLengths = uint16(f)
+		case "TotalWork":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalWork = uint32(f)
+		case "AvgAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.AvgAltitude = uint16(f)
+		case "MaxAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.MaxAltitude = uint16(f)
+		case "GpsAccuracy":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.GpsAccuracy = uint8(f)
+		case "AvgGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgGrade = int16(f)
+		case "AvgPosGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgPosGrade = int16(f)
+		case "AvgNegGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgNegGrade = int16(f)
+		case "MaxPosGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.MaxPosGrade = int16(f)
+		case "MaxNegGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.MaxNegGrade = int16(f)
+		case "AvgTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.AvgTemperature = int8(f)
+		case "MaxTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.MaxTemperature = int8(f)
+		case "TotalMovingTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalMovingTime = uint32(f)
+		case "AvgPosVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.AvgPosVerticalSpeed = int16(f)
+		case "AvgNegVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.AvgNegVerticalSpeed = int16(f)
+		case "MaxPosVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.MaxPosVerticalSpeed = int16(f)
+		case "MaxNegVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.MaxNegVerticalSpeed = int16(f)
+		case "TimeInHrZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInHrZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInHrZone[i] = uint32(f)
+				}
+			}
+		case "TimeInSpeedZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInSpeedZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInSpeedZone[i] = uint32(f)
+				}
+			}
+		case "TimeInCadenceZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInCadenceZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInCadenceZone[i] = uint32(f)
+				}
+			}
+		case "TimeInPowerZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInPowerZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInPowerZone[i] = uint32(f)
+				}
+			}
+		case "RepetitionNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.RepetitionNum = uint16(f)
+		case "MinAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.MinAltitude = uint16(f)
+		case "MinHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MinHeartRate = uint8(f)
+		case "WktStepIndex":
+			err = unmarshalJSONValue(v, &x.WktStepIndex)
+		case "OpponentScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.OpponentScore = uint16(f)
+		case "StrokeCount":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.StrokeCount = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.StrokeCount[i] = uint16(f)
+				}
+			}
+		case "ZoneCount":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.ZoneCount = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.ZoneCount[i] = uint16(f)
+				}
+			}
+		case "AvgVerticalOscillation":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.AvgVerticalOscillation = uint16(f)
+		case "AvgStanceTimePercent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.AvgStanceTimePercent = uint16(f)
+		case "AvgStanceTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.AvgStanceTime = uint16(f)
+		case "AvgFractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.AvgFractionalCadence = uint8(f)
+		case "MaxFractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.MaxFractionalCadence = uint8(f)
+		case "TotalFractionalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.TotalFractionalCycles = uint8(f)
+		case "PlayerScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.PlayerScore = uint16(f)
+		case "AvgTotalHemoglobinConc":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 100, 0, 0xFFFF)
+			if fs != nil {
+				x.AvgTotalHemoglobinConc = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.AvgTotalHemoglobinConc[i] = uint16(f)
+				}
+			}
+		case "MinTotalHemoglobinConc":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 100, 0, 0xFFFF)
+			if fs != nil {
+				x.MinTotalHemoglobinConc = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.MinTotalHemoglobinConc[i] = uint16(f)
+				}
+			}
+		case "MaxTotalHemoglobinConc":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 100, 0, 0xFFFF)
+			if fs != nil {
+				x.MaxTotalHemoglobinConc = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.MaxTotalHemoglobinConc[i] = uint16(f)
+				}
+			}
+		case "AvgSaturatedHemoglobinPercent":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 10, 0, 0xFFFF)
+			if fs != nil {
+				x.AvgSaturatedHemoglobinPercent = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.AvgSaturatedHemoglobinPercent[i] = uint16(f)
+				}
+			}
+		case "MinSaturatedHemoglobinPercent":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 10, 0, 0xFFFF)
+			if fs != nil {
+				x.MinSaturatedHemoglobinPercent = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.MinSaturatedHemoglobinPercent[i] = uint16(f)
+				}
+			}
+		case "MaxSaturatedHemoglobinPercent":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 10, 0, 0xFFFF)
+			if fs != nil {
+				x.MaxSaturatedHemoglobinPercent = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.MaxSaturatedHemoglobinPercent[i] = uint16(f)
+				}
+			}
+		case "EnhancedAvgSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.EnhancedAvgSpeed = uint32(f)
+		case "EnhancedMaxSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.EnhancedMaxSpeed = uint32(f)
+		case "EnhancedAvgAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedAvgAltitude = uint32(f)
+		case "EnhancedMinAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedMinAltitude = uint32(f)
+		case "EnhancedMaxAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedMaxAltitude = uint32(f)
+		case "AvgVam":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AvgVam = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"LapMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // LengthMsg represents the length FIT message type.
 type LengthMsg struct {
 	MessageIndex       MessageIndex
@@ -1879,6 +5894,210 @@ func (x *LengthMsg) GetAvgSpeedScaled() float64 {
 	return float64(x.AvgSpeed) / 1000
 }
 
+// NewLengthMsg returns a length FIT message
+// initialized to all-invalid values.
+func NewLengthMsg() *LengthMsg {
+	msg := msgsAllInvalid[MesgNumLength].Interface().(LengthMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x LengthMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Event != 0xFF {
+		e.field("Event")
+		e.writeEnum(x.Event.String(), uint64(x.Event))
+	}
+	if x.EventType != 0xFF {
+		e.field("EventType")
+		e.writeEnum(x.EventType.String(), uint64(x.EventType))
+	}
+	if isValidTime(x.StartTime) {
+		e.field("StartTime")
+		e.writeTime(x.StartTime)
+	}
+	if x.TotalElapsedTime != 0xFFFFFFFF {
+		e.field("TotalElapsedTime")
+		e.writeScaled(float64(x.TotalElapsedTime)/1000, "s")
+	}
+	if x.TotalTimerTime != 0xFFFFFFFF {
+		e.field("TotalTimerTime")
+		e.writeScaled(float64(x.TotalTimerTime)/1000, "s")
+	}
+	if x.TotalStrokes != 0xFFFF {
+		e.field("TotalStrokes")
+		e.writeUintUnits(uint64(x.TotalStrokes), "strokes")
+	}
+	if x.AvgSpeed != 0xFFFF {
+		e.field("AvgSpeed")
+		e.writeScaled(float64(x.AvgSpeed)/1000, "m/s")
+	}
+	if x.SwimStroke != 0xFF {
+		e.field("SwimStroke")
+		e.writeEnum(x.SwimStroke.String(), uint64(x.SwimStroke))
+	}
+	if x.AvgSwimmingCadence != 0xFF {
+		e.field("AvgSwimmingCadence")
+		e.writeUintUnits(uint64(x.AvgSwimmingCadence), "strokes/min")
+	}
+	if x.EventGroup != 0xFF {
+		e.field("EventGroup")
+		e.writeUintUnits(uint64(x.EventGroup), "")
+	}
+	if x.TotalCalories != 0xFFFF {
+		e.field("TotalCalories")
+		e.writeUintUnits(uint64(x.TotalCalories), "kcal")
+	}
+	if x.LengthType != 0xFF {
+		e.field("LengthType")
+		e.writeEnum(x.LengthType.String(), uint64(x.LengthType))
+	}
+	if x.PlayerScore != 0xFFFF {
+		e.field("PlayerScore")
+		e.writeUintUnits(uint64(x.PlayerScore), "")
+	}
+	if x.OpponentScore != 0xFFFF {
+		e.field("OpponentScore")
+		e.writeUintUnits(uint64(x.OpponentScore), "")
+	}
+	if len(x.StrokeCount) != 0 {
+		e.field("StrokeCount")
+		e.openUnits("counts")
+		e.openArray()
+		for i, v := range x.StrokeCount {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("counts")
+	}
+	if len(x.ZoneCount) != 0 {
+		e.field("ZoneCount")
+		e.openUnits("counts")
+		e.openArray()
+		for i, v := range x.ZoneCount {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("counts")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *LengthMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumLength].Interface().(LengthMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Event":
+			err = unmarshalJSONValue(v, &x.Event)
+		case "EventType":
+			err = unmarshalJSONValue(v, &x.EventType)
+		case "StartTime":
+			err = unmarshalJSONValue(v, &x.StartTime)
+		case "TotalElapsedTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalElapsedTime = uint32(f)
+		case "TotalTimerTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalTimerTime = uint32(f)
+		case "TotalStrokes":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalStrokes = uint16(f)
+		case "AvgSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AvgSpeed = uint16(f)
+		case "SwimStroke":
+			err = unmarshalJSONValue(v, &x.SwimStroke)
+		case "AvgSwimmingCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgSwimmingCadence = uint8(f)
+		case "EventGroup":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.EventGroup = uint8(f)
+		case "TotalCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalCalories = uint16(f)
+		case "LengthType":
+			err = unmarshalJSONValue(v, &x.LengthType)
+		case "PlayerScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.PlayerScore = uint16(f)
+		case "OpponentScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.OpponentScore = uint16(f)
+		case "StrokeCount":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.StrokeCount = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.StrokeCount[i] = uint16(f)
+				}
+			}
+		case "ZoneCount":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFF)
+			if fs != nil {
+				x.ZoneCount = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.ZoneCount[i] = uint16(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"LengthMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // RecordMsg represents the record FIT message type.
 type RecordMsg struct {
 	Timestamp                     time.Time
@@ -2315,6 +6534,450 @@ func (x *RecordMsg) expandComponents() {
 	}
 }
 
+// NewRecordMsg returns a record FIT message
+// initialized to all-invalid values.
+func NewRecordMsg() *RecordMsg {
+	msg := msgsAllInvalid[MesgNumRecord].Interface().(RecordMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x RecordMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if !x.PositionLat.Invalid() {
+		e.field("PositionLat")
+		e.writeFloat(x.PositionLat.Degrees())
+	}
+	if !x.PositionLong.Invalid() {
+		e.field("PositionLong")
+		e.writeFloat(x.PositionLong.Degrees())
+	}
+	if x.Altitude != 0xFFFF {
+		e.field("Altitude")
+		e.writeScaled(float64(x.Altitude)/5-500, "m")
+	}
+	if x.HeartRate != 0xFF {
+		e.field("HeartRate")
+		e.writeUintUnits(uint64(x.HeartRate), "bpm")
+	}
+	if x.Cadence != 0xFF {
+		e.field("Cadence")
+		e.writeUintUnits(uint64(x.Cadence), "rpm")
+	}
+	if x.Distance != 0xFFFFFFFF {
+		e.field("Distance")
+		e.writeScaled(float64(x.Distance)/100, "m")
+	}
+	if x.Speed != 0xFFFF {
+		e.field("Speed")
+		e.writeScaled(float64(x.Speed)/1000, "m/s")
+	}
+	if x.Power != 0xFFFF {
+		e.field("Power")
+		e.writeUintUnits(uint64(x.Power), "watts")
+	}
+	if len(x.CompressedSpeedDistance) != 0 {
+		e.field("CompressedSpeedDistance")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.CompressedSpeedDistance {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.Grade != 0x7FFF {
+		e.field("Grade")
+		e.writeScaled(float64(x.Grade)/100, "%")
+	}
+	if x.Resistance != 0xFF {
+		e.field("Resistance")
+		e.writeUintUnits(uint64(x.Resistance), "")
+	}
+	if x.TimeFromCourse != 0x7FFFFFFF {
+		e.field("TimeFromCourse")
+		e.writeScaled(float64(x.TimeFromCourse)/1000, "s")
+	}
+	if x.CycleLength != 0xFF {
+		e.field("CycleLength")
+		e.writeScaled(float64(x.CycleLength)/100, "m")
+	}
+	if x.Temperature != 0x7F {
+		e.field("Temperature")
+		e.writeIntUnits(int64(x.Temperature), "C")
+	}
+	if len(x.Speed1s) != 0 {
+		e.field("Speed1s")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.Speed1s {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 16)
+			}
+		}
+		e.closeArray()
+		e.closeValue("m/s")
+	}
+	if x.Cycles != 0xFF {
+		e.field("Cycles")
+		e.writeUintUnits(uint64(x.Cycles), "cycles")
+	}
+	if x.TotalCycles != 0xFFFFFFFF {
+		e.field("TotalCycles")
+		e.writeUintUnits(uint64(x.TotalCycles), "cycles")
+	}
+	if x.CompressedAccumulatedPower != 0xFFFF {
+		e.field("CompressedAccumulatedPower")
+		e.writeUintUnits(uint64(x.CompressedAccumulatedPower), "watts")
+	}
+	if x.AccumulatedPower != 0xFFFFFFFF {
+		e.field("AccumulatedPower")
+		e.writeUintUnits(uint64(x.AccumulatedPower), "watts")
+	}
+	if x.LeftRightBalance != 0xFF {
+		e.field("LeftRightBalance")
+		e.writeEnum(x.LeftRightBalance.String(), uint64(x.LeftRightBalance))
+	}
+	if x.GpsAccuracy != 0xFF {
+		e.field("GpsAccuracy")
+		e.writeUintUnits(uint64(x.GpsAccuracy), "m")
+	}
+	if x.VerticalSpeed != 0x7FFF {
+		e.field("VerticalSpeed")
+		e.writeScaled(float64(x.VerticalSpeed)/1000, "m/s")
+	}
+	if x.Calories != 0xFFFF {
+		e.field("Calories")
+		e.writeUintUnits(uint64(x.Calories), "kcal")
+	}
+	if x.VerticalOscillation != 0xFFFF {
+		e.field("VerticalOscillation")
+		e.writeScaled(float64(x.VerticalOscillation)/10, "mm")
+	}
+	if x.StanceTimePercent != 0xFFFF {
+		e.field("StanceTimePercent")
+		e.writeScaled(float64(x.StanceTimePercent)/100, "percent")
+	}
+	if x.StanceTime != 0xFFFF {
+		e.field("StanceTime")
+		e.writeScaled(float64(x.StanceTime)/10, "ms")
+	}
+	if x.ActivityType != 0xFF {
+		e.field("ActivityType")
+		e.writeEnum(x.ActivityType.String(), uint64(x.ActivityType))
+	}
+	if x.LeftTorqueEffectiveness != 0xFF {
+		e.field("LeftTorqueEffectiveness")
+		e.writeScaled(float64(x.LeftTorqueEffectiveness)/2, "percent")
+	}
+	if x.RightTorqueEffectiveness != 0xFF {
+		e.field("RightTorqueEffectiveness")
+		e.writeScaled(float64(x.RightTorqueEffectiveness)/2, "percent")
+	}
+	if x.LeftPedalSmoothness != 0xFF {
+		e.field("LeftPedalSmoothness")
+		e.writeScaled(float64(x.LeftPedalSmoothness)/2, "percent")
+	}
+	if x.RightPedalSmoothness != 0xFF {
+		e.field("RightPedalSmoothness")
+		e.writeScaled(float64(x.RightPedalSmoothness)/2, "percent")
+	}
+	if x.CombinedPedalSmoothness != 0xFF {
+		e.field("CombinedPedalSmoothness")
+		e.writeScaled(float64(x.CombinedPedalSmoothness)/2, "percent")
+	}
+	if x.Time128 != 0xFF {
+		e.field("Time128")
+		e.writeScaled(float64(x.Time128)/128, "s")
+	}
+	if x.StrokeType != 0xFF {
+		e.field("StrokeType")
+		e.writeEnum(x.StrokeType.String(), uint64(x.StrokeType))
+	}
+	if x.Zone != 0xFF {
+		e.field("Zone")
+		e.writeUintUnits(uint64(x.Zone), "")
+	}
+	if x.BallSpeed != 0xFFFF {
+		e.field("BallSpeed")
+		e.writeScaled(float64(x.BallSpeed)/100, "m/s")
+	}
+	if x.Cadence256 != 0xFFFF {
+		e.field("Cadence256")
+		e.writeScaled(float64(x.Cadence256)/256, "rpm")
+	}
+	if x.FractionalCadence != 0xFF {
+		e.field("FractionalCadence")
+		e.writeScaled(float64(x.FractionalCadence)/128, "rpm")
+	}
+	if x.TotalHemoglobinConc != 0xFFFF {
+		e.field("TotalHemoglobinConc")
+		e.writeScaled(float64(x.TotalHemoglobinConc)/100, "g/dL")
+	}
+	if x.TotalHemoglobinConcMin != 0xFFFF {
+		e.field("TotalHemoglobinConcMin")
+		e.writeScaled(float64(x.TotalHemoglobinConcMin)/100, "g/dL")
+	}
+	if x.TotalHemoglobinConcMax != 0xFFFF {
+		e.field("TotalHemoglobinConcMax")
+		e.writeScaled(float64(x.TotalHemoglobinConcMax)/100, "g/dL")
+	}
+	if x.SaturatedHemoglobinPercent != 0xFFFF {
+		e.field("SaturatedHemoglobinPercent")
+		e.writeScaled(float64(x.SaturatedHemoglobinPercent)/10, "%")
+	}
+	if x.SaturatedHemoglobinPercentMin != 0xFFFF {
+		e.field("SaturatedHemoglobinPercentMin")
+		e.writeScaled(float64(x.SaturatedHemoglobinPercentMin)/10, "%")
+	}
+	if x.SaturatedHemoglobinPercentMax != 0xFFFF {
+		e.field("SaturatedHemoglobinPercentMax")
+		e.writeScaled(float64(x.SaturatedHemoglobinPercentMax)/10, "%")
+	}
+	if x.DeviceIndex != 0xFF {
+		e.field("DeviceIndex")
+		e.writeEnum(x.DeviceIndex.String(), uint64(x.DeviceIndex))
+	}
+	if x.EnhancedSpeed != 0xFFFFFFFF {
+		e.field("EnhancedSpeed")
+		e.writeScaled(float64(x.EnhancedSpeed)/1000, "m/s")
+	}
+	if x.EnhancedAltitude != 0xFFFFFFFF {
+		e.field("EnhancedAltitude")
+		e.writeScaled(float64(x.EnhancedAltitude)/5-500, "m")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *RecordMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumRecord].Interface().(RecordMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "PositionLat":
+			err = unmarshalJSONValue(v, &x.PositionLat)
+		case "PositionLong":
+			err = unmarshalJSONValue(v, &x.PositionLong)
+		case "Altitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.Altitude = uint16(f)
+		case "HeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HeartRate = uint8(f)
+		case "Cadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Cadence = uint8(f)
+		case "Distance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.Distance = uint32(f)
+		case "Speed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.Speed = uint16(f)
+		case "Power":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Power = uint16(f)
+		case "CompressedSpeedDistance":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.CompressedSpeedDistance = make([]byte, len(fs))
+				for i, f := range fs {
+					x.CompressedSpeedDistance[i] = byte(f)
+				}
+			}
+		case "Grade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.Grade = int16(f)
+		case "Resistance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Resistance = uint8(f)
+		case "TimeFromCourse":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFFFFFF)
+			x.TimeFromCourse = int32(f)
+		case "CycleLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFF)
+			x.CycleLength = uint8(f)
+		case "Temperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.Temperature = int8(f)
+		case "Speed1s":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 16, 0, 0xFF)
+			if fs != nil {
+				x.Speed1s = make([]uint8, len(fs))
+				for i, f := range fs {
+					x.Speed1s[i] = uint8(f)
+				}
+			}
+		case "Cycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Cycles = uint8(f)
+		case "TotalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalCycles = uint32(f)
+		case "CompressedAccumulatedPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.CompressedAccumulatedPower = uint16(f)
+		case "AccumulatedPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.AccumulatedPower = uint32(f)
+		case "LeftRightBalance":
+			err = unmarshalJSONValue(v, &x.LeftRightBalance)
+		case "GpsAccuracy":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.GpsAccuracy = uint8(f)
+		case "VerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.VerticalSpeed = int16(f)
+		case "Calories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Calories = uint16(f)
+		case "VerticalOscillation":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.VerticalOscillation = uint16(f)
+		case "StanceTimePercent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.StanceTimePercent = uint16(f)
+		case "StanceTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.StanceTime = uint16(f)
+		case "ActivityType":
+			err = unmarshalJSONValue(v, &x.ActivityType)
+		case "LeftTorqueEffectiveness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.LeftTorqueEffectiveness = uint8(f)
+		case "RightTorqueEffectiveness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.RightTorqueEffectiveness = uint8(f)
+		case "LeftPedalSmoothness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.LeftPedalSmoothness = uint8(f)
+		case "RightPedalSmoothness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.RightPedalSmoothness = uint8(f)
+		case "CombinedPedalSmoothness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.CombinedPedalSmoothness = uint8(f)
+		case "Time128":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.Time128 = uint8(f)
+		case "StrokeType":
+			err = unmarshalJSONValue(v, &x.StrokeType)
+		case "Zone":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Zone = uint8(f)
+		case "BallSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.BallSpeed = uint16(f)
+		case "Cadence256":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 256, 0, 0xFFFF)
+			x.Cadence256 = uint16(f)
+		case "FractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.FractionalCadence = uint8(f)
+		case "TotalHemoglobinConc":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.TotalHemoglobinConc = uint16(f)
+		case "TotalHemoglobinConcMin":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.TotalHemoglobinConcMin = uint16(f)
+		case "TotalHemoglobinConcMax":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.TotalHemoglobinConcMax = uint16(f)
+		case "SaturatedHemoglobinPercent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.SaturatedHemoglobinPercent = uint16(f)
+		case "SaturatedHemoglobinPercentMin":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.SaturatedHemoglobinPercentMin = uint16(f)
+		case "SaturatedHemoglobinPercentMax":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 10, 0, 0xFFFF)
+			x.SaturatedHemoglobinPercentMax = uint16(f)
+		case "DeviceIndex":
+			err = unmarshalJSONValue(v, &x.DeviceIndex)
+		case "EnhancedSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.EnhancedSpeed = uint32(f)
+		case "EnhancedAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFFFFFF)
+			x.EnhancedAltitude = uint32(f)
+		}
+		if err != nil {
+			return jsonFieldError{"RecordMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // EventMsg represents the event FIT message type.
 type EventMsg struct {
 	Timestamp     time.Time
@@ -2410,6 +7073,132 @@ func (x *EventMsg) expandComponents() {
 	}
 }
 
+// NewEventMsg returns a event FIT message
+// initialized to all-invalid values.
+func NewEventMsg() *EventMsg {
+	msg := msgsAllInvalid[MesgNumEvent].Interface().(EventMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x EventMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Event != 0xFF {
+		e.field("Event")
+		e.writeEnum(x.Event.String(), uint64(x.Event))
+	}
+	if x.EventType != 0xFF {
+		e.field("EventType")
+		e.writeEnum(x.EventType.String(), uint64(x.EventType))
+	}
+	if x.Data16 != 0xFFFF {
+		e.field("Data16")
+		e.writeUintUnits(uint64(x.Data16), "")
+	}
+	if x.Data != 0xFFFFFFFF {
+		e.field("Data")
+		e.writeUintUnits(uint64(x.Data), "")
+	}
+	if x.EventGroup != 0xFF {
+		e.field("EventGroup")
+		e.writeUintUnits(uint64(x.EventGroup), "")
+	}
+	if x.Score != 0xFFFF {
+		e.field("Score")
+		e.writeUintUnits(uint64(x.Score), "")
+	}
+	if x.OpponentScore != 0xFFFF {
+		e.field("OpponentScore")
+		e.writeUintUnits(uint64(x.OpponentScore), "")
+	}
+	if x.FrontGearNum != 0x00 {
+		e.field("FrontGearNum")
+		e.writeUintUnits(uint64(x.FrontGearNum), "")
+	}
+	if x.FrontGear != 0x00 {
+		e.field("FrontGear")
+		e.writeUintUnits(uint64(x.FrontGear), "")
+	}
+	if x.RearGearNum != 0x00 {
+		e.field("RearGearNum")
+		e.writeUintUnits(uint64(x.RearGearNum), "")
+	}
+	if x.RearGear != 0x00 {
+		e.field("RearGear")
+		e.writeUintUnits(uint64(x.RearGear), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *EventMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumEvent].Interface().(EventMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Event":
+			err = unmarshalJSONValue(v, &x.Event)
+		case "EventType":
+			err = unmarshalJSONValue(v, &x.EventType)
+		case "Data16":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Data16 = uint16(f)
+		case "Data":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.Data = uint32(f)
+		case "EventGroup":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.EventGroup = uint8(f)
+		case "Score":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Score = uint16(f)
+		case "OpponentScore":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.OpponentScore = uint16(f)
+		case "FrontGearNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.FrontGearNum = uint8(f)
+		case "FrontGear":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.FrontGear = uint8(f)
+		case "RearGearNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.RearGearNum = uint8(f)
+		case "RearGear":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.RearGear = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"EventMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // DeviceInfoMsg represents the device_info FIT message type.
 type DeviceInfoMsg struct {
 	Timestamp           time.Time
@@ -2481,6 +7270,168 @@ func (x *DeviceInfoMsg) GetProduct() interface{} {
 	}
 }
 
+// NewDeviceInfoMsg returns a device_info FIT message
+// initialized to all-invalid values.
+func NewDeviceInfoMsg() *DeviceInfoMsg {
+	msg := msgsAllInvalid[MesgNumDeviceInfo].Interface().(DeviceInfoMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x DeviceInfoMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.DeviceIndex != 0xFF {
+		e.field("DeviceIndex")
+		e.writeEnum(x.DeviceIndex.String(), uint64(x.DeviceIndex))
+	}
+	if x.DeviceType != 0xFF {
+		e.field("DeviceType")
+		e.writeUintUnits(uint64(x.DeviceType), "")
+	}
+	if x.Manufacturer != 0xFFFF {
+		e.field("Manufacturer")
+		e.writeEnum(x.Manufacturer.String(), uint64(x.Manufacturer))
+	}
+	if x.SerialNumber != 0x00000000 {
+		e.field("SerialNumber")
+		e.writeUintUnits(uint64(x.SerialNumber), "")
+	}
+	if x.Product != 0xFFFF {
+		e.field("Product")
+		e.writeUintUnits(uint64(x.Product), "")
+	}
+	if x.SoftwareVersion != 0xFFFF {
+		e.field("SoftwareVersion")
+		e.writeScaled(float64(x.SoftwareVersion)/100, "")
+	}
+	if x.HardwareVersion != 0xFF {
+		e.field("HardwareVersion")
+		e.writeUintUnits(uint64(x.HardwareVersion), "")
+	}
+	if x.CumOperatingTime != 0xFFFFFFFF {
+		e.field("CumOperatingTime")
+		e.writeUintUnits(uint64(x.CumOperatingTime), "s")
+	}
+	if x.BatteryVoltage != 0xFFFF {
+		e.field("BatteryVoltage")
+		e.writeScaled(float64(x.BatteryVoltage)/256, "V")
+	}
+	if x.BatteryStatus != 0xFF {
+		e.field("BatteryStatus")
+		e.writeEnum(x.BatteryStatus.String(), uint64(x.BatteryStatus))
+	}
+	if x.SensorPosition != 0xFF {
+		e.field("SensorPosition")
+		e.writeEnum(x.SensorPosition.String(), uint64(x.SensorPosition))
+	}
+	if x.Descriptor != "" {
+		e.field("Descriptor")
+		e.writeString(x.Descriptor)
+	}
+	if x.AntTransmissionType != 0x00 {
+		e.field("AntTransmissionType")
+		e.writeUintUnits(uint64(x.AntTransmissionType), "")
+	}
+	if x.AntDeviceNumber != 0x0000 {
+		e.field("AntDeviceNumber")
+		e.writeUintUnits(uint64(x.AntDeviceNumber), "")
+	}
+	if x.AntNetwork != 0xFF {
+		e.field("AntNetwork")
+		e.writeEnum(x.AntNetwork.String(), uint64(x.AntNetwork))
+	}
+	if x.SourceType != 0xFF {
+		e.field("SourceType")
+		e.writeEnum(x.SourceType.String(), uint64(x.SourceType))
+	}
+	if x.ProductName != "" {
+		e.field("ProductName")
+		e.writeString(x.ProductName)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *DeviceInfoMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumDeviceInfo].Interface().(DeviceInfoMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "DeviceIndex":
+			err = unmarshalJSONValue(v, &x.DeviceIndex)
+		case "DeviceType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DeviceType = uint8(f)
+		case "Manufacturer":
+			err = unmarshalJSONValue(v, &x.Manufacturer)
+		case "SerialNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00000000)
+			x.SerialNumber = uint32(f)
+		case "Product":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Product = uint16(f)
+		case "SoftwareVersion":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.SoftwareVersion = uint16(f)
+		case "HardwareVersion":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HardwareVersion = uint8(f)
+		case "CumOperatingTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.CumOperatingTime = uint32(f)
+		case "BatteryVoltage":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 256, 0, 0xFFFF)
+			x.BatteryVoltage = uint16(f)
+		case "BatteryStatus":
+			err = unmarshalJSONValue(v, &x.BatteryStatus)
+		case "SensorPosition":
+			err = unmarshalJSONValue(v, &x.SensorPosition)
+		case "Descriptor":
+			err = unmarshalJSONValue(v, &x.Descriptor)
+		case "AntTransmissionType":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00)
+			x.AntTransmissionType = uint8(f)
+		case "AntDeviceNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x0000)
+			x.AntDeviceNumber = uint16(f)
+		case "AntNetwork":
+			err = unmarshalJSONValue(v, &x.AntNetwork)
+		case "SourceType":
+			err = unmarshalJSONValue(v, &x.SourceType)
+		case "ProductName":
+			err = unmarshalJSONValue(v, &x.ProductName)
+		}
+		if err != nil {
+			return jsonFieldError{"DeviceInfoMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // TrainingFileMsg represents the training_file FIT message type.
 type TrainingFileMsg struct {
 	Timestamp    time.Time
@@ -2504,6 +7455,82 @@ func (x *TrainingFileMsg) GetProduct() interface{} {
 	}
 }
 
+// NewTrainingFileMsg returns a training_file FIT message
+// initialized to all-invalid values.
+func NewTrainingFileMsg() *TrainingFileMsg {
+	msg := msgsAllInvalid[MesgNumTrainingFile].Interface().(TrainingFileMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x TrainingFileMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.Manufacturer != 0xFFFF {
+		e.field("Manufacturer")
+		e.writeEnum(x.Manufacturer.String(), uint64(x.Manufacturer))
+	}
+	if x.Product != 0xFFFF {
+		e.field("Product")
+		e.writeUintUnits(uint64(x.Product), "")
+	}
+	if x.SerialNumber != 0x00000000 {
+		e.field("SerialNumber")
+		e.writeUintUnits(uint64(x.SerialNumber), "")
+	}
+	if isValidTime(x.TimeCreated) {
+		e.field("TimeCreated")
+		e.writeTime(x.TimeCreated)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *TrainingFileMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumTrainingFile].Interface().(TrainingFileMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "Manufacturer":
+			err = unmarshalJSONValue(v, &x.Manufacturer)
+		case "Product":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Product = uint16(f)
+		case "SerialNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00000000)
+			x.SerialNumber = uint32(f)
+		case "TimeCreated":
+			err = unmarshalJSONValue(v, &x.TimeCreated)
+		}
+		if err != nil {
+			return jsonFieldError{"TrainingFileMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // HrvMsg represents the hrv FIT message type.
 type HrvMsg struct {
 	Time []uint16 // Time between beats
@@ -2523,6 +7550,69 @@ func (x *HrvMsg) GetTimeScaled() []float64 {
 	return s
 }
 
+// NewHrvMsg returns a hrv FIT message
+// initialized to all-invalid values.
+func NewHrvMsg() *HrvMsg {
+	msg := msgsAllInvalid[MesgNumHrv].Interface().(HrvMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x HrvMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if len(x.Time) != 0 {
+		e.field("Time")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.Time {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *HrvMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumHrv].Interface().(HrvMsg)
+	for name, v := range fields {
+		switch name {
+		case "Time":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFF)
+			if fs != nil {
+				x.Time = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.Time[i] = uint16(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"HrvMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WeatherConditionsMsg represents the weather_conditions FIT message type.
 type WeatherConditionsMsg struct {
 	Timestamp                time.Time     // time of update for current conditions, else forecast time
@@ -2554,6 +7644,154 @@ func (x *WeatherConditionsMsg) GetWindSpeedScaled() float64 {
 	return float64(x.WindSpeed) / 1000
 }
 
+// NewWeatherConditionsMsg returns a weather_conditions FIT message
+// initialized to all-invalid values.
+func NewWeatherConditionsMsg() *WeatherConditionsMsg {
+	msg := msgsAllInvalid[MesgNumWeatherConditions].Interface().(WeatherConditionsMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WeatherConditionsMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.WeatherReport != 0xFF {
+		e.field("WeatherReport")
+		e.writeEnum(x.WeatherReport.String(), uint64(x.WeatherReport))
+	}
+	if x.Temperature != 0x7F {
+		e.field("Temperature")
+		e.writeIntUnits(int64(x.Temperature), "C")
+	}
+	if x.Condition != 0xFF {
+		e.field("Condition")
+		e.writeEnum(x.Condition.String(), uint64(x.Condition))
+	}
+	if x.WindDirection != 0xFFFF {
+		e.field("WindDirection")
+		e.writeUintUnits(uint64(x.WindDirection), "degrees")
+	}
+	if x.WindSpeed != 0xFFFF {
+		e.field("WindSpeed")
+		e.writeScaled(float64(x.WindSpeed)/1000, "m/s")
+	}
+	if x.PrecipitationProbability != 0xFF {
+		e.field("PrecipitationProbability")
+		e.writeUintUnits(uint64(x.PrecipitationProbability), "")
+	}
+	if x.TemperatureFeelsLike != 0x7F {
+		e.field("TemperatureFeelsLike")
+		e.writeIntUnits(int64(x.TemperatureFeelsLike), "C")
+	}
+	if x.RelativeHumidity != 0xFF {
+		e.field("RelativeHumidity")
+		e.writeUintUnits(uint64(x.RelativeHumidity), "")
+	}
+	if x.Location != "" {
+		e.field("Location")
+		e.writeString(x.Location)
+	}
+	if isValidTime(x.ObservedAtTime) {
+		e.field("ObservedAtTime")
+		e.writeTime(x.ObservedAtTime)
+	}
+	if !x.ObservedLocationLat.Invalid() {
+		e.field("ObservedLocationLat")
+		e.writeFloat(x.ObservedLocationLat.Degrees())
+	}
+	if !x.ObservedLocationLong.Invalid() {
+		e.field("ObservedLocationLong")
+		e.writeFloat(x.ObservedLocationLong.Degrees())
+	}
+	if x.DayOfWeek != 0xFF {
+		e.field("DayOfWeek")
+		e.writeEnum(x.DayOfWeek.String(), uint64(x.DayOfWeek))
+	}
+	if x.HighTemperature != 0x7F {
+		e.field("HighTemperature")
+		e.writeIntUnits(int64(x.HighTemperature), "C")
+	}
+	if x.LowTemperature != 0x7F {
+		e.field("LowTemperature")
+		e.writeIntUnits(int64(x.LowTemperature), "C")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WeatherConditionsMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumWeatherConditions].Interface().(WeatherConditionsMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "WeatherReport":
+			err = unmarshalJSONValue(v, &x.WeatherReport)
+		case "Temperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.Temperature = int8(f)
+		case "Condition":
+			err = unmarshalJSONValue(v, &x.Condition)
+		case "WindDirection":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.WindDirection = uint16(f)
+		case "WindSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.WindSpeed = uint16(f)
+		case "PrecipitationProbability":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.PrecipitationProbability = uint8(f)
+		case "TemperatureFeelsLike":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.TemperatureFeelsLike = int8(f)
+		case "RelativeHumidity":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.RelativeHumidity = uint8(f)
+		case "Location":
+			err = unmarshalJSONValue(v, &x.Location)
+		case "ObservedAtTime":
+			err = unmarshalJSONValue(v, &x.ObservedAtTime)
+		case "ObservedLocationLat":
+			err = unmarshalJSONValue(v, &x.ObservedLocationLat)
+		case "ObservedLocationLong":
+			err = unmarshalJSONValue(v, &x.ObservedLocationLong)
+		case "DayOfWeek":
+			err = unmarshalJSONValue(v, &x.DayOfWeek)
+		case "HighTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.HighTemperature = int8(f)
+		case "LowTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.LowTemperature = int8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"WeatherConditionsMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WeatherAlertMsg represents the weather_alert FIT message type.
 type WeatherAlertMsg struct {
 	Timestamp  time.Time
@@ -2564,38 +7802,310 @@ type WeatherAlertMsg struct {
 	Type       WeatherSevereType // Tornado, Severe Thunderstorm, etc.
 }
 
+// NewWeatherAlertMsg returns a weather_alert FIT message
+// initialized to all-invalid values.
+func NewWeatherAlertMsg() *WeatherAlertMsg {
+	msg := msgsAllInvalid[MesgNumWeatherAlert].Interface().(WeatherAlertMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WeatherAlertMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.ReportId != "" {
+		e.field("ReportId")
+		e.writeString(x.ReportId)
+	}
+	if isValidTime(x.IssueTime) {
+		e.field("IssueTime")
+		e.writeTime(x.IssueTime)
+	}
+	if isValidTime(x.ExpireTime) {
+		e.field("ExpireTime")
+		e.writeTime(x.ExpireTime)
+	}
+	if x.Severity != 0xFF {
+		e.field("Severity")
+		e.writeEnum(x.Severity.String(), uint64(x.Severity))
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WeatherAlertMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumWeatherAlert].Interface().(WeatherAlertMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "ReportId":
+			err = unmarshalJSONValue(v, &x.ReportId)
+		case "IssueTime":
+			err = unmarshalJSONValue(v, &x.IssueTime)
+		case "ExpireTime":
+			err = unmarshalJSONValue(v, &x.ExpireTime)
+		case "Severity":
+			err = unmarshalJSONValue(v, &x.Severity)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		}
+		if err != nil {
+			return jsonFieldError{"WeatherAlertMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // GpsMetadataMsg represents the gps_metadata FIT message type.
 type GpsMetadataMsg struct {
 }
 
+// NewGpsMetadataMsg returns a gps_metadata FIT message
+// initialized to all-invalid values.
+func NewGpsMetadataMsg() *GpsMetadataMsg {
+	msg := msgsAllInvalid[MesgNumGpsMetadata].Interface().(GpsMetadataMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x GpsMetadataMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *GpsMetadataMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // CameraEventMsg represents the camera_event FIT message type.
 type CameraEventMsg struct {
 }
 
+// NewCameraEventMsg returns a camera_event FIT message
+// initialized to all-invalid values.
+func NewCameraEventMsg() *CameraEventMsg {
+	msg := msgsAllInvalid[MesgNumCameraEvent].Interface().(CameraEventMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x CameraEventMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *CameraEventMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // GyroscopeDataMsg represents the gyroscope_data FIT message type.
 type GyroscopeDataMsg struct {
 }
 
+// NewGyroscopeDataMsg returns a gyroscope_data FIT message
+// initialized to all-invalid values.
+func NewGyroscopeDataMsg() *GyroscopeDataMsg {
+	msg := msgsAllInvalid[MesgNumGyroscopeData].Interface().(GyroscopeDataMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x GyroscopeDataMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *GyroscopeDataMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // AccelerometerDataMsg represents the accelerometer_data FIT message type.
 type AccelerometerDataMsg struct {
 }
 
+// NewAccelerometerDataMsg returns a accelerometer_data FIT message
+// initialized to all-invalid values.
+func NewAccelerometerDataMsg() *AccelerometerDataMsg {
+	msg := msgsAllInvalid[MesgNumAccelerometerData].Interface().(AccelerometerDataMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x AccelerometerDataMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *AccelerometerDataMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // MagnetometerDataMsg represents the magnetometer_data FIT message type.
 type MagnetometerDataMsg struct {
 }
 
+// NewMagnetometerDataMsg returns a magnetometer_data FIT message
+// initialized to all-invalid values.
+func NewMagnetometerDataMsg() *MagnetometerDataMsg {
+	msg := msgsAllInvalid[MesgNumMagnetometerData].Interface().(MagnetometerDataMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x MagnetometerDataMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *MagnetometerDataMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // ThreeDSensorCalibrationMsg represents the three_d_sensor_calibration FIT message type.
 type ThreeDSensorCalibrationMsg struct {
 }
 
+// NewThreeDSensorCalibrationMsg returns a three_d_sensor_calibration FIT message
+// initialized to all-invalid values.
+func NewThreeDSensorCalibrationMsg() *ThreeDSensorCalibrationMsg {
+	msg := msgsAllInvalid[MesgNumThreeDSensorCalibration].Interface().(ThreeDSensorCalibrationMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ThreeDSensorCalibrationMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ThreeDSensorCalibrationMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // VideoFrameMsg represents the video_frame FIT message type.
 type VideoFrameMsg struct {
 }
 
+// NewVideoFrameMsg returns a video_frame FIT message
+// initialized to all-invalid values.
+func NewVideoFrameMsg() *VideoFrameMsg {
+	msg := msgsAllInvalid[MesgNumVideoFrame].Interface().(VideoFrameMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x VideoFrameMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *VideoFrameMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // ObdiiDataMsg represents the obdii_data FIT message type.
 type ObdiiDataMsg struct {
 }
 
+// NewObdiiDataMsg returns a obdii_data FIT message
+// initialized to all-invalid values.
+func NewObdiiDataMsg() *ObdiiDataMsg {
+	msg := msgsAllInvalid[MesgNumObdiiData].Interface().(ObdiiDataMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ObdiiDataMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ObdiiDataMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // NmeaSentenceMsg represents the nmea_sentence FIT message type.
 type NmeaSentenceMsg struct {
 	Timestamp   time.Time // Timestamp message was output
@@ -2603,6 +8113,62 @@ type NmeaSentenceMsg struct {
 	Sentence    string    // NMEA sentence
 }
 
+// NewNmeaSentenceMsg returns a nmea_sentence FIT message
+// initialized to all-invalid values.
+func NewNmeaSentenceMsg() *NmeaSentenceMsg {
+	msg := msgsAllInvalid[MesgNumNmeaSentence].Interface().(NmeaSentenceMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x NmeaSentenceMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.TimestampMs != 0xFFFF {
+		e.field("TimestampMs")
+		e.writeUintUnits(uint64(x.TimestampMs), "ms")
+	}
+	if x.Sentence != "" {
+		e.field("Sentence")
+		e.writeString(x.Sentence)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *NmeaSentenceMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumNmeaSentence].Interface().(NmeaSentenceMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "TimestampMs":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TimestampMs = uint16(f)
+		case "Sentence":
+			err = unmarshalJSONValue(v, &x.Sentence)
+		}
+		if err != nil {
+			return jsonFieldError{"NmeaSentenceMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // AviationAttitudeMsg represents the aviation_attitude FIT message type.
 type AviationAttitudeMsg struct {
 	Timestamp             time.Time // Timestamp message was output
@@ -2703,10 +8269,327 @@ func (x *AviationAttitudeMsg) GetTrackScaled() []float64 {
 	return s
 }
 
+// NewAviationAttitudeMsg returns a aviation_attitude FIT message
+// initialized to all-invalid values.
+func NewAviationAttitudeMsg() *AviationAttitudeMsg {
+	msg := msgsAllInvalid[MesgNumAviationAttitude].Interface().(AviationAttitudeMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x AviationAttitudeMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.TimestampMs != 0xFFFF {
+		e.field("TimestampMs")
+		e.writeUintUnits(uint64(x.TimestampMs), "ms")
+	}
+	if len(x.SystemTime) != 0 {
+		e.field("SystemTime")
+		e.openUnits("ms")
+		e.openArray()
+		for i, v := range x.SystemTime {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("ms")
+	}
+	if len(x.Pitch) != 0 {
+		e.field("Pitch")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.Pitch {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x7FFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 10430.38)
+			}
+		}
+		e.closeArray()
+		e.closeValue("radians")
+	}
+	if len(x.Roll) != 0 {
+		e.field("Roll")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.Roll {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x7FFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 10430.38)
+			}
+		}
+		e.closeArray()
+		e.closeValue("radians")
+	}
+	if len(x.AccelLateral) != 0 {
+		e.field("AccelLateral")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.AccelLateral {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x7FFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 100)
+			}
+		}
+		e.closeArray()
+		e.closeValue("m/s^2")
+	}
+	if len(x.AccelNormal) != 0 {
+		e.field("AccelNormal")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.AccelNormal {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x7FFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 100)
+			}
+		}
+		e.closeArray()
+		e.closeValue("m/s^2")
+	}
+	if len(x.TurnRate) != 0 {
+		e.field("TurnRate")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TurnRate {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0x7FFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1024)
+			}
+		}
+		e.closeArray()
+		e.closeValue("radians/second")
+	}
+	if len(x.Stage) != 0 {
+		e.field("Stage")
+		e.openArray()
+		for i, v := range x.Stage {
+			if i > 0 {
+				e.c()
+			}
+			e.writeEnum(v.String(), uint64(v))
+		}
+		e.closeArray()
+	}
+	if len(x.AttitudeStageComplete) != 0 {
+		e.field("AttitudeStageComplete")
+		e.openUnits("%")
+		e.openArray()
+		for i, v := range x.AttitudeStageComplete {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("%")
+	}
+	if len(x.Track) != 0 {
+		e.field("Track")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.Track {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 10430.38)
+			}
+		}
+		e.closeArray()
+		e.closeValue("radians")
+	}
+	if len(x.Validity) != 0 {
+		e.field("Validity")
+		e.openArray()
+		for i, v := range x.Validity {
+			if i > 0 {
+				e.c()
+			}
+			e.writeEnum(v.String(), uint64(v))
+		}
+		e.closeArray()
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *AviationAttitudeMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumAviationAttitude].Interface().(AviationAttitudeMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "TimestampMs":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TimestampMs = uint16(f)
+		case "SystemTime":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.SystemTime = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.SystemTime[i] = uint32(f)
+				}
+			}
+		case "Pitch":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 10430.38, 0, 0x7FFF)
+			if fs != nil {
+				x.Pitch = make([]int16, len(fs))
+				for i, f := range fs {
+					x.Pitch[i] = int16(f)
+				}
+			}
+		case "Roll":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 10430.38, 0, 0x7FFF)
+			if fs != nil {
+				x.Roll = make([]int16, len(fs))
+				for i, f := range fs {
+					x.Roll[i] = int16(f)
+				}
+			}
+		case "AccelLateral":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 100, 0, 0x7FFF)
+			if fs != nil {
+				x.AccelLateral = make([]int16, len(fs))
+				for i, f := range fs {
+					x.AccelLateral[i] = int16(f)
+				}
+			}
+		case "AccelNormal":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 100, 0, 0x7FFF)
+			if fs != nil {
+				x.AccelNormal = make([]int16, len(fs))
+				for i, f := range fs {
+					x.AccelNormal[i] = int16(f)
+				}
+			}
+		case "TurnRate":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1024, 0, 0x7FFF)
+			if fs != nil {
+				x.TurnRate = make([]int16, len(fs))
+				for i, f := range fs {
+					x.TurnRate[i] = int16(f)
+				}
+			}
+		case "Stage":
+			err = unmarshalJSONValue(v, &x.Stage)
+		case "AttitudeStageComplete":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.AttitudeStageComplete = make([]uint8, len(fs))
+				for i, f := range fs {
+					x.AttitudeStageComplete[i] = uint8(f)
+				}
+			}
+		case "Track":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 10430.38, 0, 0xFFFF)
+			if fs != nil {
+				x.Track = make([]uint16, len(fs))
+				for i, f := range fs {
+					x.Track[i] = uint16(f)
+				}
+			}
+		case "Validity":
+			err = unmarshalJSONValue(v, &x.Validity)
+		}
+		if err != nil {
+			return jsonFieldError{"AviationAttitudeMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // VideoMsg represents the video FIT message type.
 type VideoMsg struct {
 }
 
+// NewVideoMsg returns a video FIT message
+// initialized to all-invalid values.
+func NewVideoMsg() *VideoMsg {
+	msg := msgsAllInvalid[MesgNumVideo].Interface().(VideoMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x VideoMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *VideoMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // VideoTitleMsg represents the video_title FIT message type.
 type VideoTitleMsg struct {
 	MessageIndex MessageIndex // Long titles will be split into multiple parts
@@ -2714,6 +8597,62 @@ type VideoTitleMsg struct {
 	Text         string
 }
 
+// NewVideoTitleMsg returns a video_title FIT message
+// initialized to all-invalid values.
+func NewVideoTitleMsg() *VideoTitleMsg {
+	msg := msgsAllInvalid[MesgNumVideoTitle].Interface().(VideoTitleMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x VideoTitleMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.MessageCount != 0xFFFF {
+		e.field("MessageCount")
+		e.writeUintUnits(uint64(x.MessageCount), "")
+	}
+	if x.Text != "" {
+		e.field("Text")
+		e.writeString(x.Text)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *VideoTitleMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumVideoTitle].Interface().(VideoTitleMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "MessageCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MessageCount = uint16(f)
+		case "Text":
+			err = unmarshalJSONValue(v, &x.Text)
+		}
+		if err != nil {
+			return jsonFieldError{"VideoTitleMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // VideoDescriptionMsg represents the video_description FIT message type.
 type VideoDescriptionMsg struct {
 	MessageIndex MessageIndex // Long descriptions will be split into multiple parts
@@ -2721,10 +8660,91 @@ type VideoDescriptionMsg struct {
 	Text         string
 }
 
+// NewVideoDescriptionMsg returns a video_description FIT message
+// initialized to all-invalid values.
+func NewVideoDescriptionMsg() *VideoDescriptionMsg {
+	msg := msgsAllInvalid[MesgNumVideoDescription].Interface().(VideoDescriptionMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x VideoDescriptionMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.MessageCount != 0xFFFF {
+		e.field("MessageCount")
+		e.writeUintUnits(uint64(x.MessageCount), "")
+	}
+	if x.Text != "" {
+		e.field("Text")
+		e.writeString(x.Text)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *VideoDescriptionMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumVideoDescription].Interface().(VideoDescriptionMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "MessageCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MessageCount = uint16(f)
+		case "Text":
+			err = unmarshalJSONValue(v, &x.Text)
+		}
+		if err != nil {
+			return jsonFieldError{"VideoDescriptionMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // VideoClipMsg represents the video_clip FIT message type.
 type VideoClipMsg struct {
 }
 
+// NewVideoClipMsg returns a video_clip FIT message
+// initialized to all-invalid values.
+func NewVideoClipMsg() *VideoClipMsg {
+	msg := msgsAllInvalid[MesgNumVideoClip].Interface().(VideoClipMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x VideoClipMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *VideoClipMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // CourseMsg represents the course FIT message type.
 type CourseMsg struct {
 	Sport        Sport
@@ -2733,6 +8753,66 @@ type CourseMsg struct {
 	SubSport     SubSport
 }
 
+// NewCourseMsg returns a course FIT message
+// initialized to all-invalid values.
+func NewCourseMsg() *CourseMsg {
+	msg := msgsAllInvalid[MesgNumCourse].Interface().(CourseMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x CourseMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.Capabilities != 0x00000000 {
+		e.field("Capabilities")
+		e.writeEnum(x.Capabilities.String(), uint64(x.Capabilities))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *CourseMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumCourse].Interface().(CourseMsg)
+	for name, v := range fields {
+		switch name {
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "Capabilities":
+			err = unmarshalJSONValue(v, &x.Capabilities)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		}
+		if err != nil {
+			return jsonFieldError{"CourseMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // CoursePointMsg represents the course_point FIT message type.
 type CoursePointMsg struct {
 	MessageIndex MessageIndex
@@ -2756,6 +8836,92 @@ func (x *CoursePointMsg) GetDistanceScaled() float64 {
 	return float64(x.Distance) / 100
 }
 
+// NewCoursePointMsg returns a course_point FIT message
+// initialized to all-invalid values.
+func NewCoursePointMsg() *CoursePointMsg {
+	msg := msgsAllInvalid[MesgNumCoursePoint].Interface().(CoursePointMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x CoursePointMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if !x.PositionLat.Invalid() {
+		e.field("PositionLat")
+		e.writeFloat(x.PositionLat.Degrees())
+	}
+	if !x.PositionLong.Invalid() {
+		e.field("PositionLong")
+		e.writeFloat(x.PositionLong.Degrees())
+	}
+	if x.Distance != 0xFFFFFFFF {
+		e.field("Distance")
+		e.writeScaled(float64(x.Distance)/100, "m")
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.Favorite != 0xFF {
+		e.field("Favorite")
+		e.writeBoolEnum(x.Favorite)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *CoursePointMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumCoursePoint].Interface().(CoursePointMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "PositionLat":
+			err = unmarshalJSONValue(v, &x.PositionLat)
+		case "PositionLong":
+			err = unmarshalJSONValue(v, &x.PositionLong)
+		case "Distance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.Distance = uint32(f)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "Favorite":
+			err = unmarshalJSONValue(v, &x.Favorite)
+		}
+		if err != nil {
+			return jsonFieldError{"CoursePointMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SegmentIdMsg represents the segment_id FIT message type.
 type SegmentIdMsg struct {
 	Name                  string               // Friendly name assigned to segment
@@ -2769,6 +8935,102 @@ type SegmentIdMsg struct {
 	SelectionType         SegmentSelectionType // Indicates how the segment was selected to be sent to the device
 }
 
+// NewSegmentIdMsg returns a segment_id FIT message
+// initialized to all-invalid values.
+func NewSegmentIdMsg() *SegmentIdMsg {
+	msg := msgsAllInvalid[MesgNumSegmentId].Interface().(SegmentIdMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SegmentIdMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.Uuid != "" {
+		e.field("Uuid")
+		e.writeString(x.Uuid)
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.Enabled != 0xFF {
+		e.field("Enabled")
+		e.writeBoolEnum(x.Enabled)
+	}
+	if x.UserProfilePrimaryKey != 0xFFFFFFFF {
+		e.field("UserProfilePrimaryKey")
+		e.writeUintUnits(uint64(x.UserProfilePrimaryKey), "")
+	}
+	if x.DeviceId != 0xFFFFFFFF {
+		e.field("DeviceId")
+		e.writeUintUnits(uint64(x.DeviceId), "")
+	}
+	if x.DefaultRaceLeader != 0xFF {
+		e.field("DefaultRaceLeader")
+		e.writeUintUnits(uint64(x.DefaultRaceLeader), "")
+	}
+	if x.DeleteStatus != 0xFF {
+		e.field("DeleteStatus")
+		e.writeEnum(x.DeleteStatus.String(), uint64(x.DeleteStatus))
+	}
+	if x.SelectionType != 0xFF {
+		e.field("SelectionType")
+		e.writeEnum(x.SelectionType.String(), uint64(x.SelectionType))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SegmentIdMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSegmentId].Interface().(SegmentIdMsg)
+	for name, v := range fields {
+		switch name {
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "Uuid":
+			err = unmarshalJSONValue(v, &x.Uuid)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "Enabled":
+			err = unmarshalJSONValue(v, &x.Enabled)
+		case "UserProfilePrimaryKey":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.UserProfilePrimaryKey = uint32(f)
+		case "DeviceId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.DeviceId = uint32(f)
+		case "DefaultRaceLeader":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DefaultRaceLeader = uint8(f)
+		case "DeleteStatus":
+			err = unmarshalJSONValue(v, &x.DeleteStatus)
+		case "SelectionType":
+			err = unmarshalJSONValue(v, &x.SelectionType)
+		}
+		if err != nil {
+			return jsonFieldError{"SegmentIdMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SegmentLeaderboardEntryMsg represents the segment_leaderboard_entry FIT message type.
 type SegmentLeaderboardEntryMsg struct {
 	MessageIndex    MessageIndex
@@ -2790,6 +9052,84 @@ func (x *SegmentLeaderboardEntryMsg) GetSegmentTimeScaled() float64 {
 	return float64(x.SegmentTime) / 1000
 }
 
+// NewSegmentLeaderboardEntryMsg returns a segment_leaderboard_entry FIT message
+// initialized to all-invalid values.
+func NewSegmentLeaderboardEntryMsg() *SegmentLeaderboardEntryMsg {
+	msg := msgsAllInvalid[MesgNumSegmentLeaderboardEntry].Interface().(SegmentLeaderboardEntryMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SegmentLeaderboardEntryMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if x.GroupPrimaryKey != 0xFFFFFFFF {
+		e.field("GroupPrimaryKey")
+		e.writeUintUnits(uint64(x.GroupPrimaryKey), "")
+	}
+	if x.ActivityId != 0xFFFFFFFF {
+		e.field("ActivityId")
+		e.writeUintUnits(uint64(x.ActivityId), "")
+	}
+	if x.SegmentTime != 0xFFFFFFFF {
+		e.field("SegmentTime")
+		e.writeScaled(float64(x.SegmentTime)/1000, "s")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SegmentLeaderboardEntryMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSegmentLeaderboardEntry].Interface().(SegmentLeaderboardEntryMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "GroupPrimaryKey":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.GroupPrimaryKey = uint32(f)
+		case "ActivityId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.ActivityId = uint32(f)
+		case "SegmentTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.SegmentTime = uint32(f)
+		}
+		if err != nil {
+			return jsonFieldError{"SegmentLeaderboardEntryMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SegmentPointMsg represents the segment_point FIT message type.
 type SegmentPointMsg struct {
 	MessageIndex MessageIndex
@@ -2829,11 +9169,108 @@ func (x *SegmentPointMsg) GetLeaderTimeScaled() []float64 {
 	if len(x.LeaderTime) == 0 {
 		return nil
 	}
-	s := make([]float64, len(x.LeaderTime))
-	for i, v := range x.LeaderTime {
-		s[i] = float64(v) / 1000
+	s := make([]float64, len(x.LeaderTime))
+	for i, v := range x.LeaderTime {
+		s[i] = float64(v) / 1000
+	}
+	return s
+}
+
+// NewSegmentPointMsg returns a segment_point FIT message
+// initialized to all-invalid values.
+func NewSegmentPointMsg() *SegmentPointMsg {
+	msg := msgsAllInvalid[MesgNumSegmentPoint].Interface().(SegmentPointMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SegmentPointMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if !x.PositionLat.Invalid() {
+		e.field("PositionLat")
+		e.writeFloat(x.PositionLat.Degrees())
 	}
-	return s
+	if !x.PositionLong.Invalid() {
+		e.field("PositionLong")
+		e.writeFloat(x.PositionLong.Degrees())
+	}
+	if x.Distance != 0xFFFFFFFF {
+		e.field("Distance")
+		e.writeScaled(float64(x.Distance)/100, "m")
+	}
+	if x.Altitude != 0xFFFF {
+		e.field("Altitude")
+		e.writeScaled(float64(x.Altitude)/5-500, "m")
+	}
+	if len(x.LeaderTime) != 0 {
+		e.field("LeaderTime")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.LeaderTime {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SegmentPointMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSegmentPoint].Interface().(SegmentPointMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "PositionLat":
+			err = unmarshalJSONValue(v, &x.PositionLat)
+		case "PositionLong":
+			err = unmarshalJSONValue(v, &x.PositionLong)
+		case "Distance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.Distance = uint32(f)
+		case "Altitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.Altitude = uint16(f)
+		case "LeaderTime":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.LeaderTime = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.LeaderTime[i] = uint32(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"SegmentPointMsg", name, err}
+		}
+	}
+	return nil
 }
 
 // SegmentLapMsg represents the segment_lap FIT message type.
@@ -3279,6 +9716,660 @@ func (x *SegmentLapMsg) GetTotalCycles() interface{} {
 	}
 }
 
+// NewSegmentLapMsg returns a segment_lap FIT message
+// initialized to all-invalid values.
+func NewSegmentLapMsg() *SegmentLapMsg {
+	msg := msgsAllInvalid[MesgNumSegmentLap].Interface().(SegmentLapMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SegmentLapMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Event != 0xFF {
+		e.field("Event")
+		e.writeEnum(x.Event.String(), uint64(x.Event))
+	}
+	if x.EventType != 0xFF {
+		e.field("EventType")
+		e.writeEnum(x.EventType.String(), uint64(x.EventType))
+	}
+	if isValidTime(x.StartTime) {
+		e.field("StartTime")
+		e.writeTime(x.StartTime)
+	}
+	if !x.StartPositionLat.Invalid() {
+		e.field("StartPositionLat")
+		e.writeFloat(x.StartPositionLat.Degrees())
+	}
+	if !x.StartPositionLong.Invalid() {
+		e.field("StartPositionLong")
+		e.writeFloat(x.StartPositionLong.Degrees())
+	}
+	if !x.EndPositionLat.Invalid() {
+		e.field("EndPositionLat")
+		e.writeFloat(x.EndPositionLat.Degrees())
+	}
+	if !x.EndPositionLong.Invalid() {
+		e.field("EndPositionLong")
+		e.writeFloat(x.EndPositionLong.Degrees())
+	}
+	if x.TotalElapsedTime != 0xFFFFFFFF {
+		e.field("TotalElapsedTime")
+		e.writeScaled(float64(x.TotalElapsedTime)/1000, "s")
+	}
+	if x.TotalTimerTime != 0xFFFFFFFF {
+		e.field("TotalTimerTime")
+		e.writeScaled(float64(x.TotalTimerTime)/1000, "s")
+	}
+	if x.TotalDistance != 0xFFFFFFFF {
+		e.field("TotalDistance")
+		e.writeScaled(float64(x.TotalDistance)/100, "m")
+	}
+	if x.TotalCycles != 0xFFFFFFFF {
+		e.field("TotalCycles")
+		e.writeUintUnits(uint64(x.TotalCycles), "cycles")
+	}
+	if x.TotalCalories != 0xFFFF {
+		e.field("TotalCalories")
+		e.writeUintUnits(uint64(x.TotalCalories), "kcal")
+	}
+	if x.TotalFatCalories != 0xFFFF {
+		e.field("TotalFatCalories")
+		e.writeUintUnits(uint64(x.TotalFatCalories), "kcal")
+	}
+	if x.AvgSpeed != 0xFFFF {
+		e.field("AvgSpeed")
+		e.writeScaled(float64(x.AvgSpeed)/1000, "m/s")
+	}
+	if x.MaxSpeed != 0xFFFF {
+		e.field("MaxSpeed")
+		e.writeScaled(float64(x.MaxSpeed)/1000, "m/s")
+	}
+	if x.AvgHeartRate != 0xFF {
+		e.field("AvgHeartRate")
+		e.writeUintUnits(uint64(x.AvgHeartRate), "bpm")
+	}
+	if x.MaxHeartRate != 0xFF {
+		e.field("MaxHeartRate")
+		e.writeUintUnits(uint64(x.MaxHeartRate), "bpm")
+	}
+	if x.AvgCadence != 0xFF {
+		e.field("AvgCadence")
+		e.writeUintUnits(uint64(x.AvgCadence), "rpm")
+	}
+	if x.MaxCadence != 0xFF {
+		e.field("MaxCadence")
+		e.writeUintUnits(uint64(x.MaxCadence), "rpm")
+	}
+	if x.AvgPower != 0xFFFF {
+		e.field("AvgPower")
+		e.writeUintUnits(uint64(x.AvgPower), "watts")
+	}
+	if x.MaxPower != 0xFFFF {
+		e.field("MaxPower")
+		e.writeUintUnits(uint64(x.MaxPower), "watts")
+	}
+	if x.TotalAscent != 0xFFFF {
+		e.field("TotalAscent")
+		e.writeUintUnits(uint64(x.TotalAscent), "m")
+	}
+	if x.TotalDescent != 0xFFFF {
+		e.field("TotalDescent")
+		e.writeUintUnits(uint64(x.TotalDescent), "m")
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.EventGroup != 0xFF {
+		e.field("EventGroup")
+		e.writeUintUnits(uint64(x.EventGroup), "")
+	}
+	if !x.NecLat.Invalid() {
+		e.field("NecLat")
+		e.writeFloat(x.NecLat.Degrees())
+	}
+	if !x.NecLong.Invalid() {
+		e.field("NecLong")
+		e.writeFloat(x.NecLong.Degrees())
+	}
+	if !x.SwcLat.Invalid() {
+		e.field("SwcLat")
+		e.writeFloat(x.SwcLat.Degrees())
+	}
+	if !x.SwcLong.Invalid() {
+		e.field("SwcLong")
+		e.writeFloat(x.SwcLong.Degrees())
+	}
+	if x.Name != "" {
+		e.field("Name")
+		e.writeString(x.Name)
+	}
+	if x.NormalizedPower != 0xFFFF {
+		e.field("NormalizedPower")
+		e.writeUintUnits(uint64(x.NormalizedPower), "watts")
+	}
+	if x.LeftRightBalance != 0xFFFF {
+		e.field("LeftRightBalance")
+		e.writeEnum(x.LeftRightBalance.String(), uint64(x.LeftRightBalance))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.TotalWork != 0xFFFFFFFF {
+		e.field("TotalWork")
+		e.writeUintUnits(uint64(x.TotalWork), "J")
+	}
+	if x.AvgAltitude != 0xFFFF {
+		e.field("AvgAltitude")
+		e.writeScaled(float64(x.AvgAltitude)/5-500, "m")
+	}
+	if x.MaxAltitude != 0xFFFF {
+		e.field("MaxAltitude")
+		e.writeScaled(float64(x.MaxAltitude)/5-500, "m")
+	}
+	if x.GpsAccuracy != 0xFF {
+		e.field("GpsAccuracy")
+		e.writeUintUnits(uint64(x.GpsAccuracy), "m")
+	}
+	if x.AvgGrade != 0x7FFF {
+		e.field("AvgGrade")
+		e.writeScaled(float64(x.AvgGrade)/100, "%")
+	}
+	if x.AvgPosGrade != 0x7FFF {
+		e.field("AvgPosGrade")
+		e.writeScaled(float64(x.AvgPosGrade)/100, "%")
+	}
+	if x.AvgNegGrade != 0x7FFF {
+		e.field("AvgNegGrade")
+		e.writeScaled(float64(x.AvgNegGrade)/100, "%")
+	}
+	if x.MaxPosGrade != 0x7FFF {
+		e.field("MaxPosGrade")
+		e.writeScaled(float64(x.MaxPosGrade)/100, "%")
+	}
+	if x.MaxNegGrade != 0x7FFF {
+		e.field("MaxNegGrade")
+		e.writeScaled(float64(x.MaxNegGrade)/100, "%")
+	}
+	if x.AvgTemperature != 0x7F {
+		e.field("AvgTemperature")
+		e.writeIntUnits(int64(x.AvgTemperature), "C")
+	}
+	if x.MaxTemperature != 0x7F {
+		e.field("MaxTemperature")
+		e.writeIntUnits(int64(x.MaxTemperature), "C")
+	}
+	if x.TotalMovingTime != 0xFFFFFFFF {
+		e.field("TotalMovingTime")
+		e.writeScaled(float64(x.TotalMovingTime)/1000, "s")
+	}
+	if x.AvgPosVerticalSpeed != 0x7FFF {
+		e.field("AvgPosVerticalSpeed")
+		e.writeScaled(float64(x.AvgPosVerticalSpeed)/1000, "m/s")
+	}
+	if x.AvgNegVerticalSpeed != 0x7FFF {
+		e.field("AvgNegVerticalSpeed")
+		e.writeScaled(float64(x.AvgNegVerticalSpeed)/1000, "m/s")
+	}
+	if x.MaxPosVerticalSpeed != 0x7FFF {
+		e.field("MaxPosVerticalSpeed")
+		e.writeScaled(float64(x.MaxPosVerticalSpeed)/1000, "m/s")
+	}
+	if x.MaxNegVerticalSpeed != 0x7FFF {
+		e.field("MaxNegVerticalSpeed")
+		e.writeScaled(float64(x.MaxNegVerticalSpeed)/1000, "m/s")
+	}
+	if len(x.TimeInHrZone) != 0 {
+		e.field("TimeInHrZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInHrZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInSpeedZone) != 0 {
+		e.field("TimeInSpeedZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInSpeedZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInCadenceZone) != 0 {
+		e.field("TimeInCadenceZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInCadenceZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.TimeInPowerZone) != 0 {
+		e.field("TimeInPowerZone")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.TimeInPowerZone {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1000)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if x.RepetitionNum != 0xFFFF {
+		e.field("RepetitionNum")
+		e.writeUintUnits(uint64(x.RepetitionNum), "")
+	}
+	if x.MinAltitude != 0xFFFF {
+		e.field("MinAltitude")
+		e.writeScaled(float64(x.MinAltitude)/5-500, "m")
+	}
+	if x.MinHeartRate != 0xFF {
+		e.field("MinHeartRate")
+		e.writeUintUnits(uint64(x.MinHeartRate), "bpm")
+	}
+	if x.ActiveTime != 0xFFFFFFFF {
+		e.field("ActiveTime")
+		e.writeScaled(float64(x.ActiveTime)/1000, "s")
+	}
+	if x.WktStepIndex != 0xFFFF {
+		e.field("WktStepIndex")
+		e.writeEnum(x.WktStepIndex.String(), uint64(x.WktStepIndex))
+	}
+	if x.SportEvent != 0xFF {
+		e.field("SportEvent")
+		e.writeEnum(x.SportEvent.String(), uint64(x.SportEvent))
+	}
+	if x.AvgLeftTorqueEffectiveness != 0xFF {
+		e.field("AvgLeftTorqueEffectiveness")
+		e.writeScaled(float64(x.AvgLeftTorqueEffectiveness)/2, "percent")
+	}
+	if x.AvgRightTorqueEffectiveness != 0xFF {
+		e.field("AvgRightTorqueEffectiveness")
+		e.writeScaled(float64(x.AvgRightTorqueEffectiveness)/2, "percent")
+	}
+	if x.AvgLeftPedalSmoothness != 0xFF {
+		e.field("AvgLeftPedalSmoothness")
+		e.writeScaled(float64(x.AvgLeftPedalSmoothness)/2, "percent")
+	}
+	if x.AvgRightPedalSmoothness != 0xFF {
+		e.field("AvgRightPedalSmoothness")
+		e.writeScaled(float64(x.AvgRightPedalSmoothness)/2, "percent")
+	}
+	if x.AvgCombinedPedalSmoothness != 0xFF {
+		e.field("AvgCombinedPedalSmoothness")
+		e.writeScaled(float64(x.AvgCombinedPedalSmoothness)/2, "percent")
+	}
+	if x.Status != 0xFF {
+		e.field("Status")
+		e.writeEnum(x.Status.String(), uint64(x.Status))
+	}
+	if x.Uuid != "" {
+		e.field("Uuid")
+		e.writeString(x.Uuid)
+	}
+	if x.AvgFractionalCadence != 0xFF {
+		e.field("AvgFractionalCadence")
+		e.writeScaled(float64(x.AvgFractionalCadence)/128, "rpm")
+	}
+	if x.MaxFractionalCadence != 0xFF {
+		e.field("MaxFractionalCadence")
+		e.writeScaled(float64(x.MaxFractionalCadence)/128, "rpm")
+	}
+	if x.TotalFractionalCycles != 0xFF {
+		e.field("TotalFractionalCycles")
+		e.writeScaled(float64(x.TotalFractionalCycles)/128, "cycles")
+	}
+	if x.FrontGearShiftCount != 0xFFFF {
+		e.field("FrontGearShiftCount")
+		e.writeUintUnits(uint64(x.FrontGearShiftCount), "")
+	}
+	if x.RearGearShiftCount != 0xFFFF {
+		e.field("RearGearShiftCount")
+		e.writeUintUnits(uint64(x.RearGearShiftCount), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SegmentLapMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSegmentLap].Interface().(SegmentLapMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Event":
+			err = unmarshalJSONValue(v, &x.Event)
+		case "EventType":
+			err = unmarshalJSONValue(v, &x.EventType)
+		case "StartTime":
+			err = unmarshalJSONValue(v, &x.StartTime)
+		case "StartPositionLat":
+			err = unmarshalJSONValue(v, &x.StartPositionLat)
+		case "StartPositionLong":
+			err = unmarshalJSONValue(v, &x.StartPositionLong)
+		case "EndPositionLat":
+			err = unmarshalJSONValue(v, &x.EndPositionLat)
+		case "EndPositionLong":
+			err = unmarshalJSONValue(v, &x.EndPositionLong)
+		case "TotalElapsedTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalElapsedTime = uint32(f)
+		case "TotalTimerTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalTimerTime = uint32(f)
+		case "TotalDistance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.TotalDistance = uint32(f)
+		case "TotalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalCycles = uint32(f)
+		case "TotalCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalCalories = uint16(f)
+		case "TotalFatCalories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalFatCalories = uint16(f)
+		case "AvgSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.AvgSpeed = uint16(f)
+		case "MaxSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFF)
+			x.MaxSpeed = uint16(f)
+		case "AvgHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgHeartRate = uint8(f)
+		case "MaxHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxHeartRate = uint8(f)
+		case "AvgCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.AvgCadence = uint8(f)
+		case "MaxCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MaxCadence = uint8(f)
+		case "AvgPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.AvgPower = uint16(f)
+		case "MaxPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MaxPower = uint16(f)
+		case "TotalAscent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalAscent = uint16(f)
+		case "TotalDescent":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.TotalDescent = uint16(f)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "EventGroup":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.EventGroup = uint8(f)
+		case "NecLat":
+			err = unmarshalJSONValue(v, &x.NecLat)
+		case "NecLong":
+			err = unmarshalJSONValue(v, &x.NecLong)
+		case "SwcLat":
+			err = unmarshalJSONValue(v, &x.SwcLat)
+		case "SwcLong":
+			err = unmarshalJSONValue(v, &x.SwcLong)
+		case "Name":
+			err = unmarshalJSONValue(v, &x.Name)
+		case "NormalizedPower":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NormalizedPower = uint16(f)
+		case "LeftRightBalance":
+			err = unmarshalJSONValue(v, &x.LeftRightBalance)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "TotalWork":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TotalWork = uint32(f)
+		case "AvgAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.AvgAltitude = uint16(f)
+		case "MaxAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.MaxAltitude = uint16(f)
+		case "GpsAccuracy":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.GpsAccuracy = uint8(f)
+		case "AvgGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgGrade = int16(f)
+		case "AvgPosGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgPosGrade = int16(f)
+		case "AvgNegGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.AvgNegGrade = int16(f)
+		case "MaxPosGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.MaxPosGrade = int16(f)
+		case "MaxNegGrade":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0x7FFF)
+			x.MaxNegGrade = int16(f)
+		case "AvgTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.AvgTemperature = int8(f)
+		case "MaxTemperature":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.MaxTemperature = int8(f)
+		case "TotalMovingTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.TotalMovingTime = uint32(f)
+		case "AvgPosVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.AvgPosVerticalSpeed = int16(f)
+		case "AvgNegVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.AvgNegVerticalSpeed = int16(f)
+		case "MaxPosVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.MaxPosVerticalSpeed = int16(f)
+		case "MaxNegVerticalSpeed":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0x7FFF)
+			x.MaxNegVerticalSpeed = int16(f)
+		case "TimeInHrZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInHrZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInHrZone[i] = uint32(f)
+				}
+			}
+		case "TimeInSpeedZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInSpeedZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInSpeedZone[i] = uint32(f)
+				}
+			}
+		case "TimeInCadenceZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInCadenceZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInCadenceZone[i] = uint32(f)
+				}
+			}
+		case "TimeInPowerZone":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1000, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.TimeInPowerZone = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.TimeInPowerZone[i] = uint32(f)
+				}
+			}
+		case "RepetitionNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.RepetitionNum = uint16(f)
+		case "MinAltitude":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 5, 500, 0xFFFF)
+			x.MinAltitude = uint16(f)
+		case "MinHeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MinHeartRate = uint8(f)
+		case "ActiveTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.ActiveTime = uint32(f)
+		case "WktStepIndex":
+			err = unmarshalJSONValue(v, &x.WktStepIndex)
+		case "SportEvent":
+			err = unmarshalJSONValue(v, &x.SportEvent)
+		case "AvgLeftTorqueEffectiveness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.AvgLeftTorqueEffectiveness = uint8(f)
+		case "AvgRightTorqueEffectiveness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.AvgRightTorqueEffectiveness = uint8(f)
+		case "AvgLeftPedalSmoothness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.AvgLeftPedalSmoothness = uint8(f)
+		case "AvgRightPedalSmoothness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.AvgRightPedalSmoothness = uint8(f)
+		case "AvgCombinedPedalSmoothness":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFF)
+			x.AvgCombinedPedalSmoothness = uint8(f)
+		case "Status":
+			err = unmarshalJSONValue(v, &x.Status)
+		case "Uuid":
+			err = unmarshalJSONValue(v, &x.Uuid)
+		case "AvgFractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.AvgFractionalCadence = uint8(f)
+		case "MaxFractionalCadence":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.MaxFractionalCadence = uint8(f)
+		case "TotalFractionalCycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 128, 0, 0xFF)
+			x.TotalFractionalCycles = uint8(f)
+		case "FrontGearShiftCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.FrontGearShiftCount = uint16(f)
+		case "RearGearShiftCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.RearGearShiftCount = uint16(f)
+		}
+		if err != nil {
+			return jsonFieldError{"SegmentLapMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // SegmentFileMsg represents the segment_file FIT message type.
 type SegmentFileMsg struct {
 	MessageIndex          MessageIndex
@@ -3290,6 +10381,135 @@ type SegmentFileMsg struct {
 	LeaderActivityId      []uint32                 // Activity ID of each leader in the segment file
 }
 
+// NewSegmentFileMsg returns a segment_file FIT message
+// initialized to all-invalid values.
+func NewSegmentFileMsg() *SegmentFileMsg {
+	msg := msgsAllInvalid[MesgNumSegmentFile].Interface().(SegmentFileMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x SegmentFileMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.FileUuid != "" {
+		e.field("FileUuid")
+		e.writeString(x.FileUuid)
+	}
+	if x.Enabled != 0xFF {
+		e.field("Enabled")
+		e.writeBoolEnum(x.Enabled)
+	}
+	if x.UserProfilePrimaryKey != 0xFFFFFFFF {
+		e.field("UserProfilePrimaryKey")
+		e.writeUintUnits(uint64(x.UserProfilePrimaryKey), "")
+	}
+	if len(x.LeaderType) != 0 {
+		e.field("LeaderType")
+		e.openArray()
+		for i, v := range x.LeaderType {
+			if i > 0 {
+				e.c()
+			}
+			e.writeEnum(v.String(), uint64(v))
+		}
+		e.closeArray()
+	}
+	if len(x.LeaderGroupPrimaryKey) != 0 {
+		e.field("LeaderGroupPrimaryKey")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.LeaderGroupPrimaryKey {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if len(x.LeaderActivityId) != 0 {
+		e.field("LeaderActivityId")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.LeaderActivityId {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *SegmentFileMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSegmentFile].Interface().(SegmentFileMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "FileUuid":
+			err = unmarshalJSONValue(v, &x.FileUuid)
+		case "Enabled":
+			err = unmarshalJSONValue(v, &x.Enabled)
+		case "UserProfilePrimaryKey":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.UserProfilePrimaryKey = uint32(f)
+		case "LeaderType":
+			err = unmarshalJSONValue(v, &x.LeaderType)
+		case "LeaderGroupPrimaryKey":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.LeaderGroupPrimaryKey = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.LeaderGroupPrimaryKey[i] = uint32(f)
+				}
+			}
+		case "LeaderActivityId":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.LeaderActivityId = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.LeaderActivityId[i] = uint32(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"SegmentFileMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WorkoutMsg represents the workout FIT message type.
 type WorkoutMsg struct {
 	Sport          Sport
@@ -3312,6 +10532,88 @@ func (x *WorkoutMsg) GetPoolLengthScaled() float64 {
 	return float64(x.PoolLength) / 100
 }
 
+// NewWorkoutMsg returns a workout FIT message
+// initialized to all-invalid values.
+func NewWorkoutMsg() *WorkoutMsg {
+	msg := msgsAllInvalid[MesgNumWorkout].Interface().(WorkoutMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WorkoutMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.Capabilities != 0x00000000 {
+		e.field("Capabilities")
+		e.writeEnum(x.Capabilities.String(), uint64(x.Capabilities))
+	}
+	if x.NumValidSteps != 0xFFFF {
+		e.field("NumValidSteps")
+		e.writeUintUnits(uint64(x.NumValidSteps), "")
+	}
+	if x.WktName != "" {
+		e.field("WktName")
+		e.writeString(x.WktName)
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.PoolLength != 0xFFFF {
+		e.field("PoolLength")
+		e.writeScaled(float64(x.PoolLength)/100, "m")
+	}
+	if x.PoolLengthUnit != 0xFF {
+		e.field("PoolLengthUnit")
+		e.writeEnum(x.PoolLengthUnit.String(), uint64(x.PoolLengthUnit))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WorkoutMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumWorkout].Interface().(WorkoutMsg)
+	for name, v := range fields {
+		switch name {
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "Capabilities":
+			err = unmarshalJSONValue(v, &x.Capabilities)
+		case "NumValidSteps":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumValidSteps = uint16(f)
+		case "WktName":
+			err = unmarshalJSONValue(v, &x.WktName)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "PoolLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.PoolLength = uint16(f)
+		case "PoolLengthUnit":
+			err = unmarshalJSONValue(v, &x.PoolLengthUnit)
+		}
+		if err != nil {
+			return jsonFieldError{"WorkoutMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WorkoutSessionMsg represents the workout_session FIT message type.
 type WorkoutSessionMsg struct {
 	MessageIndex   MessageIndex
@@ -3334,6 +10636,90 @@ func (x *WorkoutSessionMsg) GetPoolLengthScaled() float64 {
 	return float64(x.PoolLength) / 100
 }
 
+// NewWorkoutSessionMsg returns a workout_session FIT message
+// initialized to all-invalid values.
+func NewWorkoutSessionMsg() *WorkoutSessionMsg {
+	msg := msgsAllInvalid[MesgNumWorkoutSession].Interface().(WorkoutSessionMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WorkoutSessionMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.SubSport != 0xFF {
+		e.field("SubSport")
+		e.writeEnum(x.SubSport.String(), uint64(x.SubSport))
+	}
+	if x.NumValidSteps != 0xFFFF {
+		e.field("NumValidSteps")
+		e.writeUintUnits(uint64(x.NumValidSteps), "")
+	}
+	if x.FirstStepIndex != 0xFFFF {
+		e.field("FirstStepIndex")
+		e.writeUintUnits(uint64(x.FirstStepIndex), "")
+	}
+	if x.PoolLength != 0xFFFF {
+		e.field("PoolLength")
+		e.writeScaled(float64(x.PoolLength)/100, "m")
+	}
+	if x.PoolLengthUnit != 0xFF {
+		e.field("PoolLengthUnit")
+		e.writeEnum(x.PoolLengthUnit.String(), uint64(x.PoolLengthUnit))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WorkoutSessionMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumWorkoutSession].Interface().(WorkoutSessionMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "SubSport":
+			err = unmarshalJSONValue(v, &x.SubSport)
+		case "NumValidSteps":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.NumValidSteps = uint16(f)
+		case "FirstStepIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.FirstStepIndex = uint16(f)
+		case "PoolLength":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.PoolLength = uint16(f)
+		case "PoolLengthUnit":
+			err = unmarshalJSONValue(v, &x.PoolLengthUnit)
+		}
+		if err != nil {
+			return jsonFieldError{"WorkoutSessionMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WorkoutStepMsg represents the workout_step FIT message type.
 type WorkoutStepMsg struct {
 	MessageIndex          MessageIndex
@@ -3447,6 +10833,116 @@ func (x *WorkoutStepMsg) GetCustomTargetValueHigh() interface{} {
 	}
 }
 
+// NewWorkoutStepMsg returns a workout_step FIT message
+// initialized to all-invalid values.
+func NewWorkoutStepMsg() *WorkoutStepMsg {
+	msg := msgsAllInvalid[MesgNumWorkoutStep].Interface().(WorkoutStepMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WorkoutStepMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if x.WktStepName != "" {
+		e.field("WktStepName")
+		e.writeString(x.WktStepName)
+	}
+	if x.DurationType != 0xFF {
+		e.field("DurationType")
+		e.writeEnum(x.DurationType.String(), uint64(x.DurationType))
+	}
+	if x.DurationValue != 0xFFFFFFFF {
+		e.field("DurationValue")
+		e.writeUintUnits(uint64(x.DurationValue), "")
+	}
+	if x.TargetType != 0xFF {
+		e.field("TargetType")
+		e.writeEnum(x.TargetType.String(), uint64(x.TargetType))
+	}
+	if x.TargetValue != 0xFFFFFFFF {
+		e.field("TargetValue")
+		e.writeUintUnits(uint64(x.TargetValue), "")
+	}
+	if x.CustomTargetValueLow != 0xFFFFFFFF {
+		e.field("CustomTargetValueLow")
+		e.writeUintUnits(uint64(x.CustomTargetValueLow), "")
+	}
+	if x.CustomTargetValueHigh != 0xFFFFFFFF {
+		e.field("CustomTargetValueHigh")
+		e.writeUintUnits(uint64(x.CustomTargetValueHigh), "")
+	}
+	if x.Intensity != 0xFF {
+		e.field("Intensity")
+		e.writeEnum(x.Intensity.String(), uint64(x.Intensity))
+	}
+	if x.Notes != "" {
+		e.field("Notes")
+		e.writeString(x.Notes)
+	}
+	if x.Equipment != 0xFF {
+		e.field("Equipment")
+		e.writeEnum(x.Equipment.String(), uint64(x.Equipment))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WorkoutStepMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumWorkoutStep].Interface().(WorkoutStepMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "WktStepName":
+			err = unmarshalJSONValue(v, &x.WktStepName)
+		case "DurationType":
+			err = unmarshalJSONValue(v, &x.DurationType)
+		case "DurationValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.DurationValue = uint32(f)
+		case "TargetType":
+			err = unmarshalJSONValue(v, &x.TargetType)
+		case "TargetValue":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TargetValue = uint32(f)
+		case "CustomTargetValueLow":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.CustomTargetValueLow = uint32(f)
+		case "CustomTargetValueHigh":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.CustomTargetValueHigh = uint32(f)
+		case "Intensity":
+			err = unmarshalJSONValue(v, &x.Intensity)
+		case "Notes":
+			err = unmarshalJSONValue(v, &x.Notes)
+		case "Equipment":
+			err = unmarshalJSONValue(v, &x.Equipment)
+		}
+		if err != nil {
+			return jsonFieldError{"WorkoutStepMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // ScheduleMsg represents the schedule FIT message type.
 type ScheduleMsg struct {
 	Manufacturer  Manufacturer // Corresponds to file_id of scheduled workout / course.
@@ -3471,6 +10967,88 @@ func (x *ScheduleMsg) GetProduct() interface{} {
 	}
 }
 
+// NewScheduleMsg returns a schedule FIT message
+// initialized to all-invalid values.
+func NewScheduleMsg() *ScheduleMsg {
+	msg := msgsAllInvalid[MesgNumSchedule].Interface().(ScheduleMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ScheduleMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.Manufacturer != 0xFFFF {
+		e.field("Manufacturer")
+		e.writeEnum(x.Manufacturer.String(), uint64(x.Manufacturer))
+	}
+	if x.Product != 0xFFFF {
+		e.field("Product")
+		e.writeUintUnits(uint64(x.Product), "")
+	}
+	if x.SerialNumber != 0x00000000 {
+		e.field("SerialNumber")
+		e.writeUintUnits(uint64(x.SerialNumber), "")
+	}
+	if isValidTime(x.TimeCreated) {
+		e.field("TimeCreated")
+		e.writeTime(x.TimeCreated)
+	}
+	if x.Completed != 0xFF {
+		e.field("Completed")
+		e.writeBoolEnum(x.Completed)
+	}
+	if x.Type != 0xFF {
+		e.field("Type")
+		e.writeEnum(x.Type.String(), uint64(x.Type))
+	}
+	if isValidTime(x.ScheduledTime) {
+		e.field("ScheduledTime")
+		e.writeTime(x.ScheduledTime)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ScheduleMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumSchedule].Interface().(ScheduleMsg)
+	for name, v := range fields {
+		switch name {
+		case "Manufacturer":
+			err = unmarshalJSONValue(v, &x.Manufacturer)
+		case "Product":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Product = uint16(f)
+		case "SerialNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x00000000)
+			x.SerialNumber = uint32(f)
+		case "TimeCreated":
+			err = unmarshalJSONValue(v, &x.TimeCreated)
+		case "Completed":
+			err = unmarshalJSONValue(v, &x.Completed)
+		case "Type":
+			err = unmarshalJSONValue(v, &x.Type)
+		case "ScheduledTime":
+			err = unmarshalJSONValue(v, &x.ScheduledTime)
+		}
+		if err != nil {
+			return jsonFieldError{"ScheduleMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // TotalsMsg represents the totals FIT message type.
 type TotalsMsg struct {
 	MessageIndex MessageIndex
@@ -3484,6 +11062,108 @@ type TotalsMsg struct {
 	ActiveTime   uint32
 }
 
+// NewTotalsMsg returns a totals FIT message
+// initialized to all-invalid values.
+func NewTotalsMsg() *TotalsMsg {
+	msg := msgsAllInvalid[MesgNumTotals].Interface().(TotalsMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x TotalsMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.MessageIndex != 0xFFFF {
+		e.field("MessageIndex")
+		e.writeEnum(x.MessageIndex.String(), uint64(x.MessageIndex))
+	}
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.TimerTime != 0xFFFFFFFF {
+		e.field("TimerTime")
+		e.writeUintUnits(uint64(x.TimerTime), "s")
+	}
+	if x.Distance != 0xFFFFFFFF {
+		e.field("Distance")
+		e.writeUintUnits(uint64(x.Distance), "m")
+	}
+	if x.Calories != 0xFFFFFFFF {
+		e.field("Calories")
+		e.writeUintUnits(uint64(x.Calories), "kcal")
+	}
+	if x.Sport != 0xFF {
+		e.field("Sport")
+		e.writeEnum(x.Sport.String(), uint64(x.Sport))
+	}
+	if x.ElapsedTime != 0xFFFFFFFF {
+		e.field("ElapsedTime")
+		e.writeUintUnits(uint64(x.ElapsedTime), "s")
+	}
+	if x.Sessions != 0xFFFF {
+		e.field("Sessions")
+		e.writeUintUnits(uint64(x.Sessions), "")
+	}
+	if x.ActiveTime != 0xFFFFFFFF {
+		e.field("ActiveTime")
+		e.writeUintUnits(uint64(x.ActiveTime), "s")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *TotalsMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumTotals].Interface().(TotalsMsg)
+	for name, v := range fields {
+		switch name {
+		case "MessageIndex":
+			err = unmarshalJSONValue(v, &x.MessageIndex)
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "TimerTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.TimerTime = uint32(f)
+		case "Distance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.Distance = uint32(f)
+		case "Calories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.Calories = uint32(f)
+		case "Sport":
+			err = unmarshalJSONValue(v, &x.Sport)
+		case "ElapsedTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.ElapsedTime = uint32(f)
+		case "Sessions":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Sessions = uint16(f)
+		case "ActiveTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.ActiveTime = uint32(f)
+		}
+		if err != nil {
+			return jsonFieldError{"TotalsMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // WeightScaleMsg represents the weight_scale FIT message type.
 type WeightScaleMsg struct {
 	Timestamp         time.Time
@@ -3589,6 +11269,140 @@ func (x *WeightScaleMsg) GetActiveMetScaled() float64 {
 	return float64(x.ActiveMet) / 4
 }
 
+// NewWeightScaleMsg returns a weight_scale FIT message
+// initialized to all-invalid values.
+func NewWeightScaleMsg() *WeightScaleMsg {
+	msg := msgsAllInvalid[MesgNumWeightScale].Interface().(WeightScaleMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x WeightScaleMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.Weight != 0xFFFF {
+		e.field("Weight")
+		e.writeEnum(x.Weight.String(), uint64(x.Weight))
+	}
+	if x.PercentFat != 0xFFFF {
+		e.field("PercentFat")
+		e.writeScaled(float64(x.PercentFat)/100, "%")
+	}
+	if x.PercentHydration != 0xFFFF {
+		e.field("PercentHydration")
+		e.writeScaled(float64(x.PercentHydration)/100, "%")
+	}
+	if x.VisceralFatMass != 0xFFFF {
+		e.field("VisceralFatMass")
+		e.writeScaled(float64(x.VisceralFatMass)/100, "kg")
+	}
+	if x.BoneMass != 0xFFFF {
+		e.field("BoneMass")
+		e.writeScaled(float64(x.BoneMass)/100, "kg")
+	}
+	if x.MuscleMass != 0xFFFF {
+		e.field("MuscleMass")
+		e.writeScaled(float64(x.MuscleMass)/100, "kg")
+	}
+	if x.BasalMet != 0xFFFF {
+		e.field("BasalMet")
+		e.writeScaled(float64(x.BasalMet)/4, "kcal/day")
+	}
+	if x.PhysiqueRating != 0xFF {
+		e.field("PhysiqueRating")
+		e.writeUintUnits(uint64(x.PhysiqueRating), "")
+	}
+	if x.ActiveMet != 0xFFFF {
+		e.field("ActiveMet")
+		e.writeScaled(float64(x.ActiveMet)/4, "kcal/day")
+	}
+	if x.MetabolicAge != 0xFF {
+		e.field("MetabolicAge")
+		e.writeUintUnits(uint64(x.MetabolicAge), "years")
+	}
+	if x.VisceralFatRating != 0xFF {
+		e.field("VisceralFatRating")
+		e.writeUintUnits(uint64(x.VisceralFatRating), "")
+	}
+	if x.UserProfileIndex != 0xFFFF {
+		e.field("UserProfileIndex")
+		e.writeEnum(x.UserProfileIndex.String(), uint64(x.UserProfileIndex))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *WeightScaleMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumWeightScale].Interface().(WeightScaleMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "Weight":
+			err = unmarshalJSONValue(v, &x.Weight)
+		case "PercentFat":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.PercentFat = uint16(f)
+		case "PercentHydration":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.PercentHydration = uint16(f)
+		case "VisceralFatMass":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.VisceralFatMass = uint16(f)
+		case "BoneMass":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.BoneMass = uint16(f)
+		case "MuscleMass":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFF)
+			x.MuscleMass = uint16(f)
+		case "BasalMet":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 4, 0, 0xFFFF)
+			x.BasalMet = uint16(f)
+		case "PhysiqueRating":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.PhysiqueRating = uint8(f)
+		case "ActiveMet":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 4, 0, 0xFFFF)
+			x.ActiveMet = uint16(f)
+		case "MetabolicAge":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MetabolicAge = uint8(f)
+		case "VisceralFatRating":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.VisceralFatRating = uint8(f)
+		case "UserProfileIndex":
+			err = unmarshalJSONValue(v, &x.UserProfileIndex)
+		}
+		if err != nil {
+			return jsonFieldError{"WeightScaleMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // BloodPressureMsg represents the blood_pressure FIT message type.
 type BloodPressureMsg struct {
 	Timestamp            time.Time
@@ -3604,12 +11418,176 @@ type BloodPressureMsg struct {
 	UserProfileIndex     MessageIndex // Associates this blood pressure message to a user.  This corresponds to the index of the user profile message in the blood pressure file.
 }
 
+// NewBloodPressureMsg returns a blood_pressure FIT message
+// initialized to all-invalid values.
+func NewBloodPressureMsg() *BloodPressureMsg {
+	msg := msgsAllInvalid[MesgNumBloodPressure].Interface().(BloodPressureMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x BloodPressureMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.SystolicPressure != 0xFFFF {
+		e.field("SystolicPressure")
+		e.writeUintUnits(uint64(x.SystolicPressure), "mmHg")
+	}
+	if x.DiastolicPressure != 0xFFFF {
+		e.field("DiastolicPressure")
+		e.writeUintUnits(uint64(x.DiastolicPressure), "mmHg")
+	}
+	if x.MeanArterialPressure != 0xFFFF {
+		e.field("MeanArterialPressure")
+		e.writeUintUnits(uint64(x.MeanArterialPressure), "mmHg")
+	}
+	if x.Map3SampleMean != 0xFFFF {
+		e.field("Map3SampleMean")
+		e.writeUintUnits(uint64(x.Map3SampleMean), "mmHg")
+	}
+	if x.MapMorningValues != 0xFFFF {
+		e.field("MapMorningValues")
+		e.writeUintUnits(uint64(x.MapMorningValues), "mmHg")
+	}
+	if x.MapEveningValues != 0xFFFF {
+		e.field("MapEveningValues")
+		e.writeUintUnits(uint64(x.MapEveningValues), "mmHg")
+	}
+	if x.HeartRate != 0xFF {
+		e.field("HeartRate")
+		e.writeUintUnits(uint64(x.HeartRate), "bpm")
+	}
+	if x.HeartRateType != 0xFF {
+		e.field("HeartRateType")
+		e.writeEnum(x.HeartRateType.String(), uint64(x.HeartRateType))
+	}
+	if x.Status != 0xFF {
+		e.field("Status")
+		e.writeEnum(x.Status.String(), uint64(x.Status))
+	}
+	if x.UserProfileIndex != 0xFFFF {
+		e.field("UserProfileIndex")
+		e.writeEnum(x.UserProfileIndex.String(), uint64(x.UserProfileIndex))
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *BloodPressureMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumBloodPressure].Interface().(BloodPressureMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "SystolicPressure":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.SystolicPressure = uint16(f)
+		case "DiastolicPressure":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.DiastolicPressure = uint16(f)
+		case "MeanArterialPressure":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MeanArterialPressure = uint16(f)
+		case "Map3SampleMean":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Map3SampleMean = uint16(f)
+		case "MapMorningValues":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MapMorningValues = uint16(f)
+		case "MapEveningValues":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.MapEveningValues = uint16(f)
+		case "HeartRate":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.HeartRate = uint8(f)
+		case "HeartRateType":
+			err = unmarshalJSONValue(v, &x.HeartRateType)
+		case "Status":
+			err = unmarshalJSONValue(v, &x.Status)
+		case "UserProfileIndex":
+			err = unmarshalJSONValue(v, &x.UserProfileIndex)
+		}
+		if err != nil {
+			return jsonFieldError{"BloodPressureMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // MonitoringInfoMsg represents the monitoring_info FIT message type.
 type MonitoringInfoMsg struct {
 	Timestamp      time.Time
 	LocalTimestamp time.Time // Use to convert activity timestamps to local time if device does not support time zone and daylight savings time correction.
 }
 
+// NewMonitoringInfoMsg returns a monitoring_info FIT message
+// initialized to all-invalid values.
+func NewMonitoringInfoMsg() *MonitoringInfoMsg {
+	msg := msgsAllInvalid[MesgNumMonitoringInfo].Interface().(MonitoringInfoMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x MonitoringInfoMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if isValidTime(x.LocalTimestamp) {
+		e.field("LocalTimestamp")
+		e.writeTime(x.LocalTimestamp)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *MonitoringInfoMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumMonitoringInfo].Interface().(MonitoringInfoMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "LocalTimestamp":
+			err = unmarshalJSONValue(v, &x.LocalTimestamp)
+		}
+		if err != nil {
+			return jsonFieldError{"MonitoringInfoMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // MonitoringMsg represents the monitoring FIT message type.
 type MonitoringMsg struct {
 	Timestamp       time.Time   // Must align to logging interval, for example, time must be 00:00:00 for daily log.
@@ -3672,6 +11650,128 @@ func (x *MonitoringMsg) GetCycles() interface{} {
 	}
 }
 
+// NewMonitoringMsg returns a monitoring FIT message
+// initialized to all-invalid values.
+func NewMonitoringMsg() *MonitoringMsg {
+	msg := msgsAllInvalid[MesgNumMonitoring].Interface().(MonitoringMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x MonitoringMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.DeviceIndex != 0xFF {
+		e.field("DeviceIndex")
+		e.writeEnum(x.DeviceIndex.String(), uint64(x.DeviceIndex))
+	}
+	if x.Calories != 0xFFFF {
+		e.field("Calories")
+		e.writeUintUnits(uint64(x.Calories), "kcal")
+	}
+	if x.Distance != 0xFFFFFFFF {
+		e.field("Distance")
+		e.writeScaled(float64(x.Distance)/100, "m")
+	}
+	if x.Cycles != 0xFFFFFFFF {
+		e.field("Cycles")
+		e.writeScaled(float64(x.Cycles)/2, "cycles")
+	}
+	if x.ActiveTime != 0xFFFFFFFF {
+		e.field("ActiveTime")
+		e.writeScaled(float64(x.ActiveTime)/1000, "s")
+	}
+	if x.ActivityType != 0xFF {
+		e.field("ActivityType")
+		e.writeEnum(x.ActivityType.String(), uint64(x.ActivityType))
+	}
+	if x.ActivitySubtype != 0xFF {
+		e.field("ActivitySubtype")
+		e.writeEnum(x.ActivitySubtype.String(), uint64(x.ActivitySubtype))
+	}
+	if x.Distance16 != 0xFFFF {
+		e.field("Distance16")
+		e.writeUintUnits(uint64(x.Distance16), "100 * m")
+	}
+	if x.Cycles16 != 0xFFFF {
+		e.field("Cycles16")
+		e.writeUintUnits(uint64(x.Cycles16), "2 * cycles (steps)")
+	}
+	if x.ActiveTime16 != 0xFFFF {
+		e.field("ActiveTime16")
+		e.writeUintUnits(uint64(x.ActiveTime16), "s")
+	}
+	if isValidTime(x.LocalTimestamp) {
+		e.field("LocalTimestamp")
+		e.writeTime(x.LocalTimestamp)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *MonitoringMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumMonitoring].Interface().(MonitoringMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "DeviceIndex":
+			err = unmarshalJSONValue(v, &x.DeviceIndex)
+		case "Calories":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Calories = uint16(f)
+		case "Distance":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 100, 0, 0xFFFFFFFF)
+			x.Distance = uint32(f)
+		case "Cycles":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 2, 0, 0xFFFFFFFF)
+			x.Cycles = uint32(f)
+		case "ActiveTime":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1000, 0, 0xFFFFFFFF)
+			x.ActiveTime = uint32(f)
+		case "ActivityType":
+			err = unmarshalJSONValue(v, &x.ActivityType)
+		case "ActivitySubtype":
+			err = unmarshalJSONValue(v, &x.ActivitySubtype)
+		case "Distance16":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Distance16 = uint16(f)
+		case "Cycles16":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.Cycles16 = uint16(f)
+		case "ActiveTime16":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFF)
+			x.ActiveTime16 = uint16(f)
+		case "LocalTimestamp":
+			err = unmarshalJSONValue(v, &x.LocalTimestamp)
+		}
+		if err != nil {
+			return jsonFieldError{"MonitoringMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // HrMsg represents the hr FIT message type.
 type HrMsg struct {
 	Timestamp           time.Time
@@ -3724,14 +11824,203 @@ func (x *HrMsg) expandComponents() {
 	// TODO
 }
 
+// NewHrMsg returns a hr FIT message
+// initialized to all-invalid values.
+func NewHrMsg() *HrMsg {
+	msg := msgsAllInvalid[MesgNumHr].Interface().(HrMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x HrMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.FractionalTimestamp != 0xFFFF {
+		e.field("FractionalTimestamp")
+		e.writeScaled(float64(x.FractionalTimestamp)/32768, "s")
+	}
+	if x.Time256 != 0xFF {
+		e.field("Time256")
+		e.writeScaled(float64(x.Time256)/256, "s")
+	}
+	if len(x.FilteredBpm) != 0 {
+		e.field("FilteredBpm")
+		e.openUnits("bpm")
+		e.openArray()
+		for i, v := range x.FilteredBpm {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("bpm")
+	}
+	if len(x.EventTimestamp) != 0 {
+		e.field("EventTimestamp")
+		e.openValue()
+		e.openArray()
+		for i, v := range x.EventTimestamp {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFFFFFFFF:
+				e.writeNull()
+			default:
+				e.writeFloat(float64(v) / 1024)
+			}
+		}
+		e.closeArray()
+		e.closeValue("s")
+	}
+	if len(x.EventTimestamp12) != 0 {
+		e.field("EventTimestamp12")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.EventTimestamp12 {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *HrMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumHr].Interface().(HrMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "FractionalTimestamp":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 32768, 0, 0xFFFF)
+			x.FractionalTimestamp = uint16(f)
+		case "Time256":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 256, 0, 0xFF)
+			x.Time256 = uint8(f)
+		case "FilteredBpm":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.FilteredBpm = make([]uint8, len(fs))
+				for i, f := range fs {
+					x.FilteredBpm[i] = uint8(f)
+				}
+			}
+		case "EventTimestamp":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1024, 0, 0xFFFFFFFF)
+			if fs != nil {
+				x.EventTimestamp = make([]uint32, len(fs))
+				for i, f := range fs {
+					x.EventTimestamp[i] = uint32(f)
+				}
+			}
+		case "EventTimestamp12":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.EventTimestamp12 = make([]byte, len(fs))
+				for i, f := range fs {
+					x.EventTimestamp12[i] = byte(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"HrMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // MemoGlobMsg represents the memo_glob FIT message type.
 type MemoGlobMsg struct {
 }
 
+// NewMemoGlobMsg returns a memo_glob FIT message
+// initialized to all-invalid values.
+func NewMemoGlobMsg() *MemoGlobMsg {
+	msg := msgsAllInvalid[MesgNumMemoGlob].Interface().(MemoGlobMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x MemoGlobMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *MemoGlobMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // AntChannelIdMsg represents the ant_channel_id FIT message type.
 type AntChannelIdMsg struct {
 }
 
+// NewAntChannelIdMsg returns a ant_channel_id FIT message
+// initialized to all-invalid values.
+func NewAntChannelIdMsg() *AntChannelIdMsg {
+	msg := msgsAllInvalid[MesgNumAntChannelId].Interface().(AntChannelIdMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x AntChannelIdMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *AntChannelIdMsg) UnmarshalJSON(b []byte) error {
+	_, err := unmarshalJSONObject(b)
+	return err
+}
+
 // AntRxMsg represents the ant_rx FIT message type.
 type AntRxMsg struct {
 	Timestamp           time.Time
@@ -3769,6 +12058,126 @@ func (x *AntRxMsg) expandComponents() {
 	}
 }
 
+// NewAntRxMsg returns a ant_rx FIT message
+// initialized to all-invalid values.
+func NewAntRxMsg() *AntRxMsg {
+	msg := msgsAllInvalid[MesgNumAntRx].Interface().(AntRxMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x AntRxMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.FractionalTimestamp != 0xFFFF {
+		e.field("FractionalTimestamp")
+		e.writeScaled(float64(x.FractionalTimestamp)/32768, "s")
+	}
+	if x.MesgId != 0xFF {
+		e.field("MesgId")
+		e.writeUintUnits(uint64(x.MesgId), "")
+	}
+	if len(x.MesgData) != 0 {
+		e.field("MesgData")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.MesgData {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.ChannelNumber != 0xFF {
+		e.field("ChannelNumber")
+		e.writeUintUnits(uint64(x.ChannelNumber), "")
+	}
+	if len(x.Data) != 0 {
+		e.field("Data")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.Data {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *AntRxMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumAntRx].Interface().(AntRxMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "FractionalTimestamp":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 32768, 0, 0xFFFF)
+			x.FractionalTimestamp = uint16(f)
+		case "MesgId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MesgId = byte(f)
+		case "MesgData":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.MesgData = make([]byte, len(fs))
+				for i, f := range fs {
+					x.MesgData[i] = byte(f)
+				}
+			}
+		case "ChannelNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ChannelNumber = uint8(f)
+		case "Data":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.Data = make([]byte, len(fs))
+				for i, f := range fs {
+					x.Data[i] = byte(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"AntRxMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // AntTxMsg represents the ant_tx FIT message type.
 type AntTxMsg struct {
 	Timestamp           time.Time
@@ -3806,6 +12215,126 @@ func (x *AntTxMsg) expandComponents() {
 	}
 }
 
+// NewAntTxMsg returns a ant_tx FIT message
+// initialized to all-invalid values.
+func NewAntTxMsg() *AntTxMsg {
+	msg := msgsAllInvalid[MesgNumAntTx].Interface().(AntTxMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x AntTxMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if isValidTime(x.Timestamp) {
+		e.field("Timestamp")
+		e.writeTime(x.Timestamp)
+	}
+	if x.FractionalTimestamp != 0xFFFF {
+		e.field("FractionalTimestamp")
+		e.writeScaled(float64(x.FractionalTimestamp)/32768, "s")
+	}
+	if x.MesgId != 0xFF {
+		e.field("MesgId")
+		e.writeUintUnits(uint64(x.MesgId), "")
+	}
+	if len(x.MesgData) != 0 {
+		e.field("MesgData")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.MesgData {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.ChannelNumber != 0xFF {
+		e.field("ChannelNumber")
+		e.writeUintUnits(uint64(x.ChannelNumber), "")
+	}
+	if len(x.Data) != 0 {
+		e.field("Data")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.Data {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *AntTxMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumAntTx].Interface().(AntTxMsg)
+	for name, v := range fields {
+		switch name {
+		case "Timestamp":
+			err = unmarshalJSONValue(v, &x.Timestamp)
+		case "FractionalTimestamp":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 32768, 0, 0xFFFF)
+			x.FractionalTimestamp = uint16(f)
+		case "MesgId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.MesgId = byte(f)
+		case "MesgData":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.MesgData = make([]byte, len(fs))
+				for i, f := range fs {
+					x.MesgData[i] = byte(f)
+				}
+			}
+		case "ChannelNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ChannelNumber = uint8(f)
+		case "Data":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.Data = make([]byte, len(fs))
+				for i, f := range fs {
+					x.Data[i] = byte(f)
+				}
+			}
+		}
+		if err != nil {
+			return jsonFieldError{"AntTxMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // ExdScreenConfigurationMsg represents the exd_screen_configuration FIT message type.
 type ExdScreenConfigurationMsg struct {
 	ScreenIndex   uint8
@@ -3814,6 +12343,70 @@ type ExdScreenConfigurationMsg struct {
 	ScreenEnabled Bool
 }
 
+// NewExdScreenConfigurationMsg returns a exd_screen_configuration FIT message
+// initialized to all-invalid values.
+func NewExdScreenConfigurationMsg() *ExdScreenConfigurationMsg {
+	msg := msgsAllInvalid[MesgNumExdScreenConfiguration].Interface().(ExdScreenConfigurationMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ExdScreenConfigurationMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.ScreenIndex != 0xFF {
+		e.field("ScreenIndex")
+		e.writeUintUnits(uint64(x.ScreenIndex), "")
+	}
+	if x.FieldCount != 0xFF {
+		e.field("FieldCount")
+		e.writeUintUnits(uint64(x.FieldCount), "")
+	}
+	if x.Layout != 0xFF {
+		e.field("Layout")
+		e.writeEnum(x.Layout.String(), uint64(x.Layout))
+	}
+	if x.ScreenEnabled != 0xFF {
+		e.field("ScreenEnabled")
+		e.writeBoolEnum(x.ScreenEnabled)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ExdScreenConfigurationMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumExdScreenConfiguration].Interface().(ExdScreenConfigurationMsg)
+	for name, v := range fields {
+		switch name {
+		case "ScreenIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ScreenIndex = uint8(f)
+		case "FieldCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.FieldCount = uint8(f)
+		case "Layout":
+			err = unmarshalJSONValue(v, &x.Layout)
+		case "ScreenEnabled":
+			err = unmarshalJSONValue(v, &x.ScreenEnabled)
+		}
+		if err != nil {
+			return jsonFieldError{"ExdScreenConfigurationMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // ExdDataFieldConfigurationMsg represents the exd_data_field_configuration FIT message type.
 type ExdDataFieldConfigurationMsg struct {
 	ScreenIndex  uint8
@@ -3835,6 +12428,86 @@ func (x *ExdDataFieldConfigurationMsg) expandComponents() {
 	}
 }
 
+// NewExdDataFieldConfigurationMsg returns a exd_data_field_configuration FIT message
+// initialized to all-invalid values.
+func NewExdDataFieldConfigurationMsg() *ExdDataFieldConfigurationMsg {
+	msg := msgsAllInvalid[MesgNumExdDataFieldConfiguration].Interface().(ExdDataFieldConfigurationMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ExdDataFieldConfigurationMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.ScreenIndex != 0xFF {
+		e.field("ScreenIndex")
+		e.writeUintUnits(uint64(x.ScreenIndex), "")
+	}
+	if x.ConceptField != 0xFF {
+		e.field("ConceptField")
+		e.writeUintUnits(uint64(x.ConceptField), "")
+	}
+	if x.FieldId != 0xFF {
+		e.field("FieldId")
+		e.writeUintUnits(uint64(x.FieldId), "")
+	}
+	if x.ConceptCount != 0xFF {
+		e.field("ConceptCount")
+		e.writeUintUnits(uint64(x.ConceptCount), "")
+	}
+	if x.DisplayType != 0xFF {
+		e.field("DisplayType")
+		e.writeEnum(x.DisplayType.String(), uint64(x.DisplayType))
+	}
+	if len(x.Title) != 0 {
+		e.field("Title")
+		e.writeStrings(x.Title)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ExdDataFieldConfigurationMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumExdDataFieldConfiguration].Interface().(ExdDataFieldConfigurationMsg)
+	for name, v := range fields {
+		switch name {
+		case "ScreenIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ScreenIndex = uint8(f)
+		case "ConceptField":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ConceptField = byte(f)
+		case "FieldId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.FieldId = uint8(f)
+		case "ConceptCount":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ConceptCount = uint8(f)
+		case "DisplayType":
+			err = unmarshalJSONValue(v, &x.DisplayType)
+		case "Title":
+			err = unmarshalJSONValue(v, &x.Title)
+		}
+		if err != nil {
+			return jsonFieldError{"ExdDataFieldConfigurationMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // ExdDataConceptConfigurationMsg represents the exd_data_concept_configuration FIT message type.
 type ExdDataConceptConfigurationMsg struct {
 	ScreenIndex  uint8
@@ -3861,6 +12534,122 @@ func (x *ExdDataConceptConfigurationMsg) expandComponents() {
 	}
 }
 
+// NewExdDataConceptConfigurationMsg returns a exd_data_concept_configuration FIT message
+// initialized to all-invalid values.
+func NewExdDataConceptConfigurationMsg() *ExdDataConceptConfigurationMsg {
+	msg := msgsAllInvalid[MesgNumExdDataConceptConfiguration].Interface().(ExdDataConceptConfigurationMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x ExdDataConceptConfigurationMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.ScreenIndex != 0xFF {
+		e.field("ScreenIndex")
+		e.writeUintUnits(uint64(x.ScreenIndex), "")
+	}
+	if x.ConceptField != 0xFF {
+		e.field("ConceptField")
+		e.writeUintUnits(uint64(x.ConceptField), "")
+	}
+	if x.FieldId != 0xFF {
+		e.field("FieldId")
+		e.writeUintUnits(uint64(x.FieldId), "")
+	}
+	if x.ConceptIndex != 0xFF {
+		e.field("ConceptIndex")
+		e.writeUintUnits(uint64(x.ConceptIndex), "")
+	}
+	if x.DataPage != 0xFF {
+		e.field("DataPage")
+		e.writeUintUnits(uint64(x.DataPage), "")
+	}
+	if x.ConceptKey != 0xFF {
+		e.field("ConceptKey")
+		e.writeUintUnits(uint64(x.ConceptKey), "")
+	}
+	if x.Scaling != 0xFF {
+		e.field("Scaling")
+		e.writeUintUnits(uint64(x.Scaling), "")
+	}
+	if x.DataUnits != 0xFF {
+		e.field("DataUnits")
+		e.writeEnum(x.DataUnits.String(), uint64(x.DataUnits))
+	}
+	if x.Qualifier != 0xFF {
+		e.field("Qualifier")
+		e.writeEnum(x.Qualifier.String(), uint64(x.Qualifier))
+	}
+	if x.Descriptor != 0xFF {
+		e.field("Descriptor")
+		e.writeEnum(x.Descriptor.String(), uint64(x.Descriptor))
+	}
+	if x.IsSigned != 0xFF {
+		e.field("IsSigned")
+		e.writeBoolEnum(x.IsSigned)
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *ExdDataConceptConfigurationMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumExdDataConceptConfiguration].Interface().(ExdDataConceptConfigurationMsg)
+	for name, v := range fields {
+		switch name {
+		case "ScreenIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ScreenIndex = uint8(f)
+		case "ConceptField":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ConceptField = byte(f)
+		case "FieldId":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.FieldId = uint8(f)
+		case "ConceptIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ConceptIndex = uint8(f)
+		case "DataPage":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DataPage = uint8(f)
+		case "ConceptKey":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.ConceptKey = uint8(f)
+		case "Scaling":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Scaling = uint8(f)
+		case "DataUnits":
+			err = unmarshalJSONValue(v, &x.DataUnits)
+		case "Qualifier":
+			err = unmarshalJSONValue(v, &x.Qualifier)
+		case "Descriptor":
+			err = unmarshalJSONValue(v, &x.Descriptor)
+		case "IsSigned":
+			err = unmarshalJSONValue(v, &x.IsSigned)
+		}
+		if err != nil {
+			return jsonFieldError{"ExdDataConceptConfigurationMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // FieldDescriptionMsg represents the field_description FIT message type.
 type FieldDescriptionMsg struct {
 	DeveloperDataIndex    uint8
@@ -3879,6 +12668,138 @@ type FieldDescriptionMsg struct {
 	NativeFieldNum        uint8
 }
 
+// NewFieldDescriptionMsg returns a field_description FIT message
+// initialized to all-invalid values.
+func NewFieldDescriptionMsg() *FieldDescriptionMsg {
+	msg := msgsAllInvalid[MesgNumFieldDescription].Interface().(FieldDescriptionMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x FieldDescriptionMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if x.DeveloperDataIndex != 0xFF {
+		e.field("DeveloperDataIndex")
+		e.writeUintUnits(uint64(x.DeveloperDataIndex), "")
+	}
+	if x.FieldDefinitionNumber != 0xFF {
+		e.field("FieldDefinitionNumber")
+		e.writeUintUnits(uint64(x.FieldDefinitionNumber), "")
+	}
+	if x.FitBaseTypeId != 0xFF {
+		e.field("FitBaseTypeId")
+		e.writeEnum(x.FitBaseTypeId.String(), uint64(x.FitBaseTypeId))
+	}
+	if len(x.FieldName) != 0 {
+		e.field("FieldName")
+		e.writeStrings(x.FieldName)
+	}
+	if x.Array != 0xFF {
+		e.field("Array")
+		e.writeUintUnits(uint64(x.Array), "")
+	}
+	if x.Components != "" {
+		e.field("Components")
+		e.writeString(x.Components)
+	}
+	if x.Scale != 0xFF {
+		e.field("Scale")
+		e.writeUintUnits(uint64(x.Scale), "")
+	}
+	if x.Offset != 0x7F {
+		e.field("Offset")
+		e.writeIntUnits(int64(x.Offset), "")
+	}
+	if len(x.Units) != 0 {
+		e.field("Units")
+		e.writeStrings(x.Units)
+	}
+	if x.Bits != "" {
+		e.field("Bits")
+		e.writeString(x.Bits)
+	}
+	if x.Accumulate != "" {
+		e.field("Accumulate")
+		e.writeString(x.Accumulate)
+	}
+	if x.FitBaseUnitId != 0xFFFF {
+		e.field("FitBaseUnitId")
+		e.writeEnum(x.FitBaseUnitId.String(), uint64(x.FitBaseUnitId))
+	}
+	if x.NativeMesgNum != 0xFFFF {
+		e.field("NativeMesgNum")
+		e.writeEnum(x.NativeMesgNum.String(), uint64(x.NativeMesgNum))
+	}
+	if x.NativeFieldNum != 0xFF {
+		e.field("NativeFieldNum")
+		e.writeUintUnits(uint64(x.NativeFieldNum), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *FieldDescriptionMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumFieldDescription].Interface().(FieldDescriptionMsg)
+	for name, v := range fields {
+		switch name {
+		case "DeveloperDataIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DeveloperDataIndex = uint8(f)
+		case "FieldDefinitionNumber":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.FieldDefinitionNumber = uint8(f)
+		case "FitBaseTypeId":
+			err = unmarshalJSONValue(v, &x.FitBaseTypeId)
+		case "FieldName":
+			err = unmarshalJSONValue(v, &x.FieldName)
+		case "Array":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Array = uint8(f)
+		case "Components":
+			err = unmarshalJSONValue(v, &x.Components)
+		case "Scale":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.Scale = uint8(f)
+		case "Offset":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0x7F)
+			x.Offset = int8(f)
+		case "Units":
+			err = unmarshalJSONValue(v, &x.Units)
+		case "Bits":
+			err = unmarshalJSONValue(v, &x.Bits)
+		case "Accumulate":
+			err = unmarshalJSONValue(v, &x.Accumulate)
+		case "FitBaseUnitId":
+			err = unmarshalJSONValue(v, &x.FitBaseUnitId)
+		case "NativeMesgNum":
+			err = unmarshalJSONValue(v, &x.NativeMesgNum)
+		case "NativeFieldNum":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.NativeFieldNum = uint8(f)
+		}
+		if err != nil {
+			return jsonFieldError{"FieldDescriptionMsg", name, err}
+		}
+	}
+	return nil
+}
+
 // DeveloperDataIdMsg represents the developer_data_id FIT message type.
 type DeveloperDataIdMsg struct {
 	DeveloperId        []byte
@@ -3887,3 +12808,115 @@ type DeveloperDataIdMsg struct {
 	DeveloperDataIndex uint8
 	ApplicationVersion uint32
 }
+
+// NewDeveloperDataIdMsg returns a developer_data_id FIT message
+// initialized to all-invalid values.
+func NewDeveloperDataIdMsg() *DeveloperDataIdMsg {
+	msg := msgsAllInvalid[MesgNumDeveloperDataId].Interface().(DeveloperDataIdMsg)
+	return &msg
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields with invalid values are omitted. Scaled fields are
+// encoded with scale and any offset applied. Scaled fields and
+// fields with units are encoded as {"Value":x,"Units":u} objects.
+func (x DeveloperDataIdMsg) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.open()
+	if len(x.DeveloperId) != 0 {
+		e.field("DeveloperId")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.DeveloperId {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if len(x.ApplicationId) != 0 {
+		e.field("ApplicationId")
+		e.openUnits("")
+		e.openArray()
+		for i, v := range x.ApplicationId {
+			if i > 0 {
+				e.c()
+			}
+			switch {
+			case v == 0xFF:
+				e.writeNull()
+			default:
+				e.writeUint(uint64(v))
+			}
+		}
+		e.closeArray()
+		e.closeUnits("")
+	}
+	if x.ManufacturerId != 0xFFFF {
+		e.field("ManufacturerId")
+		e.writeEnum(x.ManufacturerId.String(), uint64(x.ManufacturerId))
+	}
+	if x.DeveloperDataIndex != 0xFF {
+		e.field("DeveloperDataIndex")
+		e.writeUintUnits(uint64(x.DeveloperDataIndex), "")
+	}
+	if x.ApplicationVersion != 0xFFFFFFFF {
+		e.field("ApplicationVersion")
+		e.writeUintUnits(uint64(x.ApplicationVersion), "")
+	}
+	e.close()
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Fields not present in b are set to their invalid value.
+func (x *DeveloperDataIdMsg) UnmarshalJSON(b []byte) error {
+	fields, err := unmarshalJSONObject(b)
+	if err != nil {
+		return err
+	}
+	*x = msgsAllInvalid[MesgNumDeveloperDataId].Interface().(DeveloperDataIdMsg)
+	for name, v := range fields {
+		switch name {
+		case "DeveloperId":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.DeveloperId = make([]byte, len(fs))
+				for i, f := range fs {
+					x.DeveloperId[i] = byte(f)
+				}
+			}
+		case "ApplicationId":
+			var fs []float64
+			fs, err = unmarshalJSONScaledArray(v, 1, 0, 0xFF)
+			if fs != nil {
+				x.ApplicationId = make([]byte, len(fs))
+				for i, f := range fs {
+					x.ApplicationId[i] = byte(f)
+				}
+			}
+		case "ManufacturerId":
+			err = unmarshalJSONValue(v, &x.ManufacturerId)
+		case "DeveloperDataIndex":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFF)
+			x.DeveloperDataIndex = uint8(f)
+		case "ApplicationVersion":
+			var f float64
+			f, err = unmarshalJSONScaled(v, 1, 0, 0xFFFFFFFF)
+			x.ApplicationVersion = uint32(f)
+		}
+		if err != nil {
+			return jsonFieldError{"DeveloperDataIdMsg", name, err}
+		}
+	}
+	return nil
+}
@@ -47,3 +47,14 @@ func WithUnknownMessages() DecodeOption {
 		o.unknownMessages = true
 	}
 }
+
+// EncodeOption configures an encoder.
+type EncodeOption func(*encodeOptions)
+
+// WithBigEndian configures the encoder to write multi-byte field values in
+// big-endian byte order. The default is little-endian.
+func WithBigEndian() EncodeOption {
+	return func(o *encodeOptions) {
+		o.arch = be
+	}
+}
@@ -0,0 +1,420 @@
+package fit
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// EarthRadius is the mean radius of the earth in metres, used for all
+// spherical computations.
+const EarthRadius = 6371008.8
+
+// WGS 84 ellipsoid parameters used by Vincenty's formulae.
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+const (
+	degToRad = math.Pi / 180
+	radToDeg = 180 / math.Pi
+)
+
+// Position represents a geographical position.
+type Position struct {
+	Lat  Latitude
+	Long Longitude
+}
+
+// NewPosition returns a new position from a latitude and a longitude.
+func NewPosition(lat Latitude, lng Longitude) Position {
+	return Position{Lat: lat, Long: lng}
+}
+
+// NewPositionDegrees returns a new position from a latitude and a longitude
+// in degrees. The longitude is normalized to the range [-180, 180).
+func NewPositionDegrees(lat, lng float64) Position {
+	return Position{
+		Lat:  NewLatitudeDegrees(lat),
+		Long: NewLongitudeDegrees(normalizeLongitude(lng)),
+	}
+}
+
+// NewPositionInvalid returns an invalid position.
+func NewPositionInvalid() Position {
+	return Position{Lat: NewLatitudeInvalid(), Long: NewLongitudeInvalid()}
+}
+
+// Invalid reports whether the latitude or longitude of p is invalid.
+func (p Position) Invalid() bool {
+	return p.Lat.Invalid() || p.Long.Invalid()
+}
+
+// String returns a string representation of p with 5 decimal places
+// precision.
+func (p Position) String() string {
+	return p.Lat.String() + ", " + p.Long.String()
+}
+
+func (p Position) radians() (phi, lambda float64) {
+	return p.Lat.Degrees() * degToRad, p.Long.Degrees() * degToRad
+}
+
+func positionRadians(phi, lambda float64) Position {
+	return NewPositionDegrees(phi*radToDeg, lambda*radToDeg)
+}
+
+func normalizeLongitude(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// Haversine returns the great-circle distance in metres between p and q on
+// a sphere with radius EarthRadius. NaN is returned if p or q is invalid.
+func (p Position) Haversine(q Position) float64 {
+	phi1, lambda1 := p.radians()
+	phi2, lambda2 := q.radians()
+	sdPhi := math.Sin((phi2 - phi1) / 2)
+	sdLambda := math.Sin((lambda2 - lambda1) / 2)
+	a := sdPhi*sdPhi + math.Cos(phi1)*math.Cos(phi2)*sdLambda*sdLambda
+	return 2 * EarthRadius * math.Asin(math.Sqrt(math.Min(a, 1)))
+}
+
+// Vincenty returns the distance in metres between p and q on the WGS 84
+// ellipsoid, computed with Vincenty's inverse formula. It is accurate to
+// within a millimetre, but slower than Haversine. NaN is returned if p or q
+// is invalid, or if the formula fails to converge, which may happen for
+// nearly antipodal points.
+func (p Position) Vincenty(q Position) float64 {
+	phi1, lambda1 := p.radians()
+	phi2, lambda2 := q.radians()
+	if math.IsNaN(phi1+lambda1) || math.IsNaN(phi2+lambda2) {
+		return math.NaN()
+	}
+
+	l := lambda2 - lambda1
+	u1 := math.Atan((1 - wgs84F) * math.Tan(phi1))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(phi2))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0 // Coincident points.
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0 // Equatorial line.
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) > 1e-12 {
+			continue
+		}
+
+		uSq := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+		a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+		b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+		deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		return wgs84B * a * (sigma - deltaSigma)
+	}
+	return math.NaN()
+}
+
+// Bearing returns the initial bearing in degrees, clockwise from north in
+// the range [0, 360), of the great circle from p to q. NaN is returned if p
+// or q is invalid.
+func (p Position) Bearing(q Position) float64 {
+	phi1, lambda1 := p.radians()
+	phi2, lambda2 := q.radians()
+	dLambda := lambda2 - lambda1
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	return math.Mod(math.Atan2(y, x)*radToDeg+360, 360)
+}
+
+// Destination returns the position reached by travelling distance metres
+// from p along the great circle with initial bearing in degrees. An invalid
+// position is returned if p is invalid.
+func (p Position) Destination(bearing, distance float64) Position {
+	if p.Invalid() {
+		return NewPositionInvalid()
+	}
+	phi1, lambda1 := p.radians()
+	theta := bearing * degToRad
+	delta := distance / EarthRadius
+	sinPhi2 := math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta)
+	phi2 := math.Asin(sinPhi2)
+	lambda2 := lambda1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(phi1),
+		math.Cos(delta)-math.Sin(phi1)*sinPhi2,
+	)
+	return positionRadians(phi2, lambda2)
+}
+
+// Midpoint returns the position halfway along the great circle between p
+// and q.
+func (p Position) Midpoint(q Position) Position {
+	return p.Intermediate(q, 0.5)
+}
+
+// Intermediate returns the position at fraction f of the way along the
+// great circle from p to q. An invalid position is returned if p or q is
+// invalid.
+func (p Position) Intermediate(q Position, f float64) Position {
+	if p.Invalid() || q.Invalid() {
+		return NewPositionInvalid()
+	}
+	switch f {
+	case 0:
+		return p
+	case 1:
+		return q
+	}
+	phi1, lambda1 := p.radians()
+	phi2, lambda2 := q.radians()
+	x1, y1, z1 := math.Cos(phi1)*math.Cos(lambda1), math.Cos(phi1)*math.Sin(lambda1), math.Sin(phi1)
+	x2, y2, z2 := math.Cos(phi2)*math.Cos(lambda2), math.Cos(phi2)*math.Sin(lambda2), math.Sin(phi2)
+	d := math.Acos(math.Max(-1, math.Min(1, x1*x2+y1*y2+z1*z2)))
+	if d < 1e-12 {
+		return p
+	}
+	a := math.Sin((1-f)*d) / math.Sin(d)
+	b := math.Sin(f*d) / math.Sin(d)
+	x, y, z := a*x1+b*x2, a*y1+b*y2, a*z1+b*z2
+	return positionRadians(math.Atan2(z, math.Hypot(x, y)), math.Atan2(y, x))
+}
+
+// BoundingBox is a latitude/longitude aligned rectangle.
+type BoundingBox struct {
+	Min Position // South-west corner.
+	Max Position // North-east corner.
+}
+
+// Contains reports whether p is inside b, including its edges.
+func (b BoundingBox) Contains(p Position) bool {
+	if p.Invalid() || b.Min.Invalid() || b.Max.Invalid() {
+		return false
+	}
+	lat, lng := p.Lat.Semicircles(), p.Long.Semicircles()
+	return lat >= b.Min.Lat.Semicircles() && lat <= b.Max.Lat.Semicircles() &&
+		lng >= b.Min.Long.Semicircles() && lng <= b.Max.Long.Semicircles()
+}
+
+// Bounds returns the smallest bounding box containing all valid positions
+// of ps. The bounding box of no valid positions has invalid corners. Tracks
+// crossing the antimeridian are not handled specially.
+func Bounds(ps []Position) BoundingBox {
+	b := BoundingBox{Min: NewPositionInvalid(), Max: NewPositionInvalid()}
+	for _, p := range ps {
+		if p.Invalid() {
+			continue
+		}
+		if b.Min.Invalid() {
+			b.Min, b.Max = p, p
+			continue
+		}
+		if p.Lat.Semicircles() < b.Min.Lat.Semicircles() {
+			b.Min.Lat = p.Lat
+		}
+		if p.Long.Semicircles() < b.Min.Long.Semicircles() {
+			b.Min.Long = p.Long
+		}
+		if p.Lat.Semicircles() > b.Max.Lat.Semicircles() {
+			b.Max.Lat = p.Lat
+		}
+		if p.Long.Semicircles() > b.Max.Long.Semicircles() {
+			b.Max.Long = p.Long
+		}
+	}
+	return b
+}
+
+// EncodePolyline encodes ps using Google's encoded polyline algorithm
+// format, with a precision of 5 decimal places. Invalid positions are
+// skipped.
+func EncodePolyline(ps []Position) string {
+	var (
+		sb               strings.Builder
+		prevLat, prevLng int64
+	)
+	for _, p := range ps {
+		if p.Invalid() {
+			continue
+		}
+		lat := int64(math.Round(p.Lat.Degrees() * 1e5))
+		lng := int64(math.Round(p.Long.Degrees() * 1e5))
+		encodePolylineValue(&sb, lat-prevLat)
+		encodePolylineValue(&sb, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return sb.String()
+}
+
+func encodePolylineValue(sb *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		sb.WriteByte(byte(0x20|(u&0x1f)) + 63)
+		u >>= 5
+	}
+	sb.WriteByte(byte(u) + 63)
+}
+
+// ErrInvalidPolyline is returned by DecodePolyline for malformed input.
+var ErrInvalidPolyline = errors.New("invalid encoded polyline")
+
+// DecodePolyline decodes a polyline in Google's encoded polyline algorithm
+// format with a precision of 5 decimal places.
+func DecodePolyline(s string) ([]Position, error) {
+	var (
+		ps       []Position
+		lat, lng int64
+	)
+	for i := 0; i < len(s); {
+		dlat, n, err := decodePolylineValue(s[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		dlng, n, err := decodePolylineValue(s[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		lat += dlat
+		lng += dlng
+		p := NewPosition(
+			NewLatitudeDegrees(float64(lat)/1e5),
+			NewLongitudeDegrees(float64(lng)/1e5),
+		)
+		if p.Invalid() {
+			return nil, ErrInvalidPolyline
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+func decodePolylineValue(s string) (v int64, n int, err error) {
+	var (
+		u     uint64
+		shift uint
+	)
+	for n < len(s) {
+		b := s[n]
+		n++
+		if b < 63 || b > 63+0x3f || shift > 60 {
+			return 0, n, ErrInvalidPolyline
+		}
+		b -= 63
+		u |= uint64(b&0x1f) << shift
+		shift += 5
+		if b < 0x20 {
+			v = int64(u >> 1)
+			if u&1 != 0 {
+				v = ^v
+			}
+			return v, n, nil
+		}
+	}
+	return 0, n, ErrInvalidPolyline
+}
+
+// Simplify returns the positions of ps kept by SimplifyIndices.
+func Simplify(ps []Position, tolerance float64) []Position {
+	idx := SimplifyIndices(ps, tolerance)
+	out := make([]Position, len(idx))
+	for i, j := range idx {
+		out[i] = ps[j]
+	}
+	return out
+}
+
+// SimplifyIndices simplifies the line through the valid positions of ps
+// using the Douglas-Peucker algorithm, and returns the indices of the
+// positions to keep, in increasing order. Positions closer than tolerance
+// metres to the simplified line are removed; the first and last valid
+// positions are always kept. Invalid positions are never kept. Distances
+// are computed on a local equirectangular projection, which is accurate
+// enough for the extent of a single activity.
+func SimplifyIndices(ps []Position, tolerance float64) []int {
+	var valid []int
+	for i, p := range ps {
+		if !p.Invalid() {
+			valid = append(valid, i)
+		}
+	}
+	if len(valid) <= 2 || tolerance <= 0 {
+		return valid
+	}
+
+	phi0, _ := ps[valid[0]].radians()
+	xy := make([][2]float64, len(valid))
+	for i, j := range valid {
+		phi, lambda := ps[j].radians()
+		xy[i] = [2]float64{lambda * math.Cos(phi0) * EarthRadius, phi * EarthRadius}
+	}
+
+	keep := make([]bool, len(valid))
+	keep[0], keep[len(valid)-1] = true, true
+	// Iterative to avoid deep recursion for long tracks.
+	type span struct{ first, last int }
+	stack := []span{{0, len(valid) - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		maxDist, index := 0.0, 0
+		for i := s.first + 1; i < s.last; i++ {
+			d := segmentDistance(xy[i], xy[s.first], xy[s.last])
+			if d > maxDist {
+				maxDist, index = d, i
+			}
+		}
+		if maxDist > tolerance {
+			keep[index] = true
+			stack = append(stack, span{s.first, index}, span{index, s.last})
+		}
+	}
+
+	var idx []int
+	for i, k := range keep {
+		if k {
+			idx = append(idx, valid[i])
+		}
+	}
+	return idx
+}
+
+// segmentDistance returns the distance from p to the line segment a-b.
+func segmentDistance(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p[0]-a[0], p[1]-a[1])
+	}
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / (dx*dx + dy*dy)
+	switch {
+	case t < 0:
+		t = 0
+	case t > 1:
+		t = 1
+	}
+	return math.Hypot(p[0]-(a[0]+t*dx), p[1]-(a[1]+t*dy))
+}
@@ -0,0 +1,152 @@
+package fit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tormoder/fit"
+)
+
+func dms(d, m, s float64) float64 {
+	if d < 0 {
+		return d - m/60 - s/3600
+	}
+	return d + m/60 + s/3600
+}
+
+var (
+	flindersPeak = fit.NewPositionDegrees(dms(-37, 57, 3.72030), dms(144, 25, 29.52440))
+	buninyong    = fit.NewPositionDegrees(dms(-37, 39, 10.15610), dms(143, 55, 35.38390))
+)
+
+func TestPositionDistance(t *testing.T) {
+	// Geoscience Australia's reference example for Vincenty's formulae.
+	if got, want := flindersPeak.Vincenty(buninyong), 54972.271; math.Abs(got-want) > 0.1 {
+		t.Errorf("Vincenty: got %.3f m, want %.3f m", got, want)
+	}
+	got := flindersPeak.Haversine(buninyong)
+	if want := flindersPeak.Vincenty(buninyong); math.Abs(got-want)/want > 0.005 {
+		t.Errorf("Haversine: got %.3f m, want within 0.5%% of %.3f m", got, want)
+	}
+	if d := flindersPeak.Haversine(flindersPeak); d != 0 {
+		t.Errorf("Haversine to itself: got %v, want 0", d)
+	}
+	if d := flindersPeak.Vincenty(flindersPeak); d != 0 {
+		t.Errorf("Vincenty to itself: got %v, want 0", d)
+	}
+	if d := flindersPeak.Haversine(fit.NewPositionInvalid()); !math.IsNaN(d) {
+		t.Errorf("Haversine to invalid position: got %v, want NaN", d)
+	}
+}
+
+func TestPositionBearingDestination(t *testing.T) {
+	p := fit.NewPositionDegrees(0, 0)
+	for _, tt := range []struct {
+		q       fit.Position
+		bearing float64
+	}{
+		{fit.NewPositionDegrees(1, 0), 0},
+		{fit.NewPositionDegrees(0, 1), 90},
+		{fit.NewPositionDegrees(-1, 0), 180},
+		{fit.NewPositionDegrees(0, -1), 270},
+	} {
+		if got := p.Bearing(tt.q); math.Abs(got-tt.bearing) > 1e-6 {
+			t.Errorf("bearing to %v: got %v, want %v", tt.q, got, tt.bearing)
+		}
+	}
+
+	bearing := flindersPeak.Bearing(buninyong)
+	dist := flindersPeak.Haversine(buninyong)
+	got := flindersPeak.Destination(bearing, dist)
+	if d := got.Haversine(buninyong); d > 0.05 {
+		t.Errorf("destination: got %v, want %v (%.3f m off)", got, buninyong, d)
+	}
+}
+
+func TestPositionMidpoint(t *testing.T) {
+	a, b := fit.NewPositionDegrees(10, 20), fit.NewPositionDegrees(12, 24)
+	m := a.Midpoint(b)
+	if da, db := a.Haversine(m), b.Haversine(m); math.Abs(da-db) > 0.05 {
+		t.Errorf("midpoint %v: got distances %.3f m and %.3f m, want equal", m, da, db)
+	}
+	if got := a.Intermediate(b, 0.5); got.Haversine(m) > 0.05 {
+		t.Errorf("intermediate at 0.5: got %v, want %v", got, m)
+	}
+	if got := a.Intermediate(b, 0); got != a {
+		t.Errorf("intermediate at 0: got %v, want %v", got, a)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	ps := []fit.Position{
+		fit.NewPositionDegrees(59.9, 10.7),
+		fit.NewPositionInvalid(),
+		fit.NewPositionDegrees(60.1, 10.5),
+		fit.NewPositionDegrees(60.0, 10.9),
+	}
+	b := fit.Bounds(ps)
+	if want := fit.NewPositionDegrees(59.9, 10.5); b.Min != want {
+		t.Errorf("min: got %v, want %v", b.Min, want)
+	}
+	if want := fit.NewPositionDegrees(60.1, 10.9); b.Max != want {
+		t.Errorf("max: got %v, want %v", b.Max, want)
+	}
+	if !b.Contains(fit.NewPositionDegrees(60, 10.6)) {
+		t.Error("bounds do not contain inner position")
+	}
+	if b.Contains(fit.NewPositionDegrees(60, 11)) {
+		t.Error("bounds contain outer position")
+	}
+	if b := fit.Bounds(nil); !b.Min.Invalid() || !b.Max.Invalid() {
+		t.Errorf("bounds of no positions: got %v, want invalid", b)
+	}
+}
+
+func TestPolyline(t *testing.T) {
+	// Example from Google's encoded polyline algorithm format documentation.
+	const encoded = "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+	ps := []fit.Position{
+		fit.NewPositionDegrees(38.5, -120.2),
+		fit.NewPositionDegrees(40.7, -120.95),
+		fit.NewPositionDegrees(43.252, -126.453),
+	}
+	if got := fit.EncodePolyline(ps); got != encoded {
+		t.Errorf("encode: got %q, want %q", got, encoded)
+	}
+	got, err := fit.DecodePolyline(encoded)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != len(ps) {
+		t.Fatalf("decode: got %v, want %v", got, ps)
+	}
+	for i := range ps {
+		if d := got[i].Haversine(ps[i]); d > 0.01 {
+			t.Errorf("decode %d: got %v, want %v", i, got[i], ps[i])
+		}
+	}
+	if _, err := fit.DecodePolyline(encoded[:len(encoded)-1]); err != fit.ErrInvalidPolyline {
+		t.Errorf("decode truncated: got error %v, want %v", err, fit.ErrInvalidPolyline)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	var ps []fit.Position
+	for i := 0; i <= 10; i++ {
+		ps = append(ps, fit.NewPositionDegrees(60, 10+float64(i)*0.001))
+	}
+	ps = append(ps, fit.NewPositionDegrees(60.01, 10.01))
+	got := fit.SimplifyIndices(ps, 1)
+	want := []int{0, 10, 11}
+	if len(got) != len(want) {
+		t.Fatalf("got indices %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got indices %v, want %v", got, want)
+		}
+	}
+	if got := fit.Simplify(ps, 1); len(got) != 3 || got[1] != ps[10] {
+		t.Errorf("got %v, want %v", got, []fit.Position{ps[0], ps[10], ps[11]})
+	}
+}
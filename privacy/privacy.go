@@ -0,0 +1,319 @@
+// Package privacy strips identifying data from FIT files before they are
+// shared: positions near private places, device serial numbers, personal
+// user profile fields and the absolute time of day.
+package privacy
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// A Zone is a circular area whose positions are removed.
+type Zone struct {
+	Center fit.Position
+	Radius float64 // m
+}
+
+// Contains reports whether p is inside z.
+func (z Zone) Contains(p fit.Position) bool {
+	return !p.Invalid() && z.Center.Haversine(p) <= z.Radius
+}
+
+type options struct {
+	zones []Zone
+	trim  float64
+	shift time.Duration
+}
+
+// Option configures Anonymize.
+type Option func(*options)
+
+// WithZone configures Anonymize to remove the positions within radius
+// metres of center. It may be given several times.
+func WithZone(center fit.Position, radius float64) Option {
+	return func(o *options) {
+		o.zones = append(o.zones, Zone{Center: center, Radius: radius})
+	}
+}
+
+// WithTrim configures Anonymize to remove the positions of the first and
+// last metres of each track.
+func WithTrim(metres float64) Option {
+	return func(o *options) {
+		o.trim = metres
+	}
+}
+
+// WithTimeShift configures Anonymize to shift all timestamps by d.
+func WithTimeShift(d time.Duration) Option {
+	return func(o *options) {
+		o.shift = d
+	}
+}
+
+// Anonymize strips identifying data from f in place:
+//
+//   - Serial numbers and ANT device numbers are zeroed, which is the
+//     invalid value of those fields.
+//   - The name, gender, age, height and weight of user profiles are set to
+//     invalid values.
+//   - Positions inside the zones of WithZone are set to invalid values, as
+//     are the record positions of the first and last metres of WithTrim.
+//     Other positions within those metres of the start or end of the track,
+//     such as the start position of the first lap, are removed too. The
+//     bounding boxes of activity sessions are recomputed from the remaining
+//     record positions.
+//   - All timestamps are shifted by the offset of WithTimeShift. Local
+//     timestamps are shifted alike, keeping the time zone offset.
+//
+// Records are kept even if their position is removed, so distance, speed
+// and other data stay intact.
+//
+// The contents of unknown messages and fields are not decoded into f, and
+// so are never encoded from it; f.UnknownMessages and f.UnknownFields are
+// kept as they only count them.
+func Anonymize(f *fit.File, opts ...Option) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	msgs := []reflect.Value{reflect.ValueOf(&f.FileId)}
+	for _, m := range []interface{}{f.FileCreator, f.TimestampCorrelation, f.DeviceInfo} {
+		msgs = append(msgs, reflect.ValueOf(m))
+	}
+	for _, di := range f.DeviceInfos {
+		if di != f.DeviceInfo {
+			msgs = append(msgs, reflect.ValueOf(di))
+		}
+	}
+	msgs = append(msgs, fileMessages(f)...)
+
+	var track []fit.Position
+	if records := fileRecords(f); records != nil {
+		track = trimTrack(records, o.trim)
+	}
+	hidden := func(p fit.Position) bool {
+		for _, z := range o.zones {
+			if z.Contains(p) {
+				return true
+			}
+		}
+		if o.trim > 0 && len(track) > 0 {
+			return track[0].Haversine(p) <= o.trim || track[len(track)-1].Haversine(p) <= o.trim
+		}
+		return false
+	}
+
+	for _, m := range msgs {
+		if m.IsNil() {
+			continue
+		}
+		v := m.Elem()
+		clearIdentity(v)
+		if up, ok := m.Interface().(*fit.UserProfileMsg); ok {
+			clearUserProfile(up)
+		}
+		if len(o.zones) > 0 || o.trim > 0 {
+			hidePositions(v, hidden)
+		}
+		if o.shift != 0 {
+			shiftTimes(v, o.shift)
+		}
+	}
+
+	if act, err := f.Activity(); err == nil && act != nil && (len(o.zones) > 0 || o.trim > 0) {
+		for _, s := range act.Sessions {
+			setSessionBounds(s, act.Records)
+		}
+	}
+}
+
+// fileMessages returns pointers to the file type specific messages of f.
+func fileMessages(f *fit.File) []reflect.Value {
+	var (
+		file interface{}
+		err  error
+	)
+	switch f.Type() {
+	case fit.FileTypeActivity:
+		file, err = f.Activity()
+	case fit.FileTypeDevice:
+		file, err = f.Device()
+	case fit.FileTypeSettings:
+		file, err = f.Settings()
+	case fit.FileTypeSport:
+		file, err = f.Sport()
+	case fit.FileTypeWorkout:
+		file, err = f.Workout()
+	case fit.FileTypeCourse:
+		file, err = f.Course()
+	case fit.FileTypeSchedules:
+		file, err = f.Schedules()
+	case fit.FileTypeWeight:
+		file, err = f.Weight()
+	case fit.FileTypeTotals:
+		file, err = f.Totals()
+	case fit.FileTypeGoals:
+		file, err = f.Goals()
+	case fit.FileTypeBloodPressure:
+		file, err = f.BloodPressure()
+	case fit.FileTypeMonitoringA:
+		file, err = f.MonitoringA()
+	case fit.FileTypeActivitySummary:
+		file, err = f.ActivitySummary()
+	case fit.FileTypeMonitoringDaily:
+		file, err = f.MonitoringDaily()
+	case fit.FileTypeMonitoringB:
+		file, err = f.MonitoringB()
+	case fit.FileTypeSegment:
+		file, err = f.Segment()
+	case fit.FileTypeSegmentList:
+		file, err = f.SegmentList()
+	}
+	if err != nil || file == nil {
+		return nil
+	}
+	v := reflect.ValueOf(file)
+	if v.IsNil() {
+		return nil
+	}
+	var msgs []reflect.Value
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		switch fv := v.Field(i); fv.Kind() {
+		case reflect.Ptr:
+			msgs = append(msgs, fv)
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				msgs = append(msgs, fv.Index(j))
+			}
+		}
+	}
+	return msgs
+}
+
+// fileRecords returns the records of an activity or course file, or nil.
+func fileRecords(f *fit.File) []*fit.RecordMsg {
+	if act, err := f.Activity(); err == nil && act != nil {
+		return act.Records
+	}
+	if course, err := f.Course(); err == nil && course != nil {
+		return course.Records
+	}
+	return nil
+}
+
+// trimTrack removes the positions of the records within metres of the
+// start and end of the track along it, and returns the original track.
+func trimTrack(records []*fit.RecordMsg, metres float64) []fit.Position {
+	var (
+		track []fit.Position
+		idx   []int
+		along []float64
+	)
+	for i, r := range records {
+		p := fit.NewPosition(r.PositionLat, r.PositionLong)
+		if p.Invalid() {
+			continue
+		}
+		d := 0.0
+		if len(track) > 0 {
+			d = along[len(along)-1] + track[len(track)-1].Haversine(p)
+		}
+		track, idx, along = append(track, p), append(idx, i), append(along, d)
+	}
+	if metres <= 0 || len(track) == 0 {
+		return track
+	}
+	total := along[len(along)-1]
+	for k, i := range idx {
+		if along[k] <= metres || total-along[k] <= metres {
+			records[i].PositionLat, records[i].PositionLong = fit.NewLatitudeInvalid(), fit.NewLongitudeInvalid()
+		}
+	}
+	return track
+}
+
+// identityFields are zeroed by clearIdentity. They are all of types whose
+// invalid value is zero.
+var identityFields = []string{"SerialNumber", "AntDeviceNumber"}
+
+func clearIdentity(v reflect.Value) {
+	for _, name := range identityFields {
+		if f := v.FieldByName(name); f.IsValid() {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
+
+func clearUserProfile(up *fit.UserProfileMsg) {
+	invalid := fit.NewUserProfileMsg()
+	up.FriendlyName = invalid.FriendlyName
+	up.Gender = invalid.Gender
+	up.Age = invalid.Age
+	up.Height = invalid.Height
+	up.Weight = invalid.Weight
+}
+
+var (
+	latitudeType  = reflect.TypeOf(fit.Latitude{})
+	longitudeType = reflect.TypeOf(fit.Longitude{})
+	timeType      = reflect.TypeOf(time.Time{})
+)
+
+// hidePositions sets every position of the message v for which hidden
+// returns true to invalid values. A position is a pair of fields named
+// <name>Lat and <name>Long.
+func hidePositions(v reflect.Value, hidden func(fit.Position) bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type != latitudeType || !strings.HasSuffix(f.Name, "Lat") {
+			continue
+		}
+		lng := v.FieldByName(strings.TrimSuffix(f.Name, "Lat") + "Long")
+		if !lng.IsValid() || lng.Type() != longitudeType {
+			continue
+		}
+		lat := v.Field(i)
+		p := fit.NewPosition(lat.Interface().(fit.Latitude), lng.Interface().(fit.Longitude))
+		if !p.Invalid() && hidden(p) {
+			lat.Set(reflect.ValueOf(fit.NewLatitudeInvalid()))
+			lng.Set(reflect.ValueOf(fit.NewLongitudeInvalid()))
+		}
+	}
+}
+
+// shiftTimes adds d to every valid time field of the message v.
+func shiftTimes(v reflect.Value, d time.Duration) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Type() != timeType {
+			continue
+		}
+		t := f.Interface().(time.Time)
+		if t.IsZero() || fit.IsBaseTime(t) {
+			continue
+		}
+		f.Set(reflect.ValueOf(t.Add(d)))
+	}
+}
+
+// setSessionBounds sets the bounding box of s to that of the positions of
+// its records.
+func setSessionBounds(s *fit.SessionMsg, records []*fit.RecordMsg) {
+	var ps []fit.Position
+	for _, r := range records {
+		if r.Timestamp.Before(s.StartTime) || r.Timestamp.After(s.Timestamp) {
+			continue
+		}
+		ps = append(ps, fit.NewPosition(r.PositionLat, r.PositionLong))
+	}
+	b := fit.Bounds(ps)
+	s.NecLat, s.NecLong = b.Max.Lat, b.Max.Long
+	s.SwcLat, s.SwcLong = b.Min.Lat, b.Min.Long
+}
@@ -0,0 +1,112 @@
+package privacy_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/fittest"
+	"github.com/tormoder/fit/privacy"
+)
+
+func TestAnonymizeActivity(t *testing.T) {
+	path := []string{"dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit"}
+	orig, _ := fittest.Decode(t, path...).Activity()
+	f := fittest.Decode(t, path...)
+	act, _ := f.Activity()
+
+	var home fit.Position
+	for _, r := range act.Records {
+		if home = fit.NewPosition(r.PositionLat, r.PositionLong); !home.Invalid() {
+			break
+		}
+	}
+	zone := orig.Records[len(orig.Records)/2]
+	zoneCenter := fit.NewPosition(zone.PositionLat, zone.PositionLong)
+	const shift = -24 * time.Hour
+	act.Activity.LocalTimestamp = act.Activity.Timestamp.Add(2 * time.Hour)
+	privacy.Anonymize(f, privacy.WithTrim(500), privacy.WithZone(zoneCenter, 200), privacy.WithTimeShift(shift))
+
+	if f.FileId.SerialNumber != 0 || (f.DeviceInfo != nil && f.DeviceInfo.SerialNumber != 0) {
+		t.Errorf("got serial numbers %d and %d, want 0", f.FileId.SerialNumber, f.DeviceInfo.SerialNumber)
+	}
+	var kept int
+	for i, r := range act.Records {
+		if want := orig.Records[i].Timestamp.Add(shift); !r.Timestamp.Equal(want) {
+			t.Fatalf("record %d: got time %v, want %v", i, r.Timestamp, want)
+		}
+		if r.Distance != orig.Records[i].Distance {
+			t.Fatalf("record %d: distance changed", i)
+		}
+		p := fit.NewPosition(r.PositionLat, r.PositionLong)
+		if p.Invalid() {
+			continue
+		}
+		kept++
+		if d := p.Haversine(home); d <= 500 {
+			t.Fatalf("record %d: got position %.0f m from start", i, d)
+		}
+		if d := p.Haversine(zoneCenter); d <= 200 {
+			t.Fatalf("record %d: got position %.0f m from zone center", i, d)
+		}
+	}
+	if kept == 0 {
+		t.Fatal("all positions removed")
+	}
+	for i, l := range act.Laps {
+		if p := fit.NewPosition(l.StartPositionLat, l.StartPositionLong); !p.Invalid() && p.Haversine(home) <= 500 {
+			t.Errorf("lap %d: got start position %.0f m from start", i, p.Haversine(home))
+		}
+	}
+
+	a := act.Activity
+	if !a.Timestamp.Equal(orig.Activity.Timestamp.Add(shift)) || a.LocalTimestamp.Sub(a.Timestamp) != 2*time.Hour {
+		t.Errorf("activity: got time %v, local time %v, want %v and two hours later",
+			a.Timestamp, a.LocalTimestamp, orig.Activity.Timestamp.Add(shift))
+	}
+	if !f.FileId.TimeCreated.Equal(fittest.Decode(t, path...).FileId.TimeCreated.Add(shift)) {
+		t.Errorf("file id: got time created %v", f.FileId.TimeCreated)
+	}
+
+	var buf bytes.Buffer
+	if err := fit.Encode(&buf, f); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	g, err := fit.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	gact, err := g.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gact.Records) != len(act.Records) || !gact.Records[0].Timestamp.Equal(act.Records[0].Timestamp) {
+		t.Errorf("decoded: got %d records from %v, want %d from %v",
+			len(gact.Records), gact.Records[0].Timestamp, len(act.Records), act.Records[0].Timestamp)
+	}
+	for i, di := range g.DeviceInfos {
+		if di.SerialNumber != 0 {
+			t.Errorf("decoded device info %d: got serial number %d, want 0", i, di.SerialNumber)
+		}
+	}
+}
+
+func TestAnonymizeUserProfile(t *testing.T) {
+	f := fittest.Decode(t, "fitsdk", "Settings.fit")
+	settings, err := f.Settings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settings.UserProfiles) == 0 {
+		t.Fatal("no user profiles")
+	}
+	privacy.Anonymize(f)
+	invalid := fit.NewUserProfileMsg()
+	for i, up := range settings.UserProfiles {
+		if up.FriendlyName != invalid.FriendlyName || up.Age != invalid.Age ||
+			up.Weight != invalid.Weight || up.Height != invalid.Height || up.Gender != invalid.Gender {
+			t.Errorf("user profile %d: got %+v", i, up)
+		}
+	}
+}
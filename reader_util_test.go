@@ -9,7 +9,9 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
+	"testing"
 
 	"github.com/cespare/xxhash"
 	"github.com/kortschak/utter"
@@ -159,3 +161,25 @@ func (g *gen) writeUintField(u uint64) {
 	g.WriteString(strconv.FormatUint(u, 10))
 	g.WriteString(",\n")
 }
+
+func decodeTestFile(path string) (*fit.File, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return fit.Decode(bytes.NewReader(data))
+}
+
+// decodeFile decodes the activity file at path below the test data folder.
+func decodeFile(t *testing.T, path ...string) (*fit.File, *fit.ActivityFile) {
+	t.Helper()
+	f, err := decodeTestFile(filepath.Join(tdfolder, filepath.Join(path...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := f.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, act
+}
@@ -0,0 +1,331 @@
+package fit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/tormoder/fit/dyncrc16"
+	"github.com/tormoder/fit/internal/types"
+)
+
+// BaseType is a FIT base type, as given in field definitions.
+type BaseType byte
+
+// Known reports whether t is a base type defined by the FIT protocol.
+func (t BaseType) Known() bool {
+	return types.DecodeBase(byte(t)).Known()
+}
+
+// Size returns the size in bytes of a value of type t, or 0 if t is not
+// known.
+func (t BaseType) Size() int {
+	if !t.Known() {
+		return 0
+	}
+	return types.DecodeBase(byte(t)).Size()
+}
+
+// String returns the name of t as used by the FIT protocol, such as
+// "uint16z".
+func (t BaseType) String() string {
+	if !t.Known() {
+		return types.DecodeBase(byte(t)).String()
+	}
+	return strings.ToLower(strings.TrimPrefix(types.DecodeBase(byte(t)).String(), "Base"))
+}
+
+// FieldDefinition is a field of a definition message.
+type FieldDefinition struct {
+	Num      byte
+	Size     byte
+	BaseType BaseType
+	Name     string // Name of the message struct field, empty if not in the profile.
+}
+
+// Definition is a definition message.
+type Definition struct {
+	Local     uint8
+	MesgNum   MesgNum
+	BigEndian bool
+	Fields    []FieldDefinition
+}
+
+// RawField is a field of a data message as stored in the file.
+type RawField struct {
+	FieldDefinition
+	Offset int64 // Byte offset of the value from the start of the file.
+	Bytes  []byte
+	arch   binary.ByteOrder
+}
+
+// Value returns the value of f according to its base type: a uint64,
+// int64, float64 or string for single values, a slice of those for arrays,
+// and a []byte for byte fields or fields of an unknown base type.
+func (f RawField) Value() interface{} {
+	bt := types.DecodeBase(byte(f.BaseType))
+	if !bt.Known() || bt == types.BaseByte {
+		return f.Bytes
+	}
+	if bt == types.BaseString {
+		return rawString(f.Bytes)
+	}
+	size := bt.Size()
+	n := len(f.Bytes) / size
+	if n == 1 {
+		return f.value(bt, f.Bytes)
+	}
+	switch {
+	case bt.Float():
+		vs := make([]float64, n)
+		for i := range vs {
+			vs[i] = f.value(bt, f.Bytes[i*size:]).(float64)
+		}
+		return vs
+	case bt.Signed():
+		vs := make([]int64, n)
+		for i := range vs {
+			vs[i] = f.value(bt, f.Bytes[i*size:]).(int64)
+		}
+		return vs
+	default:
+		vs := make([]uint64, n)
+		for i := range vs {
+			vs[i] = f.value(bt, f.Bytes[i*size:]).(uint64)
+		}
+		return vs
+	}
+}
+
+func (f RawField) value(bt types.Base, b []byte) interface{} {
+	switch bt {
+	case types.BaseSint8:
+		return int64(int8(b[0]))
+	case types.BaseSint16:
+		return int64(int16(f.arch.Uint16(b)))
+	case types.BaseSint32:
+		return int64(int32(f.arch.Uint32(b)))
+	case types.BaseSint64:
+		return int64(f.arch.Uint64(b))
+	case types.BaseUint16, types.BaseUint16z:
+		return uint64(f.arch.Uint16(b))
+	case types.BaseUint32, types.BaseUint32z:
+		return uint64(f.arch.Uint32(b))
+	case types.BaseUint64, types.BaseUint64z:
+		return f.arch.Uint64(b)
+	case types.BaseFloat32:
+		return float64(math.Float32frombits(f.arch.Uint32(b)))
+	case types.BaseFloat64:
+		return math.Float64frombits(f.arch.Uint64(b))
+	default:
+		return uint64(b[0])
+	}
+}
+
+func rawString(b []byte) string {
+	for i, c := range b {
+		if c == 0x00 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+// Invalid reports whether every element of f has the invalid value of its
+// base type.
+func (f RawField) Invalid() bool {
+	bt := types.DecodeBase(byte(f.BaseType))
+	if !bt.Known() {
+		return false
+	}
+	if bt == types.BaseString {
+		return rawString(f.Bytes) == ""
+	}
+	size := bt.Size()
+	for i := 0; i+size <= len(f.Bytes); i += size {
+		if !invalidBytes(bt, f.arch, f.Bytes[i:i+size]) {
+			return false
+		}
+	}
+	return true
+}
+
+func invalidBytes(bt types.Base, arch binary.ByteOrder, b []byte) bool {
+	switch bt {
+	case types.BaseUint8z, types.BaseUint16z, types.BaseUint32z, types.BaseUint64z:
+		for _, c := range b {
+			if c != 0x00 {
+				return false
+			}
+		}
+		return true
+	case types.BaseSint8:
+		return b[0] == 0x7F
+	case types.BaseSint16:
+		return arch.Uint16(b) == 0x7FFF
+	case types.BaseSint32:
+		return arch.Uint32(b) == 0x7FFFFFFF
+	case types.BaseSint64:
+		return arch.Uint64(b) == 0x7FFFFFFFFFFFFFFF
+	default:
+		for _, c := range b {
+			if c != 0xFF {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// RawMessage is a definition or data message as stored in a FIT file.
+type RawMessage struct {
+	Offset     int64 // Byte offset of the record header from the start of the file.
+	Header     byte  // Record header.
+	Compressed bool  // Compressed timestamp header.
+	Local      uint8
+	MesgNum    MesgNum
+
+	// Definition is the definition message, or nil for data messages.
+	Definition *Definition
+
+	// Fields are the fields of a data message, in file order.
+	Fields []RawField
+
+	// Msg is the decoded data message, such as a RecordMsg, or nil for
+	// definition messages and unknown data messages.
+	Msg interface{}
+}
+
+// A Scanner reads the messages of a FIT file one at a time, in file order,
+// including definition messages and unknown messages.
+type Scanner struct {
+	d   decoder
+	err error
+}
+
+// NewScanner returns a scanner reading from r. The header of the FIT file
+// is read and verified.
+func NewScanner(r io.Reader) (*Scanner, error) {
+	s := new(Scanner)
+	d := &s.d
+	d.r = r
+	d.crc = dyncrc16.New()
+	if err := d.decodeHeader(); err != nil {
+		return nil, fmt.Errorf("error decoding header: %v", err)
+	}
+	d.file = new(File)
+	d.file.Header = d.h
+	d.bytes.limit = int(d.h.DataSize)
+	d.captureRaw = true
+	return s, nil
+}
+
+// Header returns the header of the FIT file.
+func (s *Scanner) Header() Header {
+	return s.d.h
+}
+
+// CRC returns the file CRC. It is valid once Next has returned io.EOF.
+func (s *Scanner) CRC() uint16 {
+	return s.d.file.CRC
+}
+
+// Next returns the next message. After the last message the file CRC is
+// verified, and io.EOF is returned if it matches. If a message can not be
+// parsed, an error is returned along with what is known about it, such as
+// its offset; the scanner can not continue after an error.
+func (s *Scanner) Next() (*RawMessage, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	msg, err := s.next()
+	if err != nil {
+		s.err = err
+	}
+	return msg, err
+}
+
+func (s *Scanner) next() (*RawMessage, error) {
+	d := &s.d
+	if d.bytes.n >= d.bytes.limit {
+		if err := d.checkCRC(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	msg := &RawMessage{Offset: int64(d.h.Size) + int64(d.bytes.n)}
+	b, err := d.readByte()
+	if err != nil {
+		return msg, fmt.Errorf("error parsing record header: %v", err)
+	}
+	msg.Header = b
+
+	if b&compressedHeaderMask != compressedHeaderMask && b&mesgDefinitionMask == mesgDefinitionMask {
+		msg.Local = b & localMesgNumMask
+		if b&headerTypeMask != mesgDefinitionMask {
+			return msg, NotSupportedError("developer data fields")
+		}
+		dm, err := d.parseDefinitionMessage(b)
+		if err != nil {
+			return msg, fmt.Errorf("parsing definition message: %v", err)
+		}
+		d.defmsgs[dm.localMsgType] = dm
+		msg.MesgNum = dm.globalMsgNum
+		msg.Definition = &Definition{
+			Local:     dm.localMsgType,
+			MesgNum:   dm.globalMsgNum,
+			BigEndian: dm.arch == be,
+		}
+		for _, fd := range dm.fieldDefs {
+			msg.Definition.Fields = append(msg.Definition.Fields, newFieldDefinition(dm, fd))
+		}
+		return msg, nil
+	}
+
+	msg.Compressed = b&compressedHeaderMask == compressedHeaderMask
+	if msg.Compressed {
+		msg.Local = (b & compressedLocalMesgNumMask) >> 5
+	} else {
+		msg.Local = b & localMesgNumMask
+	}
+	if dm := d.defmsgs[msg.Local]; dm != nil {
+		msg.MesgNum = dm.globalMsgNum
+	}
+	d.rawFields = nil
+	v, err := d.parseDataMessage(b, msg.Compressed)
+	msg.Fields = d.rawFields
+	if err != nil {
+		return msg, fmt.Errorf("parsing data message: %v", err)
+	}
+	if v.IsValid() {
+		msg.Msg = v.Interface()
+	}
+	return msg, nil
+}
+
+func newFieldDefinition(dm *defmsg, fd fieldDef) FieldDefinition {
+	def := FieldDefinition{Num: fd.num, Size: fd.size, BaseType: BaseType(fd.btype)}
+	if pfield, ok := getField(dm.globalMsgNum, fd.num); ok && knownMsgNums[dm.globalMsgNum] {
+		def.Name = msgsTypes[dm.globalMsgNum].Field(pfield.sindex).Name
+	}
+	return def
+}
+
+// captureRawField records the raw bytes of a data field just read into
+// d.tmp if the decoder is used by a Scanner.
+func (d *decoder) captureRawField(dm *defmsg, fd fieldDef) {
+	if !d.captureRaw {
+		return
+	}
+	size := int(fd.size)
+	f := RawField{
+		FieldDefinition: newFieldDefinition(dm, fd),
+		Offset:          int64(d.h.Size) + int64(d.bytes.n-size),
+		Bytes:           append([]byte(nil), d.tmp[:size]...),
+		arch:            dm.arch,
+	}
+	d.rawFields = append(d.rawFields, f)
+}
@@ -0,0 +1,96 @@
+package fit_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/tormoder/fit"
+)
+
+func TestScanner(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fitsdk", "Activity.fit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := fit.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := f.Activity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := fit.NewScanner(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Header() != f.Header {
+		t.Errorf("got header %v, want %v", s.Header(), f.Header)
+	}
+	var (
+		records, defs int
+		offset        int64
+		first         = true
+	)
+	for {
+		msg, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Offset <= offset {
+			t.Fatalf("got offset %d after %d", msg.Offset, offset)
+		}
+		offset = msg.Offset
+		if first {
+			if msg.Definition == nil || msg.MesgNum != fit.MesgNumFileId || msg.Offset != int64(f.Header.Size) {
+				t.Errorf("first message: got %v at %d, want file id definition at %d", msg.MesgNum, msg.Offset, f.Header.Size)
+			}
+			first = false
+		}
+		if msg.Definition != nil {
+			defs++
+			continue
+		}
+		for _, rf := range msg.Fields {
+			if rf.Offset <= msg.Offset || !bytes.Equal(data[rf.Offset:rf.Offset+int64(rf.Size)], rf.Bytes) {
+				t.Fatalf("field %d of %v at %d: bytes do not match file", rf.Num, msg.MesgNum, rf.Offset)
+			}
+		}
+		switch m := msg.Msg.(type) {
+		case fit.FileIdMsg:
+			for _, rf := range msg.Fields {
+				if rf.Name == "SerialNumber" && rf.Value() != uint64(m.SerialNumber) {
+					t.Errorf("serial number: got raw value %v, want %d", rf.Value(), m.SerialNumber)
+				}
+			}
+		case fit.RecordMsg:
+			records++
+		}
+	}
+	if records != len(act.Records) || defs == 0 {
+		t.Errorf("got %d records and %d definitions, want %d records", records, defs, len(act.Records))
+	}
+	if s.CRC() != f.CRC {
+		t.Errorf("got CRC %#x, want %#x", s.CRC(), f.CRC)
+	}
+	if _, err := s.Next(); err != io.EOF {
+		t.Errorf("after end: got %v, want io.EOF", err)
+	}
+}
+
+func TestRawField(t *testing.T) {
+	f := fit.RawField{FieldDefinition: fit.FieldDefinition{BaseType: 0x84, Size: 4}, Bytes: []byte{0xFF, 0xFF, 0xFF, 0xFF}}
+	if f.BaseType.String() != "uint16" || f.BaseType.Size() != 2 {
+		t.Errorf("got base type %v of size %d, want uint16 of size 2", f.BaseType, f.BaseType.Size())
+	}
+	if !f.Invalid() {
+		t.Error("all 0xFF uint16 array: got valid")
+	}
+}
@@ -0,0 +1,125 @@
+package fit
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/tormoder/fit/internal/summary"
+	"github.com/tormoder/fit/internal/timer"
+)
+
+func init() {
+	summary.Points = func(records interface{}) []summary.Point {
+		return points(records.([]*RecordMsg))
+	}
+	summary.Timer = func(events interface{}) *timer.Timer {
+		return newTimer(events.([]*EventMsg))
+	}
+}
+
+// newTimer returns a timer built from the timer start and stop events in
+// events; see timer.New.
+func newTimer(events []*EventMsg) *timer.Timer {
+	var tes []timer.Event
+	for _, e := range events {
+		if e != nil && e.Event == EventTimer {
+			tes = append(tes, timer.Event{Time: e.Timestamp, Type: uint8(e.EventType)})
+		}
+	}
+	return timer.New(tes)
+}
+
+// summarize sets the start time, timestamp and the aggregate fields of msg,
+// a *SessionMsg or *LapMsg, that are supported by the records from start
+// through end, the way the analysis package summarizes records. The
+// elapsed and timer time span start through end. Fields the records do not
+// support are left unchanged.
+func summarize(msg interface{}, records []*RecordMsg, start, end time.Time, t *timer.Timer) {
+	rs := RecordsBetween(records, start, end)
+	pos := func(i int) Position {
+		return NewPosition(rs[i].PositionLat, rs[i].PositionLong)
+	}
+	s := summary.Compute(points(rs), summary.Options{
+		Timer:       t,
+		MovingSpeed: summary.DefaultMovingSpeed,
+		Hysteresis:  summary.DefaultHysteresis,
+		Dist:        func(i, j int) float64 { return pos(i).Haversine(pos(j)) },
+	})
+	s.StartTime, s.Timestamp = start, end
+	s.TotalElapsedTime = end.Sub(start).Seconds()
+	s.TotalTimerTime = t.Overlap(start, end).Seconds()
+	summary.SetMsg(reflect.ValueOf(msg).Elem(), reflect.ValueOf(s).Elem())
+	if s.StartPosition < 0 {
+		return
+	}
+
+	first, last := pos(s.StartPosition), pos(s.EndPosition)
+	switch m := msg.(type) {
+	case *LapMsg:
+		m.StartPositionLat, m.StartPositionLong = first.Lat, first.Long
+		m.EndPositionLat, m.EndPositionLong = last.Lat, last.Long
+	case *SessionMsg:
+		var positions []Position
+		for i := range rs {
+			if p := pos(i); !p.Invalid() {
+				positions = append(positions, p)
+			}
+		}
+		b := Bounds(positions)
+		m.StartPositionLat, m.StartPositionLong = first.Lat, first.Long
+		m.NecLat, m.NecLong = b.Max.Lat, b.Max.Long
+		m.SwcLat, m.SwcLong = b.Min.Lat, b.Min.Long
+	}
+}
+
+// points returns the summary points of records, which must not be nil.
+// Enhanced speed and altitude are preferred over their 16-bit counterparts.
+func points(records []*RecordMsg) []summary.Point {
+	ps := make([]summary.Point, len(records))
+	for i, r := range records {
+		ps[i] = summary.Point{
+			Time:        r.Timestamp,
+			Distance:    r.GetDistanceScaled(),
+			HasPosition: !NewPosition(r.PositionLat, r.PositionLong).Invalid(),
+			Speed:       r.GetEnhancedSpeedScaled(),
+			HeartRate:   invalidNaN(float64(r.HeartRate), r.HeartRate == 0xFF),
+			Cadence:     invalidNaN(float64(r.Cadence), r.Cadence == 0xFF),
+			Power:       invalidNaN(float64(r.Power), r.Power == 0xFFFF),
+			Altitude:    r.GetEnhancedAltitudeScaled(),
+			Temperature: invalidNaN(float64(r.Temperature), r.Temperature == 0x7F),
+			Calories:    invalidNaN(float64(r.Calories), r.Calories == 0xFFFF),
+		}
+		if math.IsNaN(ps[i].Speed) {
+			ps[i].Speed = r.GetSpeedScaled()
+		}
+		if math.IsNaN(ps[i].Altitude) {
+			ps[i].Altitude = r.GetAltitudeScaled()
+		}
+	}
+	return ps
+}
+
+// RecordsBetween returns the non-nil records with a timestamp in the closed
+// interval [start, end]. A zero start or end, or one that is the FIT base
+// time, leaves the interval open in that direction.
+func RecordsBetween(records []*RecordMsg, start, end time.Time) []*RecordMsg {
+	var rs []*RecordMsg
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		if boundedBy(start) && r.Timestamp.Before(start) {
+			continue
+		}
+		if boundedBy(end) && r.Timestamp.After(end) {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func boundedBy(t time.Time) bool {
+	return !t.IsZero() && !IsBaseTime(t)
+}
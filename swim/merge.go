@@ -0,0 +1,223 @@
+package swim
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// Limits used by Merge, relative to the median time of the active lengths.
+const (
+	// SplitFraction is the maximum time of each part of a mis-split
+	// length.
+	SplitFraction = 0.75
+	// SplitTolerance is the maximum deviation of the total time of the
+	// parts of a mis-split length from the median.
+	SplitTolerance = 0.25
+)
+
+// minMergeLengths is the number of active lengths needed for a meaningful
+// median length time.
+const minMergeLengths = 4
+
+// Merge detects lengths the watch split in two, typically at a glide or a
+// stop mid-pool, and merges them. Two consecutive active lengths are
+// considered split if both are shorter than SplitFraction of the median
+// active length time, while together within SplitTolerance of it. Drill
+// lengths are only merged with drill lengths. Merge returns the lengths
+// with each split pair replaced by a new merged length, and the number of
+// merges. Lengths after a merge are copied and renumbered to keep
+// MessageIndex equal to their index; the messages in lengths are not
+// modified.
+func Merge(lengths []*fit.LengthMsg, pool Pool) ([]*fit.LengthMsg, int) {
+	var times []float64
+	for _, l := range lengths {
+		if l != nil && Active(l) && TimerTime(l) > 0 {
+			times = append(times, TimerTime(l).Seconds())
+		}
+	}
+	if len(times) < minMergeLengths {
+		return lengths, 0
+	}
+	sort.Float64s(times)
+	med := times[len(times)/2]
+	if len(times)%2 == 0 {
+		med = (times[len(times)/2-1] + med) / 2
+	}
+
+	split := func(a, b *fit.LengthMsg) bool {
+		if a == nil || b == nil || !Active(a) || !Active(b) {
+			return false
+		}
+		if (a.SwimStroke == fit.SwimStrokeDrill) != (b.SwimStroke == fit.SwimStrokeDrill) {
+			return false
+		}
+		ta, tb := TimerTime(a).Seconds(), TimerTime(b).Seconds()
+		return ta < SplitFraction*med && tb < SplitFraction*med &&
+			math.Abs(ta+tb-med) <= SplitTolerance*med
+	}
+
+	var (
+		out    []*fit.LengthMsg
+		merges int
+	)
+	for i := 0; i < len(lengths); i++ {
+		if i+1 < len(lengths) && split(lengths[i], lengths[i+1]) {
+			out = append(out, mergeLengths(lengths[i], lengths[i+1], pool))
+			merges++
+			i++
+			continue
+		}
+		out = append(out, lengths[i])
+	}
+	if merges == 0 {
+		return lengths, 0
+	}
+	for i, l := range out {
+		if l != nil && l.MessageIndex != fit.MessageIndex(i) {
+			c := *l
+			c.MessageIndex = fit.MessageIndex(i)
+			out[i] = &c
+		}
+	}
+	return out, merges
+}
+
+// mergeLengths returns a new length spanning the active lengths a and b.
+func mergeLengths(a, b *fit.LengthMsg, pool Pool) *fit.LengthMsg {
+	m := *a
+	m.Timestamp = b.Timestamp
+	m.TotalElapsedTime = sumUint32(a.TotalElapsedTime, b.TotalElapsedTime)
+	m.TotalTimerTime = sumUint32(a.TotalTimerTime, b.TotalTimerTime)
+	m.TotalStrokes = sumUint16(a.TotalStrokes, b.TotalStrokes)
+	m.TotalCalories = sumUint16(a.TotalCalories, b.TotalCalories)
+	if a.SwimStroke != b.SwimStroke && Strokes(b) > Strokes(a) {
+		m.SwimStroke = b.SwimStroke
+	}
+	m.StrokeCount = sumCounts(a.StrokeCount, b.StrokeCount)
+	m.ZoneCount = sumCounts(a.ZoneCount, b.ZoneCount)
+
+	m.AvgSpeed, m.AvgSwimmingCadence = 0xFFFF, 0xFF
+	if t := TimerTime(&m).Seconds(); t > 0 {
+		if v := math.Round(pool.Length / t * 1000); v < 0xFFFF {
+			m.AvgSpeed = uint16(v)
+		}
+		if r := StrokeRate(&m); !math.IsNaN(r) && r < 0xFF {
+			m.AvgSwimmingCadence = uint8(math.Round(r))
+		}
+	}
+	return &m
+}
+
+func sumUint32(a, b uint32) uint32 {
+	if a == 0xFFFFFFFF || b == 0xFFFFFFFF {
+		return 0xFFFFFFFF
+	}
+	return a + b
+}
+
+func sumUint16(a, b uint16) uint16 {
+	if a == 0xFFFF || b == 0xFFFF {
+		return 0xFFFF
+	}
+	return a + b
+}
+
+// sumCounts returns the element-wise sum of the per-stroke or per-zone
+// counts a and b.
+func sumCounts(a, b []uint16) []uint16 {
+	if len(b) > len(a) {
+		a, b = b, a
+	}
+	if len(a) == 0 {
+		return nil
+	}
+	sum := make([]uint16, len(a))
+	for i := range a {
+		sum[i] = a[i]
+		if i < len(b) {
+			sum[i] = sumUint16(a[i], b[i])
+		}
+	}
+	return sum
+}
+
+// SetLapTotals recomputes the length related totals of each lap from the
+// lengths starting within it: FirstLengthIndex, NumLengths,
+// NumActiveLengths, TotalDistance, TotalCycles (strokes), AvgStrokeDistance,
+// SwimStroke, AvgSpeed, MaxSpeed, their enhanced variants and AvgCadence
+// (stroke rate). Speeds and stroke rate are over the time of the active
+// lengths. The stroke totals are left invalid if no active length has a
+// stroke count. Use it after merging lengths to keep laps consistent with them.
+func SetLapTotals(laps []*fit.LapMsg, lengths []*fit.LengthMsg, pool Pool) {
+	for _, lap := range laps {
+		if lap == nil {
+			continue
+		}
+		var (
+			first    = -1
+			n        int
+			active   int
+			strokes  int
+			counted  bool // Whether any active length has a stroke count.
+			swum     time.Duration
+			maxSpeed float64
+			stroke   = fit.SwimStrokeInvalid
+		)
+		for i, l := range lengths {
+			if l == nil || l.StartTime.Before(lap.StartTime) || !l.StartTime.Before(lap.Timestamp) {
+				continue
+			}
+			if first < 0 {
+				first = i
+			}
+			n++
+			if !Active(l) {
+				continue
+			}
+			active++
+			strokes += Strokes(l)
+			counted = counted || l.TotalStrokes != 0xFFFF
+			swum += TimerTime(l)
+			if t := TimerTime(l).Seconds(); t > 0 {
+				maxSpeed = math.Max(maxSpeed, pool.Length/t)
+			}
+			switch stroke {
+			case fit.SwimStrokeInvalid:
+				stroke = l.SwimStroke
+			case l.SwimStroke:
+			default:
+				stroke = fit.SwimStrokeMixed
+			}
+		}
+
+		lap.NumLengths, lap.NumActiveLengths = uint16(n), uint16(active)
+		lap.FirstLengthIndex = 0xFFFF
+		if first >= 0 {
+			lap.FirstLengthIndex = uint16(first)
+		}
+		distance := float64(active) * pool.Length
+		lap.TotalDistance = uint32(math.Round(distance * 100))
+		lap.SwimStroke = stroke
+		lap.TotalCycles, lap.AvgStrokeDistance, lap.AvgCadence = 0xFFFFFFFF, 0xFFFF, 0xFF
+		if counted {
+			lap.TotalCycles = uint32(strokes)
+		}
+		lap.AvgSpeed, lap.MaxSpeed = 0xFFFF, 0xFFFF
+		lap.EnhancedAvgSpeed, lap.EnhancedMaxSpeed = 0xFFFFFFFF, 0xFFFFFFFF
+		if strokes > 0 {
+			lap.AvgStrokeDistance = uint16(math.Min(math.Round(distance/float64(strokes)*100), 0xFFFE))
+		}
+		if swum > 0 {
+			avg, max := math.Round(distance/swum.Seconds()*1000), math.Round(maxSpeed*1000)
+			lap.EnhancedAvgSpeed, lap.EnhancedMaxSpeed = uint32(avg), uint32(max)
+			lap.AvgSpeed = uint16(math.Min(avg, 0xFFFE))
+			lap.MaxSpeed = uint16(math.Min(max, 0xFFFE))
+			if counted {
+				lap.AvgCadence = uint8(math.Min(math.Round(float64(strokes)/swum.Minutes()), 0xFE))
+			}
+		}
+	}
+}
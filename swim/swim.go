@@ -0,0 +1,163 @@
+// Package swim analyzes pool swims from the length messages of FIT activity
+// files.
+package swim
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// yard is the length of a yard in metres.
+const yard = 0.9144
+
+// A Pool is the pool an activity was swum in.
+type Pool struct {
+	Length float64            // m
+	Unit   fit.DisplayMeasure // Unit the pool length was given in.
+}
+
+// PoolFromSession returns the pool of session, and whether its pool length
+// is valid. The unit defaults to metric if it is not set.
+func PoolFromSession(session *fit.SessionMsg) (Pool, bool) {
+	p := Pool{Length: session.GetPoolLengthScaled(), Unit: session.PoolLengthUnit}
+	if p.Unit == fit.DisplayMeasureInvalid {
+		p.Unit = fit.DisplayMeasureMetric
+	}
+	return p, !math.IsNaN(p.Length) && p.Length > 0
+}
+
+// PaceDistance returns the distance in metres pace is given for: 100 m for
+// metric pools and 100 yd for statute pools.
+func (p Pool) PaceDistance() float64 {
+	if p.Unit == fit.DisplayMeasureStatute {
+		return 100 * yard
+	}
+	return 100
+}
+
+// Pace returns the time per 100 m or yd, depending on the unit of p, to
+// swim distance metres in the given time.
+func (p Pool) Pace(distance float64, d time.Duration) time.Duration {
+	if distance <= 0 {
+		return 0
+	}
+	return time.Duration(float64(d) * p.PaceDistance() / distance)
+}
+
+// Active reports whether l is a length with strokes, as opposed to rest.
+func Active(l *fit.LengthMsg) bool {
+	return l.LengthType == fit.LengthTypeActive
+}
+
+// TimerTime returns the timer time of l.
+func TimerTime(l *fit.LengthMsg) time.Duration {
+	if l.TotalTimerTime == 0xFFFFFFFF {
+		if l.TotalElapsedTime == 0xFFFFFFFF {
+			return 0
+		}
+		return time.Duration(l.TotalElapsedTime) * time.Millisecond
+	}
+	return time.Duration(l.TotalTimerTime) * time.Millisecond
+}
+
+// Strokes returns the number of strokes of l, or 0 if it is not known.
+func Strokes(l *fit.LengthMsg) int {
+	if l.TotalStrokes == 0xFFFF {
+		return 0
+	}
+	return int(l.TotalStrokes)
+}
+
+// SWOLF returns the swim golf score of l: its time in seconds plus its
+// number of strokes. Lower is more efficient. It is NaN for lengths without
+// strokes.
+func SWOLF(l *fit.LengthMsg) float64 {
+	if Strokes(l) == 0 {
+		return math.NaN()
+	}
+	return TimerTime(l).Seconds() + float64(Strokes(l))
+}
+
+// StrokeRate returns the stroke rate of l in strokes per minute, or NaN if
+// it is not known.
+func StrokeRate(l *fit.LengthMsg) float64 {
+	t := TimerTime(l)
+	if Strokes(l) == 0 || t <= 0 {
+		return math.NaN()
+	}
+	return float64(Strokes(l)) / t.Minutes()
+}
+
+// An Interval is a sequence of active lengths swum without rest.
+type Interval struct {
+	First     int // Index of the first length.
+	Lengths   []*fit.LengthMsg
+	StartTime time.Time
+	Timestamp time.Time     // End of the last length.
+	TimerTime time.Duration // Sum of the timer time of the lengths.
+	Rest      time.Duration // Rest after the interval.
+	Distance  float64       // m
+	Strokes   int
+
+	// Stroke is the stroke of all lengths, or SwimStrokeMixed if they differ.
+	Stroke fit.SwimStroke
+	// Drill is true if the interval is a drill. Drill lengths are never
+	// grouped with other lengths.
+	Drill bool
+
+	Pace       time.Duration // Per 100 m or yd; see Pool.Pace.
+	SWOLF      float64       // Average per length.
+	StrokeRate float64       // strokes/min
+}
+
+// Intervals groups the consecutive active lengths of lengths into
+// intervals. An interval ends at an idle length, or where a drill starts or
+// ends. The time of the idle lengths after an interval is its rest.
+func Intervals(lengths []*fit.LengthMsg, pool Pool) []Interval {
+	var (
+		ivs []Interval
+		cur *Interval
+	)
+	for i, l := range lengths {
+		if l == nil {
+			continue
+		}
+		if !Active(l) {
+			if len(ivs) > 0 {
+				ivs[len(ivs)-1].Rest += TimerTime(l)
+			}
+			cur = nil
+			continue
+		}
+		drill := l.SwimStroke == fit.SwimStrokeDrill
+		if cur == nil || cur.Drill != drill {
+			ivs = append(ivs, Interval{
+				First:     i,
+				StartTime: l.StartTime,
+				Stroke:    l.SwimStroke,
+				Drill:     drill,
+			})
+			cur = &ivs[len(ivs)-1]
+		}
+		cur.Lengths = append(cur.Lengths, l)
+		cur.Timestamp = l.Timestamp
+		cur.TimerTime += TimerTime(l)
+		cur.Distance += pool.Length
+		cur.Strokes += Strokes(l)
+		if l.SwimStroke != cur.Stroke {
+			cur.Stroke = fit.SwimStrokeMixed
+		}
+	}
+	for i := range ivs {
+		iv := &ivs[i]
+		iv.Pace = pool.Pace(iv.Distance, iv.TimerTime)
+		iv.SWOLF, iv.StrokeRate = math.NaN(), math.NaN()
+		if iv.Strokes > 0 {
+			iv.SWOLF = (iv.TimerTime.Seconds() + float64(iv.Strokes)) / float64(len(iv.Lengths))
+			iv.StrokeRate = float64(iv.Strokes) / iv.TimerTime.Minutes()
+		}
+	}
+	return ivs
+}
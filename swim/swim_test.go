@@ -0,0 +1,191 @@
+package swim_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/swim"
+)
+
+var (
+	t0         = time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	metricPool = swim.Pool{Length: 25, Unit: fit.DisplayMeasureMetric}
+)
+
+// spec describes a length: its time in seconds, strokes and stroke. Lengths
+// without strokes are idle.
+type spec struct {
+	secs    float64
+	strokes uint16
+	stroke  fit.SwimStroke
+}
+
+// lengths returns consecutive lengths starting at t0.
+func lengths(specs ...spec) []*fit.LengthMsg {
+	ls := make([]*fit.LengthMsg, len(specs))
+	start := t0
+	for i, s := range specs {
+		d := time.Duration(s.secs * float64(time.Second))
+		l := fit.NewLengthMsg()
+		l.MessageIndex = fit.MessageIndex(i)
+		l.StartTime, l.Timestamp = start, start.Add(d)
+		l.TotalElapsedTime = uint32(d / time.Millisecond)
+		l.TotalTimerTime = l.TotalElapsedTime
+		l.LengthType = fit.LengthTypeIdle
+		if s.strokes > 0 {
+			l.LengthType = fit.LengthTypeActive
+			l.TotalStrokes = s.strokes
+			l.SwimStroke = s.stroke
+		}
+		ls[i] = l
+		start = start.Add(d)
+	}
+	return ls
+}
+
+const (
+	free   = fit.SwimStrokeFreestyle
+	breast = fit.SwimStrokeBreaststroke
+	drill  = fit.SwimStrokeDrill
+)
+
+func TestPool(t *testing.T) {
+	s := fit.NewSessionMsg()
+	if _, ok := swim.PoolFromSession(s); ok {
+		t.Error("invalid pool length: got ok")
+	}
+	s.PoolLength, s.PoolLengthUnit = 2286, fit.DisplayMeasureStatute
+	p, ok := swim.PoolFromSession(s)
+	if !ok || p.Length != 22.86 {
+		t.Fatalf("got %v, %t, want 22.86 m", p, ok)
+	}
+	// 100 yd in 80 s.
+	if got := p.Pace(4*22.86, 80*time.Second); got != 80*time.Second {
+		t.Errorf("got pace %v, want 1m20s per 100 yd", got)
+	}
+	if got := metricPool.Pace(50, 40*time.Second); got != 80*time.Second {
+		t.Errorf("got pace %v, want 1m20s per 100 m", got)
+	}
+}
+
+func TestLengthMetrics(t *testing.T) {
+	l := lengths(spec{20, 15, free})[0]
+	if got := swim.SWOLF(l); got != 35 {
+		t.Errorf("got SWOLF %v, want 35", got)
+	}
+	if got := swim.StrokeRate(l); got != 45 {
+		t.Errorf("got stroke rate %v, want 45", got)
+	}
+	idle := lengths(spec{30, 0, 0})[0]
+	if got := swim.SWOLF(idle); !math.IsNaN(got) {
+		t.Errorf("idle length: got SWOLF %v, want NaN", got)
+	}
+}
+
+func TestIntervals(t *testing.T) {
+	ls := lengths(
+		spec{20, 15, free}, spec{22, 16, free}, spec{30, 0, 0},
+		spec{25, 18, breast}, spec{21, 15, free},
+		spec{35, 20, drill}, spec{35, 20, drill}, spec{60, 0, 0}, spec{10, 0, 0},
+	)
+	ivs := swim.Intervals(ls, metricPool)
+	if len(ivs) != 3 {
+		t.Fatalf("got %d intervals, want 3", len(ivs))
+	}
+	iv := ivs[0]
+	if iv.First != 0 || len(iv.Lengths) != 2 || iv.Distance != 50 || iv.Stroke != free || iv.Drill {
+		t.Errorf("interval 0: got %+v", iv)
+	}
+	if iv.TimerTime != 42*time.Second || iv.Rest != 30*time.Second || iv.Pace != 84*time.Second {
+		t.Errorf("interval 0: got time %v, rest %v, pace %v, want 42s, 30s, 1m24s", iv.TimerTime, iv.Rest, iv.Pace)
+	}
+	if iv.SWOLF != (42+31)/2.0 {
+		t.Errorf("interval 0: got SWOLF %v, want %v", iv.SWOLF, (42+31)/2.0)
+	}
+	if iv := ivs[1]; iv.First != 3 || iv.Stroke != fit.SwimStrokeMixed || iv.Rest != 0 {
+		t.Errorf("interval 1: got first %d, stroke %v, rest %v, want 3, mixed, 0", iv.First, iv.Stroke, iv.Rest)
+	}
+	if iv := ivs[2]; iv.First != 5 || !iv.Drill || iv.Rest != 70*time.Second {
+		t.Errorf("interval 2: got first %d, drill %t, rest %v, want 5, true, 1m10s", iv.First, iv.Drill, iv.Rest)
+	}
+}
+
+func TestMergeSetLapTotals(t *testing.T) {
+	ls := lengths(
+		spec{20, 15, free}, spec{21, 16, free},
+		spec{9, 7, free}, spec{12, 9, free}, // Split mid-pool.
+		spec{20, 15, free}, spec{30, 0, 0}, spec{19, 14, free},
+	)
+	merged, n := swim.Merge(ls, metricPool)
+	if n != 1 || len(merged) != len(ls)-1 {
+		t.Fatalf("got %d merges, %d lengths, want 1, %d", n, len(merged), len(ls)-1)
+	}
+	m := merged[2]
+	if !m.StartTime.Equal(ls[2].StartTime) || !m.Timestamp.Equal(ls[3].Timestamp) ||
+		m.TotalTimerTime != 21000 || m.TotalStrokes != 16 {
+		t.Errorf("merged length: got %+v", m)
+	}
+	if got := m.GetAvgSpeedScaled(); math.Abs(got-25/21.0) > 1e-3 {
+		t.Errorf("merged length: got speed %v, want %v", got, 25/21.0)
+	}
+	for i, l := range merged {
+		if int(l.MessageIndex) != i {
+			t.Errorf("length %d: got message index %d", i, l.MessageIndex)
+		}
+	}
+	for i, l := range ls {
+		if int(l.MessageIndex) != i {
+			t.Errorf("input length %d: got message index %d, want unchanged", i, l.MessageIndex)
+		}
+	}
+
+	lap := fit.NewLapMsg()
+	lap.StartTime, lap.Timestamp = t0, merged[len(merged)-1].Timestamp
+	rest := fit.NewLapMsg()
+	rest.StartTime, rest.Timestamp = lap.Timestamp, lap.Timestamp.Add(time.Minute)
+	swim.SetLapTotals([]*fit.LapMsg{lap, rest}, merged, metricPool)
+	if lap.FirstLengthIndex != 0 || lap.NumLengths != 6 || lap.NumActiveLengths != 5 {
+		t.Errorf("lap: got first %d, lengths %d, active %d, want 0, 6, 5",
+			lap.FirstLengthIndex, lap.NumLengths, lap.NumActiveLengths)
+	}
+	if lap.GetTotalDistanceScaled() != 125 || lap.TotalCycles != 76 || lap.SwimStroke != free {
+		t.Errorf("lap: got distance %v, strokes %d, stroke %v, want 125, 76, freestyle",
+			lap.GetTotalDistanceScaled(), lap.TotalCycles, lap.SwimStroke)
+	}
+	if got, want := lap.GetAvgSpeedScaled(), 125/101.0; math.Abs(got-want) > 1e-3 {
+		t.Errorf("lap: got speed %v, want %v", got, want)
+	}
+	if rest.NumLengths != 0 || rest.FirstLengthIndex != 0xFFFF || rest.AvgSpeed != 0xFFFF {
+		t.Errorf("empty lap: got %d lengths, first %d, speed %d", rest.NumLengths, rest.FirstLengthIndex, rest.AvgSpeed)
+	}
+}
+
+func TestMergeDrill(t *testing.T) {
+	ls := lengths(
+		spec{20, 15, free}, spec{21, 16, free},
+		spec{9, 7, free}, spec{12, 9, drill},
+		spec{20, 15, free}, spec{19, 14, free},
+	)
+	if merged, n := swim.Merge(ls, metricPool); n != 0 || len(merged) != len(ls) {
+		t.Errorf("got %d merges, %d lengths, want 0, %d", n, len(merged), len(ls))
+	}
+}
+
+func TestSetLapTotalsUnknownStrokes(t *testing.T) {
+	ls := lengths(spec{20, 15, drill}, spec{21, 16, drill})
+	for _, l := range ls {
+		l.TotalStrokes = 0xFFFF
+	}
+	lap := fit.NewLapMsg()
+	lap.StartTime, lap.Timestamp = t0, ls[len(ls)-1].Timestamp
+	swim.SetLapTotals([]*fit.LapMsg{lap}, ls, metricPool)
+	if lap.NumActiveLengths != 2 || lap.GetTotalDistanceScaled() != 50 {
+		t.Errorf("got %d active lengths, distance %v, want 2, 50", lap.NumActiveLengths, lap.GetTotalDistanceScaled())
+	}
+	if lap.TotalCycles != 0xFFFFFFFF || lap.AvgCadence != 0xFF || lap.AvgStrokeDistance != 0xFFFF {
+		t.Errorf("got strokes %d, cadence %d, stroke distance %d, want invalid",
+			lap.TotalCycles, lap.AvgCadence, lap.AvgStrokeDistance)
+	}
+}
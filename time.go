@@ -14,6 +14,14 @@ func IsBaseTime(t time.Time) bool {
 	return t.Equal(timeBase)
 }
 
+// IsSystemTime reports if t is a FIT system time rather than a date: a
+// time in seconds since the device was powered on, below 0x10000000, as
+// recorded before the clock of the device is set. The FIT base time is a
+// system time.
+func IsSystemTime(t time.Time) bool {
+	return t.Before(timeBase.Add(systemTimeMarker * time.Second))
+}
+
 func decodeDateTime(dt uint32) time.Time {
 	return timeBase.Add(time.Duration(dt) * time.Second)
 }
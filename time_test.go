@@ -29,3 +29,27 @@ func TestDateTimeEncDec(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordsBetween(t *testing.T) {
+	at := func(s int) *RecordMsg {
+		r := NewRecordMsg()
+		r.Timestamp = decodeDateTime(uint32(1000000000 + s))
+		return r
+	}
+	records := []*RecordMsg{at(0), nil, at(10), at(20), at(30)}
+	tests := []struct {
+		start, end time.Time
+		want       int
+	}{
+		{records[2].Timestamp, records[3].Timestamp, 2},
+		{records[2].Timestamp, time.Time{}, 3},
+		{timeBase, records[2].Timestamp, 2},
+		{time.Time{}, timeBase, 4},
+		{records[4].Timestamp, records[0].Timestamp, 0},
+	}
+	for i, test := range tests {
+		if got := len(RecordsBetween(records, test.start, test.end)); got != test.want {
+			t.Errorf("%d: got %d records, want %d", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package fit
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit/internal/timer"
+)
+
+// Trim returns a copy of act holding only what was recorded from from
+// through to. A zero or FIT base time leaves that end unbounded. Records,
+// events and lengths outside the range are dropped, as are the RR
+// intervals of HRV messages, whose beats are timed from the first timer
+// start event, or the first record. If the timer was running at a cut, a
+// timer start event is inserted at from and a timer stop all event at to.
+//
+// Sessions and laps outside the range are dropped and those cut by it are
+// clipped: their aggregate fields that records support, such as times,
+// distance, positions, speed, heart rate, cadence, power, work, ascent,
+// altitude, temperature and accumulated calories, are recomputed from the
+// remaining records the way analysis.Summarize does. Aggregate fields the
+// records do not support, such as normalized power or time in zones, are
+// reset to invalid values. Message
+// indices and the lap and length indices of sessions and laps are
+// renumbered. The activity message has its NumSessions and TotalTimerTime
+// updated, and its Timestamp and LocalTimestamp moved to the new end of
+// the activity if it was cut. The messages of act are not modified.
+func Trim(act *ActivityFile, from, to time.Time) *ActivityFile {
+	w := window{from: from, to: to}
+	old := newTimer(act.Events)
+	out := new(ActivityFile)
+
+	for _, r := range act.Records {
+		if r != nil && w.contains(r.Timestamp) {
+			tmp := *r
+			out.Records = append(out.Records, &tmp)
+		}
+	}
+	first, last := recordSpan(act.Records)
+	out.Events = trimEvents(act.Events, w, old, first, last)
+	out.Hrvs = trimHrvs(act, w)
+	for _, l := range act.Lengths {
+		if l != nil && w.contains(l.StartTime) && w.contains(l.Timestamp) {
+			tmp := *l
+			tmp.MessageIndex = MessageIndex(len(out.Lengths))
+			out.Lengths = append(out.Lengths, &tmp)
+		}
+	}
+
+	t := newTimer(out.Events)
+	for _, l := range act.Laps {
+		if l == nil {
+			continue
+		}
+		start, end, clipped, ok := w.clip(l.StartTime, l.Timestamp)
+		if !ok {
+			continue
+		}
+		tmp := *l
+		tmp.MessageIndex = MessageIndex(len(out.Laps))
+		if clipped {
+			resetAggregates(&tmp, NewLapMsg())
+			summarize(&tmp, out.Records, start, end, t)
+		}
+		if len(act.Lengths) > 0 {
+			tmp.FirstLengthIndex, tmp.NumLengths, tmp.NumActiveLengths = lengthRange(out.Lengths, tmp.StartTime, tmp.Timestamp)
+		}
+		out.Laps = append(out.Laps, &tmp)
+	}
+	for _, s := range act.Sessions {
+		if s == nil {
+			continue
+		}
+		start, end, clipped, ok := w.clip(s.StartTime, s.Timestamp)
+		if !ok {
+			continue
+		}
+		tmp := *s
+		tmp.MessageIndex = MessageIndex(len(out.Sessions))
+		if clipped {
+			resetAggregates(&tmp, NewSessionMsg())
+			summarize(&tmp, out.Records, start, end, t)
+		}
+		if len(act.Lengths) > 0 {
+			_, _, tmp.NumActiveLengths = lengthRange(out.Lengths, tmp.StartTime, tmp.Timestamp)
+		}
+		if len(act.Laps) > 0 {
+			tmp.FirstLapIndex, tmp.NumLaps = lapRange(out.Laps, tmp.StartTime, tmp.Timestamp)
+		}
+		out.Sessions = append(out.Sessions, &tmp)
+	}
+
+	if act.Activity != nil {
+		tmp := *act.Activity
+		out.Activity = &tmp
+		trimActivityMsg(&tmp, out, w, t)
+	}
+	return out
+}
+
+// Split splits act at the time at, returning copies of act holding what was
+// recorded through at and from at; see Trim. Records at at belong to both.
+func Split(act *ActivityFile, at time.Time) (before, after *ActivityFile) {
+	return Trim(act, time.Time{}, at), Trim(act, at, time.Time{})
+}
+
+// SplitSessions returns one activity per session of act, such as the legs
+// of a multisport activity; see Trim.
+func SplitSessions(act *ActivityFile) []*ActivityFile {
+	var acts []*ActivityFile
+	for _, s := range act.Sessions {
+		if s != nil {
+			acts = append(acts, Trim(act, s.StartTime, s.Timestamp))
+		}
+	}
+	return acts
+}
+
+// TimeAtDistance returns the timestamp of the first record at or beyond
+// distance metres, for trimming or splitting by distance. False is returned
+// if no record gets that far.
+func TimeAtDistance(records []*RecordMsg, distance float64) (time.Time, bool) {
+	for _, r := range records {
+		if r != nil && r.GetDistanceScaled() >= distance {
+			return r.Timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// window is a closed time range. A zero or FIT base time leaves that end
+// unbounded.
+type window struct {
+	from, to time.Time
+}
+
+func bounded(t time.Time) bool {
+	return !t.IsZero() && !IsBaseTime(t)
+}
+
+func (w window) contains(t time.Time) bool {
+	if bounded(w.from) && t.Before(w.from) {
+		return false
+	}
+	return !bounded(w.to) || !t.After(w.to)
+}
+
+// clip returns the part of the range from start through end within w, and
+// whether it was clipped. False is returned for ok if no part of a
+// non-empty range is within w.
+func (w window) clip(start, end time.Time) (cs, ce time.Time, clipped, ok bool) {
+	cs, ce = start, end
+	if bounded(w.from) && cs.Before(w.from) {
+		cs, clipped = w.from, true
+	}
+	if bounded(w.to) && ce.After(w.to) {
+		ce, clipped = w.to, true
+	}
+	if ce.Before(cs) || (ce.Equal(cs) && !end.Equal(start)) {
+		return cs, ce, clipped, false
+	}
+	return cs, ce, clipped, true
+}
+
+// recordSpan returns the timestamps of the first and last record.
+func recordSpan(records []*RecordMsg) (first, last time.Time) {
+	for _, r := range records {
+		if r == nil || IsBaseTime(r.Timestamp) {
+			continue
+		}
+		if first.IsZero() || r.Timestamp.Before(first) {
+			first = r.Timestamp
+		}
+		if r.Timestamp.After(last) {
+			last = r.Timestamp
+		}
+	}
+	return first, last
+}
+
+// trimEvents returns copies of the events within w, with timer events
+// inserted at cuts made within the recording from first through last while
+// the timer was running.
+func trimEvents(events []*EventMsg, w window, old *timer.Timer, first, last time.Time) []*EventMsg {
+	var out []*EventMsg
+	for _, e := range events {
+		if e != nil && w.contains(e.Timestamp) {
+			tmp := *e
+			out = append(out, &tmp)
+		}
+	}
+	timerEvent := func(ts time.Time, et EventType) {
+		for _, e := range out {
+			if e.Event == EventTimer && e.Timestamp.Equal(ts) {
+				return
+			}
+		}
+		e := NewEventMsg()
+		e.Timestamp, e.Event, e.EventType, e.EventGroup = ts, EventTimer, et, 0
+		out = append(out, e)
+	}
+	if bounded(w.from) && w.from.After(first) && !w.from.After(last) && old.Running(w.from) {
+		timerEvent(w.from, EventTypeStart)
+	}
+	if bounded(w.to) && w.to.Before(last) && !w.to.Before(first) && old.Running(w.to) {
+		timerEvent(w.to, EventTypeStopAll)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Timestamp.Before(out[j].Timestamp)
+	})
+	return out
+}
+
+// trimHrvs returns the RR intervals of act whose beats are within w, packed
+// into HRV messages. Invalid intervals, which pad the last message, are
+// skipped.
+func trimHrvs(act *ActivityFile, w window) []*HrvMsg {
+	if len(act.Hrvs) == 0 {
+		return nil
+	}
+	start, _ := recordSpan(act.Records)
+	for _, e := range act.Events {
+		if e != nil && e.Event == EventTimer && e.EventType == EventTypeStart {
+			start = e.Timestamp
+			break
+		}
+	}
+	const perMsg = 5
+	var (
+		out     []*HrvMsg
+		elapsed time.Duration
+	)
+	for _, h := range act.Hrvs {
+		if h == nil {
+			continue
+		}
+		for _, v := range h.Time {
+			if v == 0xFFFF {
+				continue
+			}
+			elapsed += time.Duration(v) * time.Millisecond
+			if !w.contains(start.Add(elapsed)) {
+				continue
+			}
+			if len(out) == 0 || len(out[len(out)-1].Time) == perMsg {
+				out = append(out, &HrvMsg{})
+			}
+			msg := out[len(out)-1]
+			msg.Time = append(msg.Time, v)
+		}
+	}
+	return out
+}
+
+// lengthRange returns the index of the first of lengths within start
+// through end, the number of lengths and the number of active lengths.
+func lengthRange(lengths []*LengthMsg, start, end time.Time) (first, n, active uint16) {
+	first = 0xFFFF
+	for i, l := range lengths {
+		if l.StartTime.Before(start) || l.Timestamp.After(end) {
+			continue
+		}
+		if n == 0 {
+			first = uint16(i)
+		}
+		n++
+		if l.LengthType == LengthTypeActive {
+			active++
+		}
+	}
+	return first, n, active
+}
+
+// lapRange returns the index of the first of laps within start through end
+// and the number of such laps.
+func lapRange(laps []*LapMsg, start, end time.Time) (first, n uint16) {
+	first = 0xFFFF
+	for i, l := range laps {
+		if l.StartTime.Before(start) || l.Timestamp.After(end) {
+			continue
+		}
+		if n == 0 {
+			first = uint16(i)
+		}
+		n++
+	}
+	return first, n
+}
+
+// aggregatePrefixes and aggregateFields name the session and lap fields
+// that summarize their records.
+var (
+	aggregatePrefixes = []string{"Total", "Avg", "Max", "Min", "Enhanced", "TimeIn", "StartPosition", "EndPosition", "Nec", "Swc"}
+	aggregateFields   = map[string]bool{
+		"NormalizedPower": true, "LeftRightBalance": true, "GpsAccuracy": true,
+		"TrainingStressScore": true, "IntensityFactor": true, "StrokeCount": true,
+		"ZoneCount": true, "BestLapIndex": true,
+	}
+)
+
+// resetAggregates sets the aggregate fields of msg to those of invalid, a
+// new message of the same type.
+func resetAggregates(msg, invalid interface{}) {
+	v, inv := reflect.ValueOf(msg).Elem(), reflect.ValueOf(invalid).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		reset := aggregateFields[name]
+		for _, p := range aggregatePrefixes {
+			reset = reset || strings.HasPrefix(name, p)
+		}
+		if reset {
+			v.Field(i).Set(inv.Field(i))
+		}
+	}
+}
+
+// trimActivityMsg updates the activity message msg of the trimmed activity
+// act.
+func trimActivityMsg(msg *ActivityMsg, act *ActivityFile, w window, t *timer.Timer) {
+	msg.NumSessions = uint16(len(act.Sessions))
+	first, last := recordSpan(act.Records)
+	if len(act.Sessions) > 0 {
+		var total uint32
+		for _, s := range act.Sessions {
+			if s.TotalTimerTime != 0xFFFFFFFF {
+				total += s.TotalTimerTime
+			}
+		}
+		msg.TotalTimerTime = total
+	} else if !last.IsZero() {
+		msg.TotalTimerTime = uint32(t.Overlap(first, last) / time.Millisecond)
+	}
+	if IsBaseTime(msg.Timestamp) || w.contains(msg.Timestamp) || last.IsZero() {
+		return
+	}
+	end := last
+	if len(act.Events) > 0 {
+		if ts := act.Events[len(act.Events)-1].Timestamp; ts.After(end) {
+			end = ts
+		}
+	}
+	for _, s := range act.Sessions {
+		if s.Timestamp.After(end) {
+			end = s.Timestamp
+		}
+	}
+	if !IsBaseTime(msg.LocalTimestamp) {
+		msg.LocalTimestamp = msg.LocalTimestamp.Add(end.Sub(msg.Timestamp))
+	}
+	msg.Timestamp = end
+}
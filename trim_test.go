@@ -0,0 +1,146 @@
+package fit_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/analysis"
+)
+
+func TestTrim(t *testing.T) {
+	_, act := decodeFile(t, "dcrainmaker", "Edge810-Vector-2013-08-16-15-35-10.fit")
+	ses := act.Sessions[0]
+	to, ok := fit.TimeAtDistance(act.Records, ses.GetTotalDistanceScaled()/2)
+	if !ok {
+		t.Fatal("TimeAtDistance: no record half way")
+	}
+	if _, ok := fit.TimeAtDistance(act.Records, ses.GetTotalDistanceScaled()+1000); ok {
+		t.Error("TimeAtDistance beyond the end: got ok")
+	}
+
+	trimmed := fit.Trim(act, time.Time{}, to)
+	for _, r := range trimmed.Records {
+		if r.Timestamp.After(to) {
+			t.Fatalf("got record at %v after %v", r.Timestamp, to)
+		}
+	}
+	if n := len(trimmed.Records); n == 0 || n >= len(act.Records) {
+		t.Fatalf("got %d of %d records", n, len(act.Records))
+	}
+	last := trimmed.Events[len(trimmed.Events)-1]
+	if last.Event != fit.EventTimer || last.EventType != fit.EventTypeStopAll || !last.Timestamp.Equal(to) {
+		t.Errorf("last event: got %v %v at %v, want timer stop all at %v", last.Event, last.EventType, last.Timestamp, to)
+	}
+
+	if len(trimmed.Sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(trimmed.Sessions))
+	}
+	got := trimmed.Sessions[0]
+	if !got.Timestamp.Equal(to) || got.TotalElapsedTime != uint32(to.Sub(ses.StartTime)/time.Millisecond) {
+		t.Errorf("session: got end %v, elapsed %d", got.Timestamp, got.TotalElapsedTime)
+	}
+	if d := got.GetTotalDistanceScaled(); math.Abs(d-ses.GetTotalDistanceScaled()/2) > 50 {
+		t.Errorf("session: got distance %v, want about %v", d, ses.GetTotalDistanceScaled()/2)
+	}
+	if got.TotalTimerTime > got.TotalElapsedTime || got.TotalTimerTime < got.TotalElapsedTime/2 {
+		t.Errorf("session: got timer time %d for elapsed time %d", got.TotalTimerTime, got.TotalElapsedTime)
+	}
+	sum := analysis.Summarize(trimmed.Records, analysis.WithTimerEvents(trimmed.Events))
+	if got.AvgPower != uint16(math.Round(sum.AvgPower)) || got.TotalAscent != uint16(math.Round(sum.TotalAscent)) {
+		t.Errorf("session: got average power %d, total ascent %d, want %.0f, %.0f",
+			got.AvgPower, got.TotalAscent, sum.AvgPower, sum.TotalAscent)
+	}
+	if got.TotalAscent == 0xFFFF || got.TotalAscent >= ses.TotalAscent || got.NormalizedPower != 0xFFFF {
+		t.Errorf("session: got total ascent %d of %d, normalized power %d, want recomputed and reset",
+			got.TotalAscent, ses.TotalAscent, got.NormalizedPower)
+	}
+	if int(got.NumLaps) != len(trimmed.Laps) || got.FirstLapIndex != 0 {
+		t.Errorf("session: got %d laps from %d, want %d from 0", got.NumLaps, got.FirstLapIndex, len(trimmed.Laps))
+	}
+	for i, l := range trimmed.Laps {
+		if int(l.MessageIndex) != i || l.Timestamp.After(to) {
+			t.Errorf("lap %d: got index %d, end %v", i, l.MessageIndex, l.Timestamp)
+		}
+	}
+
+	a := trimmed.Activity
+	if a.NumSessions != 1 || a.TotalTimerTime != got.TotalTimerTime || !a.Timestamp.Equal(to) {
+		t.Errorf("activity: got %d sessions, timer time %d, end %v", a.NumSessions, a.TotalTimerTime, a.Timestamp)
+	}
+	if ses.TotalElapsedTime == got.TotalElapsedTime || act.Activity.Timestamp.Equal(to) {
+		t.Error("input modified")
+	}
+
+	before, after := fit.Split(act, to)
+	if n := len(before.Records) + len(after.Records); n != len(act.Records)+1 {
+		t.Errorf("split: got %d records in total, want %d", n, len(act.Records)+1)
+	}
+	if first := after.Events[0]; first.Event != fit.EventTimer || first.EventType != fit.EventTypeStart || !first.Timestamp.Equal(to) {
+		t.Errorf("split: first event after: got %v %v at %v, want timer start at %v", first.Event, first.EventType, first.Timestamp, to)
+	}
+}
+
+func TestSplitSessions(t *testing.T) {
+	_, act := decodeFile(t, "me", "activity-large-fenxi2-multisport.fit")
+	acts := fit.SplitSessions(act)
+	if len(acts) != len(act.Sessions) {
+		t.Fatalf("got %d activities, want %d", len(acts), len(act.Sessions))
+	}
+	offset := act.Activity.LocalTimestamp.Sub(act.Activity.Timestamp)
+	for i, a := range acts {
+		want := act.Sessions[i]
+		if len(a.Sessions) != 1 || a.Activity.NumSessions != 1 {
+			t.Errorf("activity %d: got %d sessions, NumSessions %d, want 1", i, len(a.Sessions), a.Activity.NumSessions)
+			continue
+		}
+		s := a.Sessions[0]
+		if s.Sport != want.Sport || s.TotalTimerTime != want.TotalTimerTime || s.MessageIndex != 0 {
+			t.Errorf("activity %d: got %v session with timer time %d, index %d, want %v, %d, 0",
+				i, s.Sport, s.TotalTimerTime, s.MessageIndex, want.Sport, want.TotalTimerTime)
+		}
+		if a.Activity.TotalTimerTime != want.TotalTimerTime {
+			t.Errorf("activity %d: got timer time %d, want %d", i, a.Activity.TotalTimerTime, want.TotalTimerTime)
+		}
+		if i < len(acts)-1 && !a.Activity.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("activity %d: got end %v, want %v", i, a.Activity.Timestamp, want.Timestamp)
+		}
+		if got := a.Activity.LocalTimestamp.Sub(a.Activity.Timestamp); got != offset {
+			t.Errorf("activity %d: got local time offset %v, want %v", i, got, offset)
+		}
+		for _, r := range a.Records {
+			if r.Timestamp.Before(want.StartTime) || r.Timestamp.After(want.Timestamp) {
+				t.Fatalf("activity %d: got record at %v outside session", i, r.Timestamp)
+			}
+		}
+	}
+}
+
+func TestTrimHrv(t *testing.T) {
+	t0 := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	start := fit.NewEventMsg()
+	start.Timestamp, start.Event, start.EventType = t0, fit.EventTimer, fit.EventTypeStart
+	act := &fit.ActivityFile{
+		Events: []*fit.EventMsg{start},
+		// Beats at 0.8, 1.8 and 2.7 s; the first message is padded.
+		Hrvs: []*fit.HrvMsg{{Time: []uint16{800, 0xFFFF, 0xFFFF}}, {Time: []uint16{1000, 900}}},
+	}
+	for _, tt := range []struct {
+		from, to time.Time
+		want     []uint16
+	}{
+		{time.Time{}, time.Time{}, []uint16{800, 1000, 900}},
+		{t0.Add(time.Second), t0.Add(2 * time.Second), []uint16{1000}},
+		{t0.Add(2 * time.Second), time.Time{}, []uint16{900}},
+	} {
+		var got []uint16
+		for _, h := range fit.Trim(act, tt.from, tt.to).Hrvs {
+			got = append(got, h.Time...)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("trim from %v to %v: got intervals %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
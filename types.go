@@ -14,6 +14,42 @@ const (
 	ActivityClassInvalid  ActivityClass = 0xFF
 )
 
+var activityClassValues = map[string]ActivityClass{
+	"Level":    ActivityClassLevel,
+	"LevelMax": ActivityClassLevelMax,
+	"Athlete":  ActivityClassAthlete,
+	"Invalid":  ActivityClassInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ActivityClass) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ActivityClassInvalid.
+func (x *ActivityClass) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ActivityClassInvalid
+	case name == "":
+		*x = ActivityClass(v)
+	default:
+		val, found := activityClassValues[name]
+		if !found {
+			return unknownEnumNameError{"ActivityClass", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ActivityLevel represents the activity_level FIT type.
 type ActivityLevel byte
 
@@ -24,6 +60,42 @@ const (
 	ActivityLevelInvalid ActivityLevel = 0xFF
 )
 
+var activityLevelValues = map[string]ActivityLevel{
+	"Low":     ActivityLevelLow,
+	"Medium":  ActivityLevelMedium,
+	"High":    ActivityLevelHigh,
+	"Invalid": ActivityLevelInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ActivityLevel) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ActivityLevelInvalid.
+func (x *ActivityLevel) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ActivityLevelInvalid
+	case name == "":
+		*x = ActivityLevel(v)
+	default:
+		val, found := activityLevelValues[name]
+		if !found {
+			return unknownEnumNameError{"ActivityLevel", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ActivityMode represents the activity FIT type.
 type ActivityMode byte
 
@@ -33,6 +105,41 @@ const (
 	ActivityModeInvalid        ActivityMode = 0xFF
 )
 
+var activityModeValues = map[string]ActivityMode{
+	"Manual":         ActivityModeManual,
+	"AutoMultiSport": ActivityModeAutoMultiSport,
+	"Invalid":        ActivityModeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ActivityMode) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ActivityModeInvalid.
+func (x *ActivityMode) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ActivityModeInvalid
+	case name == "":
+		*x = ActivityMode(v)
+	default:
+		val, found := activityModeValues[name]
+		if !found {
+			return unknownEnumNameError{"ActivityMode", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ActivitySubtype represents the activity_subtype FIT type.
 type ActivitySubtype byte
 
@@ -60,6 +167,59 @@ const (
 	ActivitySubtypeInvalid       ActivitySubtype = 0xFF
 )
 
+var activitySubtypeValues = map[string]ActivitySubtype{
+	"Generic":       ActivitySubtypeGeneric,
+	"Treadmill":     ActivitySubtypeTreadmill,
+	"Street":        ActivitySubtypeStreet,
+	"Trail":         ActivitySubtypeTrail,
+	"Track":         ActivitySubtypeTrack,
+	"Spin":          ActivitySubtypeSpin,
+	"IndoorCycling": ActivitySubtypeIndoorCycling,
+	"Road":          ActivitySubtypeRoad,
+	"Mountain":      ActivitySubtypeMountain,
+	"Downhill":      ActivitySubtypeDownhill,
+	"Recumbent":     ActivitySubtypeRecumbent,
+	"Cyclocross":    ActivitySubtypeCyclocross,
+	"HandCycling":   ActivitySubtypeHandCycling,
+	"TrackCycling":  ActivitySubtypeTrackCycling,
+	"IndoorRowing":  ActivitySubtypeIndoorRowing,
+	"Elliptical":    ActivitySubtypeElliptical,
+	"StairClimbing": ActivitySubtypeStairClimbing,
+	"LapSwimming":   ActivitySubtypeLapSwimming,
+	"OpenWater":     ActivitySubtypeOpenWater,
+	"All":           ActivitySubtypeAll,
+	"Invalid":       ActivitySubtypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ActivitySubtype) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ActivitySubtypeInvalid.
+func (x *ActivitySubtype) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ActivitySubtypeInvalid
+	case name == "":
+		*x = ActivitySubtype(v)
+	default:
+		val, found := activitySubtypeValues[name]
+		if !found {
+			return unknownEnumNameError{"ActivitySubtype", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ActivityType represents the activity_type FIT type.
 type ActivityType byte
 
@@ -76,6 +236,48 @@ const (
 	ActivityTypeInvalid          ActivityType = 0xFF
 )
 
+var activityTypeValues = map[string]ActivityType{
+	"Generic":          ActivityTypeGeneric,
+	"Running":          ActivityTypeRunning,
+	"Cycling":          ActivityTypeCycling,
+	"Transition":       ActivityTypeTransition,
+	"FitnessEquipment": ActivityTypeFitnessEquipment,
+	"Swimming":         ActivityTypeSwimming,
+	"Walking":          ActivityTypeWalking,
+	"Sedentary":        ActivityTypeSedentary,
+	"All":              ActivityTypeAll,
+	"Invalid":          ActivityTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ActivityType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ActivityTypeInvalid.
+func (x *ActivityType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ActivityTypeInvalid
+	case name == "":
+		*x = ActivityType(v)
+	default:
+		val, found := activityTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"ActivityType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AnalogWatchfaceLayout represents the analog_watchface_layout FIT type.
 type AnalogWatchfaceLayout byte
 
@@ -86,6 +288,42 @@ const (
 	AnalogWatchfaceLayoutInvalid     AnalogWatchfaceLayout = 0xFF
 )
 
+var analogWatchfaceLayoutValues = map[string]AnalogWatchfaceLayout{
+	"Minimal":     AnalogWatchfaceLayoutMinimal,
+	"Traditional": AnalogWatchfaceLayoutTraditional,
+	"Modern":      AnalogWatchfaceLayoutModern,
+	"Invalid":     AnalogWatchfaceLayoutInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AnalogWatchfaceLayout) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AnalogWatchfaceLayoutInvalid.
+func (x *AnalogWatchfaceLayout) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AnalogWatchfaceLayoutInvalid
+	case name == "":
+		*x = AnalogWatchfaceLayout(v)
+	default:
+		val, found := analogWatchfaceLayoutValues[name]
+		if !found {
+			return unknownEnumNameError{"AnalogWatchfaceLayout", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AntNetwork represents the ant_network FIT type.
 type AntNetwork byte
 
@@ -97,6 +335,43 @@ const (
 	AntNetworkInvalid AntNetwork = 0xFF
 )
 
+var antNetworkValues = map[string]AntNetwork{
+	"Public":  AntNetworkPublic,
+	"Antplus": AntNetworkAntplus,
+	"Antfs":   AntNetworkAntfs,
+	"Private": AntNetworkPrivate,
+	"Invalid": AntNetworkInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AntNetwork) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AntNetworkInvalid.
+func (x *AntNetwork) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AntNetworkInvalid
+	case name == "":
+		*x = AntNetwork(v)
+	default:
+		val, found := antNetworkValues[name]
+		if !found {
+			return unknownEnumNameError{"AntNetwork", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AntplusDeviceType represents the antplus_device_type FIT type.
 type AntplusDeviceType uint8
 
@@ -127,6 +402,62 @@ const (
 	AntplusDeviceTypeInvalid                 AntplusDeviceType = 0xFF
 )
 
+var antplusDeviceTypeValues = map[string]AntplusDeviceType{
+	"Antfs":                   AntplusDeviceTypeAntfs,
+	"BikePower":               AntplusDeviceTypeBikePower,
+	"EnvironmentSensorLegacy": AntplusDeviceTypeEnvironmentSensorLegacy,
+	"MultiSportSpeedDistance": AntplusDeviceTypeMultiSportSpeedDistance,
+	"Control":                 AntplusDeviceTypeControl,
+	"FitnessEquipment":        AntplusDeviceTypeFitnessEquipment,
+	"BloodPressure":           AntplusDeviceTypeBloodPressure,
+	"GeocacheNode":            AntplusDeviceTypeGeocacheNode,
+	"LightElectricVehicle":    AntplusDeviceTypeLightElectricVehicle,
+	"EnvSensor":               AntplusDeviceTypeEnvSensor,
+	"Racquet":                 AntplusDeviceTypeRacquet,
+	"ControlHub":              AntplusDeviceTypeControlHub,
+	"MuscleOxygen":            AntplusDeviceTypeMuscleOxygen,
+	"BikeLightMain":           AntplusDeviceTypeBikeLightMain,
+	"BikeLightShared":         AntplusDeviceTypeBikeLightShared,
+	"Exd":                     AntplusDeviceTypeExd,
+	"BikeRadar":               AntplusDeviceTypeBikeRadar,
+	"WeightScale":             AntplusDeviceTypeWeightScale,
+	"HeartRate":               AntplusDeviceTypeHeartRate,
+	"BikeSpeedCadence":        AntplusDeviceTypeBikeSpeedCadence,
+	"BikeCadence":             AntplusDeviceTypeBikeCadence,
+	"BikeSpeed":               AntplusDeviceTypeBikeSpeed,
+	"StrideSpeedDistance":     AntplusDeviceTypeStrideSpeedDistance,
+	"Invalid":                 AntplusDeviceTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AntplusDeviceType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AntplusDeviceTypeInvalid.
+func (x *AntplusDeviceType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AntplusDeviceTypeInvalid
+	case name == "":
+		*x = AntplusDeviceType(v)
+	default:
+		val, found := antplusDeviceTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"AntplusDeviceType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AttitudeStage represents the attitude_stage FIT type.
 type AttitudeStage byte
 
@@ -138,6 +469,43 @@ const (
 	AttitudeStageInvalid  AttitudeStage = 0xFF
 )
 
+var attitudeStageValues = map[string]AttitudeStage{
+	"Failed":   AttitudeStageFailed,
+	"Aligning": AttitudeStageAligning,
+	"Degraded": AttitudeStageDegraded,
+	"Valid":    AttitudeStageValid,
+	"Invalid":  AttitudeStageInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AttitudeStage) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AttitudeStageInvalid.
+func (x *AttitudeStage) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AttitudeStageInvalid
+	case name == "":
+		*x = AttitudeStage(v)
+	default:
+		val, found := attitudeStageValues[name]
+		if !found {
+			return unknownEnumNameError{"AttitudeStage", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AttitudeValidity represents the attitude_validity FIT type.
 type AttitudeValidity uint16
 
@@ -158,6 +526,52 @@ const (
 	AttitudeValidityInvalid                AttitudeValidity = 0xFFFF
 )
 
+var attitudeValidityValues = map[string]AttitudeValidity{
+	"TrackAngleHeadingValid": AttitudeValidityTrackAngleHeadingValid,
+	"PitchValid":             AttitudeValidityPitchValid,
+	"RollValid":              AttitudeValidityRollValid,
+	"LateralBodyAccelValid":  AttitudeValidityLateralBodyAccelValid,
+	"NormalBodyAccelValid":   AttitudeValidityNormalBodyAccelValid,
+	"TurnRateValid":          AttitudeValidityTurnRateValid,
+	"HwFail":                 AttitudeValidityHwFail,
+	"MagInvalid":             AttitudeValidityMagInvalid,
+	"NoGps":                  AttitudeValidityNoGps,
+	"GpsInvalid":             AttitudeValidityGpsInvalid,
+	"SolutionCoasting":       AttitudeValiditySolutionCoasting,
+	"TrueTrackAngle":         AttitudeValidityTrueTrackAngle,
+	"MagneticHeading":        AttitudeValidityMagneticHeading,
+	"Invalid":                AttitudeValidityInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AttitudeValidity) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AttitudeValidityInvalid.
+func (x *AttitudeValidity) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AttitudeValidityInvalid
+	case name == "":
+		*x = AttitudeValidity(v)
+	default:
+		val, found := attitudeValidityValues[name]
+		if !found {
+			return unknownEnumNameError{"AttitudeValidity", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AutoActivityDetect represents the auto_activity_detect FIT type.
 type AutoActivityDetect uint32
 
@@ -172,6 +586,46 @@ const (
 	AutoActivityDetectInvalid    AutoActivityDetect = 0xFFFFFFFF
 )
 
+var autoActivityDetectValues = map[string]AutoActivityDetect{
+	"None":       AutoActivityDetectNone,
+	"Running":    AutoActivityDetectRunning,
+	"Cycling":    AutoActivityDetectCycling,
+	"Swimming":   AutoActivityDetectSwimming,
+	"Walking":    AutoActivityDetectWalking,
+	"Elliptical": AutoActivityDetectElliptical,
+	"Sedentary":  AutoActivityDetectSedentary,
+	"Invalid":    AutoActivityDetectInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AutoActivityDetect) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AutoActivityDetectInvalid.
+func (x *AutoActivityDetect) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AutoActivityDetectInvalid
+	case name == "":
+		*x = AutoActivityDetect(v)
+	default:
+		val, found := autoActivityDetectValues[name]
+		if !found {
+			return unknownEnumNameError{"AutoActivityDetect", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AutoSyncFrequency represents the auto_sync_frequency FIT type.
 type AutoSyncFrequency byte
 
@@ -184,6 +638,44 @@ const (
 	AutoSyncFrequencyInvalid      AutoSyncFrequency = 0xFF
 )
 
+var autoSyncFrequencyValues = map[string]AutoSyncFrequency{
+	"Never":        AutoSyncFrequencyNever,
+	"Occasionally": AutoSyncFrequencyOccasionally,
+	"Frequent":     AutoSyncFrequencyFrequent,
+	"OnceADay":     AutoSyncFrequencyOnceADay,
+	"Remote":       AutoSyncFrequencyRemote,
+	"Invalid":      AutoSyncFrequencyInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AutoSyncFrequency) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AutoSyncFrequencyInvalid.
+func (x *AutoSyncFrequency) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AutoSyncFrequencyInvalid
+	case name == "":
+		*x = AutoSyncFrequency(v)
+	default:
+		val, found := autoSyncFrequencyValues[name]
+		if !found {
+			return unknownEnumNameError{"AutoSyncFrequency", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // AutolapTrigger represents the autolap_trigger FIT type.
 type AutolapTrigger byte
 
@@ -198,6 +690,46 @@ const (
 	AutolapTriggerInvalid          AutolapTrigger = 0xFF
 )
 
+var autolapTriggerValues = map[string]AutolapTrigger{
+	"Time":             AutolapTriggerTime,
+	"Distance":         AutolapTriggerDistance,
+	"PositionStart":    AutolapTriggerPositionStart,
+	"PositionLap":      AutolapTriggerPositionLap,
+	"PositionWaypoint": AutolapTriggerPositionWaypoint,
+	"PositionMarked":   AutolapTriggerPositionMarked,
+	"Off":              AutolapTriggerOff,
+	"Invalid":          AutolapTriggerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x AutolapTrigger) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AutolapTriggerInvalid.
+func (x *AutolapTrigger) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AutolapTriggerInvalid
+	case name == "":
+		*x = AutolapTrigger(v)
+	default:
+		val, found := autolapTriggerValues[name]
+		if !found {
+			return unknownEnumNameError{"AutolapTrigger", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Autoscroll represents the autoscroll FIT type.
 type Autoscroll byte
 
@@ -209,6 +741,43 @@ const (
 	AutoscrollInvalid Autoscroll = 0xFF
 )
 
+var autoscrollValues = map[string]Autoscroll{
+	"None":    AutoscrollNone,
+	"Slow":    AutoscrollSlow,
+	"Medium":  AutoscrollMedium,
+	"Fast":    AutoscrollFast,
+	"Invalid": AutoscrollInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Autoscroll) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to AutoscrollInvalid.
+func (x *Autoscroll) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = AutoscrollInvalid
+	case name == "":
+		*x = Autoscroll(v)
+	default:
+		val, found := autoscrollValues[name]
+		if !found {
+			return unknownEnumNameError{"Autoscroll", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // BacklightMode represents the backlight_mode FIT type.
 type BacklightMode byte
 
@@ -223,6 +792,46 @@ const (
 	BacklightModeInvalid                             BacklightMode = 0xFF
 )
 
+var backlightModeValues = map[string]BacklightMode{
+	"Off":                                 BacklightModeOff,
+	"Manual":                              BacklightModeManual,
+	"KeyAndMessages":                      BacklightModeKeyAndMessages,
+	"AutoBrightness":                      BacklightModeAutoBrightness,
+	"SmartNotifications":                  BacklightModeSmartNotifications,
+	"KeyAndMessagesNight":                 BacklightModeKeyAndMessagesNight,
+	"KeyAndMessagesAndSmartNotifications": BacklightModeKeyAndMessagesAndSmartNotifications,
+	"Invalid":                             BacklightModeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x BacklightMode) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to BacklightModeInvalid.
+func (x *BacklightMode) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = BacklightModeInvalid
+	case name == "":
+		*x = BacklightMode(v)
+	default:
+		val, found := backlightModeValues[name]
+		if !found {
+			return unknownEnumNameError{"BacklightMode", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // BatteryStatus represents the battery_status FIT type.
 type BatteryStatus uint8
 
@@ -237,6 +846,46 @@ const (
 	BatteryStatusInvalid  BatteryStatus = 0xFF
 )
 
+var batteryStatusValues = map[string]BatteryStatus{
+	"New":      BatteryStatusNew,
+	"Good":     BatteryStatusGood,
+	"Ok":       BatteryStatusOk,
+	"Low":      BatteryStatusLow,
+	"Critical": BatteryStatusCritical,
+	"Charging": BatteryStatusCharging,
+	"Unknown":  BatteryStatusUnknown,
+	"Invalid":  BatteryStatusInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x BatteryStatus) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to BatteryStatusInvalid.
+func (x *BatteryStatus) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = BatteryStatusInvalid
+	case name == "":
+		*x = BatteryStatus(v)
+	default:
+		val, found := batteryStatusValues[name]
+		if !found {
+			return unknownEnumNameError{"BatteryStatus", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // BikeLightBeamAngleMode represents the bike_light_beam_angle_mode FIT type.
 type BikeLightBeamAngleMode uint8
 
@@ -246,6 +895,41 @@ const (
 	BikeLightBeamAngleModeInvalid BikeLightBeamAngleMode = 0xFF
 )
 
+var bikeLightBeamAngleModeValues = map[string]BikeLightBeamAngleMode{
+	"Manual":  BikeLightBeamAngleModeManual,
+	"Auto":    BikeLightBeamAngleModeAuto,
+	"Invalid": BikeLightBeamAngleModeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x BikeLightBeamAngleMode) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to BikeLightBeamAngleModeInvalid.
+func (x *BikeLightBeamAngleMode) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = BikeLightBeamAngleModeInvalid
+	case name == "":
+		*x = BikeLightBeamAngleMode(v)
+	default:
+		val, found := bikeLightBeamAngleModeValues[name]
+		if !found {
+			return unknownEnumNameError{"BikeLightBeamAngleMode", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // BikeLightNetworkConfigType represents the bike_light_network_config_type FIT type.
 type BikeLightNetworkConfigType byte
 
@@ -257,6 +941,43 @@ const (
 	BikeLightNetworkConfigTypeInvalid        BikeLightNetworkConfigType = 0xFF
 )
 
+var bikeLightNetworkConfigTypeValues = map[string]BikeLightNetworkConfigType{
+	"Auto":           BikeLightNetworkConfigTypeAuto,
+	"Individual":     BikeLightNetworkConfigTypeIndividual,
+	"HighVisibility": BikeLightNetworkConfigTypeHighVisibility,
+	"Trail":          BikeLightNetworkConfigTypeTrail,
+	"Invalid":        BikeLightNetworkConfigTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x BikeLightNetworkConfigType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to BikeLightNetworkConfigTypeInvalid.
+func (x *BikeLightNetworkConfigType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = BikeLightNetworkConfigTypeInvalid
+	case name == "":
+		*x = BikeLightNetworkConfigType(v)
+	default:
+		val, found := bikeLightNetworkConfigTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"BikeLightNetworkConfigType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // BodyLocation represents the body_location FIT type.
 type BodyLocation byte
 
@@ -304,6 +1025,79 @@ const (
 	BodyLocationInvalid               BodyLocation = 0xFF
 )
 
+var bodyLocationValues = map[string]BodyLocation{
+	"LeftLeg":               BodyLocationLeftLeg,
+	"LeftCalf":              BodyLocationLeftCalf,
+	"LeftShin":              BodyLocationLeftShin,
+	"LeftHamstring":         BodyLocationLeftHamstring,
+	"LeftQuad":              BodyLocationLeftQuad,
+	"LeftGlute":             BodyLocationLeftGlute,
+	"RightLeg":              BodyLocationRightLeg,
+	"RightCalf":             BodyLocationRightCalf,
+	"RightShin":             BodyLocationRightShin,
+	"RightHamstring":        BodyLocationRightHamstring,
+	"RightQuad":             BodyLocationRightQuad,
+	"RightGlute":            BodyLocationRightGlute,
+	"TorsoBack":             BodyLocationTorsoBack,
+	"LeftLowerBack":         BodyLocationLeftLowerBack,
+	"LeftUpperBack":         BodyLocationLeftUpperBack,
+	"RightLowerBack":        BodyLocationRightLowerBack,
+	"RightUpperBack":        BodyLocationRightUpperBack,
+	"TorsoFront":            BodyLocationTorsoFront,
+	"LeftAbdomen":           BodyLocationLeftAbdomen,
+	"LeftChest":             BodyLocationLeftChest,
+	"RightAbdomen":          BodyLocationRightAbdomen,
+	"RightChest":            BodyLocationRightChest,
+	"LeftArm":               BodyLocationLeftArm,
+	"LeftShoulder":          BodyLocationLeftShoulder,
+	"LeftBicep":             BodyLocationLeftBicep,
+	"LeftTricep":            BodyLocationLeftTricep,
+	"LeftBrachioradialis":   BodyLocationLeftBrachioradialis,
+	"LeftForearmExtensors":  BodyLocationLeftForearmExtensors,
+	"RightArm":              BodyLocationRightArm,
+	"RightShoulder":         BodyLocationRightShoulder,
+	"RightBicep":            BodyLocationRightBicep,
+	"RightTricep":           BodyLocationRightTricep,
+	"RightBrachioradialis":  BodyLocationRightBrachioradialis,
+	"RightForearmExtensors": BodyLocationRightForearmExtensors,
+	"Neck":                  BodyLocationNeck,
+	"Throat":                BodyLocationThroat,
+	"WaistMidBack":          BodyLocationWaistMidBack,
+	"WaistFront":            BodyLocationWaistFront,
+	"WaistLeft":             BodyLocationWaistLeft,
+	"WaistRight":            BodyLocationWaistRight,
+	"Invalid":               BodyLocationInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x BodyLocation) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to BodyLocationInvalid.
+func (x *BodyLocation) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = BodyLocationInvalid
+	case name == "":
+		*x = BodyLocation(v)
+	default:
+		val, found := bodyLocationValues[name]
+		if !found {
+			return unknownEnumNameError{"BodyLocation", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // BpStatus represents the bp_status FIT type.
 type BpStatus byte
 
@@ -316,6 +1110,44 @@ const (
 	BpStatusInvalid                 BpStatus = 0xFF
 )
 
+var bpStatusValues = map[string]BpStatus{
+	"NoError":                 BpStatusNoError,
+	"ErrorIncompleteData":     BpStatusErrorIncompleteData,
+	"ErrorNoMeasurement":      BpStatusErrorNoMeasurement,
+	"ErrorDataOutOfRange":     BpStatusErrorDataOutOfRange,
+	"ErrorIrregularHeartRate": BpStatusErrorIrregularHeartRate,
+	"Invalid":                 BpStatusInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x BpStatus) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to BpStatusInvalid.
+func (x *BpStatus) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = BpStatusInvalid
+	case name == "":
+		*x = BpStatus(v)
+	default:
+		val, found := bpStatusValues[name]
+		if !found {
+			return unknownEnumNameError{"BpStatus", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // CameraEventType represents the camera_event_type FIT type.
 type CameraEventType byte
 
@@ -336,6 +1168,52 @@ const (
 	CameraEventTypeInvalid                     CameraEventType = 0xFF
 )
 
+var cameraEventTypeValues = map[string]CameraEventType{
+	"VideoStart":                  CameraEventTypeVideoStart,
+	"VideoSplit":                  CameraEventTypeVideoSplit,
+	"VideoEnd":                    CameraEventTypeVideoEnd,
+	"PhotoTaken":                  CameraEventTypePhotoTaken,
+	"VideoSecondStreamStart":      CameraEventTypeVideoSecondStreamStart,
+	"VideoSecondStreamSplit":      CameraEventTypeVideoSecondStreamSplit,
+	"VideoSecondStreamEnd":        CameraEventTypeVideoSecondStreamEnd,
+	"VideoSplitStart":             CameraEventTypeVideoSplitStart,
+	"VideoSecondStreamSplitStart": CameraEventTypeVideoSecondStreamSplitStart,
+	"VideoPause":                  CameraEventTypeVideoPause,
+	"VideoSecondStreamPause":      CameraEventTypeVideoSecondStreamPause,
+	"VideoResume":                 CameraEventTypeVideoResume,
+	"VideoSecondStreamResume":     CameraEventTypeVideoSecondStreamResume,
+	"Invalid":                     CameraEventTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x CameraEventType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to CameraEventTypeInvalid.
+func (x *CameraEventType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = CameraEventTypeInvalid
+	case name == "":
+		*x = CameraEventType(v)
+	default:
+		val, found := cameraEventTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"CameraEventType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // CameraOrientationType represents the camera_orientation_type FIT type.
 type CameraOrientationType byte
 
@@ -347,6 +1225,43 @@ const (
 	CameraOrientationTypeInvalid              CameraOrientationType = 0xFF
 )
 
+var cameraOrientationTypeValues = map[string]CameraOrientationType{
+	"CameraOrientation0":   CameraOrientationTypeCameraOrientation0,
+	"CameraOrientation90":  CameraOrientationTypeCameraOrientation90,
+	"CameraOrientation180": CameraOrientationTypeCameraOrientation180,
+	"CameraOrientation270": CameraOrientationTypeCameraOrientation270,
+	"Invalid":              CameraOrientationTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x CameraOrientationType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to CameraOrientationTypeInvalid.
+func (x *CameraOrientationType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = CameraOrientationTypeInvalid
+	case name == "":
+		*x = CameraOrientationType(v)
+	default:
+		val, found := cameraOrientationTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"CameraOrientationType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Checksum represents the checksum FIT type.
 type Checksum uint8
 
@@ -356,6 +1271,41 @@ const (
 	ChecksumInvalid Checksum = 0xFF
 )
 
+var checksumValues = map[string]Checksum{
+	"Clear":   ChecksumClear,
+	"Ok":      ChecksumOk,
+	"Invalid": ChecksumInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Checksum) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ChecksumInvalid.
+func (x *Checksum) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ChecksumInvalid
+	case name == "":
+		*x = Checksum(v)
+	default:
+		val, found := checksumValues[name]
+		if !found {
+			return unknownEnumNameError{"Checksum", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // CommTimeoutType represents the comm_timeout_type FIT type.
 type CommTimeoutType uint16
 
@@ -367,6 +1317,43 @@ const (
 	CommTimeoutTypeInvalid                CommTimeoutType = 0xFFFF
 )
 
+var commTimeoutTypeValues = map[string]CommTimeoutType{
+	"WildcardPairingTimeout": CommTimeoutTypeWildcardPairingTimeout,
+	"PairingTimeout":         CommTimeoutTypePairingTimeout,
+	"ConnectionLost":         CommTimeoutTypeConnectionLost,
+	"ConnectionTimeout":      CommTimeoutTypeConnectionTimeout,
+	"Invalid":                CommTimeoutTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x CommTimeoutType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to CommTimeoutTypeInvalid.
+func (x *CommTimeoutType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = CommTimeoutTypeInvalid
+	case name == "":
+		*x = CommTimeoutType(v)
+	default:
+		val, found := commTimeoutTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"CommTimeoutType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ConnectivityCapabilities represents the connectivity_capabilities FIT type.
 type ConnectivityCapabilities uint32
 
@@ -406,6 +1393,71 @@ const (
 	ConnectivityCapabilitiesInvalid                         ConnectivityCapabilities = 0x00000000
 )
 
+var connectivityCapabilitiesValues = map[string]ConnectivityCapabilities{
+	"Bluetooth":                       ConnectivityCapabilitiesBluetooth,
+	"BluetoothLe":                     ConnectivityCapabilitiesBluetoothLe,
+	"Ant":                             ConnectivityCapabilitiesAnt,
+	"ActivityUpload":                  ConnectivityCapabilitiesActivityUpload,
+	"CourseDownload":                  ConnectivityCapabilitiesCourseDownload,
+	"WorkoutDownload":                 ConnectivityCapabilitiesWorkoutDownload,
+	"LiveTrack":                       ConnectivityCapabilitiesLiveTrack,
+	"WeatherConditions":               ConnectivityCapabilitiesWeatherConditions,
+	"WeatherAlerts":                   ConnectivityCapabilitiesWeatherAlerts,
+	"GpsEphemerisDownload":            ConnectivityCapabilitiesGpsEphemerisDownload,
+	"ExplicitArchive":                 ConnectivityCapabilitiesExplicitArchive,
+	"SetupIncomplete":                 ConnectivityCapabilitiesSetupIncomplete,
+	"ContinueSyncAfterSoftwareUpdate": ConnectivityCapabilitiesContinueSyncAfterSoftwareUpdate,
+	"ConnectIqAppDownload":            ConnectivityCapabilitiesConnectIqAppDownload,
+	"GolfCourseDownload":              ConnectivityCapabilitiesGolfCourseDownload,
+	"DeviceInitiatesSync":             ConnectivityCapabilitiesDeviceInitiatesSync,
+	"ConnectIqWatchAppDownload":       ConnectivityCapabilitiesConnectIqWatchAppDownload,
+	"ConnectIqWidgetDownload":         ConnectivityCapabilitiesConnectIqWidgetDownload,
+	"ConnectIqWatchFaceDownload":      ConnectivityCapabilitiesConnectIqWatchFaceDownload,
+	"ConnectIqDataFieldDownload":      ConnectivityCapabilitiesConnectIqDataFieldDownload,
+	"ConnectIqAppManagment":           ConnectivityCapabilitiesConnectIqAppManagment,
+	"SwingSensor":                     ConnectivityCapabilitiesSwingSensor,
+	"SwingSensorRemote":               ConnectivityCapabilitiesSwingSensorRemote,
+	"IncidentDetection":               ConnectivityCapabilitiesIncidentDetection,
+	"AudioPrompts":                    ConnectivityCapabilitiesAudioPrompts,
+	"WifiVerification":                ConnectivityCapabilitiesWifiVerification,
+	"TrueUp":                          ConnectivityCapabilitiesTrueUp,
+	"FindMyWatch":                     ConnectivityCapabilitiesFindMyWatch,
+	"RemoteManualSync":                ConnectivityCapabilitiesRemoteManualSync,
+	"LiveTrackAutoStart":              ConnectivityCapabilitiesLiveTrackAutoStart,
+	"LiveTrackMessaging":              ConnectivityCapabilitiesLiveTrackMessaging,
+	"InstantInput":                    ConnectivityCapabilitiesInstantInput,
+	"Invalid":                         ConnectivityCapabilitiesInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ConnectivityCapabilities) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ConnectivityCapabilitiesInvalid.
+func (x *ConnectivityCapabilities) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ConnectivityCapabilitiesInvalid
+	case name == "":
+		*x = ConnectivityCapabilities(v)
+	default:
+		val, found := connectivityCapabilitiesValues[name]
+		if !found {
+			return unknownEnumNameError{"ConnectivityCapabilities", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // CourseCapabilities represents the course_capabilities FIT type.
 type CourseCapabilities uint32
 
@@ -424,6 +1476,50 @@ const (
 	CourseCapabilitiesInvalid    CourseCapabilities = 0x00000000
 )
 
+var courseCapabilitiesValues = map[string]CourseCapabilities{
+	"Processed":  CourseCapabilitiesProcessed,
+	"Valid":      CourseCapabilitiesValid,
+	"Time":       CourseCapabilitiesTime,
+	"Distance":   CourseCapabilitiesDistance,
+	"Position":   CourseCapabilitiesPosition,
+	"HeartRate":  CourseCapabilitiesHeartRate,
+	"Power":      CourseCapabilitiesPower,
+	"Cadence":    CourseCapabilitiesCadence,
+	"Training":   CourseCapabilitiesTraining,
+	"Navigation": CourseCapabilitiesNavigation,
+	"Bikeway":    CourseCapabilitiesBikeway,
+	"Invalid":    CourseCapabilitiesInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x CourseCapabilities) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to CourseCapabilitiesInvalid.
+func (x *CourseCapabilities) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = CourseCapabilitiesInvalid
+	case name == "":
+		*x = CourseCapabilities(v)
+	default:
+		val, found := courseCapabilitiesValues[name]
+		if !found {
+			return unknownEnumNameError{"CourseCapabilities", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // CoursePoint represents the course_point FIT type.
 type CoursePoint byte
 
@@ -457,6 +1553,65 @@ const (
 	CoursePointInvalid        CoursePoint = 0xFF
 )
 
+var coursePointValues = map[string]CoursePoint{
+	"Generic":        CoursePointGeneric,
+	"Summit":         CoursePointSummit,
+	"Valley":         CoursePointValley,
+	"Water":          CoursePointWater,
+	"Food":           CoursePointFood,
+	"Danger":         CoursePointDanger,
+	"Left":           CoursePointLeft,
+	"Right":          CoursePointRight,
+	"Straight":       CoursePointStraight,
+	"FirstAid":       CoursePointFirstAid,
+	"FourthCategory": CoursePointFourthCategory,
+	"ThirdCategory":  CoursePointThirdCategory,
+	"SecondCategory": CoursePointSecondCategory,
+	"FirstCategory":  CoursePointFirstCategory,
+	"HorsCategory":   CoursePointHorsCategory,
+	"Sprint":         CoursePointSprint,
+	"LeftFork":       CoursePointLeftFork,
+	"RightFork":      CoursePointRightFork,
+	"MiddleFork":     CoursePointMiddleFork,
+	"SlightLeft":     CoursePointSlightLeft,
+	"SharpLeft":      CoursePointSharpLeft,
+	"SlightRight":    CoursePointSlightRight,
+	"SharpRight":     CoursePointSharpRight,
+	"UTurn":          CoursePointUTurn,
+	"SegmentStart":   CoursePointSegmentStart,
+	"SegmentEnd":     CoursePointSegmentEnd,
+	"Invalid":        CoursePointInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x CoursePoint) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to CoursePointInvalid.
+func (x *CoursePoint) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = CoursePointInvalid
+	case name == "":
+		*x = CoursePoint(v)
+	default:
+		val, found := coursePointValues[name]
+		if !found {
+			return unknownEnumNameError{"CoursePoint", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DateMode represents the date_mode FIT type.
 type DateMode byte
 
@@ -466,6 +1621,41 @@ const (
 	DateModeInvalid  DateMode = 0xFF
 )
 
+var dateModeValues = map[string]DateMode{
+	"DayMonth": DateModeDayMonth,
+	"MonthDay": DateModeMonthDay,
+	"Invalid":  DateModeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DateMode) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DateModeInvalid.
+func (x *DateMode) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DateModeInvalid
+	case name == "":
+		*x = DateMode(v)
+	default:
+		val, found := dateModeValues[name]
+		if !found {
+			return unknownEnumNameError{"DateMode", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DayOfWeek represents the day_of_week FIT type.
 type DayOfWeek byte
 
@@ -480,6 +1670,46 @@ const (
 	DayOfWeekInvalid   DayOfWeek = 0xFF
 )
 
+var dayOfWeekValues = map[string]DayOfWeek{
+	"Sunday":    DayOfWeekSunday,
+	"Monday":    DayOfWeekMonday,
+	"Tuesday":   DayOfWeekTuesday,
+	"Wednesday": DayOfWeekWednesday,
+	"Thursday":  DayOfWeekThursday,
+	"Friday":    DayOfWeekFriday,
+	"Saturday":  DayOfWeekSaturday,
+	"Invalid":   DayOfWeekInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DayOfWeek) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DayOfWeekInvalid.
+func (x *DayOfWeek) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DayOfWeekInvalid
+	case name == "":
+		*x = DayOfWeek(v)
+	default:
+		val, found := dayOfWeekValues[name]
+		if !found {
+			return unknownEnumNameError{"DayOfWeek", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DeviceIndex represents the device_index FIT type.
 type DeviceIndex uint8
 
@@ -488,6 +1718,40 @@ const (
 	DeviceIndexInvalid DeviceIndex = 0xFF
 )
 
+var deviceIndexValues = map[string]DeviceIndex{
+	"Creator": DeviceIndexCreator,
+	"Invalid": DeviceIndexInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DeviceIndex) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DeviceIndexInvalid.
+func (x *DeviceIndex) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DeviceIndexInvalid
+	case name == "":
+		*x = DeviceIndex(v)
+	default:
+		val, found := deviceIndexValues[name]
+		if !found {
+			return unknownEnumNameError{"DeviceIndex", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DigitalWatchfaceLayout represents the digital_watchface_layout FIT type.
 type DigitalWatchfaceLayout byte
 
@@ -498,6 +1762,42 @@ const (
 	DigitalWatchfaceLayoutInvalid     DigitalWatchfaceLayout = 0xFF
 )
 
+var digitalWatchfaceLayoutValues = map[string]DigitalWatchfaceLayout{
+	"Traditional": DigitalWatchfaceLayoutTraditional,
+	"Modern":      DigitalWatchfaceLayoutModern,
+	"Bold":        DigitalWatchfaceLayoutBold,
+	"Invalid":     DigitalWatchfaceLayoutInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DigitalWatchfaceLayout) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DigitalWatchfaceLayoutInvalid.
+func (x *DigitalWatchfaceLayout) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DigitalWatchfaceLayoutInvalid
+	case name == "":
+		*x = DigitalWatchfaceLayout(v)
+	default:
+		val, found := digitalWatchfaceLayoutValues[name]
+		if !found {
+			return unknownEnumNameError{"DigitalWatchfaceLayout", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DisplayHeart represents the display_heart FIT type.
 type DisplayHeart byte
 
@@ -508,6 +1808,42 @@ const (
 	DisplayHeartInvalid DisplayHeart = 0xFF
 )
 
+var displayHeartValues = map[string]DisplayHeart{
+	"Bpm":     DisplayHeartBpm,
+	"Max":     DisplayHeartMax,
+	"Reserve": DisplayHeartReserve,
+	"Invalid": DisplayHeartInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DisplayHeart) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DisplayHeartInvalid.
+func (x *DisplayHeart) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DisplayHeartInvalid
+	case name == "":
+		*x = DisplayHeart(v)
+	default:
+		val, found := displayHeartValues[name]
+		if !found {
+			return unknownEnumNameError{"DisplayHeart", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DisplayMeasure represents the display_measure FIT type.
 type DisplayMeasure byte
 
@@ -518,6 +1854,42 @@ const (
 	DisplayMeasureInvalid  DisplayMeasure = 0xFF
 )
 
+var displayMeasureValues = map[string]DisplayMeasure{
+	"Metric":   DisplayMeasureMetric,
+	"Statute":  DisplayMeasureStatute,
+	"Nautical": DisplayMeasureNautical,
+	"Invalid":  DisplayMeasureInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DisplayMeasure) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DisplayMeasureInvalid.
+func (x *DisplayMeasure) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DisplayMeasureInvalid
+	case name == "":
+		*x = DisplayMeasure(v)
+	default:
+		val, found := displayMeasureValues[name]
+		if !found {
+			return unknownEnumNameError{"DisplayMeasure", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DisplayOrientation represents the display_orientation FIT type.
 type DisplayOrientation byte
 
@@ -530,6 +1902,44 @@ const (
 	DisplayOrientationInvalid          DisplayOrientation = 0xFF
 )
 
+var displayOrientationValues = map[string]DisplayOrientation{
+	"Auto":             DisplayOrientationAuto,
+	"Portrait":         DisplayOrientationPortrait,
+	"Landscape":        DisplayOrientationLandscape,
+	"PortraitFlipped":  DisplayOrientationPortraitFlipped,
+	"LandscapeFlipped": DisplayOrientationLandscapeFlipped,
+	"Invalid":          DisplayOrientationInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DisplayOrientation) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DisplayOrientationInvalid.
+func (x *DisplayOrientation) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DisplayOrientationInvalid
+	case name == "":
+		*x = DisplayOrientation(v)
+	default:
+		val, found := displayOrientationValues[name]
+		if !found {
+			return unknownEnumNameError{"DisplayOrientation", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DisplayPosition represents the display_position FIT type.
 type DisplayPosition byte
 
@@ -579,6 +1989,81 @@ const (
 	DisplayPositionInvalid              DisplayPosition = 0xFF
 )
 
+var displayPositionValues = map[string]DisplayPosition{
+	"Degree":               DisplayPositionDegree,
+	"DegreeMinute":         DisplayPositionDegreeMinute,
+	"DegreeMinuteSecond":   DisplayPositionDegreeMinuteSecond,
+	"AustrianGrid":         DisplayPositionAustrianGrid,
+	"BritishGrid":          DisplayPositionBritishGrid,
+	"DutchGrid":            DisplayPositionDutchGrid,
+	"HungarianGrid":        DisplayPositionHungarianGrid,
+	"FinnishGrid":          DisplayPositionFinnishGrid,
+	"GermanGrid":           DisplayPositionGermanGrid,
+	"IcelandicGrid":        DisplayPositionIcelandicGrid,
+	"IndonesianEquatorial": DisplayPositionIndonesianEquatorial,
+	"IndonesianIrian":      DisplayPositionIndonesianIrian,
+	"IndonesianSouthern":   DisplayPositionIndonesianSouthern,
+	"IndiaZone0":           DisplayPositionIndiaZone0,
+	"IndiaZoneIA":          DisplayPositionIndiaZoneIA,
+	"IndiaZoneIB":          DisplayPositionIndiaZoneIB,
+	"IndiaZoneIIA":         DisplayPositionIndiaZoneIIA,
+	"IndiaZoneIIB":         DisplayPositionIndiaZoneIIB,
+	"IndiaZoneIIIA":        DisplayPositionIndiaZoneIIIA,
+	"IndiaZoneIIIB":        DisplayPositionIndiaZoneIIIB,
+	"IndiaZoneIVA":         DisplayPositionIndiaZoneIVA,
+	"IndiaZoneIVB":         DisplayPositionIndiaZoneIVB,
+	"IrishTransverse":      DisplayPositionIrishTransverse,
+	"IrishGrid":            DisplayPositionIrishGrid,
+	"Loran":                DisplayPositionLoran,
+	"MaidenheadGrid":       DisplayPositionMaidenheadGrid,
+	"MgrsGrid":             DisplayPositionMgrsGrid,
+	"NewZealandGrid":       DisplayPositionNewZealandGrid,
+	"NewZealandTransverse": DisplayPositionNewZealandTransverse,
+	"QatarGrid":            DisplayPositionQatarGrid,
+	"ModifiedSwedishGrid":  DisplayPositionModifiedSwedishGrid,
+	"SwedishGrid":          DisplayPositionSwedishGrid,
+	"SouthAfricanGrid":     DisplayPositionSouthAfricanGrid,
+	"SwissGrid":            DisplayPositionSwissGrid,
+	"TaiwanGrid":           DisplayPositionTaiwanGrid,
+	"UnitedStatesGrid":     DisplayPositionUnitedStatesGrid,
+	"UtmUpsGrid":           DisplayPositionUtmUpsGrid,
+	"WestMalayan":          DisplayPositionWestMalayan,
+	"BorneoRso":            DisplayPositionBorneoRso,
+	"EstonianGrid":         DisplayPositionEstonianGrid,
+	"LatvianGrid":          DisplayPositionLatvianGrid,
+	"SwedishRef99Grid":     DisplayPositionSwedishRef99Grid,
+	"Invalid":              DisplayPositionInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DisplayPosition) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DisplayPositionInvalid.
+func (x *DisplayPosition) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DisplayPositionInvalid
+	case name == "":
+		*x = DisplayPosition(v)
+	default:
+		val, found := displayPositionValues[name]
+		if !found {
+			return unknownEnumNameError{"DisplayPosition", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // DisplayPower represents the display_power FIT type.
 type DisplayPower byte
 
@@ -588,6 +2073,41 @@ const (
 	DisplayPowerInvalid    DisplayPower = 0xFF
 )
 
+var displayPowerValues = map[string]DisplayPower{
+	"Watts":      DisplayPowerWatts,
+	"PercentFtp": DisplayPowerPercentFtp,
+	"Invalid":    DisplayPowerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x DisplayPower) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to DisplayPowerInvalid.
+func (x *DisplayPower) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = DisplayPowerInvalid
+	case name == "":
+		*x = DisplayPower(v)
+	default:
+		val, found := displayPowerValues[name]
+		if !found {
+			return unknownEnumNameError{"DisplayPower", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Event represents the event FIT type.
 type Event byte
 
@@ -631,6 +2151,75 @@ const (
 	EventInvalid               Event = 0xFF
 )
 
+var eventValues = map[string]Event{
+	"Timer":                 EventTimer,
+	"Workout":               EventWorkout,
+	"WorkoutStep":           EventWorkoutStep,
+	"PowerDown":             EventPowerDown,
+	"PowerUp":               EventPowerUp,
+	"OffCourse":             EventOffCourse,
+	"Session":               EventSession,
+	"Lap":                   EventLap,
+	"CoursePoint":           EventCoursePoint,
+	"Battery":               EventBattery,
+	"VirtualPartnerPace":    EventVirtualPartnerPace,
+	"HrHighAlert":           EventHrHighAlert,
+	"HrLowAlert":            EventHrLowAlert,
+	"SpeedHighAlert":        EventSpeedHighAlert,
+	"SpeedLowAlert":         EventSpeedLowAlert,
+	"CadHighAlert":          EventCadHighAlert,
+	"CadLowAlert":           EventCadLowAlert,
+	"PowerHighAlert":        EventPowerHighAlert,
+	"PowerLowAlert":         EventPowerLowAlert,
+	"RecoveryHr":            EventRecoveryHr,
+	"BatteryLow":            EventBatteryLow,
+	"TimeDurationAlert":     EventTimeDurationAlert,
+	"DistanceDurationAlert": EventDistanceDurationAlert,
+	"CalorieDurationAlert":  EventCalorieDurationAlert,
+	"Activity":              EventActivity,
+	"FitnessEquipment":      EventFitnessEquipment,
+	"Length":                EventLength,
+	"UserMarker":            EventUserMarker,
+	"SportPoint":            EventSportPoint,
+	"Calibration":           EventCalibration,
+	"FrontGearChange":       EventFrontGearChange,
+	"RearGearChange":        EventRearGearChange,
+	"RiderPositionChange":   EventRiderPositionChange,
+	"ElevHighAlert":         EventElevHighAlert,
+	"ElevLowAlert":          EventElevLowAlert,
+	"CommTimeout":           EventCommTimeout,
+	"Invalid":               EventInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Event) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to EventInvalid.
+func (x *Event) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = EventInvalid
+	case name == "":
+		*x = Event(v)
+	default:
+		val, found := eventValues[name]
+		if !found {
+			return unknownEnumNameError{"Event", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // EventType represents the event_type FIT type.
 type EventType byte
 
@@ -648,6 +2237,49 @@ const (
 	EventTypeInvalid                EventType = 0xFF
 )
 
+var eventTypeValues = map[string]EventType{
+	"Start":                  EventTypeStart,
+	"Stop":                   EventTypeStop,
+	"ConsecutiveDepreciated": EventTypeConsecutiveDepreciated,
+	"Marker":                 EventTypeMarker,
+	"StopAll":                EventTypeStopAll,
+	"BeginDepreciated":       EventTypeBeginDepreciated,
+	"EndDepreciated":         EventTypeEndDepreciated,
+	"EndAllDepreciated":      EventTypeEndAllDepreciated,
+	"StopDisable":            EventTypeStopDisable,
+	"StopDisableAll":         EventTypeStopDisableAll,
+	"Invalid":                EventTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x EventType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to EventTypeInvalid.
+func (x *EventType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = EventTypeInvalid
+	case name == "":
+		*x = EventType(v)
+	default:
+		val, found := eventTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"EventType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ExdDataUnits represents the exd_data_units FIT type.
 type ExdDataUnits byte
 
@@ -705,6 +2337,89 @@ const (
 	ExdDataUnitsInvalid                        ExdDataUnits = 0xFF
 )
 
+var exdDataUnitsValues = map[string]ExdDataUnits{
+	"NoUnits":                        ExdDataUnitsNoUnits,
+	"Laps":                           ExdDataUnitsLaps,
+	"MilesPerHour":                   ExdDataUnitsMilesPerHour,
+	"KilometersPerHour":              ExdDataUnitsKilometersPerHour,
+	"FeetPerHour":                    ExdDataUnitsFeetPerHour,
+	"MetersPerHour":                  ExdDataUnitsMetersPerHour,
+	"DegreesCelsius":                 ExdDataUnitsDegreesCelsius,
+	"DegreesFarenheit":               ExdDataUnitsDegreesFarenheit,
+	"Zone":                           ExdDataUnitsZone,
+	"Gear":                           ExdDataUnitsGear,
+	"Rpm":                            ExdDataUnitsRpm,
+	"Bpm":                            ExdDataUnitsBpm,
+	"Degrees":                        ExdDataUnitsDegrees,
+	"Millimeters":                    ExdDataUnitsMillimeters,
+	"Meters":                         ExdDataUnitsMeters,
+	"Kilometers":                     ExdDataUnitsKilometers,
+	"Feet":                           ExdDataUnitsFeet,
+	"Yards":                          ExdDataUnitsYards,
+	"Kilofeet":                       ExdDataUnitsKilofeet,
+	"Miles":                          ExdDataUnitsMiles,
+	"Time":                           ExdDataUnitsTime,
+	"EnumTurnType":                   ExdDataUnitsEnumTurnType,
+	"Percent":                        ExdDataUnitsPercent,
+	"Watts":                          ExdDataUnitsWatts,
+	"WattsPerKilogram":               ExdDataUnitsWattsPerKilogram,
+	"EnumBatteryStatus":              ExdDataUnitsEnumBatteryStatus,
+	"EnumBikeLightBeamAngleMode":     ExdDataUnitsEnumBikeLightBeamAngleMode,
+	"EnumBikeLightBatteryStatus":     ExdDataUnitsEnumBikeLightBatteryStatus,
+	"EnumBikeLightNetworkConfigType": ExdDataUnitsEnumBikeLightNetworkConfigType,
+	"Lights":                         ExdDataUnitsLights,
+	"Seconds":                        ExdDataUnitsSeconds,
+	"Minutes":                        ExdDataUnitsMinutes,
+	"Hours":                          ExdDataUnitsHours,
+	"Calories":                       ExdDataUnitsCalories,
+	"Kilojoules":                     ExdDataUnitsKilojoules,
+	"Milliseconds":                   ExdDataUnitsMilliseconds,
+	"SecondPerMile":                  ExdDataUnitsSecondPerMile,
+	"SecondPerKilometer":             ExdDataUnitsSecondPerKilometer,
+	"Centimeter":                     ExdDataUnitsCentimeter,
+	"EnumCoursePoint":                ExdDataUnitsEnumCoursePoint,
+	"Bradians":                       ExdDataUnitsBradians,
+	"EnumSport":                      ExdDataUnitsEnumSport,
+	"InchesHg":                       ExdDataUnitsInchesHg,
+	"MmHg":                           ExdDataUnitsMmHg,
+	"Mbars":                          ExdDataUnitsMbars,
+	"HectoPascals":                   ExdDataUnitsHectoPascals,
+	"FeetPerMin":                     ExdDataUnitsFeetPerMin,
+	"MetersPerMin":                   ExdDataUnitsMetersPerMin,
+	"MetersPerSec":                   ExdDataUnitsMetersPerSec,
+	"EightCardinal":                  ExdDataUnitsEightCardinal,
+	"Invalid":                        ExdDataUnitsInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ExdDataUnits) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ExdDataUnitsInvalid.
+func (x *ExdDataUnits) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ExdDataUnitsInvalid
+	case name == "":
+		*x = ExdDataUnits(v)
+	default:
+		val, found := exdDataUnitsValues[name]
+		if !found {
+			return unknownEnumNameError{"ExdDataUnits", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ExdDescriptors represents the exd_descriptors FIT type.
 type ExdDescriptors byte
 
@@ -809,6 +2524,136 @@ const (
 	ExdDescriptorsInvalid                          ExdDescriptors = 0xFF
 )
 
+var exdDescriptorsValues = map[string]ExdDescriptors{
+	"BikeLightBatteryStatus":           ExdDescriptorsBikeLightBatteryStatus,
+	"BeamAngleStatus":                  ExdDescriptorsBeamAngleStatus,
+	"BateryLevel":                      ExdDescriptorsBateryLevel,
+	"LightNetworkMode":                 ExdDescriptorsLightNetworkMode,
+	"NumberLightsConnected":            ExdDescriptorsNumberLightsConnected,
+	"Cadence":                          ExdDescriptorsCadence,
+	"Distance":                         ExdDescriptorsDistance,
+	"EstimatedTimeOfArrival":           ExdDescriptorsEstimatedTimeOfArrival,
+	"Heading":                          ExdDescriptorsHeading,
+	"Time":                             ExdDescriptorsTime,
+	"BatteryLevel":                     ExdDescriptorsBatteryLevel,
+	"TrainerResistance":                ExdDescriptorsTrainerResistance,
+	"TrainerTargetPower":               ExdDescriptorsTrainerTargetPower,
+	"TimeSeated":                       ExdDescriptorsTimeSeated,
+	"TimeStanding":                     ExdDescriptorsTimeStanding,
+	"Elevation":                        ExdDescriptorsElevation,
+	"Grade":                            ExdDescriptorsGrade,
+	"Ascent":                           ExdDescriptorsAscent,
+	"Descent":                          ExdDescriptorsDescent,
+	"VerticalSpeed":                    ExdDescriptorsVerticalSpeed,
+	"Di2BatteryLevel":                  ExdDescriptorsDi2BatteryLevel,
+	"FrontGear":                        ExdDescriptorsFrontGear,
+	"RearGear":                         ExdDescriptorsRearGear,
+	"GearRatio":                        ExdDescriptorsGearRatio,
+	"HeartRate":                        ExdDescriptorsHeartRate,
+	"HeartRateZone":                    ExdDescriptorsHeartRateZone,
+	"TimeInHeartRateZone":              ExdDescriptorsTimeInHeartRateZone,
+	"HeartRateReserve":                 ExdDescriptorsHeartRateReserve,
+	"Calories":                         ExdDescriptorsCalories,
+	"GpsAccuracy":                      ExdDescriptorsGpsAccuracy,
+	"GpsSignalStrength":                ExdDescriptorsGpsSignalStrength,
+	"Temperature":                      ExdDescriptorsTemperature,
+	"TimeOfDay":                        ExdDescriptorsTimeOfDay,
+	"Balance":                          ExdDescriptorsBalance,
+	"PedalSmoothness":                  ExdDescriptorsPedalSmoothness,
+	"Power":                            ExdDescriptorsPower,
+	"FunctionalThresholdPower":         ExdDescriptorsFunctionalThresholdPower,
+	"IntensityFactor":                  ExdDescriptorsIntensityFactor,
+	"Work":                             ExdDescriptorsWork,
+	"PowerRatio":                       ExdDescriptorsPowerRatio,
+	"NormalizedPower":                  ExdDescriptorsNormalizedPower,
+	"TrainingStressScore":              ExdDescriptorsTrainingStressScore,
+	"TimeOnZone":                       ExdDescriptorsTimeOnZone,
+	"Speed":                            ExdDescriptorsSpeed,
+	"Laps":                             ExdDescriptorsLaps,
+	"Reps":                             ExdDescriptorsReps,
+	"WorkoutStep":                      ExdDescriptorsWorkoutStep,
+	"CourseDistance":                   ExdDescriptorsCourseDistance,
+	"NavigationDistance":               ExdDescriptorsNavigationDistance,
+	"CourseEstimatedTimeOfArrival":     ExdDescriptorsCourseEstimatedTimeOfArrival,
+	"NavigationEstimatedTimeOfArrival": ExdDescriptorsNavigationEstimatedTimeOfArrival,
+	"CourseTime":                       ExdDescriptorsCourseTime,
+	"NavigationTime":                   ExdDescriptorsNavigationTime,
+	"CourseHeading":                    ExdDescriptorsCourseHeading,
+	"NavigationHeading":                ExdDescriptorsNavigationHeading,
+	"PowerZone":                        ExdDescriptorsPowerZone,
+	"TorqueEffectiveness":              ExdDescriptorsTorqueEffectiveness,
+	"TimerTime":                        ExdDescriptorsTimerTime,
+	"PowerWeightRatio":                 ExdDescriptorsPowerWeightRatio,
+	"LeftPlatformCenterOffset":         ExdDescriptorsLeftPlatformCenterOffset,
+	"RightPlatformCenterOffset":        ExdDescriptorsRightPlatformCenterOffset,
+	"LeftPowerPhaseStartAngle":         ExdDescriptorsLeftPowerPhaseStartAngle,
+	"RightPowerPhaseStartAngle":        ExdDescriptorsRightPowerPhaseStartAngle,
+	"LeftPowerPhaseFinishAngle":        ExdDescriptorsLeftPowerPhaseFinishAngle,
+	"RightPowerPhaseFinishAngle":       ExdDescriptorsRightPowerPhaseFinishAngle,
+	"Gears":                            ExdDescriptorsGears,
+	"Pace":                             ExdDescriptorsPace,
+	"TrainingEffect":                   ExdDescriptorsTrainingEffect,
+	"VerticalOscillation":              ExdDescriptorsVerticalOscillation,
+	"VerticalRatio":                    ExdDescriptorsVerticalRatio,
+	"GroundContactTime":                ExdDescriptorsGroundContactTime,
+	"LeftGroundContactTimeBalance":     ExdDescriptorsLeftGroundContactTimeBalance,
+	"RightGroundContactTimeBalance":    ExdDescriptorsRightGroundContactTimeBalance,
+	"StrideLength":                     ExdDescriptorsStrideLength,
+	"RunningCadence":                   ExdDescriptorsRunningCadence,
+	"PerformanceCondition":             ExdDescriptorsPerformanceCondition,
+	"CourseType":                       ExdDescriptorsCourseType,
+	"TimeInPowerZone":                  ExdDescriptorsTimeInPowerZone,
+	"NavigationTurn":                   ExdDescriptorsNavigationTurn,
+	"CourseLocation":                   ExdDescriptorsCourseLocation,
+	"NavigationLocation":               ExdDescriptorsNavigationLocation,
+	"Compass":                          ExdDescriptorsCompass,
+	"GearCombo":                        ExdDescriptorsGearCombo,
+	"MuscleOxygen":                     ExdDescriptorsMuscleOxygen,
+	"Icon":                             ExdDescriptorsIcon,
+	"CompassHeading":                   ExdDescriptorsCompassHeading,
+	"GpsHeading":                       ExdDescriptorsGpsHeading,
+	"GpsElevation":                     ExdDescriptorsGpsElevation,
+	"AnaerobicTrainingEffect":          ExdDescriptorsAnaerobicTrainingEffect,
+	"Course":                           ExdDescriptorsCourse,
+	"OffCourse":                        ExdDescriptorsOffCourse,
+	"GlideRatio":                       ExdDescriptorsGlideRatio,
+	"VerticalDistance":                 ExdDescriptorsVerticalDistance,
+	"Vmg":                              ExdDescriptorsVmg,
+	"AmbientPressure":                  ExdDescriptorsAmbientPressure,
+	"Pressure":                         ExdDescriptorsPressure,
+	"Vam":                              ExdDescriptorsVam,
+	"Invalid":                          ExdDescriptorsInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ExdDescriptors) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ExdDescriptorsInvalid.
+func (x *ExdDescriptors) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ExdDescriptorsInvalid
+	case name == "":
+		*x = ExdDescriptors(v)
+	default:
+		val, found := exdDescriptorsValues[name]
+		if !found {
+			return unknownEnumNameError{"ExdDescriptors", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ExdDisplayType represents the exd_display_type FIT type.
 type ExdDisplayType byte
 
@@ -827,6 +2672,50 @@ const (
 	ExdDisplayTypeInvalid           ExdDisplayType = 0xFF
 )
 
+var exdDisplayTypeValues = map[string]ExdDisplayType{
+	"Numerical":         ExdDisplayTypeNumerical,
+	"Simple":            ExdDisplayTypeSimple,
+	"Graph":             ExdDisplayTypeGraph,
+	"Bar":               ExdDisplayTypeBar,
+	"CircleGraph":       ExdDisplayTypeCircleGraph,
+	"VirtualPartner":    ExdDisplayTypeVirtualPartner,
+	"Balance":           ExdDisplayTypeBalance,
+	"StringList":        ExdDisplayTypeStringList,
+	"String":            ExdDisplayTypeString,
+	"SimpleDynamicIcon": ExdDisplayTypeSimpleDynamicIcon,
+	"Gauge":             ExdDisplayTypeGauge,
+	"Invalid":           ExdDisplayTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ExdDisplayType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ExdDisplayTypeInvalid.
+func (x *ExdDisplayType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ExdDisplayTypeInvalid
+	case name == "":
+		*x = ExdDisplayType(v)
+	default:
+		val, found := exdDisplayTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"ExdDisplayType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ExdLayout represents the exd_layout FIT type.
 type ExdLayout byte
 
@@ -842,6 +2731,47 @@ const (
 	ExdLayoutInvalid                   ExdLayout = 0xFF
 )
 
+var exdLayoutValues = map[string]ExdLayout{
+	"FullScreen":                ExdLayoutFullScreen,
+	"HalfVertical":              ExdLayoutHalfVertical,
+	"HalfHorizontal":            ExdLayoutHalfHorizontal,
+	"HalfVerticalRightSplit":    ExdLayoutHalfVerticalRightSplit,
+	"HalfHorizontalBottomSplit": ExdLayoutHalfHorizontalBottomSplit,
+	"FullQuarterSplit":          ExdLayoutFullQuarterSplit,
+	"HalfVerticalLeftSplit":     ExdLayoutHalfVerticalLeftSplit,
+	"HalfHorizontalTopSplit":    ExdLayoutHalfHorizontalTopSplit,
+	"Invalid":                   ExdLayoutInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ExdLayout) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ExdLayoutInvalid.
+func (x *ExdLayout) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ExdLayoutInvalid
+	case name == "":
+		*x = ExdLayout(v)
+	default:
+		val, found := exdLayoutValues[name]
+		if !found {
+			return unknownEnumNameError{"ExdLayout", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // ExdQualifiers represents the exd_qualifiers FIT type.
 type ExdQualifiers byte
 
@@ -892,6 +2822,82 @@ const (
 	ExdQualifiersInvalid                  ExdQualifiers = 0xFF
 )
 
+var exdQualifiersValues = map[string]ExdQualifiers{
+	"NoQualifier":              ExdQualifiersNoQualifier,
+	"Instantaneous":            ExdQualifiersInstantaneous,
+	"Average":                  ExdQualifiersAverage,
+	"Lap":                      ExdQualifiersLap,
+	"Maximum":                  ExdQualifiersMaximum,
+	"MaximumAverage":           ExdQualifiersMaximumAverage,
+	"MaximumLap":               ExdQualifiersMaximumLap,
+	"LastLap":                  ExdQualifiersLastLap,
+	"AverageLap":               ExdQualifiersAverageLap,
+	"ToDestination":            ExdQualifiersToDestination,
+	"ToGo":                     ExdQualifiersToGo,
+	"ToNext":                   ExdQualifiersToNext,
+	"NextCoursePoint":          ExdQualifiersNextCoursePoint,
+	"Total":                    ExdQualifiersTotal,
+	"ThreeSecondAverage":       ExdQualifiersThreeSecondAverage,
+	"TenSecondAverage":         ExdQualifiersTenSecondAverage,
+	"ThirtySecondAverage":      ExdQualifiersThirtySecondAverage,
+	"PercentMaximum":           ExdQualifiersPercentMaximum,
+	"PercentMaximumAverage":    ExdQualifiersPercentMaximumAverage,
+	"LapPercentMaximum":        ExdQualifiersLapPercentMaximum,
+	"Elapsed":                  ExdQualifiersElapsed,
+	"Sunrise":                  ExdQualifiersSunrise,
+	"Sunset":                   ExdQualifiersSunset,
+	"ComparedToVirtualPartner": ExdQualifiersComparedToVirtualPartner,
+	"Maximum24h":               ExdQualifiersMaximum24h,
+	"Minimum24h":               ExdQualifiersMinimum24h,
+	"Minimum":                  ExdQualifiersMinimum,
+	"First":                    ExdQualifiersFirst,
+	"Second":                   ExdQualifiersSecond,
+	"Third":                    ExdQualifiersThird,
+	"Shifter":                  ExdQualifiersShifter,
+	"LastSport":                ExdQualifiersLastSport,
+	"Moving":                   ExdQualifiersMoving,
+	"Stopped":                  ExdQualifiersStopped,
+	"Zone9":                    ExdQualifiersZone9,
+	"Zone8":                    ExdQualifiersZone8,
+	"Zone7":                    ExdQualifiersZone7,
+	"Zone6":                    ExdQualifiersZone6,
+	"Zone5":                    ExdQualifiersZone5,
+	"Zone4":                    ExdQualifiersZone4,
+	"Zone3":                    ExdQualifiersZone3,
+	"Zone2":                    ExdQualifiersZone2,
+	"Zone1":                    ExdQualifiersZone1,
+	"Invalid":                  ExdQualifiersInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x ExdQualifiers) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ExdQualifiersInvalid.
+func (x *ExdQualifiers) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ExdQualifiersInvalid
+	case name == "":
+		*x = ExdQualifiers(v)
+	default:
+		val, found := exdQualifiersValues[name]
+		if !found {
+			return unknownEnumNameError{"ExdQualifiers", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // FileFlags represents the file_flags FIT type.
 type FileFlags uint8
 
@@ -902,6 +2908,42 @@ const (
 	FileFlagsInvalid FileFlags = 0x00
 )
 
+var fileFlagsValues = map[string]FileFlags{
+	"Read":    FileFlagsRead,
+	"Write":   FileFlagsWrite,
+	"Erase":   FileFlagsErase,
+	"Invalid": FileFlagsInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x FileFlags) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to FileFlagsInvalid.
+func (x *FileFlags) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = FileFlagsInvalid
+	case name == "":
+		*x = FileFlags(v)
+	default:
+		val, found := fileFlagsValues[name]
+		if !found {
+			return unknownEnumNameError{"FileFlags", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // FileType represents the file FIT type.
 type FileType byte
 
@@ -929,6 +2971,59 @@ const (
 	FileTypeInvalid          FileType = 0xFF
 )
 
+var fileTypeValues = map[string]FileType{
+	"Device":           FileTypeDevice,
+	"Settings":         FileTypeSettings,
+	"Sport":            FileTypeSport,
+	"Activity":         FileTypeActivity,
+	"Workout":          FileTypeWorkout,
+	"Course":           FileTypeCourse,
+	"Schedules":        FileTypeSchedules,
+	"Weight":           FileTypeWeight,
+	"Totals":           FileTypeTotals,
+	"Goals":            FileTypeGoals,
+	"BloodPressure":    FileTypeBloodPressure,
+	"MonitoringA":      FileTypeMonitoringA,
+	"ActivitySummary":  FileTypeActivitySummary,
+	"MonitoringDaily":  FileTypeMonitoringDaily,
+	"MonitoringB":      FileTypeMonitoringB,
+	"Segment":          FileTypeSegment,
+	"SegmentList":      FileTypeSegmentList,
+	"ExdConfiguration": FileTypeExdConfiguration,
+	"MfgRangeMin":      FileTypeMfgRangeMin,
+	"MfgRangeMax":      FileTypeMfgRangeMax,
+	"Invalid":          FileTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x FileType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to FileTypeInvalid.
+func (x *FileType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = FileTypeInvalid
+	case name == "":
+		*x = FileType(v)
+	default:
+		val, found := fileTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"FileType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // FitBaseType represents the fit_base_type FIT type.
 type FitBaseType uint8
 
@@ -953,6 +3048,56 @@ const (
 	FitBaseTypeInvalid FitBaseType = 0xFF
 )
 
+var fitBaseTypeValues = map[string]FitBaseType{
+	"Enum":    FitBaseTypeEnum,
+	"Sint8":   FitBaseTypeSint8,
+	"Uint8":   FitBaseTypeUint8,
+	"Sint16":  FitBaseTypeSint16,
+	"Uint16":  FitBaseTypeUint16,
+	"Sint32":  FitBaseTypeSint32,
+	"Uint32":  FitBaseTypeUint32,
+	"String":  FitBaseTypeString,
+	"Float32": FitBaseTypeFloat32,
+	"Float64": FitBaseTypeFloat64,
+	"Uint8z":  FitBaseTypeUint8z,
+	"Uint16z": FitBaseTypeUint16z,
+	"Uint32z": FitBaseTypeUint32z,
+	"Byte":    FitBaseTypeByte,
+	"Sint64":  FitBaseTypeSint64,
+	"Uint64":  FitBaseTypeUint64,
+	"Uint64z": FitBaseTypeUint64z,
+	"Invalid": FitBaseTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x FitBaseType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to FitBaseTypeInvalid.
+func (x *FitBaseType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = FitBaseTypeInvalid
+	case name == "":
+		*x = FitBaseType(v)
+	default:
+		val, found := fitBaseTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"FitBaseType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // FitBaseUnit represents the fit_base_unit FIT type.
 type FitBaseUnit uint16
 
@@ -963,6 +3108,42 @@ const (
 	FitBaseUnitInvalid  FitBaseUnit = 0xFFFF
 )
 
+var fitBaseUnitValues = map[string]FitBaseUnit{
+	"Other":    FitBaseUnitOther,
+	"Kilogram": FitBaseUnitKilogram,
+	"Pound":    FitBaseUnitPound,
+	"Invalid":  FitBaseUnitInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x FitBaseUnit) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to FitBaseUnitInvalid.
+func (x *FitBaseUnit) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = FitBaseUnitInvalid
+	case name == "":
+		*x = FitBaseUnit(v)
+	default:
+		val, found := fitBaseUnitValues[name]
+		if !found {
+			return unknownEnumNameError{"FitBaseUnit", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // FitnessEquipmentState represents the fitness_equipment_state FIT type.
 type FitnessEquipmentState byte
 
@@ -974,6 +3155,43 @@ const (
 	FitnessEquipmentStateInvalid FitnessEquipmentState = 0xFF
 )
 
+var fitnessEquipmentStateValues = map[string]FitnessEquipmentState{
+	"Ready":   FitnessEquipmentStateReady,
+	"InUse":   FitnessEquipmentStateInUse,
+	"Paused":  FitnessEquipmentStatePaused,
+	"Unknown": FitnessEquipmentStateUnknown,
+	"Invalid": FitnessEquipmentStateInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x FitnessEquipmentState) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to FitnessEquipmentStateInvalid.
+func (x *FitnessEquipmentState) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = FitnessEquipmentStateInvalid
+	case name == "":
+		*x = FitnessEquipmentState(v)
+	default:
+		val, found := fitnessEquipmentStateValues[name]
+		if !found {
+			return unknownEnumNameError{"FitnessEquipmentState", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // GarminProduct represents the garmin_product FIT type.
 type GarminProduct uint16
 
@@ -1137,6 +3355,195 @@ const (
 	GarminProductInvalid                   GarminProduct = 0xFFFF
 )
 
+var garminProductValues = map[string]GarminProduct{
+	"Hrm1":                      GarminProductHrm1,
+	"Axh01":                     GarminProductAxh01,
+	"Axb01":                     GarminProductAxb01,
+	"Axb02":                     GarminProductAxb02,
+	"Hrm2ss":                    GarminProductHrm2ss,
+	"DsiAlf02":                  GarminProductDsiAlf02,
+	"Hrm3ss":                    GarminProductHrm3ss,
+	"HrmRunSingleByteProductId": GarminProductHrmRunSingleByteProductId,
+	"Bsm":                       GarminProductBsm,
+	"Bcm":                       GarminProductBcm,
+	"Axs01":                     GarminProductAxs01,
+	"HrmTriSingleByteProductId": GarminProductHrmTriSingleByteProductId,
+	"Fr225SingleByteProductId":  GarminProductFr225SingleByteProductId,
+	"Fr301China":                GarminProductFr301China,
+	"Fr301Japan":                GarminProductFr301Japan,
+	"Fr301Korea":                GarminProductFr301Korea,
+	"Fr301Taiwan":               GarminProductFr301Taiwan,
+	"Fr405":                     GarminProductFr405,
+	"Fr50":                      GarminProductFr50,
+	"Fr405Japan":                GarminProductFr405Japan,
+	"Fr60":                      GarminProductFr60,
+	"DsiAlf01":                  GarminProductDsiAlf01,
+	"Fr310xt":                   GarminProductFr310xt,
+	"Edge500":                   GarminProductEdge500,
+	"Fr110":                     GarminProductFr110,
+	"Edge800":                   GarminProductEdge800,
+	"Edge500Taiwan":             GarminProductEdge500Taiwan,
+	"Edge500Japan":              GarminProductEdge500Japan,
+	"Chirp":                     GarminProductChirp,
+	"Fr110Japan":                GarminProductFr110Japan,
+	"Edge200":                   GarminProductEdge200,
+	"Fr910xt":                   GarminProductFr910xt,
+	"Edge800Taiwan":             GarminProductEdge800Taiwan,
+	"Edge800Japan":              GarminProductEdge800Japan,
+	"Alf04":                     GarminProductAlf04,
+	"Fr610":                     GarminProductFr610,
+	"Fr210Japan":                GarminProductFr210Japan,
+	"VectorSs":                  GarminProductVectorSs,
+	"VectorCp":                  GarminProductVectorCp,
+	"Edge800China":              GarminProductEdge800China,
+	"Edge500China":              GarminProductEdge500China,
+	"Fr610Japan":                GarminProductFr610Japan,
+	"Edge500Korea":              GarminProductEdge500Korea,
+	"Fr70":                      GarminProductFr70,
+	"Fr310xt4t":                 GarminProductFr310xt4t,
+	"Amx":                       GarminProductAmx,
+	"Fr10":                      GarminProductFr10,
+	"Edge800Korea":              GarminProductEdge800Korea,
+	"Swim":                      GarminProductSwim,
+	"Fr910xtChina":              GarminProductFr910xtChina,
+	"Fenix":                     GarminProductFenix,
+	"Edge200Taiwan":             GarminProductEdge200Taiwan,
+	"Edge510":                   GarminProductEdge510,
+	"Edge810":                   GarminProductEdge810,
+	"Tempe":                     GarminProductTempe,
+	"Fr910xtJapan":              GarminProductFr910xtJapan,
+	"Fr620":                     GarminProductFr620,
+	"Fr220":                     GarminProductFr220,
+	"Fr910xtKorea":              GarminProductFr910xtKorea,
+	"Fr10Japan":                 GarminProductFr10Japan,
+	"Edge810Japan":              GarminProductEdge810Japan,
+	"VirbElite":                 GarminProductVirbElite,
+	"EdgeTouring":               GarminProductEdgeTouring,
+	"Edge510Japan":              GarminProductEdge510Japan,
+	"HrmTri":                    GarminProductHrmTri,
+	"HrmRun":                    GarminProductHrmRun,
+	"Fr920xt":                   GarminProductFr920xt,
+	"Edge510Asia":               GarminProductEdge510Asia,
+	"Edge810China":              GarminProductEdge810China,
+	"Edge810Taiwan":             GarminProductEdge810Taiwan,
+	"Edge1000":                  GarminProductEdge1000,
+	"VivoFit":                   GarminProductVivoFit,
+	"VirbRemote":                GarminProductVirbRemote,
+	"VivoKi":                    GarminProductVivoKi,
+	"Fr15":                      GarminProductFr15,
+	"VivoActive":                GarminProductVivoActive,
+	"Edge510Korea":              GarminProductEdge510Korea,
+	"Fr620Japan":                GarminProductFr620Japan,
+	"Fr620China":                GarminProductFr620China,
+	"Fr220Japan":                GarminProductFr220Japan,
+	"Fr220China":                GarminProductFr220China,
+	"ApproachS6":                GarminProductApproachS6,
+	"VivoSmart":                 GarminProductVivoSmart,
+	"Fenix2":                    GarminProductFenix2,
+	"Epix":                      GarminProductEpix,
+	"Fenix3":                    GarminProductFenix3,
+	"Edge1000Taiwan":            GarminProductEdge1000Taiwan,
+	"Edge1000Japan":             GarminProductEdge1000Japan,
+	"Fr15Japan":                 GarminProductFr15Japan,
+	"Edge520":                   GarminProductEdge520,
+	"Edge1000China":             GarminProductEdge1000China,
+	"Fr620Russia":               GarminProductFr620Russia,
+	"Fr220Russia":               GarminProductFr220Russia,
+	"VectorS":                   GarminProductVectorS,
+	"Edge1000Korea":             GarminProductEdge1000Korea,
+	"Fr920xtTaiwan":             GarminProductFr920xtTaiwan,
+	"Fr920xtChina":              GarminProductFr920xtChina,
+	"Fr920xtJapan":              GarminProductFr920xtJapan,
+	"Virbx":                     GarminProductVirbx,
+	"VivoSmartApac":             GarminProductVivoSmartApac,
+	"EtrexTouch":                GarminProductEtrexTouch,
+	"Edge25":                    GarminProductEdge25,
+	"Fr25":                      GarminProductFr25,
+	"VivoFit2":                  GarminProductVivoFit2,
+	"Fr225":                     GarminProductFr225,
+	"Fr630":                     GarminProductFr630,
+	"Fr230":                     GarminProductFr230,
+	"VivoActiveApac":            GarminProductVivoActiveApac,
+	"Vector2":                   GarminProductVector2,
+	"Vector2s":                  GarminProductVector2s,
+	"Virbxe":                    GarminProductVirbxe,
+	"Fr620Taiwan":               GarminProductFr620Taiwan,
+	"Fr220Taiwan":               GarminProductFr220Taiwan,
+	"Truswing":                  GarminProductTruswing,
+	"Fenix3China":               GarminProductFenix3China,
+	"Fenix3Twn":                 GarminProductFenix3Twn,
+	"VariaHeadlight":            GarminProductVariaHeadlight,
+	"VariaTaillightOld":         GarminProductVariaTaillightOld,
+	"EdgeExplore1000":           GarminProductEdgeExplore1000,
+	"Fr225Asia":                 GarminProductFr225Asia,
+	"VariaRadarTaillight":       GarminProductVariaRadarTaillight,
+	"VariaRadarDisplay":         GarminProductVariaRadarDisplay,
+	"Edge20":                    GarminProductEdge20,
+	"D2Bravo":                   GarminProductD2Bravo,
+	"ApproachS20":               GarminProductApproachS20,
+	"VariaRemote":               GarminProductVariaRemote,
+	"Hrm4Run":                   GarminProductHrm4Run,
+	"VivoActiveHr":              GarminProductVivoActiveHr,
+	"VivoSmartGpsHr":            GarminProductVivoSmartGpsHr,
+	"VivoSmartHr":               GarminProductVivoSmartHr,
+	"VivoMove":                  GarminProductVivoMove,
+	"VariaVision":               GarminProductVariaVision,
+	"VivoFit3":                  GarminProductVivoFit3,
+	"Fenix3Hr":                  GarminProductFenix3Hr,
+	"VirbUltra30":               GarminProductVirbUltra30,
+	"IndexSmartScale":           GarminProductIndexSmartScale,
+	"Fr235":                     GarminProductFr235,
+	"Fenix3Chronos":             GarminProductFenix3Chronos,
+	"Oregon7xx":                 GarminProductOregon7xx,
+	"Rino7xx":                   GarminProductRino7xx,
+	"Nautix":                    GarminProductNautix,
+	"Edge820":                   GarminProductEdge820,
+	"EdgeExplore820":            GarminProductEdgeExplore820,
+	"Fenix5s":                   GarminProductFenix5s,
+	"D2BravoTitanium":           GarminProductD2BravoTitanium,
+	"RunningDynamicsPod":        GarminProductRunningDynamicsPod,
+	"Fenix5x":                   GarminProductFenix5x,
+	"VivoFitJr":                 GarminProductVivoFitJr,
+	"Fr935":                     GarminProductFr935,
+	"Fenix5":                    GarminProductFenix5,
+	"Sdm4":                      GarminProductSdm4,
+	"EdgeRemote":                GarminProductEdgeRemote,
+	"TrainingCenter":            GarminProductTrainingCenter,
+	"ConnectiqSimulator":        GarminProductConnectiqSimulator,
+	"AndroidAntplusPlugin":      GarminProductAndroidAntplusPlugin,
+	"Connect":                   GarminProductConnect,
+	"Invalid":                   GarminProductInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x GarminProduct) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to GarminProductInvalid.
+func (x *GarminProduct) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = GarminProductInvalid
+	case name == "":
+		*x = GarminProduct(v)
+	default:
+		val, found := garminProductValues[name]
+		if !found {
+			return unknownEnumNameError{"GarminProduct", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Gender represents the gender FIT type.
 type Gender byte
 
@@ -1146,6 +3553,41 @@ const (
 	GenderInvalid Gender = 0xFF
 )
 
+var genderValues = map[string]Gender{
+	"Female":  GenderFemale,
+	"Male":    GenderMale,
+	"Invalid": GenderInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Gender) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to GenderInvalid.
+func (x *Gender) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = GenderInvalid
+	case name == "":
+		*x = Gender(v)
+	default:
+		val, found := genderValues[name]
+		if !found {
+			return unknownEnumNameError{"Gender", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Goal represents the goal FIT type.
 type Goal byte
 
@@ -1160,6 +3602,46 @@ const (
 	GoalInvalid       Goal = 0xFF
 )
 
+var goalValues = map[string]Goal{
+	"Time":          GoalTime,
+	"Distance":      GoalDistance,
+	"Calories":      GoalCalories,
+	"Frequency":     GoalFrequency,
+	"Steps":         GoalSteps,
+	"Ascent":        GoalAscent,
+	"ActiveMinutes": GoalActiveMinutes,
+	"Invalid":       GoalInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Goal) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to GoalInvalid.
+func (x *Goal) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = GoalInvalid
+	case name == "":
+		*x = Goal(v)
+	default:
+		val, found := goalValues[name]
+		if !found {
+			return unknownEnumNameError{"Goal", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // GoalRecurrence represents the goal_recurrence FIT type.
 type GoalRecurrence byte
 
@@ -1173,6 +3655,45 @@ const (
 	GoalRecurrenceInvalid GoalRecurrence = 0xFF
 )
 
+var goalRecurrenceValues = map[string]GoalRecurrence{
+	"Off":     GoalRecurrenceOff,
+	"Daily":   GoalRecurrenceDaily,
+	"Weekly":  GoalRecurrenceWeekly,
+	"Monthly": GoalRecurrenceMonthly,
+	"Yearly":  GoalRecurrenceYearly,
+	"Custom":  GoalRecurrenceCustom,
+	"Invalid": GoalRecurrenceInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x GoalRecurrence) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to GoalRecurrenceInvalid.
+func (x *GoalRecurrence) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = GoalRecurrenceInvalid
+	case name == "":
+		*x = GoalRecurrence(v)
+	default:
+		val, found := goalRecurrenceValues[name]
+		if !found {
+			return unknownEnumNameError{"GoalRecurrence", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // GoalSource represents the goal_source FIT type.
 type GoalSource byte
 
@@ -1183,6 +3704,42 @@ const (
 	GoalSourceInvalid   GoalSource = 0xFF
 )
 
+var goalSourceValues = map[string]GoalSource{
+	"Auto":      GoalSourceAuto,
+	"Community": GoalSourceCommunity,
+	"User":      GoalSourceUser,
+	"Invalid":   GoalSourceInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x GoalSource) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to GoalSourceInvalid.
+func (x *GoalSource) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = GoalSourceInvalid
+	case name == "":
+		*x = GoalSource(v)
+	default:
+		val, found := goalSourceValues[name]
+		if !found {
+			return unknownEnumNameError{"GoalSource", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // HrType represents the hr_type FIT type.
 type HrType byte
 
@@ -1192,6 +3749,41 @@ const (
 	HrTypeInvalid   HrType = 0xFF
 )
 
+var hrTypeValues = map[string]HrType{
+	"Normal":    HrTypeNormal,
+	"Irregular": HrTypeIrregular,
+	"Invalid":   HrTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x HrType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to HrTypeInvalid.
+func (x *HrType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = HrTypeInvalid
+	case name == "":
+		*x = HrType(v)
+	default:
+		val, found := hrTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"HrType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // HrZoneCalc represents the hr_zone_calc FIT type.
 type HrZoneCalc byte
 
@@ -1202,6 +3794,42 @@ const (
 	HrZoneCalcInvalid      HrZoneCalc = 0xFF
 )
 
+var hrZoneCalcValues = map[string]HrZoneCalc{
+	"Custom":       HrZoneCalcCustom,
+	"PercentMaxHr": HrZoneCalcPercentMaxHr,
+	"PercentHrr":   HrZoneCalcPercentHrr,
+	"Invalid":      HrZoneCalcInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x HrZoneCalc) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to HrZoneCalcInvalid.
+func (x *HrZoneCalc) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = HrZoneCalcInvalid
+	case name == "":
+		*x = HrZoneCalc(v)
+	default:
+		val, found := hrZoneCalcValues[name]
+		if !found {
+			return unknownEnumNameError{"HrZoneCalc", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Intensity represents the intensity FIT type.
 type Intensity byte
 
@@ -1213,6 +3841,43 @@ const (
 	IntensityInvalid  Intensity = 0xFF
 )
 
+var intensityValues = map[string]Intensity{
+	"Active":   IntensityActive,
+	"Rest":     IntensityRest,
+	"Warmup":   IntensityWarmup,
+	"Cooldown": IntensityCooldown,
+	"Invalid":  IntensityInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Intensity) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to IntensityInvalid.
+func (x *Intensity) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = IntensityInvalid
+	case name == "":
+		*x = Intensity(v)
+	default:
+		val, found := intensityValues[name]
+		if !found {
+			return unknownEnumNameError{"Intensity", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Language represents the language FIT type.
 type Language byte
 
@@ -1259,6 +3924,78 @@ const (
 	LanguageInvalid             Language = 0xFF
 )
 
+var languageValues = map[string]Language{
+	"English":             LanguageEnglish,
+	"French":              LanguageFrench,
+	"Italian":             LanguageItalian,
+	"German":              LanguageGerman,
+	"Spanish":             LanguageSpanish,
+	"Croatian":            LanguageCroatian,
+	"Czech":               LanguageCzech,
+	"Danish":              LanguageDanish,
+	"Dutch":               LanguageDutch,
+	"Finnish":             LanguageFinnish,
+	"Greek":               LanguageGreek,
+	"Hungarian":           LanguageHungarian,
+	"Norwegian":           LanguageNorwegian,
+	"Polish":              LanguagePolish,
+	"Portuguese":          LanguagePortuguese,
+	"Slovakian":           LanguageSlovakian,
+	"Slovenian":           LanguageSlovenian,
+	"Swedish":             LanguageSwedish,
+	"Russian":             LanguageRussian,
+	"Turkish":             LanguageTurkish,
+	"Latvian":             LanguageLatvian,
+	"Ukrainian":           LanguageUkrainian,
+	"Arabic":              LanguageArabic,
+	"Farsi":               LanguageFarsi,
+	"Bulgarian":           LanguageBulgarian,
+	"Romanian":            LanguageRomanian,
+	"Chinese":             LanguageChinese,
+	"Japanese":            LanguageJapanese,
+	"Korean":              LanguageKorean,
+	"Taiwanese":           LanguageTaiwanese,
+	"Thai":                LanguageThai,
+	"Hebrew":              LanguageHebrew,
+	"BrazilianPortuguese": LanguageBrazilianPortuguese,
+	"Indonesian":          LanguageIndonesian,
+	"Malaysian":           LanguageMalaysian,
+	"Vietnamese":          LanguageVietnamese,
+	"Burmese":             LanguageBurmese,
+	"Mongolian":           LanguageMongolian,
+	"Custom":              LanguageCustom,
+	"Invalid":             LanguageInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Language) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LanguageInvalid.
+func (x *Language) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LanguageInvalid
+	case name == "":
+		*x = Language(v)
+	default:
+		val, found := languageValues[name]
+		if !found {
+			return unknownEnumNameError{"Language", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LanguageBits0 represents the language_bits_0 FIT type.
 type LanguageBits0 uint8
 
@@ -1274,6 +4011,47 @@ const (
 	LanguageBits0Invalid  LanguageBits0 = 0x00
 )
 
+var languageBits0Values = map[string]LanguageBits0{
+	"English":  LanguageBits0English,
+	"French":   LanguageBits0French,
+	"Italian":  LanguageBits0Italian,
+	"German":   LanguageBits0German,
+	"Spanish":  LanguageBits0Spanish,
+	"Croatian": LanguageBits0Croatian,
+	"Czech":    LanguageBits0Czech,
+	"Danish":   LanguageBits0Danish,
+	"Invalid":  LanguageBits0Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LanguageBits0) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LanguageBits0Invalid.
+func (x *LanguageBits0) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LanguageBits0Invalid
+	case name == "":
+		*x = LanguageBits0(v)
+	default:
+		val, found := languageBits0Values[name]
+		if !found {
+			return unknownEnumNameError{"LanguageBits0", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LanguageBits1 represents the language_bits_1 FIT type.
 type LanguageBits1 uint8
 
@@ -1289,6 +4067,47 @@ const (
 	LanguageBits1Invalid    LanguageBits1 = 0x00
 )
 
+var languageBits1Values = map[string]LanguageBits1{
+	"Dutch":      LanguageBits1Dutch,
+	"Finnish":    LanguageBits1Finnish,
+	"Greek":      LanguageBits1Greek,
+	"Hungarian":  LanguageBits1Hungarian,
+	"Norwegian":  LanguageBits1Norwegian,
+	"Polish":     LanguageBits1Polish,
+	"Portuguese": LanguageBits1Portuguese,
+	"Slovakian":  LanguageBits1Slovakian,
+	"Invalid":    LanguageBits1Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LanguageBits1) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LanguageBits1Invalid.
+func (x *LanguageBits1) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LanguageBits1Invalid
+	case name == "":
+		*x = LanguageBits1(v)
+	default:
+		val, found := languageBits1Values[name]
+		if !found {
+			return unknownEnumNameError{"LanguageBits1", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LanguageBits2 represents the language_bits_2 FIT type.
 type LanguageBits2 uint8
 
@@ -1304,6 +4123,47 @@ const (
 	LanguageBits2Invalid   LanguageBits2 = 0x00
 )
 
+var languageBits2Values = map[string]LanguageBits2{
+	"Slovenian": LanguageBits2Slovenian,
+	"Swedish":   LanguageBits2Swedish,
+	"Russian":   LanguageBits2Russian,
+	"Turkish":   LanguageBits2Turkish,
+	"Latvian":   LanguageBits2Latvian,
+	"Ukrainian": LanguageBits2Ukrainian,
+	"Arabic":    LanguageBits2Arabic,
+	"Farsi":     LanguageBits2Farsi,
+	"Invalid":   LanguageBits2Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LanguageBits2) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LanguageBits2Invalid.
+func (x *LanguageBits2) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LanguageBits2Invalid
+	case name == "":
+		*x = LanguageBits2(v)
+	default:
+		val, found := languageBits2Values[name]
+		if !found {
+			return unknownEnumNameError{"LanguageBits2", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LanguageBits3 represents the language_bits_3 FIT type.
 type LanguageBits3 uint8
 
@@ -1319,6 +4179,47 @@ const (
 	LanguageBits3Invalid   LanguageBits3 = 0x00
 )
 
+var languageBits3Values = map[string]LanguageBits3{
+	"Bulgarian": LanguageBits3Bulgarian,
+	"Romanian":  LanguageBits3Romanian,
+	"Chinese":   LanguageBits3Chinese,
+	"Japanese":  LanguageBits3Japanese,
+	"Korean":    LanguageBits3Korean,
+	"Taiwanese": LanguageBits3Taiwanese,
+	"Thai":      LanguageBits3Thai,
+	"Hebrew":    LanguageBits3Hebrew,
+	"Invalid":   LanguageBits3Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LanguageBits3) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LanguageBits3Invalid.
+func (x *LanguageBits3) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LanguageBits3Invalid
+	case name == "":
+		*x = LanguageBits3(v)
+	default:
+		val, found := languageBits3Values[name]
+		if !found {
+			return unknownEnumNameError{"LanguageBits3", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LanguageBits4 represents the language_bits_4 FIT type.
 type LanguageBits4 uint8
 
@@ -1332,6 +4233,45 @@ const (
 	LanguageBits4Invalid             LanguageBits4 = 0x00
 )
 
+var languageBits4Values = map[string]LanguageBits4{
+	"BrazilianPortuguese": LanguageBits4BrazilianPortuguese,
+	"Indonesian":          LanguageBits4Indonesian,
+	"Malaysian":           LanguageBits4Malaysian,
+	"Vietnamese":          LanguageBits4Vietnamese,
+	"Burmese":             LanguageBits4Burmese,
+	"Mongolian":           LanguageBits4Mongolian,
+	"Invalid":             LanguageBits4Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LanguageBits4) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LanguageBits4Invalid.
+func (x *LanguageBits4) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LanguageBits4Invalid
+	case name == "":
+		*x = LanguageBits4(v)
+	default:
+		val, found := languageBits4Values[name]
+		if !found {
+			return unknownEnumNameError{"LanguageBits4", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LapTrigger represents the lap_trigger FIT type.
 type LapTrigger byte
 
@@ -1348,6 +4288,48 @@ const (
 	LapTriggerInvalid          LapTrigger = 0xFF
 )
 
+var lapTriggerValues = map[string]LapTrigger{
+	"Manual":           LapTriggerManual,
+	"Time":             LapTriggerTime,
+	"Distance":         LapTriggerDistance,
+	"PositionStart":    LapTriggerPositionStart,
+	"PositionLap":      LapTriggerPositionLap,
+	"PositionWaypoint": LapTriggerPositionWaypoint,
+	"PositionMarked":   LapTriggerPositionMarked,
+	"SessionEnd":       LapTriggerSessionEnd,
+	"FitnessEquipment": LapTriggerFitnessEquipment,
+	"Invalid":          LapTriggerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LapTrigger) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LapTriggerInvalid.
+func (x *LapTrigger) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LapTriggerInvalid
+	case name == "":
+		*x = LapTrigger(v)
+	default:
+		val, found := lapTriggerValues[name]
+		if !found {
+			return unknownEnumNameError{"LapTrigger", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LeftRightBalance represents the left_right_balance FIT type.
 type LeftRightBalance uint8
 
@@ -1357,6 +4339,41 @@ const (
 	LeftRightBalanceInvalid LeftRightBalance = 0xFF
 )
 
+var leftRightBalanceValues = map[string]LeftRightBalance{
+	"Mask":    LeftRightBalanceMask,
+	"Right":   LeftRightBalanceRight,
+	"Invalid": LeftRightBalanceInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LeftRightBalance) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LeftRightBalanceInvalid.
+func (x *LeftRightBalance) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LeftRightBalanceInvalid
+	case name == "":
+		*x = LeftRightBalance(v)
+	default:
+		val, found := leftRightBalanceValues[name]
+		if !found {
+			return unknownEnumNameError{"LeftRightBalance", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LeftRightBalance100 represents the left_right_balance_100 FIT type.
 type LeftRightBalance100 uint16
 
@@ -1366,6 +4383,41 @@ const (
 	LeftRightBalance100Invalid LeftRightBalance100 = 0xFFFF
 )
 
+var leftRightBalance100Values = map[string]LeftRightBalance100{
+	"Mask":    LeftRightBalance100Mask,
+	"Right":   LeftRightBalance100Right,
+	"Invalid": LeftRightBalance100Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LeftRightBalance100) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LeftRightBalance100Invalid.
+func (x *LeftRightBalance100) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LeftRightBalance100Invalid
+	case name == "":
+		*x = LeftRightBalance100(v)
+	default:
+		val, found := leftRightBalance100Values[name]
+		if !found {
+			return unknownEnumNameError{"LeftRightBalance100", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LengthType represents the length_type FIT type.
 type LengthType byte
 
@@ -1375,6 +4427,41 @@ const (
 	LengthTypeInvalid LengthType = 0xFF
 )
 
+var lengthTypeValues = map[string]LengthType{
+	"Idle":    LengthTypeIdle,
+	"Active":  LengthTypeActive,
+	"Invalid": LengthTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LengthType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LengthTypeInvalid.
+func (x *LengthType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LengthTypeInvalid
+	case name == "":
+		*x = LengthType(v)
+	default:
+		val, found := lengthTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"LengthType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // LocaltimeIntoDay represents the localtime_into_day FIT type.
 type LocaltimeIntoDay uint32
 
@@ -1382,6 +4469,39 @@ const (
 	LocaltimeIntoDayInvalid LocaltimeIntoDay = 0xFFFFFFFF
 )
 
+var localtimeIntoDayValues = map[string]LocaltimeIntoDay{
+	"Invalid": LocaltimeIntoDayInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x LocaltimeIntoDay) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to LocaltimeIntoDayInvalid.
+func (x *LocaltimeIntoDay) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = LocaltimeIntoDayInvalid
+	case name == "":
+		*x = LocaltimeIntoDay(v)
+	default:
+		val, found := localtimeIntoDayValues[name]
+		if !found {
+			return unknownEnumNameError{"LocaltimeIntoDay", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Manufacturer represents the manufacturer FIT type.
 type Manufacturer uint16
 
@@ -1530,6 +4650,180 @@ const (
 	ManufacturerInvalid                Manufacturer = 0xFFFF
 )
 
+var manufacturerValues = map[string]Manufacturer{
+	"Garmin":                 ManufacturerGarmin,
+	"GarminFr405Antfs":       ManufacturerGarminFr405Antfs,
+	"Zephyr":                 ManufacturerZephyr,
+	"Dayton":                 ManufacturerDayton,
+	"Idt":                    ManufacturerIdt,
+	"Srm":                    ManufacturerSrm,
+	"Quarq":                  ManufacturerQuarq,
+	"Ibike":                  ManufacturerIbike,
+	"Saris":                  ManufacturerSaris,
+	"SparkHk":                ManufacturerSparkHk,
+	"Tanita":                 ManufacturerTanita,
+	"Echowell":               ManufacturerEchowell,
+	"DynastreamOem":          ManufacturerDynastreamOem,
+	"Nautilus":               ManufacturerNautilus,
+	"Dynastream":             ManufacturerDynastream,
+	"Timex":                  ManufacturerTimex,
+	"Metrigear":              ManufacturerMetrigear,
+	"Xelic":                  ManufacturerXelic,
+	"Beurer":                 ManufacturerBeurer,
+	"Cardiosport":            ManufacturerCardiosport,
+	"AAndD":                  ManufacturerAAndD,
+	"Hmm":                    ManufacturerHmm,
+	"Suunto":                 ManufacturerSuunto,
+	"ThitaElektronik":        ManufacturerThitaElektronik,
+	"Gpulse":                 ManufacturerGpulse,
+	"CleanMobile":            ManufacturerCleanMobile,
+	"PedalBrain":             ManufacturerPedalBrain,
+	"Peaksware":              ManufacturerPeaksware,
+	"Saxonar":                ManufacturerSaxonar,
+	"LemondFitness":          ManufacturerLemondFitness,
+	"Dexcom":                 ManufacturerDexcom,
+	"WahooFitness":           ManufacturerWahooFitness,
+	"OctaneFitness":          ManufacturerOctaneFitness,
+	"Archinoetics":           ManufacturerArchinoetics,
+	"TheHurtBox":             ManufacturerTheHurtBox,
+	"CitizenSystems":         ManufacturerCitizenSystems,
+	"Magellan":               ManufacturerMagellan,
+	"Osynce":                 ManufacturerOsynce,
+	"Holux":                  ManufacturerHolux,
+	"Concept2":               ManufacturerConcept2,
+	"OneGiantLeap":           ManufacturerOneGiantLeap,
+	"AceSensor":              ManufacturerAceSensor,
+	"BrimBrothers":           ManufacturerBrimBrothers,
+	"Xplova":                 ManufacturerXplova,
+	"PerceptionDigital":      ManufacturerPerceptionDigital,
+	"Bf1systems":             ManufacturerBf1systems,
+	"Pioneer":                ManufacturerPioneer,
+	"Spantec":                ManufacturerSpantec,
+	"Metalogics":             ManufacturerMetalogics,
+	"4iiiis":                 Manufacturer4iiiis,
+	"SeikoEpson":             ManufacturerSeikoEpson,
+	"SeikoEpsonOem":          ManufacturerSeikoEpsonOem,
+	"IforPowell":             ManufacturerIforPowell,
+	"MaxwellGuider":          ManufacturerMaxwellGuider,
+	"StarTrac":               ManufacturerStarTrac,
+	"Breakaway":              ManufacturerBreakaway,
+	"AlatechTechnologyLtd":   ManufacturerAlatechTechnologyLtd,
+	"MioTechnologyEurope":    ManufacturerMioTechnologyEurope,
+	"Rotor":                  ManufacturerRotor,
+	"Geonaute":               ManufacturerGeonaute,
+	"IdBike":                 ManufacturerIdBike,
+	"Specialized":            ManufacturerSpecialized,
+	"Wtek":                   ManufacturerWtek,
+	"PhysicalEnterprises":    ManufacturerPhysicalEnterprises,
+	"NorthPoleEngineering":   ManufacturerNorthPoleEngineering,
+	"Bkool":                  ManufacturerBkool,
+	"Cateye":                 ManufacturerCateye,
+	"StagesCycling":          ManufacturerStagesCycling,
+	"Sigmasport":             ManufacturerSigmasport,
+	"Tomtom":                 ManufacturerTomtom,
+	"Peripedal":              ManufacturerPeripedal,
+	"Wattbike":               ManufacturerWattbike,
+	"Moxy":                   ManufacturerMoxy,
+	"Ciclosport":             ManufacturerCiclosport,
+	"Powerbahn":              ManufacturerPowerbahn,
+	"AcornProjectsAps":       ManufacturerAcornProjectsAps,
+	"Lifebeam":               ManufacturerLifebeam,
+	"Bontrager":              ManufacturerBontrager,
+	"Wellgo":                 ManufacturerWellgo,
+	"Scosche":                ManufacturerScosche,
+	"Magura":                 ManufacturerMagura,
+	"Woodway":                ManufacturerWoodway,
+	"Elite":                  ManufacturerElite,
+	"NielsenKellerman":       ManufacturerNielsenKellerman,
+	"DkCity":                 ManufacturerDkCity,
+	"Tacx":                   ManufacturerTacx,
+	"DirectionTechnology":    ManufacturerDirectionTechnology,
+	"Magtonic":               ManufacturerMagtonic,
+	"1partcarbon":            Manufacturer1partcarbon,
+	"InsideRideTechnologies": ManufacturerInsideRideTechnologies,
+	"SoundOfMotion":          ManufacturerSoundOfMotion,
+	"Stryd":                  ManufacturerStryd,
+	"Icg":                    ManufacturerIcg,
+	"MiPulse":                ManufacturerMiPulse,
+	"BsxAthletics":           ManufacturerBsxAthletics,
+	"Look":                   ManufacturerLook,
+	"CampagnoloSrl":          ManufacturerCampagnoloSrl,
+	"BodyBikeSmart":          ManufacturerBodyBikeSmart,
+	"Praxisworks":            ManufacturerPraxisworks,
+	"LimitsTechnology":       ManufacturerLimitsTechnology,
+	"TopactionTechnology":    ManufacturerTopactionTechnology,
+	"Cosinuss":               ManufacturerCosinuss,
+	"Fitcare":                ManufacturerFitcare,
+	"Magene":                 ManufacturerMagene,
+	"GiantManufacturingCo":   ManufacturerGiantManufacturingCo,
+	"Tigrasport":             ManufacturerTigrasport,
+	"Salutron":               ManufacturerSalutron,
+	"Technogym":              ManufacturerTechnogym,
+	"BrytonSensors":          ManufacturerBrytonSensors,
+	"LatitudeLimited":        ManufacturerLatitudeLimited,
+	"SoaringTechnology":      ManufacturerSoaringTechnology,
+	"Igpsport":               ManufacturerIgpsport,
+	"Development":            ManufacturerDevelopment,
+	"Healthandlife":          ManufacturerHealthandlife,
+	"Lezyne":                 ManufacturerLezyne,
+	"ScribeLabs":             ManufacturerScribeLabs,
+	"Zwift":                  ManufacturerZwift,
+	"Watteam":                ManufacturerWatteam,
+	"Recon":                  ManufacturerRecon,
+	"FaveroElectronics":      ManufacturerFaveroElectronics,
+	"Dynovelo":               ManufacturerDynovelo,
+	"Strava":                 ManufacturerStrava,
+	"Precor":                 ManufacturerPrecor,
+	"Bryton":                 ManufacturerBryton,
+	"Sram":                   ManufacturerSram,
+	"Navman":                 ManufacturerNavman,
+	"Cobi":                   ManufacturerCobi,
+	"Spivi":                  ManufacturerSpivi,
+	"MioMagellan":            ManufacturerMioMagellan,
+	"Evesports":              ManufacturerEvesports,
+	"SensitivusGauge":        ManufacturerSensitivusGauge,
+	"Podoon":                 ManufacturerPodoon,
+	"LifeTimeFitness":        ManufacturerLifeTimeFitness,
+	"FalcoEMotors":           ManufacturerFalcoEMotors,
+	"Minoura":                ManufacturerMinoura,
+	"Cycliq":                 ManufacturerCycliq,
+	"Luxottica":              ManufacturerLuxottica,
+	"TrainerRoad":            ManufacturerTrainerRoad,
+	"TheSufferfest":          ManufacturerTheSufferfest,
+	"Fullspeedahead":         ManufacturerFullspeedahead,
+	"Actigraphcorp":          ManufacturerActigraphcorp,
+	"Invalid":                ManufacturerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Manufacturer) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ManufacturerInvalid.
+func (x *Manufacturer) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ManufacturerInvalid
+	case name == "":
+		*x = Manufacturer(v)
+	default:
+		val, found := manufacturerValues[name]
+		if !found {
+			return unknownEnumNameError{"Manufacturer", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // MesgCount represents the mesg_count FIT type.
 type MesgCount byte
 
@@ -1540,6 +4834,42 @@ const (
 	MesgCountInvalid        MesgCount = 0xFF
 )
 
+var mesgCountValues = map[string]MesgCount{
+	"NumPerFile":     MesgCountNumPerFile,
+	"MaxPerFile":     MesgCountMaxPerFile,
+	"MaxPerFileType": MesgCountMaxPerFileType,
+	"Invalid":        MesgCountInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x MesgCount) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to MesgCountInvalid.
+func (x *MesgCount) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = MesgCountInvalid
+	case name == "":
+		*x = MesgCount(v)
+	default:
+		val, found := mesgCountValues[name]
+		if !found {
+			return unknownEnumNameError{"MesgCount", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // MesgNum represents the mesg_num FIT type.
 type MesgNum uint16
 
@@ -1626,6 +4956,118 @@ const (
 	MesgNumInvalid                     MesgNum = 0xFFFF
 )
 
+var mesgNumValues = map[string]MesgNum{
+	"FileId":                      MesgNumFileId,
+	"Capabilities":                MesgNumCapabilities,
+	"DeviceSettings":              MesgNumDeviceSettings,
+	"UserProfile":                 MesgNumUserProfile,
+	"HrmProfile":                  MesgNumHrmProfile,
+	"SdmProfile":                  MesgNumSdmProfile,
+	"BikeProfile":                 MesgNumBikeProfile,
+	"ZonesTarget":                 MesgNumZonesTarget,
+	"HrZone":                      MesgNumHrZone,
+	"PowerZone":                   MesgNumPowerZone,
+	"MetZone":                     MesgNumMetZone,
+	"Sport":                       MesgNumSport,
+	"Goal":                        MesgNumGoal,
+	"Session":                     MesgNumSession,
+	"Lap":                         MesgNumLap,
+	"Record":                      MesgNumRecord,
+	"Event":                       MesgNumEvent,
+	"DeviceInfo":                  MesgNumDeviceInfo,
+	"Workout":                     MesgNumWorkout,
+	"WorkoutStep":                 MesgNumWorkoutStep,
+	"Schedule":                    MesgNumSchedule,
+	"WeightScale":                 MesgNumWeightScale,
+	"Course":                      MesgNumCourse,
+	"CoursePoint":                 MesgNumCoursePoint,
+	"Totals":                      MesgNumTotals,
+	"Activity":                    MesgNumActivity,
+	"Software":                    MesgNumSoftware,
+	"FileCapabilities":            MesgNumFileCapabilities,
+	"MesgCapabilities":            MesgNumMesgCapabilities,
+	"FieldCapabilities":           MesgNumFieldCapabilities,
+	"FileCreator":                 MesgNumFileCreator,
+	"BloodPressure":               MesgNumBloodPressure,
+	"SpeedZone":                   MesgNumSpeedZone,
+	"Monitoring":                  MesgNumMonitoring,
+	"TrainingFile":                MesgNumTrainingFile,
+	"Hrv":                         MesgNumHrv,
+	"AntRx":                       MesgNumAntRx,
+	"AntTx":                       MesgNumAntTx,
+	"AntChannelId":                MesgNumAntChannelId,
+	"Length":                      MesgNumLength,
+	"MonitoringInfo":              MesgNumMonitoringInfo,
+	"Pad":                         MesgNumPad,
+	"SlaveDevice":                 MesgNumSlaveDevice,
+	"Connectivity":                MesgNumConnectivity,
+	"WeatherConditions":           MesgNumWeatherConditions,
+	"WeatherAlert":                MesgNumWeatherAlert,
+	"CadenceZone":                 MesgNumCadenceZone,
+	"Hr":                          MesgNumHr,
+	"SegmentLap":                  MesgNumSegmentLap,
+	"MemoGlob":                    MesgNumMemoGlob,
+	"SegmentId":                   MesgNumSegmentId,
+	"SegmentLeaderboardEntry":     MesgNumSegmentLeaderboardEntry,
+	"SegmentPoint":                MesgNumSegmentPoint,
+	"SegmentFile":                 MesgNumSegmentFile,
+	"WorkoutSession":              MesgNumWorkoutSession,
+	"WatchfaceSettings":           MesgNumWatchfaceSettings,
+	"GpsMetadata":                 MesgNumGpsMetadata,
+	"CameraEvent":                 MesgNumCameraEvent,
+	"TimestampCorrelation":        MesgNumTimestampCorrelation,
+	"GyroscopeData":               MesgNumGyroscopeData,
+	"AccelerometerData":           MesgNumAccelerometerData,
+	"ThreeDSensorCalibration":     MesgNumThreeDSensorCalibration,
+	"VideoFrame":                  MesgNumVideoFrame,
+	"ObdiiData":                   MesgNumObdiiData,
+	"NmeaSentence":                MesgNumNmeaSentence,
+	"AviationAttitude":            MesgNumAviationAttitude,
+	"Video":                       MesgNumVideo,
+	"VideoTitle":                  MesgNumVideoTitle,
+	"VideoDescription":            MesgNumVideoDescription,
+	"VideoClip":                   MesgNumVideoClip,
+	"OhrSettings":                 MesgNumOhrSettings,
+	"ExdScreenConfiguration":      MesgNumExdScreenConfiguration,
+	"ExdDataFieldConfiguration":   MesgNumExdDataFieldConfiguration,
+	"ExdDataConceptConfiguration": MesgNumExdDataConceptConfiguration,
+	"FieldDescription":            MesgNumFieldDescription,
+	"DeveloperDataId":             MesgNumDeveloperDataId,
+	"MagnetometerData":            MesgNumMagnetometerData,
+	"MfgRangeMin":                 MesgNumMfgRangeMin,
+	"MfgRangeMax":                 MesgNumMfgRangeMax,
+	"Invalid":                     MesgNumInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x MesgNum) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to MesgNumInvalid.
+func (x *MesgNum) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = MesgNumInvalid
+	case name == "":
+		*x = MesgNum(v)
+	default:
+		val, found := mesgNumValues[name]
+		if !found {
+			return unknownEnumNameError{"MesgNum", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // MessageIndex represents the message_index FIT type.
 type MessageIndex uint16
 
@@ -1636,6 +5078,42 @@ const (
 	MessageIndexInvalid  MessageIndex = 0xFFFF
 )
 
+var messageIndexValues = map[string]MessageIndex{
+	"Selected": MessageIndexSelected,
+	"Reserved": MessageIndexReserved,
+	"Mask":     MessageIndexMask,
+	"Invalid":  MessageIndexInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x MessageIndex) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to MessageIndexInvalid.
+func (x *MessageIndex) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = MessageIndexInvalid
+	case name == "":
+		*x = MessageIndex(v)
+	default:
+		val, found := messageIndexValues[name]
+		if !found {
+			return unknownEnumNameError{"MessageIndex", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // PowerPhaseType represents the power_phase_type FIT type.
 type PowerPhaseType byte
 
@@ -1647,6 +5125,43 @@ const (
 	PowerPhaseTypeInvalid              PowerPhaseType = 0xFF
 )
 
+var powerPhaseTypeValues = map[string]PowerPhaseType{
+	"PowerPhaseStartAngle": PowerPhaseTypePowerPhaseStartAngle,
+	"PowerPhaseEndAngle":   PowerPhaseTypePowerPhaseEndAngle,
+	"PowerPhaseArcLength":  PowerPhaseTypePowerPhaseArcLength,
+	"PowerPhaseCenter":     PowerPhaseTypePowerPhaseCenter,
+	"Invalid":              PowerPhaseTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x PowerPhaseType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to PowerPhaseTypeInvalid.
+func (x *PowerPhaseType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = PowerPhaseTypeInvalid
+	case name == "":
+		*x = PowerPhaseType(v)
+	default:
+		val, found := powerPhaseTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"PowerPhaseType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // PwrZoneCalc represents the pwr_zone_calc FIT type.
 type PwrZoneCalc byte
 
@@ -1656,6 +5171,41 @@ const (
 	PwrZoneCalcInvalid    PwrZoneCalc = 0xFF
 )
 
+var pwrZoneCalcValues = map[string]PwrZoneCalc{
+	"Custom":     PwrZoneCalcCustom,
+	"PercentFtp": PwrZoneCalcPercentFtp,
+	"Invalid":    PwrZoneCalcInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x PwrZoneCalc) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to PwrZoneCalcInvalid.
+func (x *PwrZoneCalc) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = PwrZoneCalcInvalid
+	case name == "":
+		*x = PwrZoneCalc(v)
+	default:
+		val, found := pwrZoneCalcValues[name]
+		if !found {
+			return unknownEnumNameError{"PwrZoneCalc", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // RiderPositionType represents the rider_position_type FIT type.
 type RiderPositionType byte
 
@@ -1667,6 +5217,43 @@ const (
 	RiderPositionTypeInvalid              RiderPositionType = 0xFF
 )
 
+var riderPositionTypeValues = map[string]RiderPositionType{
+	"Seated":               RiderPositionTypeSeated,
+	"Standing":             RiderPositionTypeStanding,
+	"TransitionToSeated":   RiderPositionTypeTransitionToSeated,
+	"TransitionToStanding": RiderPositionTypeTransitionToStanding,
+	"Invalid":              RiderPositionTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x RiderPositionType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to RiderPositionTypeInvalid.
+func (x *RiderPositionType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = RiderPositionTypeInvalid
+	case name == "":
+		*x = RiderPositionType(v)
+	default:
+		val, found := riderPositionTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"RiderPositionType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Schedule represents the schedule FIT type.
 type Schedule byte
 
@@ -1676,6 +5263,41 @@ const (
 	ScheduleInvalid Schedule = 0xFF
 )
 
+var scheduleValues = map[string]Schedule{
+	"Workout": ScheduleWorkout,
+	"Course":  ScheduleCourse,
+	"Invalid": ScheduleInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Schedule) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to ScheduleInvalid.
+func (x *Schedule) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = ScheduleInvalid
+	case name == "":
+		*x = Schedule(v)
+	default:
+		val, found := scheduleValues[name]
+		if !found {
+			return unknownEnumNameError{"Schedule", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SegmentDeleteStatus represents the segment_delete_status FIT type.
 type SegmentDeleteStatus byte
 
@@ -1686,6 +5308,42 @@ const (
 	SegmentDeleteStatusInvalid     SegmentDeleteStatus = 0xFF
 )
 
+var segmentDeleteStatusValues = map[string]SegmentDeleteStatus{
+	"DoNotDelete": SegmentDeleteStatusDoNotDelete,
+	"DeleteOne":   SegmentDeleteStatusDeleteOne,
+	"DeleteAll":   SegmentDeleteStatusDeleteAll,
+	"Invalid":     SegmentDeleteStatusInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SegmentDeleteStatus) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SegmentDeleteStatusInvalid.
+func (x *SegmentDeleteStatus) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SegmentDeleteStatusInvalid
+	case name == "":
+		*x = SegmentDeleteStatus(v)
+	default:
+		val, found := segmentDeleteStatusValues[name]
+		if !found {
+			return unknownEnumNameError{"SegmentDeleteStatus", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SegmentLapStatus represents the segment_lap_status FIT type.
 type SegmentLapStatus byte
 
@@ -1695,6 +5353,41 @@ const (
 	SegmentLapStatusInvalid SegmentLapStatus = 0xFF
 )
 
+var segmentLapStatusValues = map[string]SegmentLapStatus{
+	"End":     SegmentLapStatusEnd,
+	"Fail":    SegmentLapStatusFail,
+	"Invalid": SegmentLapStatusInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SegmentLapStatus) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SegmentLapStatusInvalid.
+func (x *SegmentLapStatus) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SegmentLapStatusInvalid
+	case name == "":
+		*x = SegmentLapStatus(v)
+	default:
+		val, found := segmentLapStatusValues[name]
+		if !found {
+			return unknownEnumNameError{"SegmentLapStatus", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SegmentLeaderboardType represents the segment_leaderboard_type FIT type.
 type SegmentLeaderboardType byte
 
@@ -1713,6 +5406,50 @@ const (
 	SegmentLeaderboardTypeInvalid      SegmentLeaderboardType = 0xFF
 )
 
+var segmentLeaderboardTypeValues = map[string]SegmentLeaderboardType{
+	"Overall":      SegmentLeaderboardTypeOverall,
+	"PersonalBest": SegmentLeaderboardTypePersonalBest,
+	"Connections":  SegmentLeaderboardTypeConnections,
+	"Group":        SegmentLeaderboardTypeGroup,
+	"Challenger":   SegmentLeaderboardTypeChallenger,
+	"Kom":          SegmentLeaderboardTypeKom,
+	"Qom":          SegmentLeaderboardTypeQom,
+	"Pr":           SegmentLeaderboardTypePr,
+	"Goal":         SegmentLeaderboardTypeGoal,
+	"Rival":        SegmentLeaderboardTypeRival,
+	"ClubLeader":   SegmentLeaderboardTypeClubLeader,
+	"Invalid":      SegmentLeaderboardTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SegmentLeaderboardType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SegmentLeaderboardTypeInvalid.
+func (x *SegmentLeaderboardType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SegmentLeaderboardTypeInvalid
+	case name == "":
+		*x = SegmentLeaderboardType(v)
+	default:
+		val, found := segmentLeaderboardTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"SegmentLeaderboardType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SegmentSelectionType represents the segment_selection_type FIT type.
 type SegmentSelectionType byte
 
@@ -1722,6 +5459,41 @@ const (
 	SegmentSelectionTypeInvalid   SegmentSelectionType = 0xFF
 )
 
+var segmentSelectionTypeValues = map[string]SegmentSelectionType{
+	"Starred":   SegmentSelectionTypeStarred,
+	"Suggested": SegmentSelectionTypeSuggested,
+	"Invalid":   SegmentSelectionTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SegmentSelectionType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SegmentSelectionTypeInvalid.
+func (x *SegmentSelectionType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SegmentSelectionTypeInvalid
+	case name == "":
+		*x = SegmentSelectionType(v)
+	default:
+		val, found := segmentSelectionTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"SegmentSelectionType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SensorType represents the sensor_type FIT type.
 type SensorType byte
 
@@ -1732,6 +5504,42 @@ const (
 	SensorTypeInvalid       SensorType = 0xFF
 )
 
+var sensorTypeValues = map[string]SensorType{
+	"Accelerometer": SensorTypeAccelerometer,
+	"Gyroscope":     SensorTypeGyroscope,
+	"Compass":       SensorTypeCompass,
+	"Invalid":       SensorTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SensorType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SensorTypeInvalid.
+func (x *SensorType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SensorTypeInvalid
+	case name == "":
+		*x = SensorType(v)
+	default:
+		val, found := sensorTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"SensorType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SessionTrigger represents the session_trigger FIT type.
 type SessionTrigger byte
 
@@ -1743,6 +5551,43 @@ const (
 	SessionTriggerInvalid          SessionTrigger = 0xFF
 )
 
+var sessionTriggerValues = map[string]SessionTrigger{
+	"ActivityEnd":      SessionTriggerActivityEnd,
+	"Manual":           SessionTriggerManual,
+	"AutoMultiSport":   SessionTriggerAutoMultiSport,
+	"FitnessEquipment": SessionTriggerFitnessEquipment,
+	"Invalid":          SessionTriggerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SessionTrigger) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SessionTriggerInvalid.
+func (x *SessionTrigger) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SessionTriggerInvalid
+	case name == "":
+		*x = SessionTrigger(v)
+	default:
+		val, found := sessionTriggerValues[name]
+		if !found {
+			return unknownEnumNameError{"SessionTrigger", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Side represents the side FIT type.
 type Side byte
 
@@ -1752,6 +5597,41 @@ const (
 	SideInvalid Side = 0xFF
 )
 
+var sideValues = map[string]Side{
+	"Right":   SideRight,
+	"Left":    SideLeft,
+	"Invalid": SideInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Side) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SideInvalid.
+func (x *Side) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SideInvalid
+	case name == "":
+		*x = Side(v)
+	default:
+		val, found := sideValues[name]
+		if !found {
+			return unknownEnumNameError{"Side", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SourceType represents the source_type FIT type.
 type SourceType byte
 
@@ -1765,6 +5645,45 @@ const (
 	SourceTypeInvalid            SourceType = 0xFF
 )
 
+var sourceTypeValues = map[string]SourceType{
+	"Ant":                SourceTypeAnt,
+	"Antplus":            SourceTypeAntplus,
+	"Bluetooth":          SourceTypeBluetooth,
+	"BluetoothLowEnergy": SourceTypeBluetoothLowEnergy,
+	"Wifi":               SourceTypeWifi,
+	"Local":              SourceTypeLocal,
+	"Invalid":            SourceTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SourceType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SourceTypeInvalid.
+func (x *SourceType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SourceTypeInvalid
+	case name == "":
+		*x = SourceType(v)
+	default:
+		val, found := sourceTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"SourceType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Sport represents the sport FIT type.
 type Sport byte
 
@@ -1822,6 +5741,89 @@ const (
 	SportInvalid               Sport = 0xFF
 )
 
+var sportValues = map[string]Sport{
+	"Generic":               SportGeneric,
+	"Running":               SportRunning,
+	"Cycling":               SportCycling,
+	"Transition":            SportTransition,
+	"FitnessEquipment":      SportFitnessEquipment,
+	"Swimming":              SportSwimming,
+	"Basketball":            SportBasketball,
+	"Soccer":                SportSoccer,
+	"Tennis":                SportTennis,
+	"AmericanFootball":      SportAmericanFootball,
+	"Training":              SportTraining,
+	"Walking":               SportWalking,
+	"CrossCountrySkiing":    SportCrossCountrySkiing,
+	"AlpineSkiing":          SportAlpineSkiing,
+	"Snowboarding":          SportSnowboarding,
+	"Rowing":                SportRowing,
+	"Mountaineering":        SportMountaineering,
+	"Hiking":                SportHiking,
+	"Multisport":            SportMultisport,
+	"Paddling":              SportPaddling,
+	"Flying":                SportFlying,
+	"EBiking":               SportEBiking,
+	"Motorcycling":          SportMotorcycling,
+	"Boating":               SportBoating,
+	"Driving":               SportDriving,
+	"Golf":                  SportGolf,
+	"HangGliding":           SportHangGliding,
+	"HorsebackRiding":       SportHorsebackRiding,
+	"Hunting":               SportHunting,
+	"Fishing":               SportFishing,
+	"InlineSkating":         SportInlineSkating,
+	"RockClimbing":          SportRockClimbing,
+	"Sailing":               SportSailing,
+	"IceSkating":            SportIceSkating,
+	"SkyDiving":             SportSkyDiving,
+	"Snowshoeing":           SportSnowshoeing,
+	"Snowmobiling":          SportSnowmobiling,
+	"StandUpPaddleboarding": SportStandUpPaddleboarding,
+	"Surfing":               SportSurfing,
+	"Wakeboarding":          SportWakeboarding,
+	"WaterSkiing":           SportWaterSkiing,
+	"Kayaking":              SportKayaking,
+	"Rafting":               SportRafting,
+	"Windsurfing":           SportWindsurfing,
+	"Kitesurfing":           SportKitesurfing,
+	"Tactical":              SportTactical,
+	"Jumpmaster":            SportJumpmaster,
+	"Boxing":                SportBoxing,
+	"FloorClimbing":         SportFloorClimbing,
+	"All":                   SportAll,
+	"Invalid":               SportInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Sport) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportInvalid.
+func (x *Sport) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportInvalid
+	case name == "":
+		*x = Sport(v)
+	default:
+		val, found := sportValues[name]
+		if !found {
+			return unknownEnumNameError{"Sport", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits0 represents the sport_bits_0 FIT type.
 type SportBits0 uint8
 
@@ -1837,6 +5839,47 @@ const (
 	SportBits0Invalid          SportBits0 = 0x00
 )
 
+var sportBits0Values = map[string]SportBits0{
+	"Generic":          SportBits0Generic,
+	"Running":          SportBits0Running,
+	"Cycling":          SportBits0Cycling,
+	"Transition":       SportBits0Transition,
+	"FitnessEquipment": SportBits0FitnessEquipment,
+	"Swimming":         SportBits0Swimming,
+	"Basketball":       SportBits0Basketball,
+	"Soccer":           SportBits0Soccer,
+	"Invalid":          SportBits0Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits0) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits0Invalid.
+func (x *SportBits0) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits0Invalid
+	case name == "":
+		*x = SportBits0(v)
+	default:
+		val, found := sportBits0Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits0", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits1 represents the sport_bits_1 FIT type.
 type SportBits1 uint8
 
@@ -1852,6 +5895,47 @@ const (
 	SportBits1Invalid            SportBits1 = 0x00
 )
 
+var sportBits1Values = map[string]SportBits1{
+	"Tennis":             SportBits1Tennis,
+	"AmericanFootball":   SportBits1AmericanFootball,
+	"Training":           SportBits1Training,
+	"Walking":            SportBits1Walking,
+	"CrossCountrySkiing": SportBits1CrossCountrySkiing,
+	"AlpineSkiing":       SportBits1AlpineSkiing,
+	"Snowboarding":       SportBits1Snowboarding,
+	"Rowing":             SportBits1Rowing,
+	"Invalid":            SportBits1Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits1) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits1Invalid.
+func (x *SportBits1) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits1Invalid
+	case name == "":
+		*x = SportBits1(v)
+	default:
+		val, found := sportBits1Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits1", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits2 represents the sport_bits_2 FIT type.
 type SportBits2 uint8
 
@@ -1867,6 +5951,47 @@ const (
 	SportBits2Invalid        SportBits2 = 0x00
 )
 
+var sportBits2Values = map[string]SportBits2{
+	"Mountaineering": SportBits2Mountaineering,
+	"Hiking":         SportBits2Hiking,
+	"Multisport":     SportBits2Multisport,
+	"Paddling":       SportBits2Paddling,
+	"Flying":         SportBits2Flying,
+	"EBiking":        SportBits2EBiking,
+	"Motorcycling":   SportBits2Motorcycling,
+	"Boating":        SportBits2Boating,
+	"Invalid":        SportBits2Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits2) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits2Invalid.
+func (x *SportBits2) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits2Invalid
+	case name == "":
+		*x = SportBits2(v)
+	default:
+		val, found := sportBits2Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits2", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits3 represents the sport_bits_3 FIT type.
 type SportBits3 uint8
 
@@ -1882,6 +6007,47 @@ const (
 	SportBits3Invalid         SportBits3 = 0x00
 )
 
+var sportBits3Values = map[string]SportBits3{
+	"Driving":         SportBits3Driving,
+	"Golf":            SportBits3Golf,
+	"HangGliding":     SportBits3HangGliding,
+	"HorsebackRiding": SportBits3HorsebackRiding,
+	"Hunting":         SportBits3Hunting,
+	"Fishing":         SportBits3Fishing,
+	"InlineSkating":   SportBits3InlineSkating,
+	"RockClimbing":    SportBits3RockClimbing,
+	"Invalid":         SportBits3Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits3) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits3Invalid.
+func (x *SportBits3) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits3Invalid
+	case name == "":
+		*x = SportBits3(v)
+	default:
+		val, found := sportBits3Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits3", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits4 represents the sport_bits_4 FIT type.
 type SportBits4 uint8
 
@@ -1897,6 +6063,47 @@ const (
 	SportBits4Invalid               SportBits4 = 0x00
 )
 
+var sportBits4Values = map[string]SportBits4{
+	"Sailing":               SportBits4Sailing,
+	"IceSkating":            SportBits4IceSkating,
+	"SkyDiving":             SportBits4SkyDiving,
+	"Snowshoeing":           SportBits4Snowshoeing,
+	"Snowmobiling":          SportBits4Snowmobiling,
+	"StandUpPaddleboarding": SportBits4StandUpPaddleboarding,
+	"Surfing":               SportBits4Surfing,
+	"Wakeboarding":          SportBits4Wakeboarding,
+	"Invalid":               SportBits4Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits4) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits4Invalid.
+func (x *SportBits4) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits4Invalid
+	case name == "":
+		*x = SportBits4(v)
+	default:
+		val, found := sportBits4Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits4", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits5 represents the sport_bits_5 FIT type.
 type SportBits5 uint8
 
@@ -1912,6 +6119,47 @@ const (
 	SportBits5Invalid     SportBits5 = 0x00
 )
 
+var sportBits5Values = map[string]SportBits5{
+	"WaterSkiing": SportBits5WaterSkiing,
+	"Kayaking":    SportBits5Kayaking,
+	"Rafting":     SportBits5Rafting,
+	"Windsurfing": SportBits5Windsurfing,
+	"Kitesurfing": SportBits5Kitesurfing,
+	"Tactical":    SportBits5Tactical,
+	"Jumpmaster":  SportBits5Jumpmaster,
+	"Boxing":      SportBits5Boxing,
+	"Invalid":     SportBits5Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits5) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits5Invalid.
+func (x *SportBits5) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits5Invalid
+	case name == "":
+		*x = SportBits5(v)
+	default:
+		val, found := sportBits5Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits5", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportBits6 represents the sport_bits_6 FIT type.
 type SportBits6 uint8
 
@@ -1920,6 +6168,40 @@ const (
 	SportBits6Invalid       SportBits6 = 0x00
 )
 
+var sportBits6Values = map[string]SportBits6{
+	"FloorClimbing": SportBits6FloorClimbing,
+	"Invalid":       SportBits6Invalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportBits6) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportBits6Invalid.
+func (x *SportBits6) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportBits6Invalid
+	case name == "":
+		*x = SportBits6(v)
+	default:
+		val, found := sportBits6Values[name]
+		if !found {
+			return unknownEnumNameError{"SportBits6", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SportEvent represents the sport_event FIT type.
 type SportEvent byte
 
@@ -1936,6 +6218,48 @@ const (
 	SportEventInvalid        SportEvent = 0xFF
 )
 
+var sportEventValues = map[string]SportEvent{
+	"Uncategorized":  SportEventUncategorized,
+	"Geocaching":     SportEventGeocaching,
+	"Fitness":        SportEventFitness,
+	"Recreation":     SportEventRecreation,
+	"Race":           SportEventRace,
+	"SpecialEvent":   SportEventSpecialEvent,
+	"Training":       SportEventTraining,
+	"Transportation": SportEventTransportation,
+	"Touring":        SportEventTouring,
+	"Invalid":        SportEventInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SportEvent) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SportEventInvalid.
+func (x *SportEvent) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SportEventInvalid
+	case name == "":
+		*x = SportEvent(v)
+	default:
+		val, found := sportEventValues[name]
+		if !found {
+			return unknownEnumNameError{"SportEvent", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // StrokeType represents the stroke_type FIT type.
 type StrokeType byte
 
@@ -1949,6 +6273,45 @@ const (
 	StrokeTypeInvalid  StrokeType = 0xFF
 )
 
+var strokeTypeValues = map[string]StrokeType{
+	"NoEvent":  StrokeTypeNoEvent,
+	"Other":    StrokeTypeOther,
+	"Serve":    StrokeTypeServe,
+	"Forehand": StrokeTypeForehand,
+	"Backhand": StrokeTypeBackhand,
+	"Smash":    StrokeTypeSmash,
+	"Invalid":  StrokeTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x StrokeType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to StrokeTypeInvalid.
+func (x *StrokeType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = StrokeTypeInvalid
+	case name == "":
+		*x = StrokeType(v)
+	default:
+		val, found := strokeTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"StrokeType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SubSport represents the sub_sport FIT type.
 type SubSport byte
 
@@ -2012,6 +6375,95 @@ const (
 	SubSportInvalid              SubSport = 0xFF
 )
 
+var subSportValues = map[string]SubSport{
+	"Generic":              SubSportGeneric,
+	"Treadmill":            SubSportTreadmill,
+	"Street":               SubSportStreet,
+	"Trail":                SubSportTrail,
+	"Track":                SubSportTrack,
+	"Spin":                 SubSportSpin,
+	"IndoorCycling":        SubSportIndoorCycling,
+	"Road":                 SubSportRoad,
+	"Mountain":             SubSportMountain,
+	"Downhill":             SubSportDownhill,
+	"Recumbent":            SubSportRecumbent,
+	"Cyclocross":           SubSportCyclocross,
+	"HandCycling":          SubSportHandCycling,
+	"TrackCycling":         SubSportTrackCycling,
+	"IndoorRowing":         SubSportIndoorRowing,
+	"Elliptical":           SubSportElliptical,
+	"StairClimbing":        SubSportStairClimbing,
+	"LapSwimming":          SubSportLapSwimming,
+	"OpenWater":            SubSportOpenWater,
+	"FlexibilityTraining":  SubSportFlexibilityTraining,
+	"StrengthTraining":     SubSportStrengthTraining,
+	"WarmUp":               SubSportWarmUp,
+	"Match":                SubSportMatch,
+	"Exercise":             SubSportExercise,
+	"Challenge":            SubSportChallenge,
+	"IndoorSkiing":         SubSportIndoorSkiing,
+	"CardioTraining":       SubSportCardioTraining,
+	"IndoorWalking":        SubSportIndoorWalking,
+	"EBikeFitness":         SubSportEBikeFitness,
+	"Bmx":                  SubSportBmx,
+	"CasualWalking":        SubSportCasualWalking,
+	"SpeedWalking":         SubSportSpeedWalking,
+	"BikeToRunTransition":  SubSportBikeToRunTransition,
+	"RunToBikeTransition":  SubSportRunToBikeTransition,
+	"SwimToBikeTransition": SubSportSwimToBikeTransition,
+	"Atv":                  SubSportAtv,
+	"Motocross":            SubSportMotocross,
+	"Backcountry":          SubSportBackcountry,
+	"Resort":               SubSportResort,
+	"RcDrone":              SubSportRcDrone,
+	"Wingsuit":             SubSportWingsuit,
+	"Whitewater":           SubSportWhitewater,
+	"SkateSkiing":          SubSportSkateSkiing,
+	"Yoga":                 SubSportYoga,
+	"Pilates":              SubSportPilates,
+	"IndoorRunning":        SubSportIndoorRunning,
+	"GravelCycling":        SubSportGravelCycling,
+	"EBikeMountain":        SubSportEBikeMountain,
+	"Commuting":            SubSportCommuting,
+	"MixedSurface":         SubSportMixedSurface,
+	"Navigate":             SubSportNavigate,
+	"TrackMe":              SubSportTrackMe,
+	"Map":                  SubSportMap,
+	"VirtualActivity":      SubSportVirtualActivity,
+	"Obstacle":             SubSportObstacle,
+	"All":                  SubSportAll,
+	"Invalid":              SubSportInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SubSport) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SubSportInvalid.
+func (x *SubSport) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SubSportInvalid
+	case name == "":
+		*x = SubSport(v)
+	default:
+		val, found := subSportValues[name]
+		if !found {
+			return unknownEnumNameError{"SubSport", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SupportedExdScreenLayouts represents the supported_exd_screen_layouts FIT type.
 type SupportedExdScreenLayouts uint32
 
@@ -2027,6 +6479,47 @@ const (
 	SupportedExdScreenLayoutsInvalid                   SupportedExdScreenLayouts = 0x00000000
 )
 
+var supportedExdScreenLayoutsValues = map[string]SupportedExdScreenLayouts{
+	"FullScreen":                SupportedExdScreenLayoutsFullScreen,
+	"HalfVertical":              SupportedExdScreenLayoutsHalfVertical,
+	"HalfHorizontal":            SupportedExdScreenLayoutsHalfHorizontal,
+	"HalfVerticalRightSplit":    SupportedExdScreenLayoutsHalfVerticalRightSplit,
+	"HalfHorizontalBottomSplit": SupportedExdScreenLayoutsHalfHorizontalBottomSplit,
+	"FullQuarterSplit":          SupportedExdScreenLayoutsFullQuarterSplit,
+	"HalfVerticalLeftSplit":     SupportedExdScreenLayoutsHalfVerticalLeftSplit,
+	"HalfHorizontalTopSplit":    SupportedExdScreenLayoutsHalfHorizontalTopSplit,
+	"Invalid":                   SupportedExdScreenLayoutsInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SupportedExdScreenLayouts) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SupportedExdScreenLayoutsInvalid.
+func (x *SupportedExdScreenLayouts) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SupportedExdScreenLayoutsInvalid
+	case name == "":
+		*x = SupportedExdScreenLayouts(v)
+	default:
+		val, found := supportedExdScreenLayoutsValues[name]
+		if !found {
+			return unknownEnumNameError{"SupportedExdScreenLayouts", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // SwimStroke represents the swim_stroke FIT type.
 type SwimStroke byte
 
@@ -2041,6 +6534,46 @@ const (
 	SwimStrokeInvalid      SwimStroke = 0xFF
 )
 
+var swimStrokeValues = map[string]SwimStroke{
+	"Freestyle":    SwimStrokeFreestyle,
+	"Backstroke":   SwimStrokeBackstroke,
+	"Breaststroke": SwimStrokeBreaststroke,
+	"Butterfly":    SwimStrokeButterfly,
+	"Drill":        SwimStrokeDrill,
+	"Mixed":        SwimStrokeMixed,
+	"Im":           SwimStrokeIm,
+	"Invalid":      SwimStrokeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x SwimStroke) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SwimStrokeInvalid.
+func (x *SwimStroke) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SwimStrokeInvalid
+	case name == "":
+		*x = SwimStroke(v)
+	default:
+		val, found := swimStrokeValues[name]
+		if !found {
+			return unknownEnumNameError{"SwimStroke", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Switch represents the switch FIT type.
 type Switch byte
 
@@ -2051,6 +6584,42 @@ const (
 	SwitchInvalid Switch = 0xFF
 )
 
+var switchValues = map[string]Switch{
+	"Off":     SwitchOff,
+	"On":      SwitchOn,
+	"Auto":    SwitchAuto,
+	"Invalid": SwitchInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Switch) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to SwitchInvalid.
+func (x *Switch) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = SwitchInvalid
+	case name == "":
+		*x = Switch(v)
+	default:
+		val, found := switchValues[name]
+		if !found {
+			return unknownEnumNameError{"Switch", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // TimeIntoDay represents the time_into_day FIT type.
 type TimeIntoDay uint32
 
@@ -2058,6 +6627,39 @@ const (
 	TimeIntoDayInvalid TimeIntoDay = 0xFFFFFFFF
 )
 
+var timeIntoDayValues = map[string]TimeIntoDay{
+	"Invalid": TimeIntoDayInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x TimeIntoDay) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to TimeIntoDayInvalid.
+func (x *TimeIntoDay) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = TimeIntoDayInvalid
+	case name == "":
+		*x = TimeIntoDay(v)
+	default:
+		val, found := timeIntoDayValues[name]
+		if !found {
+			return unknownEnumNameError{"TimeIntoDay", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // TimeMode represents the time_mode FIT type.
 type TimeMode byte
 
@@ -2071,6 +6673,45 @@ const (
 	TimeModeInvalid           TimeMode = 0xFF
 )
 
+var timeModeValues = map[string]TimeMode{
+	"Hour12":            TimeModeHour12,
+	"Hour24":            TimeModeHour24,
+	"Military":          TimeModeMilitary,
+	"Hour12WithSeconds": TimeModeHour12WithSeconds,
+	"Hour24WithSeconds": TimeModeHour24WithSeconds,
+	"Utc":               TimeModeUtc,
+	"Invalid":           TimeModeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x TimeMode) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to TimeModeInvalid.
+func (x *TimeMode) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = TimeModeInvalid
+	case name == "":
+		*x = TimeMode(v)
+	default:
+		val, found := timeModeValues[name]
+		if !found {
+			return unknownEnumNameError{"TimeMode", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // TimeZone represents the time_zone FIT type.
 type TimeZone byte
 
@@ -2184,6 +6825,145 @@ const (
 	TimeZoneInvalid                  TimeZone = 0xFF
 )
 
+var timeZoneValues = map[string]TimeZone{
+	"Almaty":                   TimeZoneAlmaty,
+	"Bangkok":                  TimeZoneBangkok,
+	"Bombay":                   TimeZoneBombay,
+	"Brasilia":                 TimeZoneBrasilia,
+	"Cairo":                    TimeZoneCairo,
+	"CapeVerdeIs":              TimeZoneCapeVerdeIs,
+	"Darwin":                   TimeZoneDarwin,
+	"Eniwetok":                 TimeZoneEniwetok,
+	"Fiji":                     TimeZoneFiji,
+	"HongKong":                 TimeZoneHongKong,
+	"Islamabad":                TimeZoneIslamabad,
+	"Kabul":                    TimeZoneKabul,
+	"Magadan":                  TimeZoneMagadan,
+	"MidAtlantic":              TimeZoneMidAtlantic,
+	"Moscow":                   TimeZoneMoscow,
+	"Muscat":                   TimeZoneMuscat,
+	"Newfoundland":             TimeZoneNewfoundland,
+	"Samoa":                    TimeZoneSamoa,
+	"Sydney":                   TimeZoneSydney,
+	"Tehran":                   TimeZoneTehran,
+	"Tokyo":                    TimeZoneTokyo,
+	"UsAlaska":                 TimeZoneUsAlaska,
+	"UsAtlantic":               TimeZoneUsAtlantic,
+	"UsCentral":                TimeZoneUsCentral,
+	"UsEastern":                TimeZoneUsEastern,
+	"UsHawaii":                 TimeZoneUsHawaii,
+	"UsMountain":               TimeZoneUsMountain,
+	"UsPacific":                TimeZoneUsPacific,
+	"Other":                    TimeZoneOther,
+	"Auckland":                 TimeZoneAuckland,
+	"Kathmandu":                TimeZoneKathmandu,
+	"EuropeWesternWet":         TimeZoneEuropeWesternWet,
+	"EuropeCentralCet":         TimeZoneEuropeCentralCet,
+	"EuropeEasternEet":         TimeZoneEuropeEasternEet,
+	"Jakarta":                  TimeZoneJakarta,
+	"Perth":                    TimeZonePerth,
+	"Adelaide":                 TimeZoneAdelaide,
+	"Brisbane":                 TimeZoneBrisbane,
+	"Tasmania":                 TimeZoneTasmania,
+	"Iceland":                  TimeZoneIceland,
+	"Amsterdam":                TimeZoneAmsterdam,
+	"Athens":                   TimeZoneAthens,
+	"Barcelona":                TimeZoneBarcelona,
+	"Berlin":                   TimeZoneBerlin,
+	"Brussels":                 TimeZoneBrussels,
+	"Budapest":                 TimeZoneBudapest,
+	"Copenhagen":               TimeZoneCopenhagen,
+	"Dublin":                   TimeZoneDublin,
+	"Helsinki":                 TimeZoneHelsinki,
+	"Lisbon":                   TimeZoneLisbon,
+	"London":                   TimeZoneLondon,
+	"Madrid":                   TimeZoneMadrid,
+	"Munich":                   TimeZoneMunich,
+	"Oslo":                     TimeZoneOslo,
+	"Paris":                    TimeZoneParis,
+	"Prague":                   TimeZonePrague,
+	"Reykjavik":                TimeZoneReykjavik,
+	"Rome":                     TimeZoneRome,
+	"Stockholm":                TimeZoneStockholm,
+	"Vienna":                   TimeZoneVienna,
+	"Warsaw":                   TimeZoneWarsaw,
+	"Zurich":                   TimeZoneZurich,
+	"Quebec":                   TimeZoneQuebec,
+	"Ontario":                  TimeZoneOntario,
+	"Manitoba":                 TimeZoneManitoba,
+	"Saskatchewan":             TimeZoneSaskatchewan,
+	"Alberta":                  TimeZoneAlberta,
+	"BritishColumbia":          TimeZoneBritishColumbia,
+	"Boise":                    TimeZoneBoise,
+	"Boston":                   TimeZoneBoston,
+	"Chicago":                  TimeZoneChicago,
+	"Dallas":                   TimeZoneDallas,
+	"Denver":                   TimeZoneDenver,
+	"KansasCity":               TimeZoneKansasCity,
+	"LasVegas":                 TimeZoneLasVegas,
+	"LosAngeles":               TimeZoneLosAngeles,
+	"Miami":                    TimeZoneMiami,
+	"Minneapolis":              TimeZoneMinneapolis,
+	"NewYork":                  TimeZoneNewYork,
+	"NewOrleans":               TimeZoneNewOrleans,
+	"Phoenix":                  TimeZonePhoenix,
+	"SantaFe":                  TimeZoneSantaFe,
+	"Seattle":                  TimeZoneSeattle,
+	"WashingtonDc":             TimeZoneWashingtonDc,
+	"UsArizona":                TimeZoneUsArizona,
+	"Chita":                    TimeZoneChita,
+	"Ekaterinburg":             TimeZoneEkaterinburg,
+	"Irkutsk":                  TimeZoneIrkutsk,
+	"Kaliningrad":              TimeZoneKaliningrad,
+	"Krasnoyarsk":              TimeZoneKrasnoyarsk,
+	"Novosibirsk":              TimeZoneNovosibirsk,
+	"PetropavlovskKamchatskiy": TimeZonePetropavlovskKamchatskiy,
+	"Samara":                   TimeZoneSamara,
+	"Vladivostok":              TimeZoneVladivostok,
+	"MexicoCentral":            TimeZoneMexicoCentral,
+	"MexicoMountain":           TimeZoneMexicoMountain,
+	"MexicoPacific":            TimeZoneMexicoPacific,
+	"CapeTown":                 TimeZoneCapeTown,
+	"Winkhoek":                 TimeZoneWinkhoek,
+	"Lagos":                    TimeZoneLagos,
+	"Riyahd":                   TimeZoneRiyahd,
+	"Venezuela":                TimeZoneVenezuela,
+	"AustraliaLh":              TimeZoneAustraliaLh,
+	"Santiago":                 TimeZoneSantiago,
+	"Manual":                   TimeZoneManual,
+	"Automatic":                TimeZoneAutomatic,
+	"Invalid":                  TimeZoneInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x TimeZone) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to TimeZoneInvalid.
+func (x *TimeZone) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = TimeZoneInvalid
+	case name == "":
+		*x = TimeZone(v)
+	default:
+		val, found := timeZoneValues[name]
+		if !found {
+			return unknownEnumNameError{"TimeZone", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // TimerTrigger represents the timer_trigger FIT type.
 type TimerTrigger byte
 
@@ -2194,6 +6974,42 @@ const (
 	TimerTriggerInvalid          TimerTrigger = 0xFF
 )
 
+var timerTriggerValues = map[string]TimerTrigger{
+	"Manual":           TimerTriggerManual,
+	"Auto":             TimerTriggerAuto,
+	"FitnessEquipment": TimerTriggerFitnessEquipment,
+	"Invalid":          TimerTriggerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x TimerTrigger) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to TimerTriggerInvalid.
+func (x *TimerTrigger) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = TimerTriggerInvalid
+	case name == "":
+		*x = TimerTrigger(v)
+	default:
+		val, found := timerTriggerValues[name]
+		if !found {
+			return unknownEnumNameError{"TimerTrigger", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // TurnType represents the turn_type FIT type.
 type TurnType byte
 
@@ -2239,6 +7055,77 @@ const (
 	TurnTypeInvalid                 TurnType = 0xFF
 )
 
+var turnTypeValues = map[string]TurnType{
+	"ArrivingIdx":             TurnTypeArrivingIdx,
+	"ArrivingLeftIdx":         TurnTypeArrivingLeftIdx,
+	"ArrivingRightIdx":        TurnTypeArrivingRightIdx,
+	"ArrivingViaIdx":          TurnTypeArrivingViaIdx,
+	"ArrivingViaLeftIdx":      TurnTypeArrivingViaLeftIdx,
+	"ArrivingViaRightIdx":     TurnTypeArrivingViaRightIdx,
+	"BearKeepLeftIdx":         TurnTypeBearKeepLeftIdx,
+	"BearKeepRightIdx":        TurnTypeBearKeepRightIdx,
+	"ContinueIdx":             TurnTypeContinueIdx,
+	"ExitLeftIdx":             TurnTypeExitLeftIdx,
+	"ExitRightIdx":            TurnTypeExitRightIdx,
+	"FerryIdx":                TurnTypeFerryIdx,
+	"Roundabout45Idx":         TurnTypeRoundabout45Idx,
+	"Roundabout90Idx":         TurnTypeRoundabout90Idx,
+	"Roundabout135Idx":        TurnTypeRoundabout135Idx,
+	"Roundabout180Idx":        TurnTypeRoundabout180Idx,
+	"Roundabout225Idx":        TurnTypeRoundabout225Idx,
+	"Roundabout270Idx":        TurnTypeRoundabout270Idx,
+	"Roundabout315Idx":        TurnTypeRoundabout315Idx,
+	"Roundabout360Idx":        TurnTypeRoundabout360Idx,
+	"RoundaboutNeg45Idx":      TurnTypeRoundaboutNeg45Idx,
+	"RoundaboutNeg90Idx":      TurnTypeRoundaboutNeg90Idx,
+	"RoundaboutNeg135Idx":     TurnTypeRoundaboutNeg135Idx,
+	"RoundaboutNeg180Idx":     TurnTypeRoundaboutNeg180Idx,
+	"RoundaboutNeg225Idx":     TurnTypeRoundaboutNeg225Idx,
+	"RoundaboutNeg270Idx":     TurnTypeRoundaboutNeg270Idx,
+	"RoundaboutNeg315Idx":     TurnTypeRoundaboutNeg315Idx,
+	"RoundaboutNeg360Idx":     TurnTypeRoundaboutNeg360Idx,
+	"RoundaboutGenericIdx":    TurnTypeRoundaboutGenericIdx,
+	"RoundaboutNegGenericIdx": TurnTypeRoundaboutNegGenericIdx,
+	"SharpTurnLeftIdx":        TurnTypeSharpTurnLeftIdx,
+	"SharpTurnRightIdx":       TurnTypeSharpTurnRightIdx,
+	"TurnLeftIdx":             TurnTypeTurnLeftIdx,
+	"TurnRightIdx":            TurnTypeTurnRightIdx,
+	"UturnLeftIdx":            TurnTypeUturnLeftIdx,
+	"UturnRightIdx":           TurnTypeUturnRightIdx,
+	"IconInvIdx":              TurnTypeIconInvIdx,
+	"IconIdxCnt":              TurnTypeIconIdxCnt,
+	"Invalid":                 TurnTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x TurnType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to TurnTypeInvalid.
+func (x *TurnType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = TurnTypeInvalid
+	case name == "":
+		*x = TurnType(v)
+	default:
+		val, found := turnTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"TurnType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // UserLocalId represents the user_local_id FIT type.
 type UserLocalId uint16
 
@@ -2252,6 +7139,45 @@ const (
 	UserLocalIdInvalid       UserLocalId = 0xFFFF
 )
 
+var userLocalIdValues = map[string]UserLocalId{
+	"LocalMin":      UserLocalIdLocalMin,
+	"LocalMax":      UserLocalIdLocalMax,
+	"StationaryMin": UserLocalIdStationaryMin,
+	"StationaryMax": UserLocalIdStationaryMax,
+	"PortableMin":   UserLocalIdPortableMin,
+	"PortableMax":   UserLocalIdPortableMax,
+	"Invalid":       UserLocalIdInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x UserLocalId) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to UserLocalIdInvalid.
+func (x *UserLocalId) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = UserLocalIdInvalid
+	case name == "":
+		*x = UserLocalId(v)
+	default:
+		val, found := userLocalIdValues[name]
+		if !found {
+			return unknownEnumNameError{"UserLocalId", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WatchfaceMode represents the watchface_mode FIT type.
 type WatchfaceMode byte
 
@@ -2263,6 +7189,43 @@ const (
 	WatchfaceModeInvalid   WatchfaceMode = 0xFF
 )
 
+var watchfaceModeValues = map[string]WatchfaceMode{
+	"Digital":   WatchfaceModeDigital,
+	"Analog":    WatchfaceModeAnalog,
+	"ConnectIq": WatchfaceModeConnectIq,
+	"Disabled":  WatchfaceModeDisabled,
+	"Invalid":   WatchfaceModeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WatchfaceMode) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WatchfaceModeInvalid.
+func (x *WatchfaceMode) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WatchfaceModeInvalid
+	case name == "":
+		*x = WatchfaceMode(v)
+	default:
+		val, found := watchfaceModeValues[name]
+		if !found {
+			return unknownEnumNameError{"WatchfaceMode", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WeatherReport represents the weather_report FIT type.
 type WeatherReport byte
 
@@ -2274,6 +7237,43 @@ const (
 	WeatherReportInvalid        WeatherReport = 0xFF
 )
 
+var weatherReportValues = map[string]WeatherReport{
+	"Current":        WeatherReportCurrent,
+	"Forecast":       WeatherReportForecast,
+	"HourlyForecast": WeatherReportHourlyForecast,
+	"DailyForecast":  WeatherReportDailyForecast,
+	"Invalid":        WeatherReportInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WeatherReport) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WeatherReportInvalid.
+func (x *WeatherReport) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WeatherReportInvalid
+	case name == "":
+		*x = WeatherReport(v)
+	default:
+		val, found := weatherReportValues[name]
+		if !found {
+			return unknownEnumNameError{"WeatherReport", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WeatherSevereType represents the weather_severe_type FIT type.
 type WeatherSevereType byte
 
@@ -2366,6 +7366,124 @@ const (
 	WeatherSevereTypeInvalid                 WeatherSevereType = 0xFF
 )
 
+var weatherSevereTypeValues = map[string]WeatherSevereType{
+	"Unspecified":             WeatherSevereTypeUnspecified,
+	"Tornado":                 WeatherSevereTypeTornado,
+	"Tsunami":                 WeatherSevereTypeTsunami,
+	"Hurricane":               WeatherSevereTypeHurricane,
+	"ExtremeWind":             WeatherSevereTypeExtremeWind,
+	"Typhoon":                 WeatherSevereTypeTyphoon,
+	"InlandHurricane":         WeatherSevereTypeInlandHurricane,
+	"HurricaneForceWind":      WeatherSevereTypeHurricaneForceWind,
+	"Waterspout":              WeatherSevereTypeWaterspout,
+	"SevereThunderstorm":      WeatherSevereTypeSevereThunderstorm,
+	"WreckhouseWinds":         WeatherSevereTypeWreckhouseWinds,
+	"LesSuetesWind":           WeatherSevereTypeLesSuetesWind,
+	"Avalanche":               WeatherSevereTypeAvalanche,
+	"FlashFlood":              WeatherSevereTypeFlashFlood,
+	"TropicalStorm":           WeatherSevereTypeTropicalStorm,
+	"InlandTropicalStorm":     WeatherSevereTypeInlandTropicalStorm,
+	"Blizzard":                WeatherSevereTypeBlizzard,
+	"IceStorm":                WeatherSevereTypeIceStorm,
+	"FreezingRain":            WeatherSevereTypeFreezingRain,
+	"DebrisFlow":              WeatherSevereTypeDebrisFlow,
+	"FlashFreeze":             WeatherSevereTypeFlashFreeze,
+	"DustStorm":               WeatherSevereTypeDustStorm,
+	"HighWind":                WeatherSevereTypeHighWind,
+	"WinterStorm":             WeatherSevereTypeWinterStorm,
+	"HeavyFreezingSpray":      WeatherSevereTypeHeavyFreezingSpray,
+	"ExtremeCold":             WeatherSevereTypeExtremeCold,
+	"WindChill":               WeatherSevereTypeWindChill,
+	"ColdWave":                WeatherSevereTypeColdWave,
+	"HeavySnowAlert":          WeatherSevereTypeHeavySnowAlert,
+	"LakeEffectBlowingSnow":   WeatherSevereTypeLakeEffectBlowingSnow,
+	"SnowSquall":              WeatherSevereTypeSnowSquall,
+	"LakeEffectSnow":          WeatherSevereTypeLakeEffectSnow,
+	"WinterWeather":           WeatherSevereTypeWinterWeather,
+	"Sleet":                   WeatherSevereTypeSleet,
+	"Snowfall":                WeatherSevereTypeSnowfall,
+	"SnowAndBlowingSnow":      WeatherSevereTypeSnowAndBlowingSnow,
+	"BlowingSnow":             WeatherSevereTypeBlowingSnow,
+	"SnowAlert":               WeatherSevereTypeSnowAlert,
+	"ArcticOutflow":           WeatherSevereTypeArcticOutflow,
+	"FreezingDrizzle":         WeatherSevereTypeFreezingDrizzle,
+	"Storm":                   WeatherSevereTypeStorm,
+	"StormSurge":              WeatherSevereTypeStormSurge,
+	"Rainfall":                WeatherSevereTypeRainfall,
+	"ArealFlood":              WeatherSevereTypeArealFlood,
+	"CoastalFlood":            WeatherSevereTypeCoastalFlood,
+	"LakeshoreFlood":          WeatherSevereTypeLakeshoreFlood,
+	"ExcessiveHeat":           WeatherSevereTypeExcessiveHeat,
+	"Heat":                    WeatherSevereTypeHeat,
+	"Weather":                 WeatherSevereTypeWeather,
+	"HighHeatAndHumidity":     WeatherSevereTypeHighHeatAndHumidity,
+	"HumidexAndHealth":        WeatherSevereTypeHumidexAndHealth,
+	"Humidex":                 WeatherSevereTypeHumidex,
+	"Gale":                    WeatherSevereTypeGale,
+	"FreezingSpray":           WeatherSevereTypeFreezingSpray,
+	"SpecialMarine":           WeatherSevereTypeSpecialMarine,
+	"Squall":                  WeatherSevereTypeSquall,
+	"StrongWind":              WeatherSevereTypeStrongWind,
+	"LakeWind":                WeatherSevereTypeLakeWind,
+	"MarineWeather":           WeatherSevereTypeMarineWeather,
+	"Wind":                    WeatherSevereTypeWind,
+	"SmallCraftHazardousSeas": WeatherSevereTypeSmallCraftHazardousSeas,
+	"HazardousSeas":           WeatherSevereTypeHazardousSeas,
+	"SmallCraft":              WeatherSevereTypeSmallCraft,
+	"SmallCraftWinds":         WeatherSevereTypeSmallCraftWinds,
+	"SmallCraftRoughBar":      WeatherSevereTypeSmallCraftRoughBar,
+	"HighWaterLevel":          WeatherSevereTypeHighWaterLevel,
+	"Ashfall":                 WeatherSevereTypeAshfall,
+	"FreezingFog":             WeatherSevereTypeFreezingFog,
+	"DenseFog":                WeatherSevereTypeDenseFog,
+	"DenseSmoke":              WeatherSevereTypeDenseSmoke,
+	"BlowingDust":             WeatherSevereTypeBlowingDust,
+	"HardFreeze":              WeatherSevereTypeHardFreeze,
+	"Freeze":                  WeatherSevereTypeFreeze,
+	"Frost":                   WeatherSevereTypeFrost,
+	"FireWeather":             WeatherSevereTypeFireWeather,
+	"Flood":                   WeatherSevereTypeFlood,
+	"RipTide":                 WeatherSevereTypeRipTide,
+	"HighSurf":                WeatherSevereTypeHighSurf,
+	"Smog":                    WeatherSevereTypeSmog,
+	"AirQuality":              WeatherSevereTypeAirQuality,
+	"BriskWind":               WeatherSevereTypeBriskWind,
+	"AirStagnation":           WeatherSevereTypeAirStagnation,
+	"LowWater":                WeatherSevereTypeLowWater,
+	"Hydrological":            WeatherSevereTypeHydrological,
+	"SpecialWeather":          WeatherSevereTypeSpecialWeather,
+	"Invalid":                 WeatherSevereTypeInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WeatherSevereType) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WeatherSevereTypeInvalid.
+func (x *WeatherSevereType) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WeatherSevereTypeInvalid
+	case name == "":
+		*x = WeatherSevereType(v)
+	default:
+		val, found := weatherSevereTypeValues[name]
+		if !found {
+			return unknownEnumNameError{"WeatherSevereType", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WeatherSeverity represents the weather_severity FIT type.
 type WeatherSeverity byte
 
@@ -2378,6 +7496,44 @@ const (
 	WeatherSeverityInvalid   WeatherSeverity = 0xFF
 )
 
+var weatherSeverityValues = map[string]WeatherSeverity{
+	"Unknown":   WeatherSeverityUnknown,
+	"Warning":   WeatherSeverityWarning,
+	"Watch":     WeatherSeverityWatch,
+	"Advisory":  WeatherSeverityAdvisory,
+	"Statement": WeatherSeverityStatement,
+	"Invalid":   WeatherSeverityInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WeatherSeverity) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WeatherSeverityInvalid.
+func (x *WeatherSeverity) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WeatherSeverityInvalid
+	case name == "":
+		*x = WeatherSeverity(v)
+	default:
+		val, found := weatherSeverityValues[name]
+		if !found {
+			return unknownEnumNameError{"WeatherSeverity", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WeatherStatus represents the weather_status FIT type.
 type WeatherStatus byte
 
@@ -2406,6 +7562,60 @@ const (
 	WeatherStatusInvalid                WeatherStatus = 0xFF
 )
 
+var weatherStatusValues = map[string]WeatherStatus{
+	"Clear":                  WeatherStatusClear,
+	"PartlyCloudy":           WeatherStatusPartlyCloudy,
+	"MostlyCloudy":           WeatherStatusMostlyCloudy,
+	"Rain":                   WeatherStatusRain,
+	"Snow":                   WeatherStatusSnow,
+	"Windy":                  WeatherStatusWindy,
+	"Thunderstorms":          WeatherStatusThunderstorms,
+	"WintryMix":              WeatherStatusWintryMix,
+	"Fog":                    WeatherStatusFog,
+	"Hazy":                   WeatherStatusHazy,
+	"Hail":                   WeatherStatusHail,
+	"ScatteredShowers":       WeatherStatusScatteredShowers,
+	"ScatteredThunderstorms": WeatherStatusScatteredThunderstorms,
+	"UnknownPrecipitation":   WeatherStatusUnknownPrecipitation,
+	"LightRain":              WeatherStatusLightRain,
+	"HeavyRain":              WeatherStatusHeavyRain,
+	"LightSnow":              WeatherStatusLightSnow,
+	"HeavySnow":              WeatherStatusHeavySnow,
+	"LightRainSnow":          WeatherStatusLightRainSnow,
+	"HeavyRainSnow":          WeatherStatusHeavyRainSnow,
+	"Cloudy":                 WeatherStatusCloudy,
+	"Invalid":                WeatherStatusInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WeatherStatus) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WeatherStatusInvalid.
+func (x *WeatherStatus) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WeatherStatusInvalid
+	case name == "":
+		*x = WeatherStatus(v)
+	default:
+		val, found := weatherStatusValues[name]
+		if !found {
+			return unknownEnumNameError{"WeatherStatus", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // Weight represents the weight FIT type.
 type Weight uint16
 
@@ -2414,6 +7624,40 @@ const (
 	WeightInvalid     Weight = 0xFFFF
 )
 
+var weightValues = map[string]Weight{
+	"Calculating": WeightCalculating,
+	"Invalid":     WeightInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x Weight) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WeightInvalid.
+func (x *Weight) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WeightInvalid
+	case name == "":
+		*x = Weight(v)
+	default:
+		val, found := weightValues[name]
+		if !found {
+			return unknownEnumNameError{"Weight", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WktStepDuration represents the wkt_step_duration FIT type.
 type WktStepDuration byte
 
@@ -2450,6 +7694,68 @@ const (
 	WktStepDurationInvalid                            WktStepDuration = 0xFF
 )
 
+var wktStepDurationValues = map[string]WktStepDuration{
+	"Time":                               WktStepDurationTime,
+	"Distance":                           WktStepDurationDistance,
+	"HrLessThan":                         WktStepDurationHrLessThan,
+	"HrGreaterThan":                      WktStepDurationHrGreaterThan,
+	"Calories":                           WktStepDurationCalories,
+	"Open":                               WktStepDurationOpen,
+	"RepeatUntilStepsCmplt":              WktStepDurationRepeatUntilStepsCmplt,
+	"RepeatUntilTime":                    WktStepDurationRepeatUntilTime,
+	"RepeatUntilDistance":                WktStepDurationRepeatUntilDistance,
+	"RepeatUntilCalories":                WktStepDurationRepeatUntilCalories,
+	"RepeatUntilHrLessThan":              WktStepDurationRepeatUntilHrLessThan,
+	"RepeatUntilHrGreaterThan":           WktStepDurationRepeatUntilHrGreaterThan,
+	"RepeatUntilPowerLessThan":           WktStepDurationRepeatUntilPowerLessThan,
+	"RepeatUntilPowerGreaterThan":        WktStepDurationRepeatUntilPowerGreaterThan,
+	"PowerLessThan":                      WktStepDurationPowerLessThan,
+	"PowerGreaterThan":                   WktStepDurationPowerGreaterThan,
+	"TrainingPeaksTss":                   WktStepDurationTrainingPeaksTss,
+	"RepeatUntilPowerLastLapLessThan":    WktStepDurationRepeatUntilPowerLastLapLessThan,
+	"RepeatUntilMaxPowerLastLapLessThan": WktStepDurationRepeatUntilMaxPowerLastLapLessThan,
+	"Power3sLessThan":                    WktStepDurationPower3sLessThan,
+	"Power10sLessThan":                   WktStepDurationPower10sLessThan,
+	"Power30sLessThan":                   WktStepDurationPower30sLessThan,
+	"Power3sGreaterThan":                 WktStepDurationPower3sGreaterThan,
+	"Power10sGreaterThan":                WktStepDurationPower10sGreaterThan,
+	"Power30sGreaterThan":                WktStepDurationPower30sGreaterThan,
+	"PowerLapLessThan":                   WktStepDurationPowerLapLessThan,
+	"PowerLapGreaterThan":                WktStepDurationPowerLapGreaterThan,
+	"RepeatUntilTrainingPeaksTss":        WktStepDurationRepeatUntilTrainingPeaksTss,
+	"RepetitionTime":                     WktStepDurationRepetitionTime,
+	"Invalid":                            WktStepDurationInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WktStepDuration) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WktStepDurationInvalid.
+func (x *WktStepDuration) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WktStepDurationInvalid
+	case name == "":
+		*x = WktStepDuration(v)
+	default:
+		val, found := wktStepDurationValues[name]
+		if !found {
+			return unknownEnumNameError{"WktStepDuration", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WktStepTarget represents the wkt_step_target FIT type.
 type WktStepTarget byte
 
@@ -2471,6 +7777,53 @@ const (
 	WktStepTargetInvalid      WktStepTarget = 0xFF
 )
 
+var wktStepTargetValues = map[string]WktStepTarget{
+	"Speed":        WktStepTargetSpeed,
+	"HeartRate":    WktStepTargetHeartRate,
+	"Open":         WktStepTargetOpen,
+	"Cadence":      WktStepTargetCadence,
+	"Power":        WktStepTargetPower,
+	"Grade":        WktStepTargetGrade,
+	"Resistance":   WktStepTargetResistance,
+	"Power3s":      WktStepTargetPower3s,
+	"Power10s":     WktStepTargetPower10s,
+	"Power30s":     WktStepTargetPower30s,
+	"PowerLap":     WktStepTargetPowerLap,
+	"SwimStroke":   WktStepTargetSwimStroke,
+	"SpeedLap":     WktStepTargetSpeedLap,
+	"HeartRateLap": WktStepTargetHeartRateLap,
+	"Invalid":      WktStepTargetInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WktStepTarget) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WktStepTargetInvalid.
+func (x *WktStepTarget) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WktStepTargetInvalid
+	case name == "":
+		*x = WktStepTarget(v)
+	default:
+		val, found := wktStepTargetValues[name]
+		if !found {
+			return unknownEnumNameError{"WktStepTarget", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WorkoutCapabilities represents the workout_capabilities FIT type.
 type WorkoutCapabilities uint32
 
@@ -2492,6 +7845,53 @@ const (
 	WorkoutCapabilitiesInvalid          WorkoutCapabilities = 0x00000000
 )
 
+var workoutCapabilitiesValues = map[string]WorkoutCapabilities{
+	"Interval":         WorkoutCapabilitiesInterval,
+	"Custom":           WorkoutCapabilitiesCustom,
+	"FitnessEquipment": WorkoutCapabilitiesFitnessEquipment,
+	"Firstbeat":        WorkoutCapabilitiesFirstbeat,
+	"NewLeaf":          WorkoutCapabilitiesNewLeaf,
+	"Tcx":              WorkoutCapabilitiesTcx,
+	"Speed":            WorkoutCapabilitiesSpeed,
+	"HeartRate":        WorkoutCapabilitiesHeartRate,
+	"Distance":         WorkoutCapabilitiesDistance,
+	"Cadence":          WorkoutCapabilitiesCadence,
+	"Power":            WorkoutCapabilitiesPower,
+	"Grade":            WorkoutCapabilitiesGrade,
+	"Resistance":       WorkoutCapabilitiesResistance,
+	"Protected":        WorkoutCapabilitiesProtected,
+	"Invalid":          WorkoutCapabilitiesInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WorkoutCapabilities) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WorkoutCapabilitiesInvalid.
+func (x *WorkoutCapabilities) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WorkoutCapabilitiesInvalid
+	case name == "":
+		*x = WorkoutCapabilities(v)
+	default:
+		val, found := workoutCapabilitiesValues[name]
+		if !found {
+			return unknownEnumNameError{"WorkoutCapabilities", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WorkoutEquipment represents the workout_equipment FIT type.
 type WorkoutEquipment byte
 
@@ -2505,6 +7905,45 @@ const (
 	WorkoutEquipmentInvalid       WorkoutEquipment = 0xFF
 )
 
+var workoutEquipmentValues = map[string]WorkoutEquipment{
+	"None":          WorkoutEquipmentNone,
+	"SwimFins":      WorkoutEquipmentSwimFins,
+	"SwimKickboard": WorkoutEquipmentSwimKickboard,
+	"SwimPaddles":   WorkoutEquipmentSwimPaddles,
+	"SwimPullBuoy":  WorkoutEquipmentSwimPullBuoy,
+	"SwimSnorkel":   WorkoutEquipmentSwimSnorkel,
+	"Invalid":       WorkoutEquipmentInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WorkoutEquipment) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WorkoutEquipmentInvalid.
+func (x *WorkoutEquipment) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WorkoutEquipmentInvalid
+	case name == "":
+		*x = WorkoutEquipment(v)
+	default:
+		val, found := workoutEquipmentValues[name]
+		if !found {
+			return unknownEnumNameError{"WorkoutEquipment", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WorkoutHr represents the workout_hr FIT type.
 type WorkoutHr uint32
 
@@ -2513,6 +7952,40 @@ const (
 	WorkoutHrInvalid   WorkoutHr = 0xFFFFFFFF
 )
 
+var workoutHrValues = map[string]WorkoutHr{
+	"BpmOffset": WorkoutHrBpmOffset,
+	"Invalid":   WorkoutHrInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WorkoutHr) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WorkoutHrInvalid.
+func (x *WorkoutHr) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WorkoutHrInvalid
+	case name == "":
+		*x = WorkoutHr(v)
+	default:
+		val, found := workoutHrValues[name]
+		if !found {
+			return unknownEnumNameError{"WorkoutHr", name}
+		}
+		*x = val
+	}
+	return nil
+}
+
 // WorkoutPower represents the workout_power FIT type.
 type WorkoutPower uint32
 
@@ -2520,3 +7993,37 @@ const (
 	WorkoutPowerWattsOffset WorkoutPower = 1000
 	WorkoutPowerInvalid     WorkoutPower = 0xFFFFFFFF
 )
+
+var workoutPowerValues = map[string]WorkoutPower{
+	"WattsOffset": WorkoutPowerWattsOffset,
+	"Invalid":     WorkoutPowerInvalid,
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Named values are encoded as a string, others as a number.
+func (x WorkoutPower) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeEnum(x.String(), uint64(x))
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both names and numbers are accepted. JSON null decodes to WorkoutPowerInvalid.
+func (x *WorkoutPower) UnmarshalJSON(b []byte) error {
+	name, v, ok, err := unmarshalJSONEnum(b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		*x = WorkoutPowerInvalid
+	case name == "":
+		*x = WorkoutPower(v)
+	default:
+		val, found := workoutPowerValues[name]
+		if !found {
+			return unknownEnumNameError{"WorkoutPower", name}
+		}
+		*x = val
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package fit
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // Manually generated types: The 'Bool' type is not found in the SDK
 // specification, so it won't be auto-generated, but it is also not a base
@@ -32,3 +35,37 @@ func (i Bool) String() string {
 		return fmt.Sprintf("Bool(%d)", i)
 	}
 }
+
+// MarshalJSON implements the json.Marshaler interface. BoolFalse and BoolTrue
+// are encoded as JSON booleans, other values as numbers.
+func (i Bool) MarshalJSON() ([]byte, error) {
+	e := newJSONEncodeState()
+	e.writeBoolEnum(i)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. JSON null decodes
+// to BoolInvalid.
+func (i *Bool) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		*i = BoolInvalid
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		if v {
+			*i = BoolTrue
+		} else {
+			*i = BoolFalse
+		}
+	case float64:
+		*i = Bool(v)
+	default:
+		return fmt.Errorf("json: cannot decode %s into Bool", b)
+	}
+	return nil
+}
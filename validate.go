@@ -0,0 +1,337 @@
+package fit
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/tormoder/fit/internal/types"
+)
+
+// Severity is the severity of a validation issue.
+type Severity int
+
+// Validation issue severities.
+const (
+	SeverityInfo    Severity = iota // Unusual, but allowed.
+	SeverityWarning                 // Likely to be misinterpreted by readers.
+	SeverityError                   // Not valid FIT, or inconsistent data.
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// A ValidationIssue is a problem found by Validate.
+type ValidationIssue struct {
+	Severity Severity
+	Offset   int64   // Byte offset of the message from the start of the file, or -1.
+	MesgNum  MesgNum // Message the issue concerns, or MesgNumInvalid.
+	Message  string
+}
+
+func (v ValidationIssue) String() string {
+	if v.Offset < 0 {
+		return fmt.Sprintf("%v: %s", v.Severity, v.Message)
+	}
+	return fmt.Sprintf("%d: %v: %s", v.Offset, v.Severity, v.Message)
+}
+
+// requiredMesgs are the messages required in files of a type, with the
+// severity of their absence.
+var requiredMesgs = map[FileType][]struct {
+	num      MesgNum
+	severity Severity
+}{
+	FileTypeActivity: {
+		{MesgNumActivity, SeverityError},
+		{MesgNumSession, SeverityError},
+		{MesgNumLap, SeverityWarning},
+		{MesgNumRecord, SeverityInfo},
+	},
+	FileTypeCourse: {
+		{MesgNumCourse, SeverityError},
+		{MesgNumLap, SeverityWarning},
+		{MesgNumRecord, SeverityError},
+	},
+	FileTypeWorkout: {
+		{MesgNumWorkout, SeverityError},
+		{MesgNumWorkoutStep, SeverityError},
+	},
+	FileTypeSchedules:   {{MesgNumSchedule, SeverityWarning}},
+	FileTypeTotals:      {{MesgNumTotals, SeverityWarning}},
+	FileTypeGoals:       {{MesgNumGoal, SeverityWarning}},
+	FileTypeMonitoringA: {{MesgNumMonitoring, SeverityWarning}},
+	FileTypeMonitoringB: {{MesgNumMonitoring, SeverityWarning}},
+	FileTypeSegment:     {{MesgNumSegmentId, SeverityError}, {MesgNumSegmentPoint, SeverityWarning}},
+}
+
+// maxTimestampStepBack is the largest decrease in timestamps reported as
+// info rather than as a warning.
+const maxTimestampStepBack = 2 * time.Second
+
+// validator holds the state of Validate.
+type validator struct {
+	issues     []ValidationIssue
+	fileType   FileType
+	seen       map[MesgNum]int
+	lastTime   map[MesgNum]time.Time
+	lastIndex  map[MesgNum]int
+	sessions   []offsetMsg
+	laps       []offsetMsg
+	activities []offsetMsg
+}
+
+type offsetMsg struct {
+	offset int64
+	msg    interface{}
+}
+
+func (v *validator) add(s Severity, offset int64, mn MesgNum, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{
+		Severity: s,
+		Offset:   offset,
+		MesgNum:  mn,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks the FIT file read from r against the protocol and
+// profile, and returns the issues found in file order, followed by issues
+// concerning the file as a whole. It checks that:
+//
+//   - the file can be decoded, including its header and file CRC,
+//   - the file starts with a FileId message,
+//   - the messages required for the file type are present,
+//   - field base types and sizes match the profile,
+//   - timestamps of each message type do not decrease,
+//   - message indices of each message type count up by one, or restart
+//     from zero, as lap indices do for each session of a multisport file,
+//   - lap, session and activity summaries are consistent.
+//
+// Decoding stops at the first error that makes the rest of the file
+// unreadable; it is reported as an issue with severity error. A file using
+// features the decoder does not support, such as developer data fields, is
+// reported with a warning, and its remainder is not validated.
+func Validate(r io.Reader) []ValidationIssue {
+	v := &validator{
+		fileType:  FileTypeInvalid,
+		seen:      make(map[MesgNum]int),
+		lastTime:  make(map[MesgNum]time.Time),
+		lastIndex: make(map[MesgNum]int),
+	}
+	s, err := NewScanner(r)
+	if err != nil {
+		v.add(SeverityError, 0, MesgNumInvalid, "%v", err)
+		return v.issues
+	}
+	if h := s.Header(); ProtocolVersion(h.ProtocolVersion).Major() > CurrentProtocolVersion().Major() {
+		v.add(SeverityWarning, 0, MesgNumInvalid, "protocol version %v is newer than supported %v",
+			ProtocolVersion(h.ProtocolVersion), CurrentProtocolVersion())
+	}
+
+	first := true
+	for {
+		msg, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			offset := int64(-1)
+			mn := MesgNumInvalid
+			if msg != nil {
+				offset, mn = msg.Offset, msg.MesgNum
+			}
+			if _, ok := err.(NotSupportedError); ok {
+				// Valid, but the rest of the file cannot
+				// be checked.
+				v.add(SeverityWarning, offset, mn, "%v; not validating the rest of the file", err)
+				return v.issues
+			}
+			v.add(SeverityError, offset, mn, "%v", err)
+			break
+		}
+		if first && msg.MesgNum != MesgNumFileId {
+			v.add(SeverityError, msg.Offset, msg.MesgNum, "first message is %v, not FileId", msg.MesgNum)
+		}
+		first = false
+		if msg.Definition != nil {
+			v.definition(msg)
+		} else {
+			v.data(msg)
+		}
+	}
+
+	for _, req := range requiredMesgs[v.fileType] {
+		if v.seen[req.num] == 0 {
+			v.add(req.severity, -1, req.num, "%v file has no %v message", v.fileType, req.num)
+		}
+	}
+	v.summaries()
+	return v.issues
+}
+
+func (v *validator) definition(msg *RawMessage) {
+	mn := msg.MesgNum
+	if !knownMsgNums[mn] {
+		v.add(SeverityInfo, msg.Offset, mn, "definition of unknown message %v", mn)
+		return
+	}
+	for _, fd := range msg.Definition.Fields {
+		pfield, ok := getField(mn, fd.Num)
+		if !ok {
+			v.add(SeverityInfo, msg.Offset, mn, "%v: unknown field %d", mn, fd.Num)
+			continue
+		}
+		pbt := BaseType(pfield.t.BaseType())
+		if bt := fd.BaseType & 0x1F; bt != pbt {
+			// The decoder converts smaller types of the same
+			// signedness, as used by dynamic fields such as
+			// Event.Data.
+			s := SeverityInfo
+			if !compatibleBaseType(bt, pfield.t) {
+				s = SeverityWarning
+			}
+			v.add(s, msg.Offset, mn, "%v.%s: base type %v, profile has %v", mn, fd.Name, bt, pbt)
+		}
+		switch {
+		case pbt.Size() == 0, pbt.String() == "string":
+		case pfield.t.Array():
+			if int(fd.Size)%pbt.Size() != 0 {
+				v.add(SeverityWarning, msg.Offset, mn, "%v.%s: array size %d not a multiple of %v", mn, fd.Name, fd.Size, pbt)
+			}
+		case int(fd.Size) != pbt.Size():
+			v.add(SeverityInfo, msg.Offset, mn, "%v.%s: size %d, profile type %v has size %d", mn, fd.Name, fd.Size, pbt, pbt.Size())
+		}
+	}
+}
+
+// compatibleBaseType reports whether the decoder converts values of base
+// type bt to the profile field type pt, as it does for dynamic fields; see
+// decoder.validateFieldDef.
+func compatibleBaseType(bt BaseType, pt types.Fit) bool {
+	dbt, pbt := types.DecodeBase(byte(bt)), pt.BaseType()
+	switch {
+	case pt.Array(), dbt.Size() > pbt.Size():
+		return false
+	case dbt.Signed() != pbt.Signed(), dbt.Float() && !pbt.Float():
+		return false
+	}
+	return pbt != types.BaseString
+}
+
+func (v *validator) data(msg *RawMessage) {
+	mn := msg.MesgNum
+	n := v.seen[mn]
+	v.seen[mn]++
+	if msg.Msg == nil {
+		if n == 0 {
+			v.add(SeverityInfo, msg.Offset, mn, "unknown message %v", mn)
+		}
+		return
+	}
+	if fid, ok := msg.Msg.(FileIdMsg); ok {
+		if n > 0 {
+			v.add(SeverityWarning, msg.Offset, mn, "more than one FileId message")
+		} else {
+			v.fileType = fid.Type
+		}
+	}
+
+	mv := reflect.ValueOf(msg.Msg)
+	if f := mv.FieldByName("Timestamp"); f.IsValid() {
+		if ts, ok := f.Interface().(time.Time); ok && !IsBaseTime(ts) {
+			if last, ok := v.lastTime[mn]; ok && ts.Before(last) {
+				// Devices adjusting their clock step back a
+				// second or two.
+				s := SeverityInfo
+				if last.Sub(ts) > maxTimestampStepBack {
+					s = SeverityWarning
+				}
+				v.add(s, msg.Offset, mn, "%v timestamp %v is before previous %v", mn, ts.Format(time.RFC3339), last.Format(time.RFC3339))
+			}
+			v.lastTime[mn] = ts
+		}
+	}
+	if f := mv.FieldByName("MessageIndex"); f.IsValid() {
+		if mi, ok := f.Interface().(MessageIndex); ok && mi != MessageIndexInvalid {
+			i := int(mi & MessageIndexMask)
+			last, ok := v.lastIndex[mn]
+			if want := last + 1; ok && i != want && i != 0 || !ok && i != 0 {
+				if !ok {
+					want = 0
+				}
+				v.add(SeverityWarning, msg.Offset, mn, "%v message index %d, want %d", mn, i, want)
+			}
+			v.lastIndex[mn] = i
+		}
+	}
+
+	switch msg.Msg.(type) {
+	case SessionMsg:
+		v.sessions = append(v.sessions, offsetMsg{msg.Offset, msg.Msg})
+	case LapMsg:
+		v.laps = append(v.laps, offsetMsg{msg.Offset, msg.Msg})
+	case ActivityMsg:
+		v.activities = append(v.activities, offsetMsg{msg.Offset, msg.Msg})
+	}
+}
+
+// summaries checks that the activity, session and lap messages agree.
+func (v *validator) summaries() {
+	for _, a := range v.activities {
+		am := a.msg.(ActivityMsg)
+		if am.NumSessions != 0xFFFF && int(am.NumSessions) != len(v.sessions) {
+			v.add(SeverityWarning, a.offset, MesgNumActivity, "activity has NumSessions %d, file has %d sessions", am.NumSessions, len(v.sessions))
+		}
+	}
+	for _, s := range v.sessions {
+		sm := s.msg.(SessionMsg)
+		if sm.NumLaps == 0xFFFF || sm.FirstLapIndex == 0xFFFF {
+			continue
+		}
+		first, n := int(sm.FirstLapIndex), int(sm.NumLaps)
+		if first+n > len(v.laps) {
+			v.add(SeverityError, s.offset, MesgNumSession, "session laps %d to %d, file has %d laps", first, first+n-1, len(v.laps))
+			continue
+		}
+		var (
+			timer, distance        float64
+			validTimer, validDist  = true, true
+			start, end             = sm.StartTime, sm.Timestamp
+			outside                int
+			sessionTimer, sessDist = sm.GetTotalTimerTimeScaled(), sm.GetTotalDistanceScaled()
+		)
+		for _, l := range v.laps[first : first+n] {
+			lm := l.msg.(LapMsg)
+			t, d := lm.GetTotalTimerTimeScaled(), lm.GetTotalDistanceScaled()
+			validTimer = validTimer && !math.IsNaN(t)
+			validDist = validDist && !math.IsNaN(d)
+			timer += t
+			distance += d
+			if !IsBaseTime(lm.StartTime) && !IsBaseTime(start) && lm.StartTime.Before(start) ||
+				!IsBaseTime(lm.Timestamp) && !IsBaseTime(end) && lm.Timestamp.After(end) {
+				outside++
+			}
+		}
+		if validTimer && !math.IsNaN(sessionTimer) && math.Abs(timer-sessionTimer) > 1 {
+			v.add(SeverityWarning, s.offset, MesgNumSession, "session timer time %.3f s, its laps sum to %.3f s", sessionTimer, timer)
+		}
+		if validDist && !math.IsNaN(sessDist) && math.Abs(distance-sessDist) > math.Max(1, sessDist*0.001) {
+			v.add(SeverityWarning, s.offset, MesgNumSession, "session distance %.2f m, its laps sum to %.2f m", sessDist, distance)
+		}
+		if outside > 0 {
+			v.add(SeverityWarning, s.offset, MesgNumSession, "%d of the session's laps are outside its time range", outside)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package fit_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tormoder/fit"
+)
+
+func validate(t *testing.T, path ...string) []fit.ValidationIssue {
+	t.Helper()
+	f, err := os.Open(filepath.Join(append([]string{"testdata"}, path...)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return fit.Validate(f)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		path     []string
+		errors   int
+		offset   int64 // Offset of the first error.
+		mesgNums []fit.MesgNum
+	}{
+		{
+			path:   []string{"corrupt", "activity-filecrc.fit"},
+			errors: 1,
+			offset: -1,
+		},
+		{
+			path:     []string{"corrupt", "activity-unexpected-eof.fit"},
+			errors:   2,
+			offset:   751,
+			mesgNums: []fit.MesgNum{fit.MesgNumActivity, fit.MesgNumActivity},
+		},
+	}
+	for _, test := range tests {
+		errs := validationErrors(t, test.path...)
+		name := filepath.Join(test.path...)
+		if len(errs) != test.errors {
+			t.Errorf("%s: got %d errors, want %d: %v", name, len(errs), test.errors, errs)
+			continue
+		}
+		if len(errs) > 0 && errs[0].Offset != test.offset {
+			t.Errorf("%s: got first error at offset %d, want %d", name, errs[0].Offset, test.offset)
+		}
+		for i, mn := range test.mesgNums {
+			if errs[i].MesgNum != mn {
+				t.Errorf("%s: error %d: got message %v, want %v", name, i, errs[i].MesgNum, mn)
+			}
+		}
+	}
+}
+
+func validationErrors(t *testing.T, path ...string) []fit.ValidationIssue {
+	t.Helper()
+	var errs []fit.ValidationIssue
+	for _, issue := range validate(t, path...) {
+		if issue.Severity == fit.SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+func TestValidateValidFiles(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "*.fit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		rel, _ := filepath.Rel("testdata", path)
+		if filepath.Dir(rel) == "corrupt" {
+			continue
+		}
+		if errs := validationErrors(t, rel); len(errs) > 0 {
+			t.Errorf("%s: got %d errors, want none: %v", rel, len(errs), errs)
+		}
+	}
+}
+
+func TestValidateMultisport(t *testing.T) {
+	// Lap indices restart for each session, and record timestamps step
+	// back a second twice.
+	for _, issue := range validate(t, "me", "activity-large-fenxi2-multisport.fit") {
+		if issue.Severity != fit.SeverityInfo {
+			t.Errorf("got %v", issue)
+		}
+	}
+}
+
+func TestValidateDynamicField(t *testing.T) {
+	for _, issue := range validate(t, "fitsdk", "Activity.fit") {
+		if issue.MesgNum == fit.MesgNumEvent && issue.Severity != fit.SeverityInfo {
+			t.Errorf("got %v", issue)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package fit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// WorkoutUnit is the unit of a workout step value.
+type WorkoutUnit byte
+
+// Units of workout step values.
+const (
+	WorkoutUnitNone            WorkoutUnit = iota // Raw value of an uninterpreted type.
+	WorkoutUnitBpm                                // Heart rate in beats per minute.
+	WorkoutUnitPercentMaxHR                       // Percent of the maximum heart rate.
+	WorkoutUnitWatts                              // Power in watts.
+	WorkoutUnitPercentFTP                         // Percent of the functional threshold power.
+	WorkoutUnitMetersPerSecond                    // Speed.
+	WorkoutUnitRpm                                // Cadence in revolutions, strides or strokes per minute.
+)
+
+var workoutUnitStrings = [...]string{"", "bpm", "% HRmax", "W", "% FTP", "m/s", "rpm"}
+
+func (u WorkoutUnit) String() string {
+	if int(u) < len(workoutUnitStrings) {
+		return workoutUnitStrings[u]
+	}
+	return "WorkoutUnit(" + strconv.Itoa(int(u)) + ")"
+}
+
+// WorkoutValue is a workout step value with its unit.
+type WorkoutValue struct {
+	Value float64
+	Unit  WorkoutUnit
+}
+
+func (v WorkoutValue) String() string {
+	s := strconv.FormatFloat(v.Value, 'f', -1, 64)
+	switch v.Unit {
+	case WorkoutUnitNone:
+		return s
+	case WorkoutUnitPercentMaxHR, WorkoutUnitPercentFTP:
+		return s + v.Unit.String()
+	}
+	return s + " " + v.Unit.String()
+}
+
+// workoutHrValue interprets a workout_hr value: a percentage of the maximum
+// heart rate up to 100, and bpm offset by 100 above.
+func workoutHrValue(v uint32) WorkoutValue {
+	if v > uint32(WorkoutHrBpmOffset) {
+		return WorkoutValue{float64(v - uint32(WorkoutHrBpmOffset)), WorkoutUnitBpm}
+	}
+	return WorkoutValue{float64(v), WorkoutUnitPercentMaxHR}
+}
+
+// workoutPowerValue interprets a workout_power value: a percentage of the
+// functional threshold power up to 1000, and watts offset by 1000 above.
+func workoutPowerValue(v uint32) WorkoutValue {
+	if v > uint32(WorkoutPowerWattsOffset) {
+		return WorkoutValue{float64(v - uint32(WorkoutPowerWattsOffset)), WorkoutUnitWatts}
+	}
+	return WorkoutValue{float64(v), WorkoutUnitPercentFTP}
+}
+
+// WorkoutDuration is the interpreted duration of a workout step, which is
+// the condition ending the step. Only the fields relevant for Type are set.
+type WorkoutDuration struct {
+	Type WktStepDuration
+
+	Time     time.Duration // Time, RepetitionTime and RepeatUntilTime.
+	Distance float64       // m; Distance and RepeatUntilDistance.
+	Calories uint32        // kcal; Calories and RepeatUntilCalories.
+
+	// Value is the heart rate or power of the HrLessThan, HrGreaterThan,
+	// PowerLessThan and PowerGreaterThan conditions and their repeat
+	// variants, or the raw value of other types.
+	Value WorkoutValue
+
+	// RepeatFrom is the index of the first repeated step of a repeat
+	// step, and -1 for other steps.
+	RepeatFrom int
+
+	// Repeat is the number of times steps are repeated in total by a
+	// RepeatUntilStepsCmplt step.
+	Repeat int
+}
+
+// IsRepeat reports whether d is the duration of a repeat step.
+func (d WorkoutDuration) IsRepeat() bool {
+	return d.RepeatFrom >= 0
+}
+
+func (d WorkoutDuration) String() string {
+	switch d.Type {
+	case WktStepDurationTime, WktStepDurationRepetitionTime:
+		return d.Time.String()
+	case WktStepDurationDistance:
+		return strconv.FormatFloat(d.Distance, 'f', -1, 64) + " m"
+	case WktStepDurationCalories:
+		return strconv.FormatUint(uint64(d.Calories), 10) + " kcal"
+	case WktStepDurationOpen:
+		return "open"
+	case WktStepDurationHrLessThan, WktStepDurationPowerLessThan:
+		return "until < " + d.Value.String()
+	case WktStepDurationHrGreaterThan, WktStepDurationPowerGreaterThan:
+		return "until > " + d.Value.String()
+	case WktStepDurationRepeatUntilStepsCmplt:
+		return fmt.Sprintf("repeat from step %d, %d times", d.RepeatFrom, d.Repeat)
+	case WktStepDurationRepeatUntilTime:
+		return fmt.Sprintf("repeat from step %d until %v", d.RepeatFrom, d.Time)
+	case WktStepDurationRepeatUntilDistance:
+		return fmt.Sprintf("repeat from step %d until %g m", d.RepeatFrom, d.Distance)
+	case WktStepDurationRepeatUntilCalories:
+		return fmt.Sprintf("repeat from step %d until %d kcal", d.RepeatFrom, d.Calories)
+	case WktStepDurationRepeatUntilHrLessThan, WktStepDurationRepeatUntilPowerLessThan:
+		return fmt.Sprintf("repeat from step %d until < %v", d.RepeatFrom, d.Value)
+	case WktStepDurationRepeatUntilHrGreaterThan, WktStepDurationRepeatUntilPowerGreaterThan:
+		return fmt.Sprintf("repeat from step %d until > %v", d.RepeatFrom, d.Value)
+	}
+	return fmt.Sprintf("%v %v", d.Type, d.Value)
+}
+
+// Duration returns the interpreted duration of x. Heart rates and powers
+// are resolved to bpm or watts, or to percentages of the maximum heart rate
+// or the functional threshold power. For repeat steps, the target value
+// holds the number of repetitions or the value ending the repeat.
+func (x *WorkoutStepMsg) Duration() WorkoutDuration {
+	d := WorkoutDuration{Type: x.DurationType, RepeatFrom: -1}
+	switch x.DurationType {
+	case WktStepDurationTime, WktStepDurationRepetitionTime:
+		d.Time = time.Duration(x.DurationValue) * time.Millisecond
+	case WktStepDurationDistance:
+		d.Distance = float64(x.DurationValue) / 100
+	case WktStepDurationCalories:
+		d.Calories = x.DurationValue
+	case WktStepDurationOpen:
+	case WktStepDurationHrLessThan, WktStepDurationHrGreaterThan:
+		d.Value = workoutHrValue(x.DurationValue)
+	case WktStepDurationPowerLessThan, WktStepDurationPowerGreaterThan:
+		d.Value = workoutPowerValue(x.DurationValue)
+	case WktStepDurationRepeatUntilStepsCmplt, WktStepDurationRepeatUntilTime,
+		WktStepDurationRepeatUntilDistance, WktStepDurationRepeatUntilCalories,
+		WktStepDurationRepeatUntilHrLessThan, WktStepDurationRepeatUntilHrGreaterThan,
+		WktStepDurationRepeatUntilPowerLessThan, WktStepDurationRepeatUntilPowerGreaterThan:
+		d.RepeatFrom = int(x.DurationValue)
+		switch x.DurationType {
+		case WktStepDurationRepeatUntilStepsCmplt:
+			d.Repeat = int(x.TargetValue)
+		case WktStepDurationRepeatUntilTime:
+			d.Time = time.Duration(x.TargetValue) * time.Millisecond
+		case WktStepDurationRepeatUntilDistance:
+			d.Distance = float64(x.TargetValue) / 100
+		case WktStepDurationRepeatUntilCalories:
+			d.Calories = x.TargetValue
+		case WktStepDurationRepeatUntilHrLessThan, WktStepDurationRepeatUntilHrGreaterThan:
+			d.Value = workoutHrValue(x.TargetValue)
+		default:
+			d.Value = workoutPowerValue(x.TargetValue)
+		}
+	default:
+		d.Value = WorkoutValue{Value: float64(x.DurationValue)}
+	}
+	return d
+}
+
+// WorkoutTarget is the interpreted target of a workout step: a zone, or a
+// custom range from Low to High. The target of repeat steps and steps
+// without a target has type Open.
+type WorkoutTarget struct {
+	Type       WktStepTarget
+	Zone       int // 0 for a custom range.
+	Low, High  WorkoutValue
+	SwimStroke SwimStroke // For SwimStroke targets.
+}
+
+// IsRange reports whether t is a custom range.
+func (t WorkoutTarget) IsRange() bool {
+	return t.Type != WktStepTargetOpen && t.Type != WktStepTargetSwimStroke && t.Zone == 0
+}
+
+func (t WorkoutTarget) String() string {
+	switch {
+	case t.Type == WktStepTargetOpen:
+		return "open"
+	case t.Type == WktStepTargetSwimStroke:
+		return t.SwimStroke.String()
+	case t.Zone != 0:
+		return fmt.Sprintf("%v zone %d", t.Type, t.Zone)
+	}
+	return fmt.Sprintf("%v %v-%v", t.Type, t.Low, t.High)
+}
+
+// Target returns the interpreted target of x. Heart rates and powers of
+// custom ranges are resolved to bpm or watts, or to percentages of the
+// maximum heart rate or the functional threshold power, and speeds are
+// scaled to m/s.
+func (x *WorkoutStepMsg) Target() WorkoutTarget {
+	t := WorkoutTarget{Type: x.TargetType}
+	if x.Duration().IsRepeat() || x.TargetType == WktStepTargetInvalid {
+		t.Type = WktStepTargetOpen
+		return t
+	}
+	switch x.TargetType {
+	case WktStepTargetOpen:
+		return t
+	case WktStepTargetSwimStroke:
+		t.SwimStroke = SwimStroke(x.TargetValue)
+		return t
+	}
+	if x.TargetValue != 0 && x.TargetValue != 0xFFFFFFFF {
+		t.Zone = int(x.TargetValue)
+		return t
+	}
+	low, high := x.CustomTargetValueLow, x.CustomTargetValueHigh
+	switch x.TargetType {
+	case WktStepTargetHeartRate, WktStepTargetHeartRateLap:
+		t.Low, t.High = workoutHrValue(low), workoutHrValue(high)
+	case WktStepTargetPower, WktStepTargetPowerLap:
+		t.Low, t.High = workoutPowerValue(low), workoutPowerValue(high)
+	case WktStepTargetSpeed, WktStepTargetSpeedLap:
+		t.Low = WorkoutValue{float64(low) / 1000, WorkoutUnitMetersPerSecond}
+		t.High = WorkoutValue{float64(high) / 1000, WorkoutUnitMetersPerSecond}
+	case WktStepTargetCadence:
+		t.Low = WorkoutValue{float64(low), WorkoutUnitRpm}
+		t.High = WorkoutValue{float64(high), WorkoutUnitRpm}
+	default:
+		t.Low = WorkoutValue{Value: float64(low)}
+		t.High = WorkoutValue{Value: float64(high)}
+	}
+	return t
+}
+
+// maxExpandedSteps limits the length of expanded workouts.
+const maxExpandedSteps = 10000
+
+// ExpandedWorkoutStep is a step of a workout with its repeats expanded.
+type ExpandedWorkoutStep struct {
+	Step *WorkoutStepMsg
+
+	// Index is the index of Step in the workout.
+	Index int
+
+	// Repeat is the innermost repeat step repeating Step, or nil if Step is
+	// not repeated, and Iteration is the 1-based iteration of that repeat.
+	Repeat    *WorkoutStepMsg
+	Iteration int
+}
+
+// ExpandWorkoutSteps expands the repeat steps of steps into a flat
+// timeline of the steps performed, without the repeat steps themselves.
+// Steps are identified by their position in steps, which their message
+// index should match. The steps of a repeat with a fixed count are
+// repeated that many times; the steps of a repeat ending on a condition,
+// such as a time or heart rate, are included once.
+func ExpandWorkoutSteps(steps []*WorkoutStepMsg) ([]ExpandedWorkoutStep, error) {
+	var (
+		out   []ExpandedWorkoutStep
+		start = make([]int, len(steps)) // Position in out of each step.
+	)
+	for i, s := range steps {
+		if s == nil {
+			return nil, fmt.Errorf("workout step %d is nil", i)
+		}
+		start[i] = len(out)
+		d := s.Duration()
+		if !d.IsRepeat() {
+			out = append(out, ExpandedWorkoutStep{Step: s, Index: i})
+			continue
+		}
+		if d.RepeatFrom >= i {
+			return nil, fmt.Errorf("workout step %d repeats from later step %d", i, d.RepeatFrom)
+		}
+		block := out[start[d.RepeatFrom]:]
+		for j := range block {
+			if block[j].Repeat == nil {
+				block[j].Repeat, block[j].Iteration = s, 1
+			}
+		}
+		if d.Type != WktStepDurationRepeatUntilStepsCmplt {
+			continue
+		}
+		if len(out)+len(block)*(d.Repeat-1) > maxExpandedSteps {
+			return nil, errors.New("expanded workout has too many steps")
+		}
+		block = append([]ExpandedWorkoutStep(nil), block...)
+		for n := 2; n <= d.Repeat; n++ {
+			for _, e := range block {
+				if e.Repeat == s {
+					e.Iteration = n
+				}
+				out = append(out, e)
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,212 @@
+package workout
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// node is a workout step, or a repeat step with the steps it repeats.
+type node struct {
+	msg      *fit.WorkoutStepMsg
+	first    int // Index of the first step of the node.
+	children []node
+}
+
+// Format formats the steps of w in the workout text format described by
+// Parse, on one line. Steps and targets that the format cannot express,
+// such as repeats ending on a condition, step notes, and intensities other
+// than warm-up, active, rest and cool-down, are reported as errors.
+func Format(w *fit.WorkoutFile) (string, error) {
+	var nodes []node
+	for i, s := range w.WorkoutSteps {
+		if s == nil {
+			return "", fmt.Errorf("workout step %d is nil", i)
+		}
+		d := s.Duration()
+		if !d.IsRepeat() {
+			nodes = append(nodes, node{msg: s, first: i})
+			continue
+		}
+		if d.Type != fit.WktStepDurationRepeatUntilStepsCmplt {
+			return "", fmt.Errorf("step %d: cannot format %v repeats", i, d.Type)
+		}
+		j := len(nodes)
+		for j > 0 && nodes[j-1].first >= d.RepeatFrom {
+			j--
+		}
+		if j == len(nodes) || nodes[j].first != d.RepeatFrom {
+			return "", fmt.Errorf("step %d: invalid repeat from step %d", i, d.RepeatFrom)
+		}
+		children := append([]node(nil), nodes[j:]...)
+		nodes = append(nodes[:j], node{msg: s, first: d.RepeatFrom, children: children})
+	}
+	if len(nodes) == 0 {
+		return "", errors.New("workout has no steps")
+	}
+
+	sport := fit.SportGeneric
+	if w.Workout != nil {
+		sport = w.Workout.Sport
+	}
+	var sb strings.Builder
+	if err := formatNodes(&sb, nodes, "; ", sport); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func formatNodes(sb *strings.Builder, nodes []node, sep string, sport fit.Sport) error {
+	for i, n := range nodes {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		if n.msg.Notes != "" {
+			return fmt.Errorf("step %d: cannot format notes", n.first)
+		}
+		if n.children != nil {
+			fmt.Fprintf(sb, "%dx(", n.msg.Duration().Repeat)
+			if err := formatNodes(sb, n.children, ", ", sport); err != nil {
+				return err
+			}
+			sb.WriteString(")")
+		} else if err := formatStep(sb, n.msg, sport); err != nil {
+			return fmt.Errorf("step %d: %v", n.first, err)
+		}
+		if name := n.msg.WktStepName; name != "" {
+			if strings.ContainsAny(name, "\"\n") {
+				return fmt.Errorf("step %d: cannot format name %q", n.first, name)
+			}
+			fmt.Fprintf(sb, " %q", name)
+		}
+	}
+	return nil
+}
+
+var intensityKeywords = map[fit.Intensity]string{
+	fit.IntensityInvalid:  "",
+	fit.IntensityActive:   "",
+	fit.IntensityWarmup:   "warmup ",
+	fit.IntensityRest:     "recover ",
+	fit.IntensityCooldown: "cooldown ",
+}
+
+func formatStep(sb *strings.Builder, s *fit.WorkoutStepMsg, sport fit.Sport) error {
+	keyword, ok := intensityKeywords[s.Intensity]
+	if !ok {
+		return fmt.Errorf("cannot format intensity %d", s.Intensity)
+	}
+	sb.WriteString(keyword)
+
+	d := s.Duration()
+	switch d.Type {
+	case fit.WktStepDurationTime:
+		sb.WriteString(formatTime(d.Time))
+	case fit.WktStepDurationDistance:
+		if math.Mod(d.Distance, 1000) == 0 {
+			fmt.Fprintf(sb, "%gkm", d.Distance/1000)
+		} else {
+			fmt.Fprintf(sb, "%g meters", d.Distance)
+		}
+	case fit.WktStepDurationCalories:
+		fmt.Fprintf(sb, "%dkcal", d.Calories)
+	case fit.WktStepDurationOpen:
+		sb.WriteString("open")
+	case fit.WktStepDurationHrLessThan, fit.WktStepDurationPowerLessThan:
+		sb.WriteString("until < " + formatValue(d.Value))
+	case fit.WktStepDurationHrGreaterThan, fit.WktStepDurationPowerGreaterThan:
+		sb.WriteString("until > " + formatValue(d.Value))
+	default:
+		return fmt.Errorf("cannot format %v durations", d.Type)
+	}
+
+	t := s.Target()
+	switch {
+	case t.Type == fit.WktStepTargetOpen:
+	case t.Zone != 0:
+		prefix, ok := map[fit.WktStepTarget]string{
+			fit.WktStepTargetHeartRate: "",
+			fit.WktStepTargetPower:     "power ",
+			fit.WktStepTargetSpeed:     "speed ",
+		}[t.Type]
+		if !ok {
+			return fmt.Errorf("cannot format %v zones", t.Type)
+		}
+		fmt.Fprintf(sb, " %sZ%d", prefix, t.Zone)
+	case t.Type == fit.WktStepTargetSpeed:
+		if t.Low.Value <= 0 || t.High.Value <= 0 {
+			return errors.New("cannot format zero speed as pace")
+		}
+		dist, unit := 1000.0, "/km"
+		if sport == fit.SportSwimming {
+			dist, unit = 100, "/100m"
+		}
+		slow := formatClock(time.Duration(dist / t.Low.Value * float64(time.Second)))
+		fast := formatClock(time.Duration(dist / t.High.Value * float64(time.Second)))
+		sb.WriteString(" @ " + slow)
+		if fast != slow {
+			sb.WriteString("-" + fast)
+		}
+		sb.WriteString(unit)
+	case t.Type == fit.WktStepTargetHeartRate, t.Type == fit.WktStepTargetPower, t.Type == fit.WktStepTargetCadence:
+		if t.Low.Unit != t.High.Unit {
+			return fmt.Errorf("cannot format %v range in mixed units", t.Type)
+		}
+		sb.WriteString(" @ ")
+		if t.Low != t.High {
+			fmt.Fprintf(sb, "%g-", t.Low.Value)
+		}
+		sb.WriteString(formatValue(t.High))
+	default:
+		return fmt.Errorf("cannot format %v targets", t.Type)
+	}
+	return nil
+}
+
+func formatValue(v fit.WorkoutValue) string {
+	switch v.Unit {
+	case fit.WorkoutUnitBpm:
+		return fmt.Sprintf("%gbpm", v.Value)
+	case fit.WorkoutUnitPercentMaxHR:
+		return fmt.Sprintf("%g%% HRmax", v.Value)
+	case fit.WorkoutUnitWatts:
+		return fmt.Sprintf("%gW", v.Value)
+	case fit.WorkoutUnitPercentFTP:
+		return fmt.Sprintf("%g%% FTP", v.Value)
+	case fit.WorkoutUnitRpm:
+		return fmt.Sprintf("%grpm", v.Value)
+	}
+	return fmt.Sprintf("%g", v.Value)
+}
+
+// formatTime formats d as hours, minutes and seconds, such as 1h30m.
+func formatTime(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d == 0 {
+		return "0s"
+	}
+	var sb strings.Builder
+	for _, u := range []struct {
+		d    time.Duration
+		name string
+	}{{time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / u.d; n > 0 {
+			fmt.Fprintf(&sb, "%d%s", n, u.name)
+			d -= n * u.d
+		}
+	}
+	return sb.String()
+}
+
+// formatClock formats d as m:ss, or h:mm:ss.
+func formatClock(d time.Duration) string {
+	s := int(d.Round(time.Second) / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -0,0 +1,600 @@
+package workout
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/tormoder/fit"
+)
+
+// A SyntaxError is an error in workout text, at a 1-based line and column.
+type SyntaxError struct {
+	Line, Col int
+	Msg       string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Col, e.Msg)
+}
+
+// Parse parses a workout written in the workout text format, such as
+//
+//	warmup 10m Z2; 5x(3m @ 105% FTP, recover 2m @ 55%); cooldown 10m Z1
+//
+// A workout is a list of steps and repeats, separated by semicolons, commas
+// or newlines. Text from # to the end of a line is a comment. A repeat is
+// a count, an x and a parenthesized list of steps, optionally followed by a
+// quoted name. A step is an optional intensity, a duration, an optional
+// target, optionally preceded by @, and an optional quoted name:
+//
+//	intensity  warmup, interval or active (the default), recover or rest,
+//	           cooldown
+//	duration   time: 90s, 10m, 10min, 1h30m, 3:30
+//	           distance: 400 meters, 5km, 1mi, 100yd
+//	           energy: 300kcal
+//	           open, or until a heart rate or power: until < 130bpm,
+//	           until > 80% HRmax, until < 200W, until > 90% FTP
+//	target     heart rate: Z2, HR Z2, 140-150bpm, 70-80% HRmax
+//	           power: power Z4, 250-280W, 95-105% FTP, 55% (of FTP)
+//	           pace: 4:30-4:15/km, 7:00/mi, 1:45/100m, 1:40/100yd
+//	           speed zone: speed Z3
+//	           cadence: 85-95rpm
+//
+// Note that m is minutes; metres are written as meters or metres. Units
+// and keywords are case insensitive. A single target value is a range with
+// equal bounds.
+func Parse(name string, sport fit.Sport, text string) (*Workout, error) {
+	p := &parser{lex: lexer{src: []rune(text), line: 1, col: 1}}
+	p.next()
+	steps, err := p.list(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, p.errorf("workout has no steps")
+	}
+	return New(name, sport, steps...), nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNumber
+	tokClock // A time such as 3:30 or 1:00:00.
+	tokWord
+	tokString
+	tokSep // ; , or newline.
+	tokPunct
+)
+
+type token struct {
+	kind      tokKind
+	text      string
+	num       float64       // tokNumber.
+	clock     time.Duration // tokClock.
+	line, col int
+}
+
+type lexer struct {
+	src       []rune
+	pos       int
+	line, col int
+}
+
+func (l *lexer) peek() rune {
+	if l.pos < len(l.src) {
+		return l.src[l.pos]
+	}
+	return 0
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) token() (token, error) {
+	for l.pos < len(l.src) {
+		r := l.peek()
+		if r == '#' {
+			for l.pos < len(l.src) && l.peek() != '\n' {
+				l.advance()
+			}
+			continue
+		}
+		if r == '\n' || !unicode.IsSpace(r) {
+			break
+		}
+		l.advance()
+	}
+	t := token{line: l.line, col: l.col}
+	if l.pos == len(l.src) {
+		return t, nil
+	}
+	start := l.pos
+	r := l.advance()
+	switch {
+	case r == '\n' || r == ';' || r == ',':
+		t.kind, t.text = tokSep, string(r)
+	case r == '"':
+		for l.pos < len(l.src) && l.peek() != '"' && l.peek() != '\n' {
+			l.advance()
+		}
+		if l.peek() != '"' {
+			return t, &SyntaxError{t.line, t.col, "unterminated name"}
+		}
+		l.advance()
+		t.kind, t.text = tokString, string(l.src[start+1:l.pos-1])
+	case unicode.IsDigit(r) || r == '.':
+		for unicode.IsDigit(l.peek()) || l.peek() == '.' || l.peek() == ':' {
+			l.advance()
+		}
+		t.text = string(l.src[start:l.pos])
+		if strings.Contains(t.text, ":") {
+			d, ok := parseClock(t.text)
+			if !ok {
+				return t, &SyntaxError{t.line, t.col, fmt.Sprintf("invalid time %q", t.text)}
+			}
+			t.kind, t.clock = tokClock, d
+			break
+		}
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return t, &SyntaxError{t.line, t.col, fmt.Sprintf("invalid number %q", t.text)}
+		}
+		t.kind, t.num = tokNumber, n
+	case unicode.IsLetter(r):
+		for unicode.IsLetter(l.peek()) {
+			l.advance()
+		}
+		t.kind, t.text = tokWord, strings.ToLower(string(l.src[start:l.pos]))
+	default:
+		t.kind, t.text = tokPunct, string(r)
+	}
+	return t, nil
+}
+
+// parseClock parses m:ss or h:mm:ss.
+func parseClock(s string) (time.Duration, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+	var d time.Duration
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && (len(p) != 2 || n >= 60)) {
+			return 0, false
+		}
+		d = d*60 + time.Duration(n)
+	}
+	return d * time.Second, true
+}
+
+type parser struct {
+	lex lexer
+	tok token
+	err error
+}
+
+func (p *parser) next() {
+	if p.err != nil {
+		return
+	}
+	p.tok, p.err = p.lex.token()
+	if p.err != nil {
+		p.tok = token{kind: tokEOF, line: p.tok.line, col: p.tok.col}
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	return &SyntaxError{p.tok.line, p.tok.col, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isWord(words ...string) bool {
+	if p.tok.kind != tokWord {
+		return false
+	}
+	for _, w := range words {
+		if p.tok.text == w {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) isPunct(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.text == s
+}
+
+// list parses steps and repeats until the end of the text, or a closing
+// parenthesis if nested.
+func (p *parser) list(nested bool) ([]*Step, error) {
+	var steps []*Step
+	for {
+		for p.tok.kind == tokSep {
+			p.next()
+		}
+		if p.tok.kind == tokEOF || p.isPunct(")") {
+			if p.err != nil {
+				return nil, p.err
+			}
+			if !nested && p.isPunct(")") {
+				return nil, p.errorf("unexpected )")
+			}
+			if nested && p.tok.kind == tokEOF {
+				return nil, p.errorf("missing )")
+			}
+			return steps, nil
+		}
+		s, err := p.item()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+		if p.tok.kind != tokSep && p.tok.kind != tokEOF && !p.isPunct(")") {
+			return nil, p.errorf("unexpected %q after step", p.tok.text)
+		}
+	}
+}
+
+func (p *parser) item() (*Step, error) {
+	if p.tok.kind == tokNumber {
+		count, line, col := p.tok.num, p.tok.line, p.tok.col
+		save := *p
+		p.next()
+		if p.isWord("x") {
+			if count < 1 || count != math.Trunc(count) {
+				return nil, &SyntaxError{line, col, fmt.Sprintf("invalid repeat count %v", count)}
+			}
+			p.next()
+			if !p.isPunct("(") {
+				return nil, p.errorf("expected ( after %vx", count)
+			}
+			p.next()
+			steps, err := p.list(true)
+			if err != nil {
+				return nil, err
+			}
+			if len(steps) == 0 {
+				return nil, p.errorf("repeat has no steps")
+			}
+			p.next()
+			s := Repeat(int(count), steps...)
+			if p.tok.kind == tokString {
+				s.Name(p.tok.text)
+				p.next()
+			}
+			return s, nil
+		}
+		*p = save
+	}
+	return p.step()
+}
+
+var intensities = map[string]fit.Intensity{
+	"warmup":   fit.IntensityWarmup,
+	"interval": fit.IntensityActive,
+	"active":   fit.IntensityActive,
+	"recover":  fit.IntensityRest,
+	"rest":     fit.IntensityRest,
+	"cooldown": fit.IntensityCooldown,
+}
+
+func (p *parser) step() (*Step, error) {
+	intensity := fit.IntensityActive
+	if i, ok := intensities[p.tok.text]; ok && p.tok.kind == tokWord {
+		intensity = i
+		p.next()
+	}
+	line, col := p.tok.line, p.tok.col
+	d, err := p.duration()
+	if err != nil {
+		return nil, err
+	}
+	if d.err != nil {
+		return nil, &SyntaxError{line, col, d.err.Error()}
+	}
+	s := newStep(intensity, d)
+	at := p.isPunct("@")
+	if at {
+		p.next()
+	}
+	if at || p.tok.kind != tokSep && p.tok.kind != tokEOF && p.tok.kind != tokString && !p.isPunct(")") {
+		line, col := p.tok.line, p.tok.col
+		if err := p.target(s); err != nil {
+			if _, ok := err.(*SyntaxError); !ok {
+				// An invalid value reported by the step.
+				err = &SyntaxError{line, col, err.Error()}
+			}
+			return nil, err
+		}
+	}
+	if p.tok.kind == tokString {
+		s.Name(p.tok.text)
+		p.next()
+	}
+	return s, nil
+}
+
+var (
+	timeUnits = map[string]time.Duration{
+		"h": time.Hour, "hr": time.Hour,
+		"m": time.Minute, "min": time.Minute,
+		"s": time.Second, "sec": time.Second,
+	}
+	distanceUnits = map[string]float64{
+		"meters": 1, "metres": 1, "meter": 1, "metre": 1,
+		"km": 1000, "mi": 1609.344, "yd": 0.9144,
+	}
+)
+
+func (p *parser) duration() (Duration, error) {
+	switch {
+	case p.tok.kind == tokClock:
+		d := p.tok.clock
+		p.next()
+		return Time(d), nil
+	case p.isWord("open", "lap"):
+		p.next()
+		return Open(), nil
+	case p.isWord("until"):
+		return p.until()
+	case p.tok.kind != tokNumber:
+		return Duration{}, p.errorf("expected duration")
+	}
+
+	n, line, col := p.tok.num, p.tok.line, p.tok.col
+	p.next()
+	if p.tok.kind != tokWord {
+		return Duration{}, p.errorf("missing unit after %v", n)
+	}
+	if m, ok := distanceUnits[p.tok.text]; ok {
+		p.next()
+		return Distance(n * m), nil
+	}
+	if p.isWord("kcal", "cal") {
+		p.next()
+		if n > maxValue {
+			return Duration{}, &SyntaxError{line, col, fmt.Sprintf("invalid energy %v kcal", n)}
+		}
+		return Calories(uint32(math.Round(n))), nil
+	}
+	var d time.Duration
+	for {
+		u, ok := timeUnits[p.tok.text]
+		if !ok || p.tok.kind != tokWord {
+			return Duration{}, p.errorf("unknown unit %q", p.tok.text)
+		}
+		d += time.Duration(n * float64(u))
+		p.next()
+		if p.tok.kind != tokNumber {
+			break
+		}
+		// Compound times such as 1h30m.
+		save := *p
+		n = p.tok.num
+		p.next()
+		if _, ok := timeUnits[p.tok.text]; !ok || p.tok.kind != tokWord {
+			*p = save
+			break
+		}
+	}
+	return Time(d), nil
+}
+
+// until parses until < or > a heart rate or power.
+func (p *parser) until() (Duration, error) {
+	p.next()
+	less := p.isPunct("<")
+	if !less && !p.isPunct(">") {
+		return Duration{}, p.errorf("expected < or > after until")
+	}
+	p.next()
+	n, ok := p.value()
+	if !ok || !n.isNum {
+		return Duration{}, p.errorf("expected value after until")
+	}
+	var d Duration
+	switch {
+	case p.isWord("bpm"):
+		if err := checkInt(maxBpm, "bpm", n); err != nil {
+			return Duration{}, err
+		}
+		d = HRAbove(uint8(n.num))
+		if less {
+			d = HRBelow(uint8(n.num))
+		}
+	case p.isWord("w"):
+		if err := checkInt(maxWatts, "W", n); err != nil {
+			return Duration{}, err
+		}
+		d = PowerAbove(uint16(n.num))
+		if less {
+			d = PowerBelow(uint16(n.num))
+		}
+	case p.isPunct("%"):
+		p.next()
+		switch {
+		case p.isWord("hrmax", "hr", "mhr"):
+			d = HRAbovePercent(n.num)
+			if less {
+				d = HRBelowPercent(n.num)
+			}
+		case p.isWord("ftp"):
+			d = PowerAbovePercent(n.num)
+			if less {
+				d = PowerBelowPercent(n.num)
+			}
+		default:
+			return Duration{}, p.errorf("expected HRmax or FTP after %%")
+		}
+	default:
+		return Duration{}, p.errorf("expected bpm, W or %% after %v", n.num)
+	}
+	p.next()
+	if d.err != nil {
+		return Duration{}, &SyntaxError{n.line, n.col, d.err.Error()}
+	}
+	return d, nil
+}
+
+// Largest heart rate, power and cadence values. Powers are limited so that
+// they fit in 16 bits with the offset of 1000 W.
+const (
+	maxBpm   = 0xFF
+	maxWatts = 0xFFFF - 1000
+	maxRpm   = 0xFF
+)
+
+// checkInt returns an error at the first of values that is not an integer
+// of at most max.
+func checkInt(max float64, unit string, values ...value) error {
+	for _, v := range values {
+		if v.num > max || v.num != math.Trunc(v.num) {
+			return &SyntaxError{v.line, v.col, fmt.Sprintf("%v %s is not an integer of at most %v", v.num, unit, max)}
+		}
+	}
+	return nil
+}
+
+// value is a number or a clock time.
+type value struct {
+	num       float64
+	clock     time.Duration
+	isNum     bool
+	line, col int
+}
+
+func (p *parser) value() (value, bool) {
+	switch p.tok.kind {
+	case tokNumber:
+		v := value{num: p.tok.num, isNum: true, line: p.tok.line, col: p.tok.col}
+		p.next()
+		return v, true
+	case tokClock:
+		v := value{clock: p.tok.clock, line: p.tok.line, col: p.tok.col}
+		p.next()
+		return v, true
+	}
+	return value{}, false
+}
+
+func (p *parser) target(s *Step) error {
+	zoneTargets := map[string]func(int) *Step{
+		"z": s.HRZone, "hr": s.HRZone, "power": s.PowerZone, "speed": s.SpeedZone,
+	}
+	if p.tok.kind == tokWord {
+		set, ok := zoneTargets[p.tok.text]
+		if !ok {
+			return p.errorf("unknown target %q", p.tok.text)
+		}
+		if p.tok.text != "z" {
+			p.next()
+			if !p.isWord("z") {
+				return p.errorf("expected zone")
+			}
+		}
+		p.next()
+		if p.tok.kind != tokNumber {
+			return p.errorf("expected zone number")
+		}
+		set(int(p.tok.num))
+		p.next()
+		return s.err
+	}
+
+	low, ok := p.value()
+	if !ok {
+		return p.errorf("expected target")
+	}
+	high := low
+	if p.isPunct("-") {
+		p.next()
+		if high, ok = p.value(); !ok || high.isNum != low.isNum {
+			return p.errorf("invalid range")
+		}
+	}
+	if !low.isNum {
+		return p.pace(s, low.clock, high.clock)
+	}
+
+	switch {
+	case p.isWord("bpm"):
+		if err := checkInt(maxBpm, "bpm", low, high); err != nil {
+			return err
+		}
+		s.HR(uint8(low.num), uint8(high.num))
+	case p.isWord("w"):
+		if err := checkInt(maxWatts, "W", low, high); err != nil {
+			return err
+		}
+		s.Power(uint16(low.num), uint16(high.num))
+	case p.isWord("rpm"):
+		if err := checkInt(maxRpm, "rpm", low, high); err != nil {
+			return err
+		}
+		s.Cadence(uint8(low.num), uint8(high.num))
+	case p.isPunct("%"):
+		p.next()
+		if p.isWord("hrmax", "hr", "mhr") {
+			s.HRPercent(low.num, high.num)
+			break
+		}
+		s.PowerPercent(low.num, high.num)
+		if !p.isWord("ftp") {
+			return s.err
+		}
+	default:
+		return p.errorf("expected target unit")
+	}
+	p.next()
+	return s.err
+}
+
+// pace parses the distance of a pace target, such as /km or /100m.
+func (p *parser) pace(s *Step, slow, fast time.Duration) error {
+	if !p.isPunct("/") {
+		return p.errorf("expected / after pace")
+	}
+	p.next()
+	dist := 1.0
+	if p.tok.kind == tokNumber {
+		dist = p.tok.num
+		p.next()
+	}
+	var unit float64
+	switch {
+	case p.isWord("m"):
+		unit = 1
+	default:
+		if p.tok.kind == tokWord {
+			unit = distanceUnits[p.tok.text]
+		}
+	}
+	if unit == 0 {
+		return p.errorf("expected distance unit after /")
+	}
+	p.next()
+	if slow <= 0 || fast <= 0 {
+		return p.errorf("invalid pace")
+	}
+	m := dist * unit
+	s.Speed(m/slow.Seconds(), m/fast.Seconds())
+	return s.err
+}
@@ -0,0 +1,185 @@
+package workout_test
+
+import (
+	"testing"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/internal/fittest"
+	"github.com/tormoder/fit/workout"
+)
+
+func parseFile(t *testing.T, sport fit.Sport, text string) *fit.WorkoutFile {
+	t.Helper()
+	w, err := workout.Parse("test", sport, text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := w.File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf, err := f.Workout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wf
+}
+
+func TestParse(t *testing.T) {
+	wf := parseFile(t, fit.SportCycling, "10m Z2; 5x(3m @ 105% FTP, 2m @ 55%); 10m Z1")
+
+	const none = 0xFFFFFFFF
+	want := []fit.WorkoutStepMsg{
+		{DurationType: fit.WktStepDurationTime, DurationValue: 600000, TargetType: fit.WktStepTargetHeartRate, TargetValue: 2, CustomTargetValueLow: none, CustomTargetValueHigh: none, Intensity: fit.IntensityActive},
+		{DurationType: fit.WktStepDurationTime, DurationValue: 180000, TargetType: fit.WktStepTargetPower, TargetValue: 0, CustomTargetValueLow: 105, CustomTargetValueHigh: 105, Intensity: fit.IntensityActive},
+		{DurationType: fit.WktStepDurationTime, DurationValue: 120000, TargetType: fit.WktStepTargetPower, TargetValue: 0, CustomTargetValueLow: 55, CustomTargetValueHigh: 55, Intensity: fit.IntensityActive},
+		{DurationType: fit.WktStepDurationRepeatUntilStepsCmplt, DurationValue: 1, TargetType: fit.WktStepTargetOpen, TargetValue: 5, CustomTargetValueLow: none, CustomTargetValueHigh: none, Intensity: fit.IntensityInvalid},
+		{DurationType: fit.WktStepDurationTime, DurationValue: 600000, TargetType: fit.WktStepTargetHeartRate, TargetValue: 1, CustomTargetValueLow: none, CustomTargetValueHigh: none, Intensity: fit.IntensityActive},
+	}
+	if len(wf.WorkoutSteps) != len(want) {
+		t.Fatalf("got %d steps, want %d", len(wf.WorkoutSteps), len(want))
+	}
+	for i, got := range wf.WorkoutSteps {
+		want[i].MessageIndex = fit.MessageIndex(i)
+		want[i].Equipment = fit.WorkoutEquipmentInvalid
+		if *got != want[i] {
+			t.Errorf("step %d:\ngot  %+v\nwant %+v", i, *got, want[i])
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		sport fit.Sport
+		in    string
+		want  string
+	}{
+		{
+			fit.SportCycling,
+			"10m Z2; 5x(3m @ 105% FTP, 2m @ 55%); 10m Z1",
+			"10m Z2; 5x(3m @ 105% FTP, 2m @ 55% FTP); 10m Z1",
+		},
+		{
+			fit.SportRunning,
+			"warmup 15min @ 5:30/km \"easy\"\n" +
+				"6x(400 meters @ 3:50-3:40/km, recover 90s @ 120-140bpm) \"track\" # intervals\n" +
+				"cooldown until < 110bpm",
+			"warmup 15m @ 5:30/km \"easy\"; 6x(400 meters @ 3:50-3:40/km, recover 1m30s @ 120-140bpm) \"track\"; cooldown until < 110bpm",
+		},
+		{
+			fit.SportSwimming,
+			"400 meters @ 1:45/100m; 4x(100 meters @ 1:30-1:25/100m, rest 20s)",
+			"400 meters @ 1:45/100m; 4x(100 meters @ 1:30-1:25/100m, recover 20s)",
+		},
+		{
+			fit.SportCycling,
+			"1h30m @ 250-280W; 300kcal power Z3; open @ 85-95rpm; 5km @ 70-80% HRmax; until > 90% FTP",
+			"1h30m @ 250-280W; 300kcal power Z3; open @ 85-95rpm; 5km @ 70-80% HRmax; until > 90% FTP",
+		},
+		{
+			fit.SportCycling,
+			"2x(1m power Z5, 3x(10s power Z7, recover 20s))",
+			"2x(1m power Z5, 3x(10s power Z7, recover 20s))",
+		},
+	}
+	for _, test := range tests {
+		got, err := workout.Format(parseFile(t, test.sport, test.in))
+		if err != nil {
+			t.Errorf("%q: %v", test.in, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q:\ngot  %q\nwant %q", test.in, got, test.want)
+			continue
+		}
+		again, err := workout.Format(parseFile(t, test.sport, got))
+		if err != nil || again != got {
+			t.Errorf("%q: formatted again as %q, %v", got, again, err)
+		}
+	}
+}
+
+func TestFormatFile(t *testing.T) {
+	f := fittest.Decode(t, "fitsdk", "WorkoutRepeatSteps.fit")
+	wf, err := f.Workout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := workout.Format(wf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `warmup 1m Z2 "_A_"; 3x(500 meters power Z5 "B1_", 500 meters power Z3 "B2_") "Rep"; cooldown until < 125bpm power Z1 "_C_"`
+	if got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	step := func() *fit.WorkoutStepMsg {
+		s := fit.NewWorkoutStepMsg()
+		s.DurationType = fit.WktStepDurationOpen
+		s.TargetType = fit.WktStepTargetOpen
+		s.Intensity = fit.IntensityActive
+		return s
+	}
+	notes := step()
+	notes.Notes = "easy"
+	recovery := step()
+	recovery.Intensity = 4 // recovery in later profile versions.
+	repeat := step()
+	repeat.DurationType = fit.WktStepDurationRepeatUntilStepsCmplt
+	repeat.DurationValue = 0
+	repeat.TargetValue = 2
+	repeat.Notes = "twice"
+	tests := []struct {
+		steps []*fit.WorkoutStepMsg
+		want  string
+	}{
+		{[]*fit.WorkoutStepMsg{step(), notes}, "step 1: cannot format notes"},
+		{[]*fit.WorkoutStepMsg{recovery}, "step 0: cannot format intensity 4"},
+		{[]*fit.WorkoutStepMsg{step(), repeat}, "step 0: cannot format notes"},
+	}
+	for i, test := range tests {
+		_, err := workout.Format(&fit.WorkoutFile{WorkoutSteps: test.steps})
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%d: got %v, want %q", i, err, test.want)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "1:1: workout has no steps"},
+		{"10m @", "1:6: expected target"},
+		{"10m @ 5:00", "1:11: expected / after pace"},
+		{"5x(3m", "1:6: missing )"},
+		{"10m Z2\n10 furlongs", "2:4: unknown unit \"furlongs\""},
+		{"10m Z9", "1:5: HeartRate zone 9 is not in [1, 5]"},
+		{"until < 0 bpm", "1:9: heart rate of 0 bpm"},
+		{"until > 0.4W", "1:9: 0.4 W is not an integer of at most 64535"},
+		{"until > 256bpm", "1:9: 256 bpm is not an integer of at most 255"},
+		{"until > 101% HRmax", "1:9: heart rate percentage 101 is not in [0, 100]"},
+		{"until < 1000% FTP", "1:9: power percentage 1000 is not less than 1000"},
+		{"10m @ 120-256bpm", "1:11: 256 bpm is not an integer of at most 255"},
+		{"10m @ 0-150bpm", "1:7: heart rate of 0 bpm"},
+		{"10m @ 64536W", "1:7: 64536 W is not an integer of at most 64535"},
+		{"10m @ 90.5rpm", "1:7: 90.5 rpm is not an integer of at most 255"},
+		{"0s", "1:1: invalid time 0s"},
+		{"warmup 0km", "1:8: invalid distance 0 m"},
+		{"5000000000kcal", "1:1: invalid energy 5e+09 kcal"},
+	}
+	for _, test := range tests {
+		_, err := workout.Parse("test", fit.SportRunning, test.in)
+		if err == nil {
+			t.Errorf("%q: no error", test.in)
+			continue
+		}
+		if _, ok := err.(*workout.SyntaxError); !ok || err.Error() != test.want {
+			t.Errorf("%q: got %T %q, want %q", test.in, err, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package workout
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// A Duration is the condition ending a workout step.
+type Duration struct {
+	typ   fit.WktStepDuration
+	value uint32
+	err   error
+}
+
+// maxValue is the largest valid duration value or custom target value;
+// 0xFFFFFFFF is invalid.
+const maxValue = 0xFFFFFFFE
+
+// Time returns a duration ending a step after d, which must be positive and
+// at most about 49 days.
+func Time(d time.Duration) Duration {
+	ms := d / time.Millisecond
+	if d <= 0 || ms > maxValue {
+		return Duration{typ: fit.WktStepDurationTime, err: fmt.Errorf("invalid time %v", d)}
+	}
+	return Duration{typ: fit.WktStepDurationTime, value: uint32(ms)}
+}
+
+// Distance returns a duration ending a step after m metres, which must be
+// positive and at most about 42950 km.
+func Distance(m float64) Duration {
+	cm := math.Round(m * 100)
+	if !(m > 0 && cm <= maxValue) {
+		return Duration{typ: fit.WktStepDurationDistance, err: fmt.Errorf("invalid distance %g m", m)}
+	}
+	return Duration{typ: fit.WktStepDurationDistance, value: uint32(cm)}
+}
+
+// Calories returns a duration ending a step after kcal kilocalories, which
+// must be at least 1 and less than 0xFFFFFFFF.
+func Calories(kcal uint32) Duration {
+	d := Duration{typ: fit.WktStepDurationCalories, value: kcal}
+	if kcal == 0 || kcal > maxValue {
+		d.err = fmt.Errorf("invalid energy %d kcal", kcal)
+	}
+	return d
+}
+
+// Open returns a duration ending a step when the lap button is pressed.
+func Open() Duration {
+	return Duration{typ: fit.WktStepDurationOpen, value: 0xFFFFFFFF}
+}
+
+// HRBelow returns a duration ending a step when the heart rate drops below
+// bpm, which must be at least 1.
+func HRBelow(bpm uint8) Duration {
+	return hrDuration(fit.WktStepDurationHrLessThan, bpm)
+}
+
+// HRAbove returns a duration ending a step when the heart rate rises above
+// bpm, which must be at least 1.
+func HRAbove(bpm uint8) Duration {
+	return hrDuration(fit.WktStepDurationHrGreaterThan, bpm)
+}
+
+// PowerBelow returns a duration ending a step when the power drops below
+// watts, which must be at least 1.
+func PowerBelow(watts uint16) Duration {
+	return powerDuration(fit.WktStepDurationPowerLessThan, watts)
+}
+
+// PowerAbove returns a duration ending a step when the power rises above
+// watts, which must be at least 1.
+func PowerAbove(watts uint16) Duration {
+	return powerDuration(fit.WktStepDurationPowerGreaterThan, watts)
+}
+
+// HRBelowPercent returns a duration ending a step when the heart rate drops
+// below pct percent of the maximum heart rate, at most 100.
+func HRBelowPercent(pct float64) Duration {
+	return hrPercentDuration(fit.WktStepDurationHrLessThan, pct)
+}
+
+// HRAbovePercent returns a duration ending a step when the heart rate rises
+// above pct percent of the maximum heart rate, at most 100.
+func HRAbovePercent(pct float64) Duration {
+	return hrPercentDuration(fit.WktStepDurationHrGreaterThan, pct)
+}
+
+// PowerBelowPercent returns a duration ending a step when the power drops
+// below pct percent of the functional threshold power, less than 1000.
+func PowerBelowPercent(pct float64) Duration {
+	return powerPercentDuration(fit.WktStepDurationPowerLessThan, pct)
+}
+
+// PowerAbovePercent returns a duration ending a step when the power rises
+// above pct percent of the functional threshold power, less than 1000.
+func PowerAbovePercent(pct float64) Duration {
+	return powerPercentDuration(fit.WktStepDurationPowerGreaterThan, pct)
+}
+
+// hrDuration returns a heart rate duration. Heart rates are offset by 100,
+// as values up to 100 are percentages of the maximum heart rate, so 0 bpm
+// would read as 100 %.
+func hrDuration(t fit.WktStepDuration, bpm uint8) Duration {
+	d := Duration{typ: t, value: uint32(bpm) + uint32(fit.WorkoutHrBpmOffset)}
+	if bpm == 0 {
+		d.err = errors.New("heart rate of 0 bpm")
+	}
+	return d
+}
+
+// powerDuration returns a power duration. Powers are offset by 1000, as
+// values up to 1000 are percentages of the FTP, so 0 W would read as
+// 1000 %.
+func powerDuration(t fit.WktStepDuration, watts uint16) Duration {
+	d := Duration{typ: t, value: uint32(watts) + uint32(fit.WorkoutPowerWattsOffset)}
+	if watts == 0 {
+		d.err = errors.New("power of 0 W")
+	}
+	return d
+}
+
+// hrPercentDuration returns a heart rate duration in percent of the maximum
+// heart rate. Percentages above 100 would read as heart rates in bpm.
+func hrPercentDuration(t fit.WktStepDuration, pct float64) Duration {
+	d := Duration{typ: t}
+	if !(pct >= 0 && math.Round(pct) <= float64(fit.WorkoutHrBpmOffset)) {
+		d.err = fmt.Errorf("heart rate percentage %g is not in [0, %d]", pct, fit.WorkoutHrBpmOffset)
+		return d
+	}
+	d.value = uint32(math.Round(pct))
+	return d
+}
+
+// powerPercentDuration returns a power duration in percent of the FTP.
+// Percentages of 1000 or more would read as powers in watts.
+func powerPercentDuration(t fit.WktStepDuration, pct float64) Duration {
+	d := Duration{typ: t}
+	if !(pct >= 0 && math.Round(pct) < float64(fit.WorkoutPowerWattsOffset)) {
+		d.err = fmt.Errorf("power percentage %g is not less than %d", pct, fit.WorkoutPowerWattsOffset)
+		return d
+	}
+	d.value = uint32(math.Round(pct))
+	return d
+}
+
+// A Step is a step of a workout, or a repeat of steps. Targets are set with
+// the methods of Step, which return the step so that calls can be chained:
+//
+//	workout.Interval(workout.Distance(400)).Pace(4*time.Minute, 3*time.Minute+50*time.Second)
+//
+// A step has no target unless one is set, and setting a target replaces
+// any previous one. Invalid arguments are reported when the workout is
+// built.
+type Step struct {
+	name      string
+	notes     string
+	intensity fit.Intensity
+	duration  Duration
+
+	target fit.WktStepTarget
+	value  uint32 // Zone, or 0 for a custom range.
+	low    uint32
+	high   uint32
+
+	repeat int
+	steps  []*Step
+
+	err error
+}
+
+func newStep(intensity fit.Intensity, d Duration) *Step {
+	return &Step{
+		intensity: intensity,
+		duration:  d,
+		target:    fit.WktStepTargetOpen,
+		value:     0xFFFFFFFF,
+		low:       0xFFFFFFFF,
+		high:      0xFFFFFFFF,
+		err:       d.err,
+	}
+}
+
+// Warmup returns a warm-up step.
+func Warmup(d Duration) *Step {
+	return newStep(fit.IntensityWarmup, d)
+}
+
+// Interval returns an active step.
+func Interval(d Duration) *Step {
+	return newStep(fit.IntensityActive, d)
+}
+
+// Recover returns a rest step.
+func Recover(d Duration) *Step {
+	return newStep(fit.IntensityRest, d)
+}
+
+// Cooldown returns a cool-down step.
+func Cooldown(d Duration) *Step {
+	return newStep(fit.IntensityCooldown, d)
+}
+
+// Repeat returns a step repeating steps n times in total.
+func Repeat(n int, steps ...*Step) *Step {
+	s := newStep(fit.IntensityInvalid, Duration{typ: fit.WktStepDurationRepeatUntilStepsCmplt})
+	s.repeat, s.steps = n, steps
+	switch {
+	case n < 1:
+		s.err = fmt.Errorf("repeat count %d is less than 1", n)
+	case len(steps) == 0:
+		s.err = errors.New("repeat has no steps")
+	}
+	return s
+}
+
+// Name sets the name of the step.
+func (s *Step) Name(name string) *Step {
+	s.name = name
+	return s
+}
+
+// Notes sets the notes of the step.
+func (s *Step) Notes(notes string) *Step {
+	s.notes = notes
+	return s
+}
+
+func (s *Step) setZone(t fit.WktStepTarget, zone, max int) *Step {
+	if zone < 1 || zone > max {
+		s.setErr(fmt.Errorf("%v zone %d is not in [1, %d]", t, zone, max))
+	}
+	s.target, s.value, s.low, s.high = t, uint32(zone), 0xFFFFFFFF, 0xFFFFFFFF
+	return s
+}
+
+func (s *Step) setRange(t fit.WktStepTarget, low, high, offset float64) *Step {
+	if low > high {
+		low, high = high, low
+	}
+	// Written so that NaN fails.
+	if !(low >= 0 && math.Round(high+offset) <= maxValue) {
+		s.setErr(fmt.Errorf("invalid %v range [%g, %g]", t, low, high))
+	}
+	s.target, s.value = t, 0
+	s.low = uint32(math.Round(low + offset))
+	s.high = uint32(math.Round(high + offset))
+	return s
+}
+
+func (s *Step) setErr(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// HRZone sets the target to heart rate zone 1 to 5.
+func (s *Step) HRZone(zone int) *Step {
+	return s.setZone(fit.WktStepTargetHeartRate, zone, 5)
+}
+
+// HR sets the target to a heart rate range in bpm, from at least 1 bpm.
+func (s *Step) HR(low, high uint8) *Step {
+	if low == 0 || high == 0 {
+		s.setErr(errors.New("heart rate of 0 bpm"))
+	}
+	return s.setRange(fit.WktStepTargetHeartRate, float64(low), float64(high), float64(fit.WorkoutHrBpmOffset))
+}
+
+// HRPercent sets the target to a heart rate range in percent of the
+// maximum heart rate.
+func (s *Step) HRPercent(low, high float64) *Step {
+	if high > 100 || low > 100 {
+		s.setErr(errors.New("heart rate percentage above 100"))
+	}
+	return s.setRange(fit.WktStepTargetHeartRate, low, high, 0)
+}
+
+// PowerZone sets the target to power zone 1 to 7.
+func (s *Step) PowerZone(zone int) *Step {
+	return s.setZone(fit.WktStepTargetPower, zone, 7)
+}
+
+// Power sets the target to a power range in watts, from at least 1 W.
+func (s *Step) Power(low, high uint16) *Step {
+	if low == 0 || high == 0 {
+		s.setErr(errors.New("power of 0 W"))
+	}
+	return s.setRange(fit.WktStepTargetPower, float64(low), float64(high), float64(fit.WorkoutPowerWattsOffset))
+}
+
+// PowerPercent sets the target to a power range in percent of the
+// functional threshold power.
+func (s *Step) PowerPercent(low, high float64) *Step {
+	if high >= float64(fit.WorkoutPowerWattsOffset) || low >= float64(fit.WorkoutPowerWattsOffset) {
+		s.setErr(fmt.Errorf("power percentage %g is too high", math.Max(low, high)))
+	}
+	return s.setRange(fit.WktStepTargetPower, low, high, 0)
+}
+
+// SpeedZone sets the target to speed zone 1 to 10.
+func (s *Step) SpeedZone(zone int) *Step {
+	return s.setZone(fit.WktStepTargetSpeed, zone, 10)
+}
+
+// Speed sets the target to a speed range in m/s.
+func (s *Step) Speed(low, high float64) *Step {
+	return s.setRange(fit.WktStepTargetSpeed, low*1000, high*1000, 0)
+}
+
+// Pace sets the target to a speed range given as the time per kilometre,
+// in either order.
+func (s *Step) Pace(slow, fast time.Duration) *Step {
+	if slow <= 0 || fast <= 0 {
+		s.setErr(fmt.Errorf("invalid pace range [%v, %v]", slow, fast))
+		return s.Speed(0, 0)
+	}
+	return s.Speed(1000/slow.Seconds(), 1000/fast.Seconds())
+}
+
+// Cadence sets the target to a cadence range in rpm, or strides or
+// strokes per minute.
+func (s *Step) Cadence(low, high uint8) *Step {
+	return s.setRange(fit.WktStepTargetCadence, float64(low), float64(high), 0)
+}
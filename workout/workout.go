@@ -0,0 +1,144 @@
+// Package workout builds structured workouts and encodes them as FIT workout
+// files that devices can load.
+//
+// A workout is a sequence of steps, each with a duration and an optional
+// target, and repeats of steps:
+//
+//	w := workout.New("5x400", fit.SportRunning,
+//		workout.Warmup(workout.Time(10*time.Minute)).HRZone(2),
+//		workout.Repeat(5,
+//			workout.Interval(workout.Distance(400)).Pace(4*time.Minute, 3*time.Minute+50*time.Second),
+//			workout.Recover(workout.Time(90*time.Second)),
+//		),
+//		workout.Cooldown(workout.Time(10*time.Minute)).HRZone(1),
+//	)
+//	err := w.Encode(out)
+//
+// Steps are encoded as WorkoutStepMsg messages as specified by the FIT
+// profile: heart rates in bpm are offset by 100 and powers in watts by 1000
+// to distinguish them from percentages of the maximum heart rate and the
+// functional threshold power, and a repeat is a step following the repeated
+// steps, with the index of its first step as duration value and the number
+// of repetitions as target value.
+package workout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// A Workout is a structured workout.
+type Workout struct {
+	Name       string
+	Sport      fit.Sport
+	SubSport   fit.SubSport
+	PoolLength float64 // m, for pool swimming; zero if not set
+	Steps      []*Step
+}
+
+// New returns a workout for sport with the given steps.
+func New(name string, sport fit.Sport, steps ...*Step) *Workout {
+	return &Workout{
+		Name:     name,
+		Sport:    sport,
+		SubSport: fit.SubSportInvalid,
+		Steps:    steps,
+	}
+}
+
+// Messages returns the workout message and the workout step messages of w.
+func (w *Workout) Messages() (*fit.WorkoutMsg, []*fit.WorkoutStepMsg, error) {
+	if len(w.Steps) == 0 {
+		return nil, nil, errors.New("workout has no steps")
+	}
+	var steps []*fit.WorkoutStepMsg
+	if err := appendSteps(&steps, w.Steps); err != nil {
+		return nil, nil, err
+	}
+	if len(steps) >= 0xFFFF {
+		return nil, nil, fmt.Errorf("workout has %d steps", len(steps))
+	}
+
+	msg := fit.NewWorkoutMsg()
+	msg.WktName = w.Name
+	msg.Sport = w.Sport
+	msg.SubSport = w.SubSport
+	msg.NumValidSteps = uint16(len(steps))
+	if w.PoolLength > 0 {
+		if r := math.Round(w.PoolLength * 100); r < 0xFFFF {
+			msg.PoolLength = uint16(r)
+			msg.PoolLengthUnit = fit.DisplayMeasureMetric
+		}
+	}
+	return msg, steps, nil
+}
+
+// appendSteps appends the messages of steps to msgs, with repeats after the
+// steps they repeat.
+func appendSteps(msgs *[]*fit.WorkoutStepMsg, steps []*Step) error {
+	for _, s := range steps {
+		if s == nil {
+			return errors.New("nil workout step")
+		}
+		if s.err != nil {
+			return fmt.Errorf("step %d: %v", len(*msgs), s.err)
+		}
+		first := len(*msgs)
+		if s.repeat > 0 {
+			if err := appendSteps(msgs, s.steps); err != nil {
+				return err
+			}
+		}
+
+		m := fit.NewWorkoutStepMsg()
+		m.MessageIndex = fit.MessageIndex(len(*msgs))
+		m.WktStepName = s.name
+		m.Notes = s.notes
+		m.Intensity = s.intensity
+		m.DurationType = s.duration.typ
+		m.DurationValue = s.duration.value
+		m.TargetType = s.target
+		m.TargetValue = s.value
+		m.CustomTargetValueLow = s.low
+		m.CustomTargetValueHigh = s.high
+		if s.repeat > 0 {
+			m.DurationValue = uint32(first)
+			m.TargetValue = uint32(s.repeat)
+		}
+		*msgs = append(*msgs, m)
+	}
+	return nil
+}
+
+// File returns w as a FIT workout file created now by a development
+// device.
+func (w *Workout) File() (*fit.File, error) {
+	msg, steps, err := w.Messages()
+	if err != nil {
+		return nil, err
+	}
+	f, err := fit.NewFile(fit.FileTypeWorkout)
+	if err != nil {
+		return nil, err
+	}
+	f.FileId.Manufacturer = fit.ManufacturerDevelopment
+	f.FileId.TimeCreated = time.Now().UTC().Truncate(time.Second)
+	wf, _ := f.Workout()
+	wf.Workout = msg
+	wf.WorkoutSteps = steps
+	return f, nil
+}
+
+// Encode writes w to out as a FIT workout file.
+func (w *Workout) Encode(out io.Writer) error {
+	f, err := w.File()
+	if err != nil {
+		return err
+	}
+	return fit.Encode(out, f)
+}
@@ -0,0 +1,171 @@
+package workout_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+	"github.com/tormoder/fit/workout"
+)
+
+func TestEncode(t *testing.T) {
+	w := workout.New("5x400", fit.SportRunning,
+		workout.Warmup(workout.Time(10*time.Minute)).HRZone(2),
+		workout.Repeat(5,
+			workout.Interval(workout.Distance(400)).Pace(4*time.Minute, 3*time.Minute+20*time.Second),
+			workout.Recover(workout.Time(90*time.Second)).HR(120, 140),
+		).Name("Intervals"),
+		workout.Cooldown(workout.HRBelow(125)).PowerPercent(50, 60),
+		workout.Cooldown(workout.Open()).Power(150, 200),
+	)
+	var buf bytes.Buffer
+	if err := w.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range fit.Validate(bytes.NewReader(buf.Bytes())) {
+		if issue.Severity == fit.SeverityError {
+			t.Error(issue)
+		}
+	}
+	f, err := fit.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf, err := f.Workout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wf.Workout.WktName != "5x400" || wf.Workout.Sport != fit.SportRunning || wf.Workout.NumValidSteps != 6 {
+		t.Errorf("workout: got %+v", wf.Workout)
+	}
+
+	const none = 0xFFFFFFFF
+	want := []fit.WorkoutStepMsg{
+		{DurationType: fit.WktStepDurationTime, DurationValue: 600000, TargetType: fit.WktStepTargetHeartRate, TargetValue: 2, CustomTargetValueLow: none, CustomTargetValueHigh: none, Intensity: fit.IntensityWarmup},
+		{DurationType: fit.WktStepDurationDistance, DurationValue: 40000, TargetType: fit.WktStepTargetSpeed, TargetValue: 0, CustomTargetValueLow: 4167, CustomTargetValueHigh: 5000, Intensity: fit.IntensityActive},
+		{DurationType: fit.WktStepDurationTime, DurationValue: 90000, TargetType: fit.WktStepTargetHeartRate, TargetValue: 0, CustomTargetValueLow: 220, CustomTargetValueHigh: 240, Intensity: fit.IntensityRest},
+		{WktStepName: "Intervals", DurationType: fit.WktStepDurationRepeatUntilStepsCmplt, DurationValue: 1, TargetType: fit.WktStepTargetOpen, TargetValue: 5, CustomTargetValueLow: none, CustomTargetValueHigh: none, Intensity: fit.IntensityInvalid},
+		{DurationType: fit.WktStepDurationHrLessThan, DurationValue: 225, TargetType: fit.WktStepTargetPower, TargetValue: 0, CustomTargetValueLow: 50, CustomTargetValueHigh: 60, Intensity: fit.IntensityCooldown},
+		{DurationType: fit.WktStepDurationOpen, DurationValue: none, TargetType: fit.WktStepTargetPower, TargetValue: 0, CustomTargetValueLow: 1150, CustomTargetValueHigh: 1200, Intensity: fit.IntensityCooldown},
+	}
+	if len(wf.WorkoutSteps) != len(want) {
+		t.Fatalf("got %d steps, want %d", len(wf.WorkoutSteps), len(want))
+	}
+	for i, got := range wf.WorkoutSteps {
+		want[i].MessageIndex = fit.MessageIndex(i)
+		want[i].Equipment = fit.WorkoutEquipmentInvalid
+		if *got != want[i] {
+			t.Errorf("step %d:\ngot  %+v\nwant %+v", i, *got, want[i])
+		}
+	}
+}
+
+func TestNestedRepeat(t *testing.T) {
+	w := workout.New("nested", fit.SportCycling,
+		workout.Repeat(2,
+			workout.Interval(workout.Time(time.Minute)).PowerZone(5),
+			workout.Repeat(3,
+				workout.Interval(workout.Time(10*time.Second)).PowerZone(7),
+				workout.Recover(workout.Time(20*time.Second)),
+			),
+		),
+	)
+	_, steps, err := w.Messages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repeats := map[int][2]uint32{3: {1, 3}, 4: {0, 2}}
+	if len(steps) != 5 {
+		t.Fatalf("got %d steps, want 5", len(steps))
+	}
+	for i, r := range repeats {
+		s := steps[i]
+		if s.DurationType != fit.WktStepDurationRepeatUntilStepsCmplt || s.DurationValue != r[0] || s.TargetValue != r[1] {
+			t.Errorf("step %d: got %v from %d, %d times", i, s.DurationType, s.DurationValue, s.TargetValue)
+		}
+	}
+}
+
+func TestInterpret(t *testing.T) {
+	value := func(v float64, u fit.WorkoutUnit) fit.WorkoutValue {
+		return fit.WorkoutValue{Value: v, Unit: u}
+	}
+	open := func() *workout.Step { return workout.Interval(workout.Open()) }
+	tests := []struct {
+		step      *workout.Step
+		duration  fit.WorkoutValue
+		low, high fit.WorkoutValue
+	}{
+		{step: open().HR(1, 2), low: value(1, fit.WorkoutUnitBpm), high: value(2, fit.WorkoutUnitBpm)},
+		{step: open().HR(100, 255), low: value(100, fit.WorkoutUnitBpm), high: value(255, fit.WorkoutUnitBpm)},
+		{step: open().HRPercent(0, 100), low: value(0, fit.WorkoutUnitPercentMaxHR), high: value(100, fit.WorkoutUnitPercentMaxHR)},
+		{step: open().Power(1, 1000), low: value(1, fit.WorkoutUnitWatts), high: value(1000, fit.WorkoutUnitWatts)},
+		{step: open().Power(65535, 65535), low: value(65535, fit.WorkoutUnitWatts), high: value(65535, fit.WorkoutUnitWatts)},
+		{step: open().PowerPercent(0, 999), low: value(0, fit.WorkoutUnitPercentFTP), high: value(999, fit.WorkoutUnitPercentFTP)},
+		{step: workout.Interval(workout.HRBelow(1)), duration: value(1, fit.WorkoutUnitBpm)},
+		{step: workout.Interval(workout.HRAbove(100)), duration: value(100, fit.WorkoutUnitBpm)},
+		{step: workout.Interval(workout.PowerBelow(1)), duration: value(1, fit.WorkoutUnitWatts)},
+		{step: workout.Interval(workout.PowerAbove(1000)), duration: value(1000, fit.WorkoutUnitWatts)},
+		{step: workout.Interval(workout.HRBelowPercent(100)), duration: value(100, fit.WorkoutUnitPercentMaxHR)},
+		{step: workout.Interval(workout.PowerAbovePercent(999)), duration: value(999, fit.WorkoutUnitPercentFTP)},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		if err := workout.New("test", fit.SportCycling, test.step).Encode(&buf); err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		f, err := fit.Decode(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wf, err := f.Workout()
+		if err != nil {
+			t.Fatal(err)
+		}
+		step := wf.WorkoutSteps[0]
+		if d := step.Duration(); test.duration.Unit != fit.WorkoutUnitNone && d.Value != test.duration {
+			t.Errorf("%d: got duration %v, want %v", i, d.Value, test.duration)
+		}
+		if tg := step.Target(); test.low.Unit != fit.WorkoutUnitNone && (tg.Low != test.low || tg.High != test.high) {
+			t.Errorf("%d: got target %v-%v, want %v-%v", i, tg.Low, tg.High, test.low, test.high)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, s := range []*workout.Step{
+		workout.Repeat(0, workout.Interval(workout.Open())),
+		workout.Repeat(2),
+		workout.Interval(workout.Open()).HRZone(6),
+		workout.Interval(workout.Open()).PowerZone(0),
+		workout.Interval(workout.Open()).HRPercent(90, 110),
+		workout.Interval(workout.Open()).Pace(0, time.Minute),
+		workout.Interval(workout.Open()).HR(0, 120),
+		workout.Interval(workout.Open()).Power(200, 0),
+		workout.Interval(workout.HRBelow(0)),
+		workout.Interval(workout.PowerAbove(0)),
+		workout.Interval(workout.HRAbovePercent(101)),
+		workout.Interval(workout.PowerBelowPercent(1000)),
+		workout.Interval(workout.HRBelowPercent(math.NaN())),
+		workout.Interval(workout.Time(0)),
+		workout.Interval(workout.Time(-time.Minute)),
+		workout.Interval(workout.Time(50 * 24 * time.Hour)),
+		workout.Interval(workout.Distance(-400)),
+		workout.Interval(workout.Distance(math.NaN())),
+		workout.Interval(workout.Distance(1e8)),
+		workout.Interval(workout.Calories(0)),
+		workout.Interval(workout.Open()).Speed(3, math.NaN()),
+		workout.Interval(workout.Open()).Speed(3, 5e6),
+		workout.Interval(workout.Open()).PowerPercent(math.NaN(), 90),
+	} {
+		if _, err := workout.New("bad", fit.SportRunning, s).File(); err == nil {
+			t.Errorf("no error for %+v", s)
+		}
+	}
+	if _, err := workout.New("empty", fit.SportRunning).File(); err == nil {
+		t.Error("no error for a workout without steps")
+	}
+}
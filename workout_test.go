@@ -0,0 +1,119 @@
+package fit_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+func decodeWorkout(t *testing.T, name string) *fit.WorkoutFile {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fitsdk", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := fit.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := f.Workout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestWorkoutStepDurationTarget(t *testing.T) {
+	tests := []struct {
+		file     string
+		duration []string
+		target   []string
+	}{
+		{
+			"WorkoutCustomTargetValues.fit",
+			[]string{"1m0s", "500 m", "500 m", "until < 125 bpm"},
+			[]string{"HeartRate 50% HRmax-60% HRmax", "Power 300 W-310 W", "Power 260 W-270 W", "Power 220 W-230 W"},
+		},
+		{
+			"WorkoutRepeatSteps.fit",
+			[]string{"1m0s", "500 m", "500 m", "repeat from step 1, 3 times", "until < 125 bpm"},
+			[]string{"HeartRate zone 2", "Power zone 5", "Power zone 3", "open", "Power zone 1"},
+		},
+		{
+			"WorkoutRepeatGreaterThanStep.fit",
+			[]string{"1m0s", "500 m", "500 m", "repeat from step 1 until > 80% HRmax", "until < 125 bpm"},
+			[]string{"HeartRate zone 2", "Power zone 5", "Power zone 3", "open", "Power zone 1"},
+		},
+	}
+	for _, test := range tests {
+		w := decodeWorkout(t, test.file)
+		if len(w.WorkoutSteps) != len(test.duration) {
+			t.Fatalf("%s: got %d steps, want %d", test.file, len(w.WorkoutSteps), len(test.duration))
+		}
+		for i, s := range w.WorkoutSteps {
+			if got := s.Duration().String(); got != test.duration[i] {
+				t.Errorf("%s: step %d: duration: got %q, want %q", test.file, i, got, test.duration[i])
+			}
+			if got := s.Target().String(); got != test.target[i] {
+				t.Errorf("%s: step %d: target: got %q, want %q", test.file, i, got, test.target[i])
+			}
+		}
+	}
+
+	s := decodeWorkout(t, "WorkoutCustomTargetValues.fit").WorkoutSteps[1]
+	d, tg := s.Duration(), s.Target()
+	if d.Distance != 500 || d.IsRepeat() || !tg.IsRange() || tg.Low != (fit.WorkoutValue{Value: 300, Unit: fit.WorkoutUnitWatts}) {
+		t.Errorf("got duration %+v, target %+v", d, tg)
+	}
+}
+
+func TestExpandWorkoutSteps(t *testing.T) {
+	step := func(d fit.WktStepDuration, dv, tv uint32) *fit.WorkoutStepMsg {
+		s := fit.NewWorkoutStepMsg()
+		s.DurationType, s.DurationValue, s.TargetValue = d, dv, tv
+		return s
+	}
+	minute := uint32(time.Minute / time.Millisecond)
+	steps := []*fit.WorkoutStepMsg{
+		step(fit.WktStepDurationTime, minute, 0),                // 0
+		step(fit.WktStepDurationTime, minute, 0),                // 1
+		step(fit.WktStepDurationTime, minute, 0),                // 2
+		step(fit.WktStepDurationRepeatUntilStepsCmplt, 2, 3),    // 3: 2 x3
+		step(fit.WktStepDurationRepeatUntilStepsCmplt, 1, 2),    // 4: (1, (2 x3)) x2
+		step(fit.WktStepDurationOpen, 0, 0),                     // 5
+		step(fit.WktStepDurationRepeatUntilHrGreaterThan, 5, 0), // 6: 5 once
+	}
+	got, err := fit.ExpandWorkoutSteps(steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type want struct{ index, repeat, iteration int }
+	wants := []want{
+		{0, -1, 0},
+		{1, 4, 1}, {2, 3, 1}, {2, 3, 2}, {2, 3, 3},
+		{1, 4, 2}, {2, 3, 1}, {2, 3, 2}, {2, 3, 3},
+		{5, 6, 1},
+	}
+	if len(got) != len(wants) {
+		t.Fatalf("got %d steps, want %d", len(got), len(wants))
+	}
+	for i, w := range wants {
+		g := got[i]
+		var repeat *fit.WorkoutStepMsg
+		if w.repeat >= 0 {
+			repeat = steps[w.repeat]
+		}
+		if g.Index != w.index || g.Step != steps[w.index] || g.Repeat != repeat || g.Iteration != w.iteration {
+			t.Errorf("step %d: got index %d, iteration %d, want %+v", i, g.Index, g.Iteration, w)
+		}
+	}
+
+	steps[3].DurationValue = 4
+	if _, err := fit.ExpandWorkoutSteps(steps); err == nil {
+		t.Error("no error for a repeat of a later step")
+	}
+}